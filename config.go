@@ -22,6 +22,7 @@ const (
 	defaultConfigFileName = "cryptopower.conf"
 	defaultLogFilename    = "cryptopower.log"
 	defaultLogDirname     = "logs"
+	defaultRPCListen      = "127.0.0.1:9190"
 )
 
 type config struct {
@@ -37,6 +38,14 @@ type config struct {
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
 	DEXTestAddr      string `long:"dextestaddr" description:"If using the dextest network, set an address for the dex harness to be used as a persistant peer for all new wallets."`
 
+	// Daemon mode
+	Daemon    bool   `long:"daemon" description:"Run without a graphical interface and serve the JSON-RPC API"`
+	RPCListen string `long:"rpclisten" description:"Interface/port to listen for JSON-RPC connections in daemon mode"`
+	RPCUser   string `long:"rpcuser" description:"Username for JSON-RPC connections"`
+	RPCPass   string `long:"rpcpass" default-mask:"-" description:"Password for JSON-RPC connections"`
+	RPCCert   string `long:"rpccert" description:"File containing the TLS certificate used by the JSON-RPC server"`
+	RPCKey    string `long:"rpckey" description:"File containing the TLS key used by the JSON-RPC server"`
+
 	net libutils.NetworkType
 }
 
@@ -45,6 +54,7 @@ func defaultConfig(defaultHomeDir string) config {
 		HomeDir:    defaultHomeDir,
 		ConfigFile: filepath.Join(defaultHomeDir, defaultConfigFileName),
 		LogDir:     filepath.Join(defaultHomeDir, defaultLogDirname),
+		RPCListen:  defaultRPCListen,
 	}
 }
 
@@ -228,6 +238,22 @@ func loadConfig() (*config, error) {
 		cfg.MaxLogZips = 0
 	}
 
+	if cfg.Daemon {
+		if appos.Current().IsMobile() {
+			return loadConfigError(fmt.Errorf("daemon mode is not supported on mobile"))
+		}
+		if cfg.RPCUser == "" || cfg.RPCPass == "" {
+			return loadConfigError(fmt.Errorf("--rpcuser and --rpcpass must be set in daemon mode"))
+		}
+		if (cfg.RPCCert == "") != (cfg.RPCKey == "") {
+			return loadConfigError(fmt.Errorf("--rpccert and --rpckey must be set together"))
+		}
+		if cfg.RPCCert != "" {
+			cfg.RPCCert = cleanAndExpandPath(cfg.RPCCert)
+			cfg.RPCKey = cleanAndExpandPath(cfg.RPCKey)
+		}
+	}
+
 	if cfg.Network != "" && libutils.ToNetworkType(cfg.Network) == libutils.Unknown {
 		return loadConfigError(fmt.Errorf("network type is not supported: %s", cfg.Network))
 	}
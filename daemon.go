@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/rpcserver"
+)
+
+// runDaemon serves the JSON-RPC API on top of the provided assets manager
+// without opening a window. It blocks until an interrupt signal is received,
+// then shuts the assets manager down.
+func runDaemon(cfg *config, version string, assetsManager *libwallet.AssetsManager) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	defer assetsManager.Shutdown()
+
+	server, err := rpcserver.New(&rpcserver.Config{
+		Listen:   cfg.RPCListen,
+		User:     cfg.RPCUser,
+		Pass:     cfg.RPCPass,
+		CertFile: cfg.RPCCert,
+		KeyFile:  cfg.RPCKey,
+		Version:  version,
+	}, assetsManager)
+	if err != nil {
+		return err
+	}
+
+	// Wallets protected by a startup passphrase are opened once a client
+	// calls the openwallets method.
+	if assetsManager.IsStartupSecuritySet() {
+		log.Info("Startup passphrase is set, waiting for the openwallets rpc to open wallets")
+	} else if err := assetsManager.OpenWallets(""); err != nil {
+		return err
+	} else {
+		for _, wallet := range assetsManager.AllWallets() {
+			if !wallet.ReadBoolConfigValueForKey(sharedW.AutoSyncConfigKey, false) {
+				continue
+			}
+			if err := wallet.SpvSync(); err != nil {
+				log.Errorf("Unable to start sync for wallet %s: %v", wallet.GetWalletName(), err)
+			}
+		}
+	}
+
+	return server.Run(ctx)
+}
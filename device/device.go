@@ -2,6 +2,7 @@ package device
 
 import (
 	"errors"
+	"image"
 
 	"gioui.org/app"
 	"gioui.org/io/event"
@@ -9,6 +10,11 @@ import (
 
 var ErrNotAvailable = errors.New("current OS not supported")
 
+// ErrCameraPermission is returned by StartCamera while the user has not
+// allowed the app to use the camera. The permission is asked for on the first
+// call, StartCamera can be called again until it is granted.
+var ErrCameraPermission = errors.New("camera permission not granted")
+
 type Device struct {
 	*device
 }
@@ -35,3 +41,56 @@ func (d *Device) ProcessEvent(w *app.Window) event.Event {
 		return evt
 	}
 }
+
+// HasCamera returns whether the device has a camera StartCamera can use.
+func (d *Device) HasCamera() bool {
+	return d.hasCamera()
+}
+
+// StartCamera starts capturing frames from the back camera.
+func (d *Device) StartCamera() error {
+	return d.startCamera()
+}
+
+// StopCamera stops capturing frames and releases the camera.
+func (d *Device) StopCamera() {
+	d.stopCamera()
+}
+
+// CameraFrame returns the luminance of the latest camera frame, upright, or
+// nil if no frame was captured since the last call.
+func (d *Device) CameraFrame() *image.Gray {
+	return d.cameraFrame()
+}
+
+// rotateGray returns img rotated clockwise by degrees, a multiple of 90.
+func rotateGray(img *image.Gray, degrees int) *image.Gray {
+	degrees = ((degrees % 360) + 360) % 360
+	if degrees == 0 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	rect := image.Rect(0, 0, h, w)
+	if degrees == 180 {
+		rect = image.Rect(0, 0, w, h)
+	}
+	rotated := image.NewGray(rect)
+	for y := 0; y < h; y++ {
+		row := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch degrees {
+			case 90:
+				dx, dy = h-1-y, x
+			case 180:
+				dx, dy = w-1-x, h-1-y
+			default:
+				dx, dy = y, w-1-x
+			}
+			rotated.Pix[dy*rotated.Stride+dx] = row[x]
+		}
+	}
+	return rotated
+}
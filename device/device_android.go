@@ -2,8 +2,12 @@ package device
 
 import (
 	"fmt"
+	"image"
+	"sync"
 
 	"gioui.org/app"
+	// Adds the CAMERA permission to the manifest built by gogio.
+	_ "gioui.org/app/permission/camera"
 	"gioui.org/io/event"
 	"git.wow.st/gmp/jni"
 )
@@ -19,8 +23,17 @@ type device struct {
 	window *app.Window
 	view   uintptr
 	// libObject jni.Object
+	initMu   sync.Mutex
 	libClass jni.Class
 	methodID jni.MethodID
+
+	hasCameraID           jni.MethodID
+	startCameraID         jni.MethodID
+	stopCameraID          jni.MethodID
+	takeCameraFrameID     jni.MethodID
+	cameraFrameWidthID    jni.MethodID
+	cameraFrameHeightID   jni.MethodID
+	cameraFrameRotationID jni.MethodID
 }
 
 func newDevice(w *app.Window) *device {
@@ -34,6 +47,8 @@ func (d *Device) listenEvents(evt event.Event) {
 }
 
 func (d *Device) init(env jni.Env) error {
+	d.initMu.Lock()
+	defer d.initMu.Unlock()
 	if d.libClass != 0 {
 		return nil // Already initialized
 	}
@@ -42,8 +57,17 @@ func (d *Device) init(env jni.Env) error {
 	if err != nil {
 		return err
 	}
-	d.libClass = class
+	// The class is used from the camera polling goroutine too, keep it past
+	// this call.
+	d.libClass = jni.Class(jni.NewGlobalRef(env, jni.Object(class)))
 	d.methodID = jni.GetStaticMethodID(env, class, "setScreenAwake", "(Landroid/view/View;Z)V")
+	d.hasCameraID = jni.GetStaticMethodID(env, class, "hasCamera", "(Landroid/view/View;)Z")
+	d.startCameraID = jni.GetStaticMethodID(env, class, "startCamera", "(Landroid/view/View;)Z")
+	d.stopCameraID = jni.GetStaticMethodID(env, class, "stopCamera", "(Landroid/view/View;)V")
+	d.takeCameraFrameID = jni.GetStaticMethodID(env, class, "takeCameraFrame", "()[B")
+	d.cameraFrameWidthID = jni.GetStaticMethodID(env, class, "cameraFrameWidth", "()I")
+	d.cameraFrameHeightID = jni.GetStaticMethodID(env, class, "cameraFrameHeight", "()I")
+	d.cameraFrameRotationID = jni.GetStaticMethodID(env, class, "cameraFrameRotation", "()I")
 
 	return nil
 }
@@ -70,3 +94,85 @@ func (d *Device) setScreenAwake(isOn bool) error {
 	})
 	return nil
 }
+
+// doJNI runs f with the library class loaded. Unlike setScreenAwake, the
+// camera methods can be called from any thread.
+func (d *Device) doJNI(f func(env jni.Env) error) error {
+	if d.view == 0 {
+		return ErrNotAvailable
+	}
+	return jni.Do(jni.JVMFor(app.JavaVM()), func(env jni.Env) error {
+		if err := d.init(env); err != nil {
+			return err
+		}
+		return f(env)
+	})
+}
+
+func (d *Device) hasCamera() bool {
+	var hasCamera bool
+	err := d.doJNI(func(env jni.Env) (err error) {
+		hasCamera, err = jni.CallStaticBooleanMethod(env, d.libClass, d.hasCameraID, jni.Value(d.view))
+		return err
+	})
+	return err == nil && hasCamera
+}
+
+func (d *Device) startCamera() error {
+	var started bool
+	err := d.doJNI(func(env jni.Env) (err error) {
+		started, err = jni.CallStaticBooleanMethod(env, d.libClass, d.startCameraID, jni.Value(d.view))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !started {
+		return ErrCameraPermission
+	}
+	return nil
+}
+
+func (d *Device) stopCamera() {
+	err := d.doJNI(func(env jni.Env) error {
+		return jni.CallStaticVoidMethod(env, d.libClass, d.stopCameraID, jni.Value(d.view))
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (d *Device) cameraFrame() *image.Gray {
+	var frame *image.Gray
+	var rotation int
+	err := d.doJNI(func(env jni.Env) error {
+		pixels, err := jni.CallStaticObjectMethod(env, d.libClass, d.takeCameraFrameID)
+		if err != nil || pixels == 0 {
+			return err
+		}
+		width, err := jni.CallStaticIntMethod(env, d.libClass, d.cameraFrameWidthID)
+		if err != nil {
+			return err
+		}
+		height, err := jni.CallStaticIntMethod(env, d.libClass, d.cameraFrameHeightID)
+		if err != nil {
+			return err
+		}
+		if rotation, err = jni.CallStaticIntMethod(env, d.libClass, d.cameraFrameRotationID); err != nil {
+			return err
+		}
+
+		frame = image.NewGray(image.Rect(0, 0, width, height))
+		if data := jni.GetByteArrayElements(env, jni.ByteArray(pixels)); len(data) == len(frame.Pix) {
+			copy(frame.Pix, data)
+		} else {
+			// The camera was restarted with another size in between.
+			frame = nil
+		}
+		return nil
+	})
+	if err != nil || frame == nil {
+		return nil
+	}
+	return rotateGray(frame, rotation)
+}
@@ -1,6 +1,12 @@
 package org.gioui.x.device;
 
+import android.Manifest;
 import android.app.Activity;
+import android.content.pm.PackageManager;
+import android.graphics.ImageFormat;
+import android.graphics.SurfaceTexture;
+import android.hardware.Camera;
+import android.os.Build;
 import android.view.View;
 
 public class device_android {
@@ -16,4 +22,162 @@ public class device_android {
             }
         });
     }
-}
\ No newline at end of file
+
+    private static final int CAMERA_PERMISSION_REQUEST = 0x0c0de;
+
+    private static Camera camera;
+    // The preview needs a surface to run, frames are read from the preview
+    // callback and drawn by the app instead.
+    private static SurfaceTexture previewTexture;
+    private static boolean permissionRequested;
+    private static byte[] frame;
+    private static int frameWidth;
+    private static int frameHeight;
+    private static int frameRotation;
+
+    // hasCamera returns whether the device has a back camera.
+    public static boolean hasCamera(View view) {
+        return view.getContext().getPackageManager().hasSystemFeature(PackageManager.FEATURE_CAMERA);
+    }
+
+    // startCamera opens the back camera. It returns false while the camera
+    // permission is not granted, asking for it on the first call.
+    public static boolean startCamera(View view) {
+        Activity activity = (Activity) view.getContext();
+        if (Build.VERSION.SDK_INT >= 23 && activity.checkSelfPermission(Manifest.permission.CAMERA) != PackageManager.PERMISSION_GRANTED) {
+            if (!permissionRequested) {
+                permissionRequested = true;
+                activity.runOnUiThread(new Runnable() {
+                    public void run() {
+                        activity.requestPermissions(new String[]{Manifest.permission.CAMERA}, CAMERA_PERMISSION_REQUEST);
+                    }
+                });
+            }
+            return false;
+        }
+
+        activity.runOnUiThread(new Runnable() {
+            public void run() {
+                openCamera();
+            }
+        });
+        return true;
+    }
+
+    private static void openCamera() {
+        if (camera != null) {
+            return;
+        }
+
+        Camera.CameraInfo info = new Camera.CameraInfo();
+        for (int id = 0; id < Camera.getNumberOfCameras(); id++) {
+            Camera.getCameraInfo(id, info);
+            if (info.facing != Camera.CameraInfo.CAMERA_FACING_BACK) {
+                continue;
+            }
+            try {
+                camera = Camera.open(id);
+            } catch (RuntimeException e) {
+                return;
+            }
+            break;
+        }
+        if (camera == null) {
+            return;
+        }
+
+        Camera.Parameters params = camera.getParameters();
+        Camera.Size size = params.getPreviewSize();
+        for (Camera.Size s : params.getSupportedPreviewSizes()) {
+            // Prefer 640x480, enough to read a QR code on screen.
+            if (s.width == 640 && s.height == 480) {
+                size = s;
+                break;
+            }
+        }
+        params.setPreviewSize(size.width, size.height);
+        params.setPreviewFormat(ImageFormat.NV21);
+        if (params.getSupportedFocusModes().contains(Camera.Parameters.FOCUS_MODE_CONTINUOUS_PICTURE)) {
+            params.setFocusMode(Camera.Parameters.FOCUS_MODE_CONTINUOUS_PICTURE);
+        }
+        camera.setParameters(params);
+
+        synchronized (device_android.class) {
+            frame = null;
+            frameWidth = size.width;
+            frameHeight = size.height;
+            frameRotation = info.orientation;
+        }
+
+        int bufferSize = size.width * size.height * ImageFormat.getBitsPerPixel(ImageFormat.NV21) / 8;
+        camera.addCallbackBuffer(new byte[bufferSize]);
+        camera.setPreviewCallbackWithBuffer(new Camera.PreviewCallback() {
+            public void onPreviewFrame(byte[] data, Camera cam) {
+                // The luminance plane comes first in NV21.
+                byte[] luminance = new byte[frameWidth * frameHeight];
+                System.arraycopy(data, 0, luminance, 0, luminance.length);
+                synchronized (device_android.class) {
+                    frame = luminance;
+                }
+                cam.addCallbackBuffer(data);
+            }
+        });
+
+        try {
+            previewTexture = new SurfaceTexture(0);
+            camera.setPreviewTexture(previewTexture);
+            camera.startPreview();
+        } catch (Exception e) {
+            closeCamera();
+        }
+    }
+
+    // stopCamera releases the camera.
+    public static void stopCamera(View view) {
+        Activity activity = (Activity) view.getContext();
+        activity.runOnUiThread(new Runnable() {
+            public void run() {
+                closeCamera();
+            }
+        });
+    }
+
+    private static void closeCamera() {
+        permissionRequested = false;
+        if (camera != null) {
+            camera.setPreviewCallbackWithBuffer(null);
+            camera.stopPreview();
+            camera.release();
+            camera = null;
+        }
+        if (previewTexture != null) {
+            previewTexture.release();
+            previewTexture = null;
+        }
+        synchronized (device_android.class) {
+            frame = null;
+        }
+    }
+
+    // takeCameraFrame returns the luminance of the latest frame, row by row,
+    // or null if there was no frame since the last call.
+    public static synchronized byte[] takeCameraFrame() {
+        byte[] f = frame;
+        frame = null;
+        return f;
+    }
+
+    public static synchronized int cameraFrameWidth() {
+        return frameWidth;
+    }
+
+    public static synchronized int cameraFrameHeight() {
+        return frameHeight;
+    }
+
+    // cameraFrameRotation returns the clockwise rotation, in degrees, that
+    // makes the frames upright.
+    public static synchronized int cameraFrameRotation() {
+        return frameRotation;
+    }
+}
@@ -1,12 +1,15 @@
 package device
 
 /*
-#cgo CFLAGS: -x objective-c
-#cgo LDFLAGS: -framework Foundation
+#cgo CFLAGS: -x objective-c -fobjc-arc
+#cgo LDFLAGS: -framework Foundation -framework AVFoundation -framework CoreMedia -framework CoreVideo
 #import "device_ios.h"
 */
 import "C"
 import (
+	"image"
+	"unsafe"
+
 	"gioui.org/app"
 	"gioui.org/io/event"
 )
@@ -32,3 +35,45 @@ func (d *Device) listenEvents(evt event.Event) {
 		d.view = evt.ViewController
 	}
 }
+
+// backCameraRotation is the clockwise rotation making the frames of the back
+// camera, captured in landscape, upright in portrait.
+const backCameraRotation = 90
+
+func (d *Device) hasCamera() bool {
+	return bool(C.hasCamera())
+}
+
+func (d *Device) startCamera() error {
+	switch C.startCamera() {
+	case 0:
+		return nil
+	case 1:
+		return ErrCameraPermission
+	default:
+		return ErrNotAvailable
+	}
+}
+
+func (d *Device) stopCamera() {
+	C.stopCamera()
+}
+
+func (d *Device) cameraFrame() *image.Gray {
+	var width, height C.int
+	frame := new(image.Gray)
+	for {
+		var buf *C.uchar
+		if len(frame.Pix) > 0 {
+			buf = (*C.uchar)(unsafe.Pointer(&frame.Pix[0]))
+		}
+		switch C.takeCameraFrame(buf, C.int(len(frame.Pix)), &width, &height) {
+		case 1:
+			return rotateGray(frame, backCameraRotation)
+		case -1:
+			frame = image.NewGray(image.Rect(0, 0, int(width), int(height)))
+		default:
+			return nil
+		}
+	}
+}
@@ -1,3 +1,8 @@
 #import <UIKit/UIKit.h>
 
-BOOL setScreenAwake(BOOL isOn);
\ No newline at end of file
+BOOL setScreenAwake(BOOL isOn);
+
+BOOL hasCamera(void);
+int startCamera(void);
+void stopCamera(void);
+int takeCameraFrame(unsigned char *buf, int bufLen, int *width, int *height);
\ No newline at end of file
@@ -1,5 +1,141 @@
 #import <device_ios.h>
+#import <AVFoundation/AVFoundation.h>
+
 BOOL setScreenAwake(BOOL isOn){
     [UIApplication sharedApplication].idleTimerDisabled = isOn;
     return isOn;
+}
+
+// CameraScanner keeps the luminance of the latest frame of the back camera.
+@interface CameraScanner : NSObject <AVCaptureVideoDataOutputSampleBufferDelegate>
+@property (nonatomic, strong) AVCaptureSession *session;
+@property (nonatomic, strong) dispatch_queue_t queue;
+@property (nonatomic, strong) NSData *frame;
+@property (nonatomic) int frameWidth;
+@property (nonatomic) int frameHeight;
+@end
+
+@implementation CameraScanner
+- (void)captureOutput:(AVCaptureOutput *)output didOutputSampleBuffer:(CMSampleBufferRef)sampleBuffer fromConnection:(AVCaptureConnection *)connection {
+    CVImageBufferRef pixelBuffer = CMSampleBufferGetImageBuffer(sampleBuffer);
+    CVPixelBufferLockBaseAddress(pixelBuffer, kCVPixelBufferLock_ReadOnly);
+    // The luminance is the first plane of the bi-planar YCbCr frames.
+    size_t width = CVPixelBufferGetWidthOfPlane(pixelBuffer, 0);
+    size_t height = CVPixelBufferGetHeightOfPlane(pixelBuffer, 0);
+    size_t stride = CVPixelBufferGetBytesPerRowOfPlane(pixelBuffer, 0);
+    const unsigned char *base = CVPixelBufferGetBaseAddressOfPlane(pixelBuffer, 0);
+    NSMutableData *luminance = [NSMutableData dataWithLength:width * height];
+    unsigned char *dst = luminance.mutableBytes;
+    for (size_t y = 0; y < height; y++) {
+        memcpy(dst + y * width, base + y * stride, width);
+    }
+    CVPixelBufferUnlockBaseAddress(pixelBuffer, kCVPixelBufferLock_ReadOnly);
+
+    @synchronized (self) {
+        self.frame = luminance;
+        self.frameWidth = (int)width;
+        self.frameHeight = (int)height;
+    }
+}
+@end
+
+static CameraScanner *scanner;
+static BOOL accessRequested;
+
+BOOL hasCamera(void){
+    return [AVCaptureDevice defaultDeviceWithMediaType:AVMediaTypeVideo] != nil;
+}
+
+// startCamera returns 0 once the camera is starting, 1 while the user is
+// asked for access to it and 2 if access was denied or it cannot be used.
+int startCamera(void){
+    switch ([AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeVideo]) {
+    case AVAuthorizationStatusAuthorized:
+        break;
+    case AVAuthorizationStatusNotDetermined:
+        if (!accessRequested) {
+            accessRequested = YES;
+            [AVCaptureDevice requestAccessForMediaType:AVMediaTypeVideo completionHandler:^(BOOL granted) {}];
+        }
+        return 1;
+    default:
+        return 2;
+    }
+
+    @synchronized ([CameraScanner class]) {
+        if (scanner != nil) {
+            return 0;
+        }
+
+        AVCaptureDevice *camera = [AVCaptureDevice defaultDeviceWithMediaType:AVMediaTypeVideo];
+        NSError *err = nil;
+        AVCaptureDeviceInput *input = [AVCaptureDeviceInput deviceInputWithDevice:camera error:&err];
+        if (input == nil) {
+            return 2;
+        }
+
+        CameraScanner *s = [[CameraScanner alloc] init];
+        s.session = [[AVCaptureSession alloc] init];
+        if ([s.session canSetSessionPreset:AVCaptureSessionPreset640x480]) {
+            s.session.sessionPreset = AVCaptureSessionPreset640x480;
+        }
+        AVCaptureVideoDataOutput *output = [[AVCaptureVideoDataOutput alloc] init];
+        output.videoSettings = @{(id)kCVPixelBufferPixelFormatTypeKey: @(kCVPixelFormatType_420YpCbCr8BiPlanarFullRange)};
+        output.alwaysDiscardsLateVideoFrames = YES;
+        s.queue = dispatch_queue_create("cryptopower.camera", DISPATCH_QUEUE_SERIAL);
+        [output setSampleBufferDelegate:s queue:s.queue];
+        if (![s.session canAddInput:input] || ![s.session canAddOutput:output]) {
+            return 2;
+        }
+        [s.session addInput:input];
+        [s.session addOutput:output];
+
+        scanner = s;
+        // startRunning blocks until the camera runs, keep it off the caller.
+        dispatch_async(s.queue, ^{
+            [s.session startRunning];
+        });
+    }
+    return 0;
+}
+
+void stopCamera(void){
+    @synchronized ([CameraScanner class]) {
+        CameraScanner *s = scanner;
+        scanner = nil;
+        accessRequested = NO;
+        if (s == nil) {
+            return;
+        }
+        dispatch_async(s.queue, ^{
+            [s.session stopRunning];
+        });
+    }
+}
+
+// takeCameraFrame copies the latest frame into buf and returns 1, or returns
+// 0 if there was no frame since the last call. If buf is too small, -1 is
+// returned with the size of the frame in width and height.
+int takeCameraFrame(unsigned char *buf, int bufLen, int *width, int *height){
+    CameraScanner *s;
+    @synchronized ([CameraScanner class]) {
+        s = scanner;
+    }
+    if (s == nil) {
+        return 0;
+    }
+
+    @synchronized (s) {
+        if (s.frame == nil) {
+            return 0;
+        }
+        *width = s.frameWidth;
+        *height = s.frameHeight;
+        if (bufLen < (int)s.frame.length) {
+            return -1;
+        }
+        memcpy(buf, s.frame.bytes, s.frame.length);
+        s.frame = nil;
+        return 1;
+    }
 }
\ No newline at end of file
@@ -4,6 +4,8 @@
 package device
 
 import (
+	"image"
+
 	"gioui.org/app"
 	"gioui.org/io/event"
 )
@@ -19,3 +21,17 @@ func (d *Device) setScreenAwake(_ bool) error {
 }
 
 func (d *Device) listenEvents(_ event.Event) {}
+
+func (d *Device) hasCamera() bool {
+	return false
+}
+
+func (d *Device) startCamera() error {
+	return ErrNotAvailable
+}
+
+func (d *Device) stopCamera() {}
+
+func (d *Device) cameraFrame() *image.Gray {
+	return nil
+}
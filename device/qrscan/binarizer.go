@@ -0,0 +1,224 @@
+package qrscan
+
+import (
+	"image"
+	"image/color"
+)
+
+// bitMatrix is a two dimensional array of bits, true for black.
+type bitMatrix struct {
+	width, height int
+	bits          []bool
+}
+
+func newBitMatrix(width, height int) *bitMatrix {
+	return &bitMatrix{width: width, height: height, bits: make([]bool, width*height)}
+}
+
+func (m *bitMatrix) get(x, y int) bool {
+	return m.bits[y*m.width+x]
+}
+
+func (m *bitMatrix) set(x, y int) {
+	m.bits[y*m.width+x] = true
+}
+
+func (m *bitMatrix) flip(x, y int) {
+	m.bits[y*m.width+x] = !m.bits[y*m.width+x]
+}
+
+// setRegion sets the bits of the rectangle of the provided size whose top
+// left corner is at left, top.
+func (m *bitMatrix) setRegion(left, top, width, height int) {
+	for y := top; y < top+height; y++ {
+		for x := left; x < left+width; x++ {
+			m.set(x, y)
+		}
+	}
+}
+
+// luminanceSource holds the luminance of every pixel of an image, row by row.
+type luminanceSource struct {
+	width, height int
+	pixels        []byte
+}
+
+// newLuminanceSource returns the luminance of img. Gray and YCbCr images,
+// what cameras produce, are read without conversion.
+func newLuminanceSource(img image.Image) *luminanceSource {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	src := &luminanceSource{width: width, height: height, pixels: make([]byte, width*height)}
+
+	switch img := img.(type) {
+	case *image.Gray:
+		for y := 0; y < height; y++ {
+			start := img.PixOffset(bounds.Min.X, bounds.Min.Y+y)
+			copy(src.pixels[y*width:(y+1)*width], img.Pix[start:start+width])
+		}
+	case *image.YCbCr:
+		for y := 0; y < height; y++ {
+			start := img.YOffset(bounds.Min.X, bounds.Min.Y+y)
+			copy(src.pixels[y*width:(y+1)*width], img.Y[start:start+width])
+		}
+	default:
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				gray := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+				src.pixels[y*width+x] = gray.Y
+			}
+		}
+	}
+	return src
+}
+
+const (
+	// binarizerBlockSize is the size of the square blocks whose black point
+	// is computed separately by the hybrid binarizer.
+	binarizerBlockSize = 8
+	// binarizerMinDynamicRange is the luminance range below which a block is
+	// considered to be of a single color.
+	binarizerMinDynamicRange = 24
+	// minHybridSize is the smallest width or height the hybrid binarizer
+	// works on. Smaller images are binarized with a single threshold.
+	minHybridSize = 5 * binarizerBlockSize
+)
+
+// binarize converts the luminance of an image to black and white. Each block
+// of 8x8 pixels is thresholded at the average of the 5x5 blocks around it, so
+// that shadows and uneven lighting, common on camera frames, do not wash out
+// part of a code.
+func binarize(src *luminanceSource) (*bitMatrix, error) {
+	if src.width < minHybridSize || src.height < minHybridSize {
+		return binarizeGlobal(src)
+	}
+
+	subWidth := (src.width + binarizerBlockSize - 1) / binarizerBlockSize
+	subHeight := (src.height + binarizerBlockSize - 1) / binarizerBlockSize
+	blackPoints := blockBlackPoints(src, subWidth, subHeight)
+
+	matrix := newBitMatrix(src.width, src.height)
+	for y := 0; y < subHeight; y++ {
+		yOffset := min(y*binarizerBlockSize, src.height-binarizerBlockSize)
+		top := clamp(y, 2, subHeight-3)
+		for x := 0; x < subWidth; x++ {
+			xOffset := min(x*binarizerBlockSize, src.width-binarizerBlockSize)
+			left := clamp(x, 2, subWidth-3)
+			var sum int
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					sum += blackPoints[top+dy][left+dx]
+				}
+			}
+			threshold := sum / 25
+			for yy := 0; yy < binarizerBlockSize; yy++ {
+				offset := (yOffset+yy)*src.width + xOffset
+				for xx := 0; xx < binarizerBlockSize; xx++ {
+					if int(src.pixels[offset+xx]) <= threshold {
+						matrix.set(xOffset+xx, yOffset+yy)
+					}
+				}
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// blockBlackPoints returns the black point of each block of the image. A
+// block of a single color takes the black point of its neighbours, so that
+// the inside of a large black or white area is not thresholded on noise.
+func blockBlackPoints(src *luminanceSource, subWidth, subHeight int) [][]int {
+	blackPoints := make([][]int, subHeight)
+	for y := 0; y < subHeight; y++ {
+		blackPoints[y] = make([]int, subWidth)
+		yOffset := min(y*binarizerBlockSize, src.height-binarizerBlockSize)
+		for x := 0; x < subWidth; x++ {
+			xOffset := min(x*binarizerBlockSize, src.width-binarizerBlockSize)
+			sum, minLum, maxLum := 0, 0xff, 0
+			for yy := 0; yy < binarizerBlockSize; yy++ {
+				offset := (yOffset+yy)*src.width + xOffset
+				for xx := 0; xx < binarizerBlockSize; xx++ {
+					pixel := int(src.pixels[offset+xx])
+					sum += pixel
+					minLum = min(minLum, pixel)
+					maxLum = max(maxLum, pixel)
+				}
+			}
+
+			average := sum / (binarizerBlockSize * binarizerBlockSize)
+			if maxLum-minLum <= binarizerMinDynamicRange {
+				// Assume the block is white, unless its neighbours say
+				// it is darker than their black point.
+				average = minLum / 2
+				if y > 0 && x > 0 {
+					neighbours := (blackPoints[y-1][x] + 2*blackPoints[y][x-1] + blackPoints[y-1][x-1]) / 4
+					if minLum < neighbours {
+						average = neighbours
+					}
+				}
+			}
+			blackPoints[y][x] = average
+		}
+	}
+	return blackPoints
+}
+
+// binarizeGlobal thresholds the whole image at the valley between the two
+// main peaks of its luminance histogram.
+func binarizeGlobal(src *luminanceSource) (*bitMatrix, error) {
+	const luminanceShift = 3
+	var buckets [256 >> luminanceShift]int
+	for _, pixel := range src.pixels {
+		buckets[pixel>>luminanceShift]++
+	}
+
+	var firstPeak, firstPeakSize, maxBucketCount int
+	for x, count := range buckets {
+		if count > firstPeakSize {
+			firstPeak, firstPeakSize = x, count
+		}
+		maxBucketCount = max(maxBucketCount, count)
+	}
+
+	var secondPeak, secondPeakScore int
+	for x, count := range buckets {
+		distance := x - firstPeak
+		if score := count * distance * distance; score > secondPeakScore {
+			secondPeak, secondPeakScore = x, score
+		}
+	}
+	if firstPeak > secondPeak {
+		firstPeak, secondPeak = secondPeak, firstPeak
+	}
+	if secondPeak-firstPeak <= len(buckets)/16 {
+		return nil, ErrNotFound
+	}
+
+	bestValley, bestValleyScore := secondPeak-1, -1
+	for x := secondPeak - 1; x > firstPeak; x-- {
+		fromFirst := x - firstPeak
+		score := fromFirst * fromFirst * (secondPeak - x) * (maxBucketCount - buckets[x])
+		if score > bestValleyScore {
+			bestValley, bestValleyScore = x, score
+		}
+	}
+
+	threshold := bestValley << luminanceShift
+	matrix := newBitMatrix(src.width, src.height)
+	for i, pixel := range src.pixels {
+		if int(pixel) < threshold {
+			matrix.bits[i] = true
+		}
+	}
+	return matrix, nil
+}
+
+func clamp(value, minValue, maxValue int) int {
+	if value < minValue {
+		return minValue
+	}
+	if value > maxValue {
+		return maxValue
+	}
+	return value
+}
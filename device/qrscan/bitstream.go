@@ -0,0 +1,239 @@
+package qrscan
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidData     = errors.New("invalid QR code data")
+	errUnsupportedMode = errors.New("unsupported QR code data mode")
+)
+
+// Modes of the segments of the data of a QR code.
+const (
+	modeTerminator         = 0x0
+	modeNumeric            = 0x1
+	modeAlphanumeric       = 0x2
+	modeStructuredAppend   = 0x3
+	modeByte               = 0x4
+	modeFNC1FirstPosition  = 0x5
+	modeECI                = 0x7
+	modeFNC1SecondPosition = 0x9
+)
+
+// ECI assignment numbers of the character sets byte segments are read in.
+const (
+	eciISO8859_1Legacy = 1
+	eciISO8859_1       = 3
+)
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// bitSource reads bits from a byte slice, most significant bit first.
+type bitSource struct {
+	data      []byte
+	bitOffset int
+}
+
+func (s *bitSource) available() int {
+	return 8*len(s.data) - s.bitOffset
+}
+
+func (s *bitSource) readBits(n int) (int, error) {
+	if n > s.available() {
+		return 0, errInvalidData
+	}
+	var result int
+	for i := 0; i < n; i++ {
+		bit := s.data[s.bitOffset/8] >> (7 - s.bitOffset%8) & 0x01
+		result = result<<1 | int(bit)
+		s.bitOffset++
+	}
+	return result, nil
+}
+
+// characterCountBits returns the length of the character count of a segment
+// of the provided mode in a code of the provided version.
+func characterCountBits(mode, version int) int {
+	sizeIndex := 2
+	if version <= 9 {
+		sizeIndex = 0
+	} else if version <= 26 {
+		sizeIndex = 1
+	}
+
+	switch mode {
+	case modeNumeric:
+		return [3]int{10, 12, 14}[sizeIndex]
+	case modeAlphanumeric:
+		return [3]int{9, 11, 13}[sizeIndex]
+	default:
+		return [3]int{8, 16, 16}[sizeIndex]
+	}
+}
+
+// decodeBitStream decodes the segments of the data codewords of a code.
+// Kanji and Hanzi segments are not supported, payment URIs never use them.
+func decodeBitStream(data []byte, version int) (string, error) {
+	source := &bitSource{data: data}
+	var result strings.Builder
+	eci := -1
+	fnc1InEffect := false
+
+	for {
+		mode := modeTerminator
+		if source.available() >= 4 {
+			mode, _ = source.readBits(4)
+		}
+
+		var err error
+		switch mode {
+		case modeTerminator:
+			return result.String(), nil
+		case modeFNC1FirstPosition:
+			fnc1InEffect = true
+		case modeFNC1SecondPosition:
+			fnc1InEffect = true
+			// Skip the application indicator.
+			_, err = source.readBits(8)
+		case modeStructuredAppend:
+			// Skip the sequence number and parity, each symbol of a
+			// structured append is decoded on its own.
+			_, err = source.readBits(16)
+		case modeECI:
+			eci, err = parseECIValue(source)
+		case modeNumeric, modeAlphanumeric, modeByte:
+			var count int
+			count, err = source.readBits(characterCountBits(mode, version))
+			if err != nil {
+				break
+			}
+			switch mode {
+			case modeNumeric:
+				err = decodeNumericSegment(source, &result, count)
+			case modeAlphanumeric:
+				err = decodeAlphanumericSegment(source, &result, count, fnc1InEffect)
+			default:
+				err = decodeByteSegment(source, &result, count, eci)
+			}
+		default:
+			return "", errUnsupportedMode
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func parseECIValue(source *bitSource) (int, error) {
+	firstByte, err := source.readBits(8)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case firstByte&0x80 == 0:
+		return firstByte & 0x7f, nil
+	case firstByte&0xc0 == 0x80:
+		secondByte, err := source.readBits(8)
+		return (firstByte&0x3f)<<8 | secondByte, err
+	case firstByte&0xe0 == 0xc0:
+		secondThirdBytes, err := source.readBits(16)
+		return (firstByte&0x1f)<<16 | secondThirdBytes, err
+	default:
+		return 0, errInvalidData
+	}
+}
+
+func decodeNumericSegment(source *bitSource, result *strings.Builder, count int) error {
+	appendDigits := func(bits, limit int) error {
+		value, err := source.readBits(bits)
+		if err != nil {
+			return err
+		}
+		if value >= limit {
+			return errInvalidData
+		}
+		for divisor := limit / 10; divisor > 0; divisor /= 10 {
+			result.WriteByte(byte('0' + value/divisor%10))
+		}
+		return nil
+	}
+
+	// Digits are read three at a time in 10 bits, with the last one or two
+	// in 4 or 7 bits.
+	for ; count >= 3; count -= 3 {
+		if err := appendDigits(10, 1000); err != nil {
+			return err
+		}
+	}
+	switch count {
+	case 2:
+		return appendDigits(7, 100)
+	case 1:
+		return appendDigits(4, 10)
+	}
+	return nil
+}
+
+func decodeAlphanumericSegment(source *bitSource, result *strings.Builder, count int, fnc1InEffect bool) error {
+	var segment []byte
+	// Characters are read two at a time in 11 bits, with the last one in 6
+	// bits.
+	for ; count > 1; count -= 2 {
+		nextTwo, err := source.readBits(11)
+		if err != nil {
+			return err
+		}
+		if nextTwo >= 45*45 {
+			return errInvalidData
+		}
+		segment = append(segment, alphanumericChars[nextTwo/45], alphanumericChars[nextTwo%45])
+	}
+	if count == 1 {
+		next, err := source.readBits(6)
+		if err != nil {
+			return err
+		}
+		if next >= 45 {
+			return errInvalidData
+		}
+		segment = append(segment, alphanumericChars[next])
+	}
+
+	if fnc1InEffect {
+		// In GS1 data, % is the group separator and %% a literal %.
+		s := strings.ReplaceAll(string(segment), "%%", "\x00")
+		s = strings.ReplaceAll(s, "%", "\x1d")
+		segment = []byte(strings.ReplaceAll(s, "\x00", "%"))
+	}
+	result.Write(segment)
+	return nil
+}
+
+func decodeByteSegment(source *bitSource, result *strings.Builder, count, eci int) error {
+	if 8*count > source.available() {
+		return errInvalidData
+	}
+	segment := make([]byte, count)
+	for i := range segment {
+		b, _ := source.readBits(8)
+		segment[i] = byte(b)
+	}
+
+	// Without an ECI, the character set is guessed: UTF-8 if the bytes are
+	// valid UTF-8, ISO-8859-1 as the standard says otherwise.
+	isLatin1 := eci == eciISO8859_1 || eci == eciISO8859_1Legacy
+	if eci == -1 {
+		isLatin1 = !utf8.Valid(segment)
+	}
+	if !isLatin1 {
+		result.Write(segment)
+		return nil
+	}
+	for _, b := range segment {
+		result.WriteRune(rune(b))
+	}
+	return nil
+}
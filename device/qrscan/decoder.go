@@ -0,0 +1,273 @@
+package qrscan
+
+// decodeMatrix reads the codewords of the sampled modules of a QR code,
+// corrects their errors and decodes the data they hold.
+func decodeMatrix(bits *bitMatrix) (string, error) {
+	dimension := bits.width
+	if dimension < 21 || dimension&0x03 != 1 {
+		return "", ErrNotFound
+	}
+
+	level, mask, err := readFormatInformation(bits)
+	if err != nil {
+		return "", err
+	}
+	version, err := readVersion(bits)
+	if err != nil {
+		return "", err
+	}
+
+	unmask(bits, mask)
+	ecBlocks := &ecBlocksTable[version-1][level]
+	codewords, err := readCodewords(bits, version, ecBlocks.totalCodewords())
+	if err != nil {
+		return "", err
+	}
+
+	data, err := correctBlocks(codewords, ecBlocks)
+	if err != nil {
+		return "", err
+	}
+	return decodeBitStream(data, version)
+}
+
+// readFormatInformation reads both copies of the format information, around
+// the top left finder pattern and split between the other two.
+func readFormatInformation(bits *bitMatrix) (ecLevel, int, error) {
+	var copy1 uint32
+	copyBit := func(info *uint32, x, y int) {
+		*info <<= 1
+		if bits.get(x, y) {
+			*info |= 1
+		}
+	}
+
+	for x := 0; x < 6; x++ {
+		copyBit(&copy1, x, 8)
+	}
+	copyBit(&copy1, 7, 8)
+	copyBit(&copy1, 8, 8)
+	copyBit(&copy1, 8, 7)
+	for y := 5; y >= 0; y-- {
+		copyBit(&copy1, 8, y)
+	}
+
+	dimension := bits.width
+	var copy2 uint32
+	for y := dimension - 1; y >= dimension-7; y-- {
+		copyBit(&copy2, 8, y)
+	}
+	for x := dimension - 8; x < dimension; x++ {
+		copyBit(&copy2, x, 8)
+	}
+
+	return decodeFormatInfo(copy1, copy2)
+}
+
+// readVersion returns the version of the code, from its dimension up to
+// version 6 and from either copy of the version information from version 7.
+func readVersion(bits *bitMatrix) (int, error) {
+	dimension := bits.width
+	provisionalVersion := (dimension - 17) / 4
+	if provisionalVersion <= 6 {
+		return provisionalVersion, nil
+	}
+
+	ijMin := dimension - 11
+	readCopy := func(transposed bool) uint32 {
+		var info uint32
+		for j := 5; j >= 0; j-- {
+			for i := dimension - 9; i >= ijMin; i-- {
+				info <<= 1
+				x, y := i, j
+				if transposed {
+					x, y = j, i
+				}
+				if bits.get(x, y) {
+					info |= 1
+				}
+			}
+		}
+		return info
+	}
+
+	for _, transposed := range []bool{false, true} {
+		version, err := decodeVersionInfo(readCopy(transposed))
+		if err == nil && dimensionForVersion(version) == dimension {
+			return version, nil
+		}
+	}
+	return 0, errInvalidInfo
+}
+
+// isMasked returns whether the module at row i, column j is flipped by the
+// data mask.
+func isMasked(mask, i, j int) bool {
+	switch mask {
+	case 0:
+		return (i+j)&0x01 == 0
+	case 1:
+		return i&0x01 == 0
+	case 2:
+		return j%3 == 0
+	case 3:
+		return (i+j)%3 == 0
+	case 4:
+		return (i/2+j/3)&0x01 == 0
+	case 5:
+		return (i*j)%6 == 0
+	case 6:
+		return (i*j)%6 < 3
+	default:
+		return (i+j+(i*j)%3)&0x01 == 0
+	}
+}
+
+func unmask(bits *bitMatrix, mask int) {
+	for i := 0; i < bits.height; i++ {
+		for j := 0; j < bits.width; j++ {
+			if isMasked(mask, i, j) {
+				bits.flip(j, i)
+			}
+		}
+	}
+}
+
+// functionPattern returns the modules of a code of the provided version that
+// do not hold data: the finder, alignment and timing patterns, and the
+// format and version information.
+func functionPattern(version int) *bitMatrix {
+	dimension := dimensionForVersion(version)
+	pattern := newBitMatrix(dimension, dimension)
+
+	// Finder patterns with their separators and the format information.
+	pattern.setRegion(0, 0, 9, 9)
+	pattern.setRegion(dimension-8, 0, 8, 9)
+	pattern.setRegion(0, dimension-8, 9, 8)
+
+	// Alignment patterns, except where they would overlap a finder pattern.
+	centers := alignmentPatternCenters[version-1]
+	last := len(centers) - 1
+	for x, cx := range centers {
+		for y, cy := range centers {
+			if (x == 0 && (y == 0 || y == last)) || (x == last && y == 0) {
+				continue
+			}
+			pattern.setRegion(cy-2, cx-2, 5, 5)
+		}
+	}
+
+	// Timing patterns.
+	pattern.setRegion(6, 9, 1, dimension-17)
+	pattern.setRegion(9, 6, dimension-17, 1)
+
+	if version > 6 {
+		// Version information.
+		pattern.setRegion(dimension-11, 0, 3, 6)
+		pattern.setRegion(0, dimension-11, 6, 3)
+	}
+	return pattern
+}
+
+// readCodewords reads the data modules two columns at a time, zigzagging up
+// and down from the bottom right corner.
+func readCodewords(bits *bitMatrix, version, totalCodewords int) ([]byte, error) {
+	pattern := functionPattern(version)
+	dimension := bits.width
+
+	result := make([]byte, 0, totalCodewords)
+	var currentByte byte
+	var bitsRead int
+	readingUp := true
+	for j := dimension - 1; j > 0; j -= 2 {
+		if j == 6 {
+			// Skip the vertical timing pattern.
+			j--
+		}
+		for count := 0; count < dimension; count++ {
+			i := count
+			if readingUp {
+				i = dimension - 1 - count
+			}
+			for col := 0; col < 2; col++ {
+				if pattern.get(j-col, i) {
+					continue
+				}
+				currentByte <<= 1
+				if bits.get(j-col, i) {
+					currentByte |= 1
+				}
+				bitsRead++
+				if bitsRead == 8 && len(result) < totalCodewords {
+					result = append(result, currentByte)
+					bitsRead, currentByte = 0, 0
+				}
+			}
+		}
+		readingUp = !readingUp
+	}
+	if len(result) != totalCodewords {
+		return nil, errInvalidInfo
+	}
+	return result, nil
+}
+
+// correctBlocks deinterleaves the codewords into their error correction
+// blocks, corrects each block and returns the data codewords in order.
+func correctBlocks(codewords []byte, ecBlocks *ecBlocks) ([]byte, error) {
+	type dataBlock struct {
+		numDataCodewords int
+		codewords        []byte
+	}
+
+	var blocks []*dataBlock
+	for _, group := range ecBlocks.blocks {
+		for i := 0; i < group.count; i++ {
+			blocks = append(blocks, &dataBlock{
+				numDataCodewords: group.dataCodewords,
+				codewords:        make([]byte, group.dataCodewords+ecBlocks.ecCodewordsPerBlock),
+			})
+		}
+	}
+
+	// The longer blocks, with one more data codeword, come last.
+	shorterBlocksTotalCodewords := len(blocks[0].codewords)
+	longerBlocksStartAt := len(blocks)
+	for longerBlocksStartAt > 0 && len(blocks[longerBlocksStartAt-1].codewords) != shorterBlocksTotalCodewords {
+		longerBlocksStartAt--
+	}
+	shorterBlocksNumDataCodewords := shorterBlocksTotalCodewords - ecBlocks.ecCodewordsPerBlock
+
+	// The data codewords of all blocks are interleaved, then the extra data
+	// codeword of the longer blocks, then the error correction codewords.
+	offset := 0
+	for i := 0; i < shorterBlocksNumDataCodewords; i++ {
+		for _, block := range blocks {
+			block.codewords[i] = codewords[offset]
+			offset++
+		}
+	}
+	for _, block := range blocks[longerBlocksStartAt:] {
+		block.codewords[shorterBlocksNumDataCodewords] = codewords[offset]
+		offset++
+	}
+	for i := shorterBlocksNumDataCodewords; i < shorterBlocksTotalCodewords; i++ {
+		for j, block := range blocks {
+			iOffset := i
+			if j >= longerBlocksStartAt {
+				iOffset++
+			}
+			block.codewords[iOffset] = codewords[offset]
+			offset++
+		}
+	}
+
+	var data []byte
+	for _, block := range blocks {
+		if err := rsCorrect(block.codewords, ecBlocks.ecCodewordsPerBlock); err != nil {
+			return nil, err
+		}
+		data = append(data, block.codewords[:block.numDataCodewords]...)
+	}
+	return data, nil
+}
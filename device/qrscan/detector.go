@@ -0,0 +1,320 @@
+package qrscan
+
+import "math"
+
+// detect finds a QR code in the image and samples its modules, true for
+// dark, into a square matrix of the code's dimension.
+func detect(image *bitMatrix) (*bitMatrix, error) {
+	finder := &finderPatternFinder{image: image}
+	patterns, err := finder.find()
+	if err != nil {
+		return nil, err
+	}
+	bottomLeft, topLeft, topRight := patterns[0], patterns[1], patterns[2]
+
+	moduleSize := calculateModuleSize(image, topLeft, topRight, bottomLeft)
+	if moduleSize < 1 {
+		return nil, ErrNotFound
+	}
+	dimension, err := computeDimension(topLeft, topRight, bottomLeft, moduleSize)
+	if err != nil {
+		return nil, err
+	}
+	version := (dimension - 17) / 4
+	if version < 1 || version > 40 {
+		return nil, ErrNotFound
+	}
+
+	// Codes from version 2 have an alignment pattern near the bottom right
+	// corner, which corrects the perspective better than guessing where
+	// the corner is from the finder patterns.
+	var alignment *resultPoint
+	if len(alignmentPatternCenters[version-1]) > 0 {
+		bottomRightX := topRight.x - topLeft.x + bottomLeft.x
+		bottomRightY := topRight.y - topLeft.y + bottomLeft.y
+		correctionToTopLeft := 1 - 3/float64(dimension-7)
+		estAlignmentX := int(topLeft.x + correctionToTopLeft*(bottomRightX-topLeft.x))
+		estAlignmentY := int(topLeft.y + correctionToTopLeft*(bottomRightY-topLeft.y))
+		for allowance := 4.0; allowance <= 16; allowance *= 2 {
+			alignment, err = findAlignmentInRegion(image, moduleSize, estAlignmentX, estAlignmentY, allowance)
+			if err == nil {
+				break
+			}
+		}
+	}
+
+	transform := createTransform(topLeft, topRight, bottomLeft, alignment, dimension)
+	return sampleGrid(image, transform, dimension)
+}
+
+// calculateModuleSize estimates the size of a module from the finder
+// patterns' black-white-black runs towards each other.
+func calculateModuleSize(image *bitMatrix, topLeft, topRight, bottomLeft *resultPoint) float64 {
+	return (calculateModuleSizeOneWay(image, topLeft, topRight) + calculateModuleSizeOneWay(image, topLeft, bottomLeft)) / 2
+}
+
+func calculateModuleSizeOneWay(image *bitMatrix, pattern, other *resultPoint) float64 {
+	est1 := sizeOfBlackWhiteBlackRunBothWays(image, int(pattern.x), int(pattern.y), int(other.x), int(other.y))
+	est2 := sizeOfBlackWhiteBlackRunBothWays(image, int(other.x), int(other.y), int(pattern.x), int(pattern.y))
+	switch {
+	case math.IsNaN(est1):
+		return est2 / 7
+	case math.IsNaN(est2):
+		return est1 / 7
+	default:
+		return (est1 + est2) / 14
+	}
+}
+
+// sizeOfBlackWhiteBlackRunBothWays measures the width of the finder pattern
+// at fromX, fromY along the line towards toX, toY, in both directions from
+// its center.
+func sizeOfBlackWhiteBlackRunBothWays(image *bitMatrix, fromX, fromY, toX, toY int) float64 {
+	result := sizeOfBlackWhiteBlackRun(image, fromX, fromY, toX, toY)
+
+	// Now count the other way, clipping the line to the image.
+	scale := 1.0
+	otherToX := fromX - (toX - fromX)
+	if otherToX < 0 {
+		scale = float64(fromX) / float64(fromX-otherToX)
+		otherToX = 0
+	} else if otherToX >= image.width {
+		scale = float64(image.width-1-fromX) / float64(otherToX-fromX)
+		otherToX = image.width - 1
+	}
+	otherToY := int(float64(fromY) - float64(toY-fromY)*scale)
+
+	scale = 1.0
+	if otherToY < 0 {
+		scale = float64(fromY) / float64(fromY-otherToY)
+		otherToY = 0
+	} else if otherToY >= image.height {
+		scale = float64(image.height-1-fromY) / float64(otherToY-fromY)
+		otherToY = image.height - 1
+	}
+	otherToX = int(float64(fromX) + float64(otherToX-fromX)*scale)
+
+	result += sizeOfBlackWhiteBlackRun(image, fromX, fromY, otherToX, otherToY)
+	// The center pixel was counted twice.
+	return result - 1
+}
+
+// sizeOfBlackWhiteBlackRun returns the distance from fromX, fromY to the end
+// of the black, white and black runs along the line towards toX, toY, or NaN
+// if the line ends first.
+func sizeOfBlackWhiteBlackRun(image *bitMatrix, fromX, fromY, toX, toY int) float64 {
+	// Walk along the line with Bresenham's algorithm, on the axis where it
+	// moves the most.
+	steep := absInt(toY-fromY) > absInt(toX-fromX)
+	if steep {
+		fromX, fromY = fromY, fromX
+		toX, toY = toY, toX
+	}
+
+	dx, dy := absInt(toX-fromX), absInt(toY-fromY)
+	err := -dx / 2
+	xStep, yStep := 1, 1
+	if fromX > toX {
+		xStep = -1
+	}
+	if fromY > toY {
+		yStep = -1
+	}
+
+	// In black pixels, looking for white, first or second time.
+	state := 0
+	xLimit := toX + xStep
+	for x, y := fromX, fromY; x != xLimit; x += xStep {
+		realX, realY := x, y
+		if steep {
+			realX, realY = y, x
+		}
+		if (state == 1) == image.get(realX, realY) {
+			if state == 2 {
+				return math.Hypot(float64(x-fromX), float64(y-fromY))
+			}
+			state++
+		}
+
+		err += dy
+		if err > 0 {
+			if y == toY {
+				break
+			}
+			y += yStep
+			err -= dx
+		}
+	}
+	if state == 2 {
+		return math.Hypot(float64(toX+xStep-fromX), float64(toY-fromY))
+	}
+	return math.NaN()
+}
+
+// computeDimension returns the number of modules on a side of the code from
+// the distances between the finder patterns.
+func computeDimension(topLeft, topRight, bottomLeft *resultPoint, moduleSize float64) (int, error) {
+	tltrCentersDimension := int(math.Round(distance(topLeft, topRight) / moduleSize))
+	tlblCentersDimension := int(math.Round(distance(topLeft, bottomLeft) / moduleSize))
+	dimension := (tltrCentersDimension+tlblCentersDimension)/2 + 7
+
+	// The dimension of a QR code is 1 modulo 4.
+	switch dimension & 0x03 {
+	case 0:
+		dimension++
+	case 2:
+		dimension--
+	case 3:
+		return 0, ErrNotFound
+	}
+	return dimension, nil
+}
+
+// findAlignmentInRegion looks for the alignment pattern within allowance
+// modules of its estimated position.
+func findAlignmentInRegion(image *bitMatrix, moduleSize float64, estX, estY int, allowance float64) (*resultPoint, error) {
+	offset := int(allowance * moduleSize)
+	left := max(0, estX-offset)
+	right := min(image.width-1, estX+offset)
+	if float64(right-left) < moduleSize*3 {
+		return nil, ErrNotFound
+	}
+	top := max(0, estY-offset)
+	bottom := min(image.height-1, estY+offset)
+	if float64(bottom-top) < moduleSize*3 {
+		return nil, ErrNotFound
+	}
+
+	finder := &alignmentPatternFinder{
+		image:      image,
+		startX:     left,
+		startY:     top,
+		width:      right - left,
+		height:     bottom - top,
+		moduleSize: moduleSize,
+	}
+	return finder.find()
+}
+
+// createTransform returns the transform from module coordinates to image
+// coordinates, anchored on the centers of the finder patterns and of the
+// alignment pattern when there is one.
+func createTransform(topLeft, topRight, bottomLeft, alignment *resultPoint, dimension int) *perspectiveTransform {
+	dimMinusThree := float64(dimension) - 3.5
+	var bottomRightX, bottomRightY, sourceBottomRightX, sourceBottomRightY float64
+	if alignment != nil {
+		bottomRightX, bottomRightY = alignment.x, alignment.y
+		sourceBottomRightX, sourceBottomRightY = dimMinusThree-3, dimMinusThree-3
+	} else {
+		// Don't have an alignment pattern, just make up the bottom right
+		// point.
+		bottomRightX = topRight.x - topLeft.x + bottomLeft.x
+		bottomRightY = topRight.y - topLeft.y + bottomLeft.y
+		sourceBottomRightX, sourceBottomRightY = dimMinusThree, dimMinusThree
+	}
+
+	return quadrilateralToQuadrilateral(
+		[4][2]float64{{3.5, 3.5}, {dimMinusThree, 3.5}, {sourceBottomRightX, sourceBottomRightY}, {3.5, dimMinusThree}},
+		[4][2]float64{{topLeft.x, topLeft.y}, {topRight.x, topRight.y}, {bottomRightX, bottomRightY}, {bottomLeft.x, bottomLeft.y}},
+	)
+}
+
+// sampleGrid reads the module at the center of each cell of the grid.
+func sampleGrid(image *bitMatrix, transform *perspectiveTransform, dimension int) (*bitMatrix, error) {
+	bits := newBitMatrix(dimension, dimension)
+	for y := 0; y < dimension; y++ {
+		for x := 0; x < dimension; x++ {
+			px, py := transform.transform(float64(x)+0.5, float64(y)+0.5)
+			ix, iy, ok := nudgePoint(image, px, py)
+			if !ok {
+				return nil, ErrNotFound
+			}
+			if image.get(ix, iy) {
+				bits.set(x, y)
+			}
+		}
+	}
+	return bits, nil
+}
+
+// nudgePoint returns the pixel at x, y, moving points just outside the image
+// onto its edge. Points further out mean the code was not detected right.
+func nudgePoint(image *bitMatrix, x, y float64) (int, int, bool) {
+	if math.IsNaN(x) || math.IsNaN(y) || x < -1 || y < -1 || x > float64(image.width) || y > float64(image.height) {
+		return 0, 0, false
+	}
+	return clamp(int(x), 0, image.width-1), clamp(int(y), 0, image.height-1), true
+}
+
+// perspectiveTransform maps points with the 3x3 matrix m, in homogeneous
+// coordinates.
+type perspectiveTransform struct {
+	m [3][3]float64
+}
+
+func (p *perspectiveTransform) transform(x, y float64) (float64, float64) {
+	denominator := p.m[2][0]*x + p.m[2][1]*y + p.m[2][2]
+	return (p.m[0][0]*x + p.m[0][1]*y + p.m[0][2]) / denominator,
+		(p.m[1][0]*x + p.m[1][1]*y + p.m[1][2]) / denominator
+}
+
+// times returns the transform applying other, then p.
+func (p *perspectiveTransform) times(other *perspectiveTransform) *perspectiveTransform {
+	product := new(perspectiveTransform)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				product.m[i][j] += p.m[i][k] * other.m[k][j]
+			}
+		}
+	}
+	return product
+}
+
+// adjoint returns the adjugate of the matrix, which is the inverse
+// transform up to a scale factor.
+func (p *perspectiveTransform) adjoint() *perspectiveTransform {
+	m := &p.m
+	return &perspectiveTransform{m: [3][3]float64{
+		{m[1][1]*m[2][2] - m[1][2]*m[2][1], m[0][2]*m[2][1] - m[0][1]*m[2][2], m[0][1]*m[1][2] - m[0][2]*m[1][1]},
+		{m[1][2]*m[2][0] - m[1][0]*m[2][2], m[0][0]*m[2][2] - m[0][2]*m[2][0], m[0][2]*m[1][0] - m[0][0]*m[1][2]},
+		{m[1][0]*m[2][1] - m[1][1]*m[2][0], m[0][1]*m[2][0] - m[0][0]*m[2][1], m[0][0]*m[1][1] - m[0][1]*m[1][0]},
+	}}
+}
+
+// quadrilateralToQuadrilateral returns the transform mapping the corners of
+// from onto those of to, in the same order.
+func quadrilateralToQuadrilateral(from, to [4][2]float64) *perspectiveTransform {
+	return squareToQuadrilateral(to).times(squareToQuadrilateral(from).adjoint())
+}
+
+// squareToQuadrilateral returns the transform mapping the corners (0, 0),
+// (1, 0), (1, 1) and (0, 1) of the unit square onto the corners of quad.
+func squareToQuadrilateral(quad [4][2]float64) *perspectiveTransform {
+	x0, y0 := quad[0][0], quad[0][1]
+	x1, y1 := quad[1][0], quad[1][1]
+	x2, y2 := quad[2][0], quad[2][1]
+	x3, y3 := quad[3][0], quad[3][1]
+
+	dx3 := x0 - x1 + x2 - x3
+	dy3 := y0 - y1 + y2 - y3
+	if dx3 == 0 && dy3 == 0 {
+		// The quadrilateral is a parallelogram, the transform is affine.
+		return &perspectiveTransform{m: [3][3]float64{
+			{x1 - x0, x2 - x1, x0},
+			{y1 - y0, y2 - y1, y0},
+			{0, 0, 1},
+		}}
+	}
+
+	dx1, dx2 := x1-x2, x3-x2
+	dy1, dy2 := y1-y2, y3-y2
+	denominator := dx1*dy2 - dx2*dy1
+	a13 := (dx3*dy2 - dx2*dy3) / denominator
+	a23 := (dx1*dy3 - dx3*dy1) / denominator
+	return &perspectiveTransform{m: [3][3]float64{
+		{x1 - x0 + a13*x1, x3 - x0 + a23*x3, x0},
+		{y1 - y0 + a13*y1, y3 - y0 + a23*y3, y0},
+		{a13, a23, 1},
+	}}
+}
@@ -0,0 +1,597 @@
+package qrscan
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// centerQuorum is the number of rows a finder pattern must be seen on
+	// before it is considered confirmed.
+	centerQuorum = 2
+	// minSkip is the smallest number of rows skipped between scans.
+	minSkip = 3
+	// maxModules is the largest code size the row skipping accounts for.
+	maxModules = 97
+)
+
+// resultPoint is the center of a finder or alignment pattern.
+type resultPoint struct {
+	x, y       float64
+	moduleSize float64
+	count      int
+}
+
+func distance(a, b *resultPoint) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+func squaredDistance(a, b *resultPoint) float64 {
+	dx, dy := a.x-b.x, a.y-b.y
+	return dx*dx + dy*dy
+}
+
+// aboutEquals returns whether the pattern at i, j with the provided module
+// size is the same as p.
+func (p *resultPoint) aboutEquals(moduleSize, i, j float64) bool {
+	if math.Abs(i-p.y) <= moduleSize && math.Abs(j-p.x) <= moduleSize {
+		diff := math.Abs(moduleSize - p.moduleSize)
+		return diff <= 1 || diff <= p.moduleSize
+	}
+	return false
+}
+
+// combineEstimate averages the pattern with another sighting of it.
+func (p *resultPoint) combineEstimate(i, j, moduleSize float64) *resultPoint {
+	count := float64(p.count)
+	return &resultPoint{
+		x:          (count*p.x + j) / (count + 1),
+		y:          (count*p.y + i) / (count + 1),
+		moduleSize: (count*p.moduleSize + moduleSize) / (count + 1),
+		count:      p.count + 1,
+	}
+}
+
+// finderPatternFinder looks for the three 1:1:3:1:1 square finder patterns
+// at the corners of a QR code.
+type finderPatternFinder struct {
+	image          *bitMatrix
+	possibleCenter []*resultPoint
+	hasSkipped     bool
+}
+
+// find returns the bottom left, top left and top right finder patterns.
+func (f *finderPatternFinder) find() ([3]*resultPoint, error) {
+	maxI, maxJ := f.image.height, f.image.width
+	iSkip := max((3*maxI)/(4*maxModules), minSkip)
+
+	var stateCount [5]int
+	done := false
+	for i := iSkip - 1; i < maxI && !done; i += iSkip {
+		stateCount = [5]int{}
+		currentState := 0
+		for j := 0; j < maxJ; j++ {
+			if f.image.get(j, i) {
+				if currentState&1 == 1 {
+					currentState++
+				}
+				stateCount[currentState]++
+				continue
+			}
+
+			if currentState&1 == 1 {
+				stateCount[currentState]++
+				continue
+			}
+			if currentState != 4 {
+				currentState++
+				stateCount[currentState]++
+				continue
+			}
+
+			if !foundPatternCross(stateCount) || !f.handlePossibleCenter(stateCount, i, j) {
+				shiftCounts2(&stateCount)
+				currentState = 3
+				continue
+			}
+
+			// Skip the rest of the pattern, a confirmed center was found.
+			iSkip = 2
+			if f.hasSkipped {
+				done = f.haveMultiplyConfirmedCenters()
+			} else if rowSkip := f.findRowSkip(); rowSkip > stateCount[2] {
+				// Jump to the row of the last pattern, past the other two.
+				i += rowSkip - stateCount[2] - iSkip
+				j = maxJ - 1
+			}
+			currentState = 0
+			stateCount = [5]int{}
+		}
+
+		if foundPatternCross(stateCount) && f.handlePossibleCenter(stateCount, i, maxJ) {
+			iSkip = stateCount[0]
+			if f.hasSkipped {
+				done = f.haveMultiplyConfirmedCenters()
+			}
+		}
+	}
+
+	patterns, err := f.selectBestPatterns()
+	if err != nil {
+		return patterns, err
+	}
+	orderBestPatterns(&patterns)
+	return patterns, nil
+}
+
+func shiftCounts2(stateCount *[5]int) {
+	stateCount[0] = stateCount[2]
+	stateCount[1] = stateCount[3]
+	stateCount[2] = stateCount[4]
+	stateCount[3] = 1
+	stateCount[4] = 0
+}
+
+func centerFromEnd(stateCount [5]int, end int) float64 {
+	return float64(end-stateCount[4]-stateCount[3]) - float64(stateCount[2])/2
+}
+
+// foundPatternCross returns whether the run lengths are close enough to the
+// 1:1:3:1:1 ratios of a finder pattern.
+func foundPatternCross(stateCount [5]int) bool {
+	return checkFinderRatios(stateCount, 2)
+}
+
+// foundPatternDiagonal is foundPatternCross with the larger tolerance of a
+// diagonal scan.
+func foundPatternDiagonal(stateCount [5]int) bool {
+	return checkFinderRatios(stateCount, 1.333)
+}
+
+func checkFinderRatios(stateCount [5]int, varianceDivisor float64) bool {
+	var total int
+	for _, count := range stateCount {
+		if count == 0 {
+			return false
+		}
+		total += count
+	}
+	if total < 7 {
+		return false
+	}
+	moduleSize := float64(total) / 7
+	maxVariance := moduleSize / varianceDivisor
+	return math.Abs(moduleSize-float64(stateCount[0])) < maxVariance &&
+		math.Abs(moduleSize-float64(stateCount[1])) < maxVariance &&
+		math.Abs(3*moduleSize-float64(stateCount[2])) < 3*maxVariance &&
+		math.Abs(moduleSize-float64(stateCount[3])) < maxVariance &&
+		math.Abs(moduleSize-float64(stateCount[4])) < maxVariance
+}
+
+// handlePossibleCenter cross checks a pattern found on row i ending at
+// column j vertically, horizontally and diagonally, and records its center.
+func (f *finderPatternFinder) handlePossibleCenter(stateCount [5]int, i, j int) bool {
+	total := stateCount[0] + stateCount[1] + stateCount[2] + stateCount[3] + stateCount[4]
+	centerJ := centerFromEnd(stateCount, j)
+	centerI := f.crossCheckVertical(i, int(centerJ), stateCount[2], total)
+	if math.IsNaN(centerI) {
+		return false
+	}
+	centerJ = f.crossCheckHorizontal(int(centerJ), int(centerI), stateCount[2], total)
+	if math.IsNaN(centerJ) || !f.crossCheckDiagonal(int(centerI), int(centerJ)) {
+		return false
+	}
+
+	moduleSize := float64(total) / 7
+	for index, center := range f.possibleCenter {
+		if center.aboutEquals(moduleSize, centerI, centerJ) {
+			f.possibleCenter[index] = center.combineEstimate(centerI, centerJ, moduleSize)
+			return true
+		}
+	}
+	f.possibleCenter = append(f.possibleCenter, &resultPoint{x: centerJ, y: centerI, moduleSize: moduleSize, count: 1})
+	return true
+}
+
+// crossCheckVertical scans the column centerJ up and down from startI and
+// returns the vertical center of the finder pattern, or NaN if the column
+// does not cross one of a size similar to originalTotal.
+func (f *finderPatternFinder) crossCheckVertical(startI, centerJ, maxCount, originalTotal int) float64 {
+	return crossCheckLine(func(k int) bool { return f.image.get(centerJ, k) }, f.image.height, startI, maxCount, originalTotal)
+}
+
+// crossCheckHorizontal is crossCheckVertical along the row centerI.
+func (f *finderPatternFinder) crossCheckHorizontal(startJ, centerI, maxCount, originalTotal int) float64 {
+	return crossCheckLine(func(k int) bool { return f.image.get(k, centerI) }, f.image.width, startJ, maxCount, originalTotal)
+}
+
+func crossCheckLine(get func(int) bool, size, start, maxCount, originalTotal int) float64 {
+	var stateCount [5]int
+
+	k := start
+	for k >= 0 && get(k) {
+		stateCount[2]++
+		k--
+	}
+	if k < 0 {
+		return math.NaN()
+	}
+	for k >= 0 && !get(k) && stateCount[1] <= maxCount {
+		stateCount[1]++
+		k--
+	}
+	if k < 0 || stateCount[1] > maxCount {
+		return math.NaN()
+	}
+	for k >= 0 && get(k) && stateCount[0] <= maxCount {
+		stateCount[0]++
+		k--
+	}
+	if stateCount[0] > maxCount {
+		return math.NaN()
+	}
+
+	k = start + 1
+	for k < size && get(k) {
+		stateCount[2]++
+		k++
+	}
+	if k == size {
+		return math.NaN()
+	}
+	for k < size && !get(k) && stateCount[3] < maxCount {
+		stateCount[3]++
+		k++
+	}
+	if k == size || stateCount[3] >= maxCount {
+		return math.NaN()
+	}
+	for k < size && get(k) && stateCount[4] < maxCount {
+		stateCount[4]++
+		k++
+	}
+	if stateCount[4] >= maxCount {
+		return math.NaN()
+	}
+
+	// Reject patterns much larger or smaller than the one found on the row.
+	total := stateCount[0] + stateCount[1] + stateCount[2] + stateCount[3] + stateCount[4]
+	if 5*absInt(total-originalTotal) >= 2*originalTotal {
+		return math.NaN()
+	}
+	if !foundPatternCross(stateCount) {
+		return math.NaN()
+	}
+	return centerFromEnd(stateCount, k)
+}
+
+// crossCheckDiagonal returns whether the diagonal through centerI, centerJ
+// crosses a finder pattern.
+func (f *finderPatternFinder) crossCheckDiagonal(centerI, centerJ int) bool {
+	var stateCount [5]int
+	get := func(offset int) bool { return f.image.get(centerJ+offset, centerI+offset) }
+
+	i := 0
+	for centerI >= i && centerJ >= i && get(-i) {
+		stateCount[2]++
+		i++
+	}
+	if stateCount[2] == 0 {
+		return false
+	}
+	for centerI >= i && centerJ >= i && !get(-i) {
+		stateCount[1]++
+		i++
+	}
+	if stateCount[1] == 0 {
+		return false
+	}
+	for centerI >= i && centerJ >= i && get(-i) {
+		stateCount[0]++
+		i++
+	}
+	if stateCount[0] == 0 {
+		return false
+	}
+
+	maxI, maxJ := f.image.height, f.image.width
+	i = 1
+	for centerI+i < maxI && centerJ+i < maxJ && get(i) {
+		stateCount[2]++
+		i++
+	}
+	for centerI+i < maxI && centerJ+i < maxJ && !get(i) {
+		stateCount[3]++
+		i++
+	}
+	if stateCount[3] == 0 {
+		return false
+	}
+	for centerI+i < maxI && centerJ+i < maxJ && get(i) {
+		stateCount[4]++
+		i++
+	}
+	if stateCount[4] == 0 {
+		return false
+	}
+	return foundPatternDiagonal(stateCount)
+}
+
+// findRowSkip returns how many rows can be skipped once two patterns are
+// confirmed: the third one cannot be above the row where the second was.
+func (f *finderPatternFinder) findRowSkip() int {
+	if len(f.possibleCenter) <= 1 {
+		return 0
+	}
+	var firstConfirmed *resultPoint
+	for _, center := range f.possibleCenter {
+		if center.count < centerQuorum {
+			continue
+		}
+		if firstConfirmed == nil {
+			firstConfirmed = center
+			continue
+		}
+		f.hasSkipped = true
+		return int((math.Abs(firstConfirmed.x-center.x) - math.Abs(firstConfirmed.y-center.y)) / 2)
+	}
+	return 0
+}
+
+// haveMultiplyConfirmedCenters returns whether three centers are confirmed
+// and their module sizes agree within 5%.
+func (f *finderPatternFinder) haveMultiplyConfirmedCenters() bool {
+	var confirmedCount int
+	var totalModuleSize float64
+	for _, center := range f.possibleCenter {
+		if center.count >= centerQuorum {
+			confirmedCount++
+			totalModuleSize += center.moduleSize
+		}
+	}
+	if confirmedCount < 3 {
+		return false
+	}
+
+	average := totalModuleSize / float64(len(f.possibleCenter))
+	var totalDeviation float64
+	for _, center := range f.possibleCenter {
+		totalDeviation += math.Abs(center.moduleSize - average)
+	}
+	return totalDeviation <= 0.05*totalModuleSize
+}
+
+// selectBestPatterns returns the three patterns of similar sizes that are
+// closest to the corners of a right isosceles triangle.
+func (f *finderPatternFinder) selectBestPatterns() ([3]*resultPoint, error) {
+	var best [3]*resultPoint
+
+	candidates := make([]*resultPoint, 0, len(f.possibleCenter))
+	for _, center := range f.possibleCenter {
+		if center.count >= centerQuorum {
+			candidates = append(candidates, center)
+		}
+	}
+	if len(candidates) < 3 {
+		candidates = append(candidates[:0], f.possibleCenter...)
+	}
+	if len(candidates) < 3 {
+		return best, ErrNotFound
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].moduleSize < candidates[j].moduleSize
+	})
+
+	distortion := math.MaxFloat64
+	for i := 0; i < len(candidates)-2; i++ {
+		fpi := candidates[i]
+		minModuleSize := fpi.moduleSize
+		for j := i + 1; j < len(candidates)-1; j++ {
+			fpj := candidates[j]
+			squares0 := squaredDistance(fpi, fpj)
+			for k := j + 1; k < len(candidates); k++ {
+				fpk := candidates[k]
+				if fpk.moduleSize > minModuleSize*1.4 {
+					continue
+				}
+
+				sides := []float64{squares0, squaredDistance(fpj, fpk), squaredDistance(fpi, fpk)}
+				sort.Float64s(sides)
+				a, b, c := sides[0], sides[1], sides[2]
+
+				// The hypotenuse is twice either other side squared.
+				if d := math.Abs(c-2*b) + math.Abs(c-2*a); d < distortion {
+					distortion = d
+					best = [3]*resultPoint{fpi, fpj, fpk}
+				}
+			}
+		}
+	}
+	if distortion == math.MaxFloat64 {
+		return best, ErrNotFound
+	}
+	return best, nil
+}
+
+// orderBestPatterns orders the patterns as bottom left, top left and top
+// right.
+func orderBestPatterns(patterns *[3]*resultPoint) {
+	zeroOne := distance(patterns[0], patterns[1])
+	oneTwo := distance(patterns[1], patterns[2])
+	zeroTwo := distance(patterns[0], patterns[2])
+
+	// The top left pattern is opposite the longest side.
+	var a, b, c *resultPoint
+	switch {
+	case oneTwo >= zeroOne && oneTwo >= zeroTwo:
+		b, a, c = patterns[0], patterns[1], patterns[2]
+	case zeroTwo >= oneTwo && zeroTwo >= zeroOne:
+		b, a, c = patterns[1], patterns[0], patterns[2]
+	default:
+		b, a, c = patterns[2], patterns[0], patterns[1]
+	}
+
+	// Use the cross product to tell the bottom left from the top right,
+	// whatever the rotation of the code.
+	if (c.x-b.x)*(a.y-b.y)-(c.y-b.y)*(a.x-b.x) < 0 {
+		a, c = c, a
+	}
+	*patterns = [3]*resultPoint{a, b, c}
+}
+
+// alignmentPatternFinder looks for the 1:1:1 alignment pattern near the
+// bottom right corner of a QR code in the provided area of the image.
+type alignmentPatternFinder struct {
+	image                *bitMatrix
+	startX, startY       int
+	width, height        int
+	moduleSize           float64
+	possibleCenter       []*resultPoint
+	crossCheckStateCount [3]int
+}
+
+func (f *alignmentPatternFinder) find() (*resultPoint, error) {
+	maxJ := f.startX + f.width
+	middleI := f.startY + f.height/2
+
+	// Search from the middle of the area outwards.
+	for iGen := 0; iGen < f.height; iGen++ {
+		i := middleI + (iGen+1)/2
+		if iGen&1 == 1 {
+			i = middleI - (iGen+1)/2
+		}
+
+		var stateCount [3]int
+		j := f.startX
+		// Skip the leading white pixels, as the first run is not known to
+		// be complete.
+		for j < maxJ && !f.image.get(j, i) {
+			j++
+		}
+		currentState := 0
+		for ; j < maxJ; j++ {
+			if !f.image.get(j, i) {
+				if currentState == 1 {
+					currentState++
+				}
+				stateCount[currentState]++
+				continue
+			}
+
+			switch currentState {
+			case 1:
+				stateCount[1]++
+			case 2:
+				if f.foundPatternCross(stateCount) {
+					if confirmed := f.handlePossibleCenter(stateCount, i, j); confirmed != nil {
+						return confirmed, nil
+					}
+				}
+				stateCount = [3]int{stateCount[2], 1, 0}
+				currentState = 1
+			default:
+				currentState++
+				stateCount[currentState]++
+			}
+		}
+
+		if f.foundPatternCross(stateCount) {
+			if confirmed := f.handlePossibleCenter(stateCount, i, maxJ); confirmed != nil {
+				return confirmed, nil
+			}
+		}
+	}
+
+	// Settle for a pattern seen once.
+	if len(f.possibleCenter) > 0 {
+		return f.possibleCenter[0], nil
+	}
+	return nil, ErrNotFound
+}
+
+func (f *alignmentPatternFinder) foundPatternCross(stateCount [3]int) bool {
+	maxVariance := f.moduleSize / 2
+	for _, count := range stateCount {
+		if math.Abs(f.moduleSize-float64(count)) >= maxVariance {
+			return false
+		}
+	}
+	return true
+}
+
+func alignmentCenterFromEnd(stateCount [3]int, end int) float64 {
+	return float64(end-stateCount[2]) - float64(stateCount[1])/2
+}
+
+// handlePossibleCenter returns the alignment pattern once it has been seen
+// twice.
+func (f *alignmentPatternFinder) handlePossibleCenter(stateCount [3]int, i, j int) *resultPoint {
+	total := stateCount[0] + stateCount[1] + stateCount[2]
+	centerJ := alignmentCenterFromEnd(stateCount, j)
+	centerI := f.crossCheckVertical(i, int(centerJ), 2*stateCount[1], total)
+	if math.IsNaN(centerI) {
+		return nil
+	}
+
+	moduleSize := float64(total) / 3
+	for _, center := range f.possibleCenter {
+		if center.aboutEquals(moduleSize, centerI, centerJ) {
+			return center.combineEstimate(centerI, centerJ, moduleSize)
+		}
+	}
+	f.possibleCenter = append(f.possibleCenter, &resultPoint{x: centerJ, y: centerI, moduleSize: moduleSize, count: 1})
+	return nil
+}
+
+func (f *alignmentPatternFinder) crossCheckVertical(startI, centerJ, maxCount, originalTotal int) float64 {
+	maxI := f.image.height
+	var stateCount [3]int
+
+	i := startI
+	for i >= 0 && f.image.get(centerJ, i) && stateCount[1] <= maxCount {
+		stateCount[1]++
+		i--
+	}
+	if i < 0 || stateCount[1] > maxCount {
+		return math.NaN()
+	}
+	for i >= 0 && !f.image.get(centerJ, i) && stateCount[0] <= maxCount {
+		stateCount[0]++
+		i--
+	}
+	if stateCount[0] > maxCount {
+		return math.NaN()
+	}
+
+	i = startI + 1
+	for i < maxI && f.image.get(centerJ, i) && stateCount[1] <= maxCount {
+		stateCount[1]++
+		i++
+	}
+	if i == maxI || stateCount[1] > maxCount {
+		return math.NaN()
+	}
+	for i < maxI && !f.image.get(centerJ, i) && stateCount[2] <= maxCount {
+		stateCount[2]++
+		i++
+	}
+	if stateCount[2] > maxCount {
+		return math.NaN()
+	}
+
+	total := stateCount[0] + stateCount[1] + stateCount[2]
+	if 5*absInt(total-originalTotal) >= 2*originalTotal {
+		return math.NaN()
+	}
+	if !f.foundPatternCross(stateCount) {
+		return math.NaN()
+	}
+	return alignmentCenterFromEnd(stateCount, i)
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
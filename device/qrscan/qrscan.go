@@ -0,0 +1,31 @@
+// Package qrscan finds and decodes QR codes in images, such as the frames of
+// a camera preview.
+package qrscan
+
+import (
+	"errors"
+	"image"
+)
+
+// ErrNotFound is returned when no QR code is found in an image.
+var ErrNotFound = errors.New("no QR code found")
+
+// Decode returns the text of the QR code in img. ErrNotFound is returned if
+// the image has no code, other errors if a code was found but could not be
+// read, which is common on blurry frames: the next frame may do better.
+func Decode(img image.Image) (string, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() < 21 || bounds.Dy() < 21 {
+		return "", ErrNotFound
+	}
+
+	matrix, err := binarize(newLuminanceSource(img))
+	if err != nil {
+		return "", err
+	}
+	bits, err := detect(matrix)
+	if err != nil {
+		return "", err
+	}
+	return decodeMatrix(bits)
+}
@@ -0,0 +1,265 @@
+package qrscan
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	qrcode "github.com/yeqown/go-qrcode"
+)
+
+// encodeTestCode returns the image of a QR code holding text, with modules
+// of moduleWidth pixels.
+func encodeTestCode(t *testing.T, text string, moduleWidth uint8) *image.Gray {
+	t.Helper()
+	code, err := qrcode.New(text, qrcode.WithQRWidth(moduleWidth), qrcode.WithBuiltinImageEncoder(qrcode.PNG_FORMAT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := code.SaveTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+	return gray
+}
+
+// warp draws src into a width x height white image, with the corners of src
+// moved to the provided corners, in the order top left, top right, bottom
+// right and bottom left.
+func warp(src *image.Gray, width, height int, corners [4][2]float64) *image.Gray {
+	b := src.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	toSrc := quadrilateralToQuadrilateral(corners, [4][2]float64{{0, 0}, {w, 0}, {w, h}, {0, h}})
+
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			sx, sy := toSrc.transform(float64(x)+0.5, float64(y)+0.5)
+			if sx < 0 || sy < 0 || sx >= w || sy >= h {
+				dst.SetGray(x, y, color.Gray{Y: 0xff})
+				continue
+			}
+			dst.SetGray(x, y, src.GrayAt(b.Min.X+int(sx), b.Min.Y+int(sy)))
+		}
+	}
+	return dst
+}
+
+func rotate90(src *image.Gray) *image.Gray {
+	b := src.Bounds()
+	dst := image.NewGray(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			dst.SetGray(b.Dy()-1-y, x, src.GrayAt(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+func TestDecode(t *testing.T) {
+	texts := []string{
+		"decred:DsmcYVbP1Nmag2H4AS17UTvmWXmGeA7nhDx?amount=1.25",
+		"bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=0.001",
+		"litecoin:ltc1qzvcgmntglcuv4smv3lzj6k8szcvsrmvk0phrr9",
+		"2718281828459045235360287471352662497757",
+		"HTTPS://CRYPTOPOWER.DEV/ABC-123",
+		"Zürich, €5",
+	}
+
+	transforms := []struct {
+		name      string
+		transform func(*image.Gray) *image.Gray
+	}{
+		{name: "as encoded", transform: func(img *image.Gray) *image.Gray { return img }},
+		{name: "rotated 90", transform: rotate90},
+		{name: "rotated 180", transform: func(img *image.Gray) *image.Gray { return rotate90(rotate90(img)) }},
+		{name: "rotated 270", transform: func(img *image.Gray) *image.Gray { return rotate90(rotate90(rotate90(img))) }},
+		{
+			name: "scaled down in a larger frame",
+			transform: func(img *image.Gray) *image.Gray {
+				return warp(img, 480, 360, [4][2]float64{{140, 60}, {380, 60}, {380, 300}, {140, 300}})
+			},
+		},
+		{
+			name: "tilted",
+			transform: func(img *image.Gray) *image.Gray {
+				return warp(img, 640, 480, [4][2]float64{{170, 60}, {470, 90}, {450, 420}, {150, 400}})
+			},
+		},
+		{
+			name: "in perspective",
+			transform: func(img *image.Gray) *image.Gray {
+				return warp(img, 640, 480, [4][2]float64{{160, 70}, {480, 40}, {500, 450}, {150, 400}})
+			},
+		},
+		{
+			name: "unevenly lit and noisy",
+			transform: func(img *image.Gray) *image.Gray {
+				rng := rand.New(rand.NewSource(1))
+				b := img.Bounds()
+				dst := image.NewGray(b)
+				for y := b.Min.Y; y < b.Max.Y; y++ {
+					for x := b.Min.X; x < b.Max.X; x++ {
+						// Dim the image from left to right and add noise.
+						v := float64(img.GrayAt(x, y).Y)*(1-0.6*float64(x-b.Min.X)/float64(b.Dx())) + 40 + float64(rng.Intn(30))
+						dst.SetGray(x, y, color.Gray{Y: uint8(min(v, 255))})
+					}
+				}
+				return dst
+			},
+		},
+		{
+			name: "partly covered",
+			transform: func(img *image.Gray) *image.Gray {
+				b := img.Bounds()
+				dst := image.NewGray(b)
+				draw.Draw(dst, b, img, b.Min, draw.Src)
+				cover := image.Rect(b.Dx()*2/5, b.Dy()*2/5, b.Dx()*3/5, b.Dy()*3/5).Add(b.Min)
+				draw.Draw(dst, cover, image.NewUniform(color.Gray{Y: 0xff}), image.Point{}, draw.Src)
+				return dst
+			},
+		},
+	}
+
+	for _, text := range texts {
+		code := encodeTestCode(t, text, 8)
+		for _, tc := range transforms {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := Decode(tc.transform(code))
+				if err != nil {
+					t.Fatalf("(%v), (%v) could not be decoded: %v", tc.name, text, err)
+				}
+				if got != text {
+					t.Errorf("(%v), expected (%v), got (%v)", tc.name, text, got)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeModuleSizes(t *testing.T) {
+	const text = "decred:DsmcYVbP1Nmag2H4AS17UTvmWXmGeA7nhDx"
+	for _, moduleWidth := range []uint8{2, 3, 5, 12} {
+		got, err := Decode(encodeTestCode(t, text, moduleWidth))
+		if err != nil {
+			t.Fatalf("(%v pixel modules), unexpected error: %v", moduleWidth, err)
+		}
+		if got != text {
+			t.Errorf("(%v pixel modules), expected (%v), got (%v)", moduleWidth, text, got)
+		}
+	}
+}
+
+func TestDecodeNoCode(t *testing.T) {
+	blank := image.NewGray(image.Rect(0, 0, 320, 240))
+	draw.Draw(blank, blank.Bounds(), image.NewUniform(color.Gray{Y: 0xc0}), image.Point{}, draw.Src)
+
+	rng := rand.New(rand.NewSource(1))
+	noise := image.NewGray(image.Rect(0, 0, 320, 240))
+	rng.Read(noise.Pix)
+
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{name: "blank", img: blank},
+		{name: "noise", img: noise},
+		{name: "too small", img: image.NewGray(image.Rect(0, 0, 10, 10))},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, err := Decode(tc.img); err == nil {
+				t.Errorf("(%v), expected an error, got (%v)", tc.name, got)
+			}
+		})
+	}
+}
+
+// rsEncode returns data followed by its numECCodewords error correction
+// codewords.
+func rsEncode(data []byte, numECCodewords int) []byte {
+	generator := gfPoly{1}
+	for i := 0; i < numECCodewords; i++ {
+		generator = generator.multiply(gfPoly{1, gfExp[i]})
+	}
+	remainder := gfPoly(append(append([]byte{}, data...), make([]byte, numECCodewords)...))
+	for i := 0; i < len(data); i++ {
+		coefficient := remainder[i]
+		if coefficient == 0 {
+			continue
+		}
+		for j, g := range generator {
+			remainder[i+j] ^= gfMul(g, coefficient)
+		}
+	}
+	return append(append([]byte{}, data...), remainder[len(data):]...)
+}
+
+func TestRSCorrect(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const numDataCodewords, numECCodewords = 40, 16
+
+	for numErrors := 0; numErrors <= numECCodewords/2; numErrors++ {
+		data := make([]byte, numDataCodewords)
+		rng.Read(data)
+		encoded := rsEncode(data, numECCodewords)
+
+		received := append([]byte{}, encoded...)
+		for _, position := range rng.Perm(len(received))[:numErrors] {
+			received[position] ^= byte(1 + rng.Intn(255))
+		}
+
+		if err := rsCorrect(received, numECCodewords); err != nil {
+			t.Fatalf("(%v errors), unexpected error: %v", numErrors, err)
+		}
+		if !bytes.Equal(received, encoded) {
+			t.Errorf("(%v errors), expected (%x), got (%x)", numErrors, encoded, received)
+		}
+	}
+}
+
+func TestECBlocksTable(t *testing.T) {
+	for version := 1; version <= 40; version++ {
+		// Every module that is not part of a function pattern holds a bit
+		// of a codeword, except for up to 7 remainder bits.
+		pattern := functionPattern(version)
+		var dataModules int
+		for _, bit := range pattern.bits {
+			if !bit {
+				dataModules++
+			}
+		}
+
+		for level := ecLevelL; level <= ecLevelH; level++ {
+			if got := ecBlocksTable[version-1][level].totalCodewords(); got != dataModules/8 {
+				t.Errorf("(version %v, level %v), expected (%v) codewords, got (%v)", version, level, dataModules/8, got)
+			}
+		}
+	}
+}
+
+func TestDecodeFormatInfo(t *testing.T) {
+	for i, code := range formatInfoCodes {
+		// Flip up to maxInfoBitErrors bits of the first copy and corrupt the
+		// second entirely.
+		corrupted := code ^ 0x4009
+		level, mask, err := decodeFormatInfo(corrupted, 0)
+		if err != nil {
+			t.Fatalf("(%#x), unexpected error: %v", code, err)
+		}
+		if level != ecLevelForBits[i>>3] || mask != i&0x07 {
+			t.Errorf("(%#x), expected level (%v) and mask (%v), got (%v) and (%v)",
+				code, ecLevelForBits[i>>3], i&0x07, level, mask)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package qrscan
+
+import "errors"
+
+var errTooManyErrors = errors.New("too many errors to correct")
+
+// QR codes use the Galois field GF(256) with the primitive polynomial
+// x^8 + x^4 + x^3 + x^2 + 1 and a generator base of 0.
+const gfPrimitive = 0x11d
+
+var (
+	gfExp [512]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= gfPrimitive
+		}
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-gfLog[a]]
+}
+
+// gfPoly is a polynomial over GF(256), highest degree coefficient first.
+type gfPoly []byte
+
+// newGFPoly returns the polynomial without its leading zero coefficients.
+func newGFPoly(coefficients []byte) gfPoly {
+	for len(coefficients) > 1 && coefficients[0] == 0 {
+		coefficients = coefficients[1:]
+	}
+	if len(coefficients) == 0 {
+		return gfPoly{0}
+	}
+	return gfPoly(coefficients)
+}
+
+func gfMonomial(degree int, coefficient byte) gfPoly {
+	if coefficient == 0 {
+		return gfPoly{0}
+	}
+	p := make(gfPoly, degree+1)
+	p[0] = coefficient
+	return p
+}
+
+func (p gfPoly) degree() int {
+	return len(p) - 1
+}
+
+func (p gfPoly) isZero() bool {
+	return p[0] == 0
+}
+
+// coefficient returns the coefficient of the term of the provided degree.
+func (p gfPoly) coefficient(degree int) byte {
+	return p[len(p)-1-degree]
+}
+
+func (p gfPoly) evaluateAt(x byte) byte {
+	if x == 0 {
+		return p.coefficient(0)
+	}
+	var result byte
+	for _, c := range p {
+		result = gfMul(result, x) ^ c
+	}
+	return result
+}
+
+func (p gfPoly) add(other gfPoly) gfPoly {
+	if p.isZero() {
+		return other
+	}
+	if other.isZero() {
+		return p
+	}
+	smaller, larger := p, other
+	if len(smaller) > len(larger) {
+		smaller, larger = larger, smaller
+	}
+	sum := make([]byte, len(larger))
+	diff := len(larger) - len(smaller)
+	copy(sum, larger[:diff])
+	for i := diff; i < len(larger); i++ {
+		sum[i] = smaller[i-diff] ^ larger[i]
+	}
+	return newGFPoly(sum)
+}
+
+func (p gfPoly) multiply(other gfPoly) gfPoly {
+	if p.isZero() || other.isZero() {
+		return gfPoly{0}
+	}
+	product := make([]byte, len(p)+len(other)-1)
+	for i, a := range p {
+		for j, b := range other {
+			product[i+j] ^= gfMul(a, b)
+		}
+	}
+	return newGFPoly(product)
+}
+
+func (p gfPoly) multiplyByScalar(scalar byte) gfPoly {
+	product := make([]byte, len(p))
+	for i, c := range p {
+		product[i] = gfMul(c, scalar)
+	}
+	return newGFPoly(product)
+}
+
+func (p gfPoly) multiplyByMonomial(degree int, coefficient byte) gfPoly {
+	if coefficient == 0 {
+		return gfPoly{0}
+	}
+	product := make([]byte, len(p)+degree)
+	for i, c := range p {
+		product[i] = gfMul(c, coefficient)
+	}
+	return newGFPoly(product)
+}
+
+// rsCorrect corrects the errors of a block of codewords, data codewords first
+// followed by numECCodewords error correction codewords, in place. An error
+// is returned if the block has more errors than can be corrected.
+func rsCorrect(codewords []byte, numECCodewords int) error {
+	received := gfPoly(codewords)
+	syndromes := make([]byte, numECCodewords)
+	noError := true
+	for i := 0; i < numECCodewords; i++ {
+		eval := received.evaluateAt(gfExp[i])
+		syndromes[numECCodewords-1-i] = eval
+		if eval != 0 {
+			noError = false
+		}
+	}
+	if noError {
+		return nil
+	}
+
+	sigma, omega, err := rsEuclidean(gfMonomial(numECCodewords, 1), newGFPoly(syndromes), numECCodewords)
+	if err != nil {
+		return err
+	}
+	locations, err := rsErrorLocations(sigma)
+	if err != nil {
+		return err
+	}
+	magnitudes := rsErrorMagnitudes(omega, locations)
+	for i, location := range locations {
+		position := len(codewords) - 1 - gfLog[location]
+		if position < 0 {
+			return errTooManyErrors
+		}
+		codewords[position] ^= magnitudes[i]
+	}
+	return nil
+}
+
+// rsEuclidean runs the extended Euclidean algorithm on a and b to find the
+// error locator polynomial sigma and the error evaluator polynomial omega.
+func rsEuclidean(a, b gfPoly, r int) (sigma, omega gfPoly, err error) {
+	if a.degree() < b.degree() {
+		a, b = b, a
+	}
+
+	rLast, rCur := a, b
+	tLast, tCur := gfPoly{0}, gfPoly{1}
+	for 2*rCur.degree() >= r {
+		rLastLast, tLastLast := rLast, tLast
+		rLast, tLast = rCur, tCur
+		if rLast.isZero() {
+			return nil, nil, errTooManyErrors
+		}
+
+		rCur = rLastLast
+		q := gfPoly{0}
+		dltInverse := gfInv(rLast.coefficient(rLast.degree()))
+		for rCur.degree() >= rLast.degree() && !rCur.isZero() {
+			degreeDiff := rCur.degree() - rLast.degree()
+			scale := gfMul(rCur.coefficient(rCur.degree()), dltInverse)
+			q = q.add(gfMonomial(degreeDiff, scale))
+			rCur = rCur.add(rLast.multiplyByMonomial(degreeDiff, scale))
+		}
+		tCur = q.multiply(tLast).add(tLastLast)
+
+		if rCur.degree() >= rLast.degree() {
+			return nil, nil, errTooManyErrors
+		}
+	}
+
+	sigmaTildeAtZero := tCur.coefficient(0)
+	if sigmaTildeAtZero == 0 {
+		return nil, nil, errTooManyErrors
+	}
+	inverse := gfInv(sigmaTildeAtZero)
+	return tCur.multiplyByScalar(inverse), rCur.multiplyByScalar(inverse), nil
+}
+
+// rsErrorLocations returns the error locations, the inverses of the roots of
+// the error locator polynomial.
+func rsErrorLocations(sigma gfPoly) ([]byte, error) {
+	numErrors := sigma.degree()
+	if numErrors == 1 {
+		return []byte{sigma.coefficient(1)}, nil
+	}
+
+	locations := make([]byte, 0, numErrors)
+	for i := 1; i < 256 && len(locations) < numErrors; i++ {
+		if sigma.evaluateAt(byte(i)) == 0 {
+			locations = append(locations, gfInv(byte(i)))
+		}
+	}
+	if len(locations) != numErrors {
+		return nil, errTooManyErrors
+	}
+	return locations, nil
+}
+
+// rsErrorMagnitudes returns the values to add to the codewords at the error
+// locations, with Forney's algorithm.
+func rsErrorMagnitudes(omega gfPoly, locations []byte) []byte {
+	magnitudes := make([]byte, len(locations))
+	for i, location := range locations {
+		xiInverse := gfInv(location)
+		denominator := byte(1)
+		for j, other := range locations {
+			if i != j {
+				denominator = gfMul(denominator, gfMul(other, xiInverse)^1)
+			}
+		}
+		magnitudes[i] = gfMul(omega.evaluateAt(xiInverse), gfInv(denominator))
+	}
+	return magnitudes
+}
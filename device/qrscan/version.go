@@ -0,0 +1,208 @@
+package qrscan
+
+import "errors"
+
+// ecLevel is the error correction level of a QR code, in the order of the
+// columns of ecBlocksTable.
+type ecLevel int
+
+const (
+	ecLevelL ecLevel = iota
+	ecLevelM
+	ecLevelQ
+	ecLevelH
+)
+
+// ecLevelForBits maps the two error correction bits of the format information
+// to the level.
+var ecLevelForBits = [4]ecLevel{ecLevelM, ecLevelL, ecLevelH, ecLevelQ}
+
+// ecBlock is a group of blocks sharing the same number of data codewords.
+type ecBlock struct {
+	count         int
+	dataCodewords int
+}
+
+// ecBlocks describes the error correction blocks of a version at a level.
+type ecBlocks struct {
+	ecCodewordsPerBlock int
+	blocks              []ecBlock
+}
+
+func (e *ecBlocks) numBlocks() int {
+	var total int
+	for _, b := range e.blocks {
+		total += b.count
+	}
+	return total
+}
+
+func (e *ecBlocks) totalCodewords() int {
+	var total int
+	for _, b := range e.blocks {
+		total += b.count * (b.dataCodewords + e.ecCodewordsPerBlock)
+	}
+	return total
+}
+
+// ecBlocksTable holds the error correction blocks of versions 1 to 40 at the
+// levels L, M, Q and H. See ISO/IEC 18004:2015 table 9.
+var ecBlocksTable = [40][4]ecBlocks{
+	{{7, []ecBlock{{1, 19}}}, {10, []ecBlock{{1, 16}}}, {13, []ecBlock{{1, 13}}}, {17, []ecBlock{{1, 9}}}},
+	{{10, []ecBlock{{1, 34}}}, {16, []ecBlock{{1, 28}}}, {22, []ecBlock{{1, 22}}}, {28, []ecBlock{{1, 16}}}},
+	{{15, []ecBlock{{1, 55}}}, {26, []ecBlock{{1, 44}}}, {18, []ecBlock{{2, 17}}}, {22, []ecBlock{{2, 13}}}},
+	{{20, []ecBlock{{1, 80}}}, {18, []ecBlock{{2, 32}}}, {26, []ecBlock{{2, 24}}}, {16, []ecBlock{{4, 9}}}},
+	{{26, []ecBlock{{1, 108}}}, {24, []ecBlock{{2, 43}}}, {18, []ecBlock{{2, 15}, {2, 16}}}, {22, []ecBlock{{2, 11}, {2, 12}}}},
+	{{18, []ecBlock{{2, 68}}}, {16, []ecBlock{{4, 27}}}, {24, []ecBlock{{4, 19}}}, {28, []ecBlock{{4, 15}}}},
+	{{20, []ecBlock{{2, 78}}}, {18, []ecBlock{{4, 31}}}, {18, []ecBlock{{2, 14}, {4, 15}}}, {26, []ecBlock{{4, 13}, {1, 14}}}},
+	{{24, []ecBlock{{2, 97}}}, {22, []ecBlock{{2, 38}, {2, 39}}}, {22, []ecBlock{{4, 18}, {2, 19}}}, {26, []ecBlock{{4, 14}, {2, 15}}}},
+	{{30, []ecBlock{{2, 116}}}, {22, []ecBlock{{3, 36}, {2, 37}}}, {20, []ecBlock{{4, 16}, {4, 17}}}, {24, []ecBlock{{4, 12}, {4, 13}}}},
+	{{18, []ecBlock{{2, 68}, {2, 69}}}, {26, []ecBlock{{4, 43}, {1, 44}}}, {24, []ecBlock{{6, 19}, {2, 20}}}, {28, []ecBlock{{6, 15}, {2, 16}}}},
+	{{20, []ecBlock{{4, 81}}}, {30, []ecBlock{{1, 50}, {4, 51}}}, {28, []ecBlock{{4, 22}, {4, 23}}}, {24, []ecBlock{{3, 12}, {8, 13}}}},
+	{{24, []ecBlock{{2, 92}, {2, 93}}}, {22, []ecBlock{{6, 36}, {2, 37}}}, {26, []ecBlock{{4, 20}, {6, 21}}}, {28, []ecBlock{{7, 14}, {4, 15}}}},
+	{{26, []ecBlock{{4, 107}}}, {22, []ecBlock{{8, 37}, {1, 38}}}, {24, []ecBlock{{8, 20}, {4, 21}}}, {22, []ecBlock{{12, 11}, {4, 12}}}},
+	{{30, []ecBlock{{3, 115}, {1, 116}}}, {24, []ecBlock{{4, 40}, {5, 41}}}, {20, []ecBlock{{11, 16}, {5, 17}}}, {24, []ecBlock{{11, 12}, {5, 13}}}},
+	{{22, []ecBlock{{5, 87}, {1, 88}}}, {24, []ecBlock{{5, 41}, {5, 42}}}, {30, []ecBlock{{5, 24}, {7, 25}}}, {24, []ecBlock{{11, 12}, {7, 13}}}},
+	{{24, []ecBlock{{5, 98}, {1, 99}}}, {28, []ecBlock{{7, 45}, {3, 46}}}, {24, []ecBlock{{15, 19}, {2, 20}}}, {30, []ecBlock{{3, 15}, {13, 16}}}},
+	{{28, []ecBlock{{1, 107}, {5, 108}}}, {28, []ecBlock{{10, 46}, {1, 47}}}, {28, []ecBlock{{1, 22}, {15, 23}}}, {28, []ecBlock{{2, 14}, {17, 15}}}},
+	{{30, []ecBlock{{5, 120}, {1, 121}}}, {26, []ecBlock{{9, 43}, {4, 44}}}, {28, []ecBlock{{17, 22}, {1, 23}}}, {28, []ecBlock{{2, 14}, {19, 15}}}},
+	{{28, []ecBlock{{3, 113}, {4, 114}}}, {26, []ecBlock{{3, 44}, {11, 45}}}, {26, []ecBlock{{17, 21}, {4, 22}}}, {26, []ecBlock{{9, 13}, {16, 14}}}},
+	{{28, []ecBlock{{3, 107}, {5, 108}}}, {26, []ecBlock{{3, 41}, {13, 42}}}, {30, []ecBlock{{15, 24}, {5, 25}}}, {28, []ecBlock{{15, 15}, {10, 16}}}},
+	{{28, []ecBlock{{4, 116}, {4, 117}}}, {26, []ecBlock{{17, 42}}}, {28, []ecBlock{{17, 22}, {6, 23}}}, {30, []ecBlock{{19, 16}, {6, 17}}}},
+	{{28, []ecBlock{{2, 111}, {7, 112}}}, {28, []ecBlock{{17, 46}}}, {30, []ecBlock{{7, 24}, {16, 25}}}, {24, []ecBlock{{34, 13}}}},
+	{{30, []ecBlock{{4, 121}, {5, 122}}}, {28, []ecBlock{{4, 47}, {14, 48}}}, {30, []ecBlock{{11, 24}, {14, 25}}}, {30, []ecBlock{{16, 15}, {14, 16}}}},
+	{{30, []ecBlock{{6, 117}, {4, 118}}}, {28, []ecBlock{{6, 45}, {14, 46}}}, {30, []ecBlock{{11, 24}, {16, 25}}}, {30, []ecBlock{{30, 16}, {2, 17}}}},
+	{{26, []ecBlock{{8, 106}, {4, 107}}}, {28, []ecBlock{{8, 47}, {13, 48}}}, {30, []ecBlock{{7, 24}, {22, 25}}}, {30, []ecBlock{{22, 15}, {13, 16}}}},
+	{{28, []ecBlock{{10, 114}, {2, 115}}}, {28, []ecBlock{{19, 46}, {4, 47}}}, {28, []ecBlock{{28, 22}, {6, 23}}}, {30, []ecBlock{{33, 16}, {4, 17}}}},
+	{{30, []ecBlock{{8, 122}, {4, 123}}}, {28, []ecBlock{{22, 45}, {3, 46}}}, {30, []ecBlock{{8, 23}, {26, 24}}}, {30, []ecBlock{{12, 15}, {28, 16}}}},
+	{{30, []ecBlock{{3, 117}, {10, 118}}}, {28, []ecBlock{{3, 45}, {23, 46}}}, {30, []ecBlock{{4, 24}, {31, 25}}}, {30, []ecBlock{{11, 15}, {31, 16}}}},
+	{{30, []ecBlock{{7, 116}, {7, 117}}}, {28, []ecBlock{{21, 45}, {7, 46}}}, {30, []ecBlock{{1, 23}, {37, 24}}}, {30, []ecBlock{{19, 15}, {26, 16}}}},
+	{{30, []ecBlock{{5, 115}, {10, 116}}}, {28, []ecBlock{{19, 47}, {10, 48}}}, {30, []ecBlock{{15, 24}, {25, 25}}}, {30, []ecBlock{{23, 15}, {25, 16}}}},
+	{{30, []ecBlock{{13, 115}, {3, 116}}}, {28, []ecBlock{{2, 46}, {29, 47}}}, {30, []ecBlock{{42, 24}, {1, 25}}}, {30, []ecBlock{{23, 15}, {28, 16}}}},
+	{{30, []ecBlock{{17, 115}}}, {28, []ecBlock{{10, 46}, {23, 47}}}, {30, []ecBlock{{10, 24}, {35, 25}}}, {30, []ecBlock{{19, 15}, {35, 16}}}},
+	{{30, []ecBlock{{17, 115}, {1, 116}}}, {28, []ecBlock{{14, 46}, {21, 47}}}, {30, []ecBlock{{29, 24}, {19, 25}}}, {30, []ecBlock{{11, 15}, {46, 16}}}},
+	{{30, []ecBlock{{13, 115}, {6, 116}}}, {28, []ecBlock{{14, 46}, {23, 47}}}, {30, []ecBlock{{44, 24}, {7, 25}}}, {30, []ecBlock{{59, 16}, {1, 17}}}},
+	{{30, []ecBlock{{12, 121}, {7, 122}}}, {28, []ecBlock{{12, 47}, {26, 48}}}, {30, []ecBlock{{39, 24}, {14, 25}}}, {30, []ecBlock{{22, 15}, {41, 16}}}},
+	{{30, []ecBlock{{6, 121}, {14, 122}}}, {28, []ecBlock{{6, 47}, {34, 48}}}, {30, []ecBlock{{46, 24}, {10, 25}}}, {30, []ecBlock{{2, 15}, {64, 16}}}},
+	{{30, []ecBlock{{17, 122}, {4, 123}}}, {28, []ecBlock{{29, 46}, {14, 47}}}, {30, []ecBlock{{49, 24}, {10, 25}}}, {30, []ecBlock{{24, 15}, {46, 16}}}},
+	{{30, []ecBlock{{4, 122}, {18, 123}}}, {28, []ecBlock{{13, 46}, {32, 47}}}, {30, []ecBlock{{48, 24}, {14, 25}}}, {30, []ecBlock{{42, 15}, {32, 16}}}},
+	{{30, []ecBlock{{20, 117}, {4, 118}}}, {28, []ecBlock{{40, 47}, {7, 48}}}, {30, []ecBlock{{43, 24}, {22, 25}}}, {30, []ecBlock{{10, 15}, {67, 16}}}},
+	{{30, []ecBlock{{19, 118}, {6, 119}}}, {28, []ecBlock{{18, 47}, {31, 48}}}, {30, []ecBlock{{34, 24}, {34, 25}}}, {30, []ecBlock{{20, 15}, {61, 16}}}},
+}
+
+// alignmentPatternCenters holds the row and column coordinates of the centers
+// of the alignment patterns of versions 1 to 40. See ISO/IEC 18004:2015
+// annex E.
+var alignmentPatternCenters = [40][]int{
+	{},
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+	{6, 30, 54},
+	{6, 32, 58},
+	{6, 34, 62},
+	{6, 26, 46, 66},
+	{6, 26, 48, 70},
+	{6, 26, 50, 74},
+	{6, 30, 54, 78},
+	{6, 30, 56, 82},
+	{6, 30, 58, 86},
+	{6, 34, 62, 90},
+	{6, 28, 50, 72, 94},
+	{6, 26, 50, 74, 98},
+	{6, 30, 54, 78, 102},
+	{6, 28, 54, 80, 106},
+	{6, 32, 58, 84, 110},
+	{6, 30, 58, 86, 114},
+	{6, 34, 62, 90, 118},
+	{6, 26, 50, 74, 98, 122},
+	{6, 30, 54, 78, 102, 126},
+	{6, 26, 52, 78, 104, 130},
+	{6, 30, 56, 82, 108, 134},
+	{6, 34, 60, 86, 112, 138},
+	{6, 30, 58, 86, 114, 142},
+	{6, 34, 62, 90, 118, 146},
+	{6, 30, 54, 78, 102, 126, 150},
+	{6, 24, 50, 76, 102, 128, 154},
+	{6, 28, 54, 80, 106, 132, 158},
+	{6, 32, 58, 84, 110, 136, 162},
+	{6, 26, 54, 82, 110, 138, 166},
+	{6, 30, 58, 86, 114, 142, 170},
+}
+
+// formatInfoCodes are the 15 bit masked format information values, indexed by
+// their 5 data bits: the error correction bits followed by the mask pattern.
+var formatInfoCodes = [32]uint32{
+	0x5412, 0x5125, 0x5e7c, 0x5b4b, 0x45f9, 0x40ce, 0x4f97, 0x4aa0,
+	0x77c4, 0x72f3, 0x7daa, 0x789d, 0x662f, 0x6318, 0x6c41, 0x6976,
+	0x1689, 0x13be, 0x1ce7, 0x19d0, 0x0762, 0x0255, 0x0d0c, 0x083b,
+	0x355f, 0x3068, 0x3f31, 0x3a06, 0x24b4, 0x2183, 0x2eda, 0x2bed,
+}
+
+// versionInfoCodes are the 18 bit version information values of versions 7
+// to 40.
+var versionInfoCodes = [34]uint32{
+	0x07c94, 0x085bc, 0x09a99, 0x0a4d3, 0x0bbf6, 0x0c762, 0x0d847, 0x0e60d,
+	0x0f928, 0x10b78, 0x1145d, 0x12a17, 0x13532, 0x149a6, 0x15683, 0x168c9,
+	0x177ec, 0x18ec4, 0x191e1, 0x1afab, 0x1b08e, 0x1cc1a, 0x1d33f, 0x1ed75,
+	0x1f250, 0x209d5, 0x216f0, 0x228ba, 0x2379f, 0x24b0b, 0x2542e, 0x26a64,
+	0x27541, 0x28c69,
+}
+
+// maxInfoBitErrors is the number of bit errors the format and version
+// information codes can correct.
+const maxInfoBitErrors = 3
+
+var errInvalidInfo = errors.New("unreadable format or version information")
+
+// bitDistance returns the number of bits that differ between a and b.
+func bitDistance(a, b uint32) int {
+	var n int
+	for x := a ^ b; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+// decodeFormatInfo returns the error correction level and mask pattern of the
+// format information read from either of its two copies.
+func decodeFormatInfo(copy1, copy2 uint32) (ecLevel, int, error) {
+	best, bestDistance := -1, maxInfoBitErrors+1
+	for i, code := range formatInfoCodes {
+		for _, bits := range []uint32{copy1, copy2} {
+			if d := bitDistance(bits, code); d < bestDistance {
+				best, bestDistance = i, d
+			}
+		}
+	}
+	if best < 0 {
+		return 0, 0, errInvalidInfo
+	}
+	return ecLevelForBits[best>>3], best & 0x07, nil
+}
+
+// decodeVersionInfo returns the version encoded by the version information
+// read from one of its copies.
+func decodeVersionInfo(bits uint32) (int, error) {
+	best, bestDistance := -1, maxInfoBitErrors+1
+	for i, code := range versionInfoCodes {
+		if d := bitDistance(bits, code); d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	if best < 0 {
+		return 0, errInvalidInfo
+	}
+	return best + 7, nil
+}
+
+// dimensionForVersion returns the number of modules on a side of a QR code.
+func dimensionForVersion(version int) int {
+	return 17 + 4*version
+}
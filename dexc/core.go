@@ -156,8 +156,9 @@ func (dc *DEXClient) BondsFeeBuffer(assetID uint32) uint64 {
 // Start prepares and starts the DEX client.
 //
 // NOTE: "lang" will be changed to the default language (en) if the the DEX
-// client does not have support for it.
-func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.NetworkType, maxLogZips int) (*DEXClient, error) {
+// client does not have support for it. The DEX servers are reached through
+// torProxy if it is not empty.
+func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.NetworkType, maxLogZips int, torProxy string, torIsolation bool) (*DEXClient, error) {
 	dexNet, err := parseDEXNet(net)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing network: %w", err)
@@ -176,6 +177,8 @@ func Start(ctx context.Context, root, lang, logDir, logLvl string, net libutils.
 		Language:           validDEXLang(lang),
 		NoAutoWalletLock:   true,
 		UnlockCoinsOnLogin: false, // TODO: Make configurable.
+		TorProxy:           torProxy,
+		TorIsolation:       torIsolation,
 	}
 
 	clientCore, err := core.New(cfg)
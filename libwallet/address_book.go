@@ -0,0 +1,70 @@
+package libwallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/addresshelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// IsAddressValid reports whether address is a valid address of assetType on
+// the current network. A loaded wallet of the asset is used when available so
+// the check matches the one done when sending.
+func (mgr *AssetsManager) IsAddressValid(assetType utils.AssetType, address string) bool {
+	if wallets := mgr.AssetWallets(assetType); len(wallets) > 0 {
+		return wallets[0].IsAddressValid(address)
+	}
+
+	var err error
+	switch assetType {
+	case utils.DCRWalletAsset:
+		_, err = addresshelper.PkScript(address, mgr.chainsParams.DCR)
+	case utils.BTCWalletAsset:
+		_, err = addresshelper.BTCPkScript(address, mgr.chainsParams.BTC)
+	case utils.LTCWalletAsset:
+		_, err = addresshelper.LTCPkScript(address, mgr.chainsParams.LTC)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// ExportAddressBook writes the address book to a JSON file in the exports
+// folder and returns its path.
+func (mgr *AssetsManager) ExportAddressBook() (string, error) {
+	dir := filepath.Join(mgr.RootDir(), exportsDirName)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	fileName := filepath.Join(dir, fmt.Sprintf("address_book_%d.json", time.Now().Unix()))
+	f, err := os.Create(fileName)
+	if err != nil {
+		return "", fmt.Errorf("os.Create error: %w", err)
+	}
+
+	err = mgr.AddressBook.Export(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return "", err
+	}
+	return fileName, nil
+}
+
+// ImportAddressBook adds the contacts of an address book file written by
+// ExportAddressBook and returns the number of contacts added.
+func (mgr *AssetsManager) ImportAddressBook(fileName string) (int, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return mgr.AddressBook.Import(f)
+}
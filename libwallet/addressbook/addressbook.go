@@ -0,0 +1,264 @@
+// Package addressbook stores named contacts and their addresses in the
+// app-level database so they can be picked when sending and recognised in
+// transaction details.
+package addressbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// exportVersion is the version of the JSON format written by Export.
+const exportVersion = 1
+
+var (
+	// ErrEmptyName is returned when a contact has no name.
+	ErrEmptyName = errors.New("contact name is required")
+	// ErrNoAddress is returned when a contact has no address.
+	ErrNoAddress = errors.New("contact has no address")
+	// ErrDuplicateName is returned when another contact of the same asset
+	// already uses the name.
+	ErrDuplicateName = errors.New("a contact with this name already exists")
+)
+
+// AddressValidator reports whether address is a valid address of assetType
+// on the current network.
+type AddressValidator func(assetType utils.AssetType, address string) bool
+
+// Contact is a named set of addresses of a single asset.
+type Contact struct {
+	ID        int               `storm:"id,increment" json:"id"`
+	Name      string            `storm:"index" json:"name"`
+	AssetType utils.AssetType   `storm:"index" json:"assetType"`
+	Network   utils.NetworkType `storm:"index" json:"network"`
+	Addresses []string          `json:"addresses"`
+	Notes     string            `json:"notes,omitempty"`
+	CreatedAt int64             `json:"createdAt"`
+	UpdatedAt int64             `json:"updatedAt"`
+}
+
+// HasAddress reports whether address is one of the contact's addresses.
+func (c *Contact) HasAddress(address string) bool {
+	for _, addr := range c.Addresses {
+		if addr == address {
+			return true
+		}
+	}
+	return false
+}
+
+// exportFile is the JSON document written by Export and read by Import.
+type exportFile struct {
+	Version  int        `json:"version"`
+	Contacts []*Contact `json:"contacts"`
+}
+
+// AddressBook manages the contacts of a single network.
+type AddressBook struct {
+	db             *storm.DB
+	net            utils.NetworkType
+	isAddressValid AddressValidator
+}
+
+// New returns an address book backed by db. Contacts are scoped to net and
+// their addresses are checked with isAddressValid before being saved.
+func New(db *storm.DB, net utils.NetworkType, isAddressValid AddressValidator) (*AddressBook, error) {
+	if err := db.Init(&Contact{}); err != nil {
+		return nil, fmt.Errorf("error initializing address book database: %w", err)
+	}
+
+	return &AddressBook{
+		db:             db,
+		net:            net,
+		isAddressValid: isAddressValid,
+	}, nil
+}
+
+// SaveContact validates the contact and stores it. A contact with a zero ID
+// is added, otherwise the existing contact with that ID is replaced.
+func (ab *AddressBook) SaveContact(contact *Contact) error {
+	if err := ab.validate(contact); err != nil {
+		return err
+	}
+
+	setTimestamps(contact)
+	return ab.db.Save(contact)
+}
+
+// setTimestamps records that the contact is being saved now.
+func setTimestamps(contact *Contact) {
+	now := time.Now().Unix()
+	contact.UpdatedAt = now
+	if contact.CreatedAt == 0 {
+		contact.CreatedAt = now
+	}
+}
+
+// DeleteContact removes the contact with the given ID.
+func (ab *AddressBook) DeleteContact(id int) error {
+	return ab.db.DeleteStruct(&Contact{ID: id})
+}
+
+// Contact returns the contact with the given ID.
+func (ab *AddressBook) Contact(id int) (*Contact, error) {
+	var contact Contact
+	if err := ab.db.One("ID", id, &contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// Contacts returns the contacts of assetType sorted by name. All contacts of
+// the network are returned if assetType is empty.
+func (ab *AddressBook) Contacts(assetType utils.AssetType) ([]*Contact, error) {
+	matchers := []q.Matcher{q.Eq("Network", ab.net)}
+	if assetType != "" {
+		matchers = append(matchers, q.Eq("AssetType", assetType))
+	}
+
+	var contacts []*Contact
+	err := ab.db.Select(matchers...).OrderBy("Name").Find(&contacts)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// ContactForAddress returns the contact of assetType that owns address or
+// nil if the address is not in the address book.
+func (ab *AddressBook) ContactForAddress(assetType utils.AssetType, address string) *Contact {
+	contacts, err := ab.Contacts(assetType)
+	if err != nil {
+		return nil
+	}
+	for _, contact := range contacts {
+		if contact.HasAddress(address) {
+			return contact
+		}
+	}
+	return nil
+}
+
+// Export writes every contact of the network as JSON.
+func (ab *AddressBook) Export(w io.Writer) error {
+	contacts, err := ab.Contacts("")
+	if err != nil {
+		return err
+	}
+	if contacts == nil {
+		contacts = []*Contact{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&exportFile{Version: exportVersion, Contacts: contacts})
+}
+
+// Import reads contacts written by Export and adds those that do not exist
+// yet. Contacts of other networks are skipped and addresses are validated as
+// in SaveContact. Of several contacts of an asset with the same name, only
+// the first is added. It returns the number of contacts added, either all of
+// them are added or none.
+func (ab *AddressBook) Import(r io.Reader) (int, error) {
+	var file exportFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return 0, fmt.Errorf("invalid address book file: %w", err)
+	}
+	if file.Version != exportVersion {
+		return 0, fmt.Errorf("unsupported address book version %d", file.Version)
+	}
+
+	// Validate everything first so a bad entry does not leave a partial import.
+	var toAdd []*Contact
+	names := make(map[utils.AssetType]map[string]bool)
+	for _, contact := range file.Contacts {
+		if contact.Network != ab.net {
+			continue
+		}
+
+		contact.ID = 0
+		if err := ab.validate(contact); err == ErrDuplicateName {
+			continue
+		} else if err != nil {
+			return 0, fmt.Errorf("contact %q: %w", contact.Name, err)
+		}
+		if names[contact.AssetType] == nil {
+			names[contact.AssetType] = make(map[string]bool)
+		}
+		if names[contact.AssetType][contact.Name] {
+			continue
+		}
+		names[contact.AssetType][contact.Name] = true
+		toAdd = append(toAdd, contact)
+	}
+
+	tx, err := ab.db.Begin(true)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, contact := range toAdd {
+		setTimestamps(contact)
+		if err := tx.Save(contact); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(toAdd), nil
+}
+
+// validate normalizes and checks the contact fields.
+func (ab *AddressBook) validate(contact *Contact) error {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Notes = strings.TrimSpace(contact.Notes)
+	if contact.Name == "" {
+		return ErrEmptyName
+	}
+	if contact.Network == "" {
+		contact.Network = ab.net
+	} else if contact.Network != ab.net {
+		return fmt.Errorf("contact network %s does not match %s", contact.Network, ab.net)
+	}
+
+	addresses := make([]string, 0, len(contact.Addresses))
+	seen := make(map[string]bool)
+	for _, addr := range contact.Addresses {
+		addr = strings.TrimSpace(addr)
+		if addr == "" || seen[addr] {
+			continue
+		}
+		if !ab.isAddressValid(contact.AssetType, addr) {
+			return fmt.Errorf("invalid %s address %s", contact.AssetType, addr)
+		}
+		seen[addr] = true
+		addresses = append(addresses, addr)
+	}
+	if len(addresses) == 0 {
+		return ErrNoAddress
+	}
+	contact.Addresses = addresses
+
+	var existing []*Contact
+	err := ab.db.Select(q.Eq("Network", ab.net), q.Eq("AssetType", contact.AssetType),
+		q.Eq("Name", contact.Name)).Find(&existing)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	for _, c := range existing {
+		if c.ID != contact.ID {
+			return ErrDuplicateName
+		}
+	}
+	return nil
+}
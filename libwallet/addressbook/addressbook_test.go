@@ -0,0 +1,167 @@
+package addressbook
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func newTestBook(t *testing.T, net utils.NetworkType) *AddressBook {
+	t.Helper()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Treat addresses starting with the asset's ticker as valid.
+	isValid := func(assetType utils.AssetType, address string) bool {
+		return strings.HasPrefix(address, string(assetType))
+	}
+	ab, err := New(db, net, isValid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ab
+}
+
+func TestSaveContact(t *testing.T) {
+	ab := newTestBook(t, utils.Mainnet)
+
+	tests := []struct {
+		name    string
+		contact *Contact
+		wantErr bool
+	}{{
+		name:    "valid",
+		contact: &Contact{Name: " Alice ", AssetType: utils.DCRWalletAsset, Addresses: []string{"DCRa", " DCRa", "DCRb"}},
+	}, {
+		name:    "same name other asset",
+		contact: &Contact{Name: "Alice", AssetType: utils.BTCWalletAsset, Addresses: []string{"BTCa"}},
+	}, {
+		name:    "duplicate name",
+		contact: &Contact{Name: "Alice", AssetType: utils.DCRWalletAsset, Addresses: []string{"DCRc"}},
+		wantErr: true,
+	}, {
+		name:    "empty name",
+		contact: &Contact{AssetType: utils.DCRWalletAsset, Addresses: []string{"DCRc"}},
+		wantErr: true,
+	}, {
+		name:    "no address",
+		contact: &Contact{Name: "Bob", AssetType: utils.DCRWalletAsset, Addresses: []string{" "}},
+		wantErr: true,
+	}, {
+		name:    "invalid address",
+		contact: &Contact{Name: "Bob", AssetType: utils.DCRWalletAsset, Addresses: []string{"BTCa"}},
+		wantErr: true,
+	}, {
+		name:    "other network",
+		contact: &Contact{Name: "Bob", AssetType: utils.DCRWalletAsset, Network: utils.Testnet, Addresses: []string{"DCRc"}},
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		err := ab.SaveContact(test.contact)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("%s: unexpected error %v", test.name, err)
+		}
+	}
+
+	contacts, err := ab.Contacts(utils.DCRWalletAsset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 1 || contacts[0].Name != "Alice" || len(contacts[0].Addresses) != 2 {
+		t.Fatalf("unexpected contacts: %+v", contacts)
+	}
+
+	if c := ab.ContactForAddress(utils.DCRWalletAsset, "DCRb"); c == nil || c.Name != "Alice" {
+		t.Fatalf("expected Alice for DCRb, got %+v", c)
+	}
+	if c := ab.ContactForAddress(utils.BTCWalletAsset, "DCRb"); c != nil {
+		t.Fatalf("expected no BTC contact for DCRb, got %+v", c)
+	}
+
+	// Renaming a contact to its own name must not count as a duplicate.
+	alice := contacts[0]
+	alice.Notes = "friend"
+	if err = ab.SaveContact(alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ab.DeleteContact(alice.ID); err != nil {
+		t.Fatal(err)
+	}
+	if contacts, _ = ab.Contacts(""); len(contacts) != 1 {
+		t.Fatalf("expected 1 contact after delete, got %d", len(contacts))
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	src := newTestBook(t, utils.Mainnet)
+	for _, c := range []*Contact{
+		{Name: "Alice", AssetType: utils.DCRWalletAsset, Addresses: []string{"DCRa"}, Notes: "n"},
+		{Name: "Bob", AssetType: utils.LTCWalletAsset, Addresses: []string{"LTCb"}},
+	} {
+		if err := src.SaveContact(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	dst := newTestBook(t, utils.Mainnet)
+	if err := dst.SaveContact(&Contact{Name: "Bob", AssetType: utils.LTCWalletAsset, Addresses: []string{"LTCx"}}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := dst.Import(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 imported contact, got %d", n)
+	}
+	if c := dst.ContactForAddress(utils.DCRWalletAsset, "DCRa"); c == nil || c.Notes != "n" {
+		t.Fatalf("imported contact missing: %+v", c)
+	}
+
+	testnet := newTestBook(t, utils.Testnet)
+	if n, err = testnet.Import(bytes.NewReader(data)); err != nil || n != 0 {
+		t.Fatalf("expected mainnet contacts to be skipped, got %d, %v", n, err)
+	}
+
+	if _, err = dst.Import(strings.NewReader(`{"version":2}`)); err == nil {
+		t.Fatal("expected unsupported version error")
+	}
+}
+
+func TestImportDuplicateNames(t *testing.T) {
+	ab := newTestBook(t, utils.Mainnet)
+	data := `{"version":1,"contacts":[
+		{"name":"Alice","assetType":"DCR","network":"mainnet","addresses":["DCRa"]},
+		{"name":" Alice ","assetType":"DCR","network":"mainnet","addresses":["DCRb"]},
+		{"name":"Alice","assetType":"BTC","network":"mainnet","addresses":["BTCa"]}
+	]}`
+	n, err := ab.Import(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 imported contacts, got %d", n)
+	}
+	contacts, err := ab.Contacts(utils.DCRWalletAsset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 1 || !contacts[0].HasAddress("DCRa") {
+		t.Fatalf("expected the first DCR contact only, got %+v", contacts)
+	}
+}
@@ -0,0 +1,52 @@
+package libwallet
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// agendaTallyInterval is how often the vote tally of the agendas is recorded.
+const agendaTallyInterval = time.Hour
+
+// SyncAgendaTallies fetches the vote tally of the agendas voting in the
+// current rule change interval and adds it to the agendas' history.
+func (mgr *AssetsManager) SyncAgendaTallies() error {
+	if !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+		return errors.New(utils.ErrUnavailable)
+	}
+
+	voteInfo, err := mgr.ExternalService.GetCurrentAgendaStatus()
+	if err != nil {
+		return err
+	}
+	return mgr.ConsensusAgenda.SaveVoteInfo(voteInfo, time.Now().Unix())
+}
+
+// startAgendaTallyTracker periodically records the vote tally of the agendas
+// so that their progress can be shown over time.
+func (mgr *AssetsManager) startAgendaTallyTracker() {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+
+	go func() {
+		ticker := time.NewTicker(agendaTallyInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+					continue
+				}
+				if err := mgr.SyncAgendaTallies(); err != nil {
+					log.Errorf("Error recording agenda tallies: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -71,6 +71,11 @@ func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
 		return nil, fmt.Errorf("%v network is not supported", net)
 	}
 
+	// Use the self-hosted Esplora instance if one is set.
+	if customURL := utils.GetBackendConfig().EsploraAPIURL(utils.BTCWalletAsset, "fee-estimates"); customURL != "" {
+		feerateURL = customURL
+	}
+
 	resp := make(map[string]float64, 0)
 
 	req := &utils.ReqConfig{
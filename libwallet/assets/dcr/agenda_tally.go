@@ -0,0 +1,133 @@
+package dcr
+
+import (
+	"fmt"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
+)
+
+// AgendaTally is a snapshot of the votes cast on an agenda in a rule change
+// interval.
+type AgendaTally struct {
+	ID            int    `storm:"id,increment"`
+	AgendaID      string `storm:"index" json:"agenda_id"`
+	IntervalStart int64  `json:"interval_start"`
+	IntervalEnd   int64  `json:"interval_end"`
+	Height        int64  `json:"height"`
+	Timestamp     int64  `json:"timestamp"`
+	Yes           uint32 `json:"yes"`
+	No            uint32 `json:"no"`
+	Abstain       uint32 `json:"abstain"`
+	Quorum        uint32 `json:"quorum"`
+	ExpireTime    int64  `json:"expire_time"`
+}
+
+// Percentages returns the share of the yes, no and abstain votes in the total
+// votes cast.
+func (t *AgendaTally) Percentages() (yes, no, abstain float64) {
+	total := float64(t.Yes) + float64(t.No) + float64(t.Abstain)
+	if total == 0 {
+		return 0, 0, 0
+	}
+	return float64(t.Yes) / total * 100, float64(t.No) / total * 100, float64(t.Abstain) / total * 100
+}
+
+// AgendaProjection is the projected outcome of an agenda vote.
+type AgendaProjection struct {
+	LockIn bool `json:"lock_in"`
+	// Time is the unix timestamp the agenda is projected to lock in or fail.
+	Time int64 `json:"time"`
+}
+
+// SaveVoteInfo adds the tallies of the agendas voting in the rule change
+// interval described by voteInfo to their history. timestamp is the time of
+// voteInfo.CurrentHeight, a tally already recorded at that height is not
+// saved again.
+func (c *ConsensusAgenda) SaveVoteInfo(voteInfo *chainjson.GetVoteInfoResult, timestamp int64) error {
+	for _, agenda := range voteInfo.Agendas {
+		if AgendaStatusFromStr(agenda.Status) != AgendaStatusInProgress {
+			continue
+		}
+
+		var existing AgendaTally
+		err := c.db.Select(q.Eq("AgendaID", agenda.ID), q.Eq("Height", voteInfo.CurrentHeight)).First(&existing)
+		if err == nil {
+			continue
+		}
+		if err != storm.ErrNotFound {
+			return fmt.Errorf("error checking agenda tally: %v", err)
+		}
+
+		tally := &AgendaTally{
+			AgendaID:      agenda.ID,
+			IntervalStart: voteInfo.StartHeight,
+			IntervalEnd:   voteInfo.EndHeight,
+			Height:        voteInfo.CurrentHeight,
+			Timestamp:     timestamp,
+			Quorum:        voteInfo.Quorum,
+			ExpireTime:    int64(agenda.ExpireTime),
+		}
+		for _, choice := range agenda.Choices {
+			switch {
+			case choice.IsAbstain:
+				tally.Abstain += choice.Count
+			case choice.IsNo:
+				tally.No += choice.Count
+			default:
+				tally.Yes += choice.Count
+			}
+		}
+		if err := c.db.Save(tally); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AgendaTallyHistory returns the recorded tallies of an agenda, oldest first.
+func (c *ConsensusAgenda) AgendaTallyHistory(agendaID string) ([]AgendaTally, error) {
+	var tallies []AgendaTally
+	err := c.db.Select(q.Eq("AgendaID", agendaID)).OrderBy("Height").Find(&tallies)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching agenda tallies: %v", err)
+	}
+	return tallies, nil
+}
+
+// ProjectAgendaOutcome projects the outcome of an agenda vote from its latest
+// tally, assuming the votes keep coming in at the same pace and ratio for the
+// rest of the rule change interval. The agenda locks in or fails at the end
+// of the interval if the quorum is met and the yes or no votes reach the
+// activation threshold, otherwise it is projected to fail when it expires.
+// A nil projection is returned if no tally was recorded for the agenda.
+func (c *ConsensusAgenda) ProjectAgendaOutcome(agendaID string) (*AgendaProjection, error) {
+	tallies, err := c.AgendaTallyHistory(agendaID)
+	if err != nil || len(tallies) == 0 {
+		return nil, err
+	}
+	latest := tallies[len(tallies)-1]
+
+	blockTime := int64(c.chainParams.TargetTimePerBlock.Seconds())
+	intervalEndTime := latest.Timestamp + (latest.IntervalEnd-latest.Height)*blockTime
+
+	elapsed := latest.Height - latest.IntervalStart + 1
+	intervalBlocks := latest.IntervalEnd - latest.IntervalStart + 1
+	nonAbstain := float64(latest.Yes) + float64(latest.No)
+	if elapsed <= 0 || nonAbstain == 0 {
+		return &AgendaProjection{Time: latest.ExpireTime}, nil
+	}
+
+	projectedVotes := nonAbstain * float64(intervalBlocks) / float64(elapsed)
+	threshold := float64(c.chainParams.RuleChangeActivationMultiplier) / float64(c.chainParams.RuleChangeActivationDivisor)
+	if projectedVotes >= float64(latest.Quorum) {
+		switch {
+		case float64(latest.Yes)/nonAbstain >= threshold:
+			return &AgendaProjection{LockIn: true, Time: intervalEndTime}, nil
+		case float64(latest.No)/nonAbstain >= threshold:
+			return &AgendaProjection{Time: intervalEndTime}, nil
+		}
+	}
+	return &AgendaProjection{Time: latest.ExpireTime}, nil
+}
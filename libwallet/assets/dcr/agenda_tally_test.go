@@ -0,0 +1,86 @@
+package dcr
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestProjectAgendaOutcome(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	params := chaincfg.MainNetParams()
+	c := NewConsensusAgenda(params, db)
+
+	// Half of a 1000 block interval has passed, so the votes cast so far are
+	// projected to double by its end. On mainnet the quorum is 4032 votes
+	// and the threshold 75% of the yes and no votes.
+	const (
+		timestamp  = int64(1700000000)
+		expireTime = int64(1800000000)
+	)
+	blockTime := int64(params.TargetTimePerBlock.Seconds())
+	intervalEndTime := timestamp + 500*blockTime
+	quorum := params.RuleChangeActivationQuorum
+
+	tests := []struct {
+		name         string
+		yes, no      uint32
+		abstain      uint32
+		expectLockIn bool
+		expectedTime int64
+	}{
+		{name: "quorum and threshold met", yes: 1512, no: 504, expectLockIn: true, expectedTime: intervalEndTime},
+		{name: "quorum missed by one vote", yes: 1512, no: 503, expectedTime: expireTime},
+		{name: "yes below threshold", yes: 1511, no: 505, expectedTime: expireTime},
+		{name: "no at threshold", yes: 504, no: 1512, expectedTime: intervalEndTime},
+		{name: "no below threshold", yes: 505, no: 1511, expectedTime: expireTime},
+		{name: "abstain votes ignored", yes: 1512, no: 504, abstain: 4000, expectLockIn: true, expectedTime: intervalEndTime},
+		{name: "abstain only", abstain: 4000, expectedTime: expireTime},
+		{name: "no votes", expectedTime: expireTime},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// An earlier tally of the interval must not affect the projection.
+			earlier := &AgendaTally{
+				AgendaID: tc.name, IntervalStart: 1000, IntervalEnd: 1999, Height: 1200,
+				Timestamp: timestamp - 300*blockTime, Yes: 10, No: 500, Quorum: quorum, ExpireTime: expireTime,
+			}
+			latest := &AgendaTally{
+				AgendaID: tc.name, IntervalStart: 1000, IntervalEnd: 1999, Height: 1499,
+				Timestamp: timestamp, Yes: tc.yes, No: tc.no, Abstain: tc.abstain, Quorum: quorum, ExpireTime: expireTime,
+			}
+			for _, tally := range []*AgendaTally{latest, earlier} {
+				if err := db.Save(tally); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			projection, err := c.ProjectAgendaOutcome(tc.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if projection == nil {
+				t.Fatalf("(%v), expected a projection", tc.name)
+			}
+			if projection.LockIn != tc.expectLockIn {
+				t.Errorf("(%v), expected lock in (%v), got (%v)", tc.name, tc.expectLockIn, projection.LockIn)
+			}
+			if projection.Time != tc.expectedTime {
+				t.Errorf("(%v), expected time (%v), got (%v)", tc.name, tc.expectedTime, projection.Time)
+			}
+		})
+	}
+
+	projection, err := c.ProjectAgendaOutcome("unknown")
+	if err != nil || projection != nil {
+		t.Errorf("expected no projection without tallies, got (%v, %v)", projection, err)
+	}
+}
@@ -46,6 +46,7 @@ type WalletHelper interface {
 	IsAccountMixerActive() bool
 	UnmixedAccountNumber() int32
 	MixedAccountNumber() int32
+	IsOutputFrozen(txID string, vout uint32) bool
 }
 
 var _ dexdcr.Wallet = (*DEXWallet)(nil)
@@ -205,10 +206,23 @@ func (dw *DEXWallet) AccountBalance(ctx context.Context, confirms int32, account
 // LockedOutputs fetches locked outputs for the Wallet.
 // Part of the Wallet interface.
 func (dw *DEXWallet) LockedOutputs(ctx context.Context, accountName string) ([]chainjson.TransactionInput, error) {
-	return dw.w.LockedOutpoints(ctx, accountName)
+	locked, err := dw.w.LockedOutpoints(ctx, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Frozen outputs are locked in dcrwallet too but they are not DEX coins.
+	dexLocked := make([]chainjson.TransactionInput, 0, len(locked))
+	for _, op := range locked {
+		if !dw.helper.IsOutputFrozen(op.Txid, op.Vout) {
+			dexLocked = append(dexLocked, op)
+		}
+	}
+	return dexLocked, nil
 }
 
-// Unspents fetches unspent outputs for the Wallet.
+// Unspents fetches unspent outputs for the Wallet. Frozen outputs are locked
+// in dcrwallet and therefore not included.
 // Part of the Wallet interface.
 func (dw *DEXWallet) Unspents(ctx context.Context, accountName string) ([]*wallettypes.ListUnspentResult, error) {
 	return dw.w.ListUnspent(ctx, 0, math.MaxInt32, nil, accountName)
@@ -222,6 +236,10 @@ func (dw *DEXWallet) LockUnspent(_ context.Context, unlock bool, ops []*wire.Out
 		fun = dw.w.UnlockOutpoint
 	}
 	for _, op := range ops {
+		// Never unlock frozen outputs.
+		if unlock && dw.helper.IsOutputFrozen(op.Hash.String(), op.Index) {
+			continue
+		}
 		fun(&op.Hash, op.Index)
 	}
 	return nil
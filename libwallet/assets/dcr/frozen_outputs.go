@@ -0,0 +1,61 @@
+package dcr
+
+import (
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// OpenWallet opens the wallet and locks its frozen outputs in dcrwallet so
+// the ticket buyer and the account mixer, which select their own inputs,
+// leave them alone as well.
+func (asset *Asset) OpenWallet() error {
+	if err := asset.Wallet.OpenWallet(); err != nil {
+		return err
+	}
+
+	for _, fo := range asset.FrozenOutputs() {
+		if err := asset.lockOutpoint(fo.TxID, fo.Vout, true); err != nil {
+			log.Errorf("locking frozen output %s failed: %v", fo.OutpointKey(), err)
+		}
+	}
+	return nil
+}
+
+// FreezeOutput excludes the output from coin selection, including the
+// selection done by dcrwallet itself.
+func (asset *Asset) FreezeOutput(txID string, vout uint32, label, reason string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	if err := asset.lockOutpoint(txID, vout, true); err != nil {
+		return err
+	}
+	return asset.Wallet.FreezeOutput(txID, vout, label, reason)
+}
+
+// UnfreezeOutput makes the output available to coin selection again.
+func (asset *Asset) UnfreezeOutput(txID string, vout uint32) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	if err := asset.Wallet.UnfreezeOutput(txID, vout); err != nil {
+		return err
+	}
+	return asset.lockOutpoint(txID, vout, false)
+}
+
+func (asset *Asset) lockOutpoint(txID string, vout uint32, lock bool) error {
+	hash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return err
+	}
+
+	if lock {
+		asset.Internal().DCR.LockOutpoint(hash, vout)
+	} else {
+		asset.Internal().DCR.UnlockOutpoint(hash, vout)
+	}
+	return nil
+}
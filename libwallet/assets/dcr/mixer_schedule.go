@@ -0,0 +1,260 @@
+package dcr
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// mixerScheduleCheckInterval is how often the mixer scheduler checks whether
+// the account mixer should be started or stopped.
+const mixerScheduleCheckInterval = time.Minute
+
+// MixerSchedule limits when the account mixer runs.
+type MixerSchedule struct {
+	// StartHour and EndHour are the local hours the mixer may run between,
+	// EndHour excluded. The window wraps around midnight if StartHour is
+	// after EndHour and the mixer may run at any hour if they are equal.
+	StartHour int `json:"start_hour"`
+	EndHour   int `json:"end_hour"`
+	// OnlyOnACPower pauses the mixer while the machine runs on battery.
+	OnlyOnACPower bool `json:"only_on_ac_power"`
+	// MaxRounds stops the scheduler after that many mixes, 0 for no limit.
+	MaxRounds int `json:"max_rounds"`
+	// StopWhenUnmixedEmpty stops the scheduler once the unmixed account has
+	// no mixable output left.
+	StopWhenUnmixedEmpty bool `json:"stop_when_unmixed_empty"`
+}
+
+// InWindow returns true if t is within the hours the mixer may run.
+func (s *MixerSchedule) InWindow(t time.Time) bool {
+	hour := t.Hour()
+	switch {
+	case s.StartHour == s.EndHour:
+		return true
+	case s.StartHour < s.EndHour:
+		return hour >= s.StartHour && hour < s.EndHour
+	default:
+		return hour >= s.StartHour || hour < s.EndHour
+	}
+}
+
+func (s *MixerSchedule) canRun(t time.Time) bool {
+	return s.InWindow(t) && (!s.OnlyOnACPower || utils.OnACPower())
+}
+
+// mixerScheduler keeps the state of the running mixer schedule.
+type mixerScheduler struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// MixDenominationStats is the wallet's share of the mixes of a denomination.
+type MixDenominationStats struct {
+	Denomination int64 `json:"denomination"`
+	Outputs      int   `json:"outputs"`
+	Amount       int64 `json:"amount"`
+}
+
+// MixerStats summarizes the mixed transactions of a wallet.
+type MixerStats struct {
+	Rounds        int                     `json:"rounds"`
+	MixedAmount   int64                   `json:"mixed_amount"`
+	Denominations []*MixDenominationStats `json:"denominations"`
+	// UnmixedBalance is the balance of the unmixed account left to mix.
+	UnmixedBalance int64 `json:"unmixed_balance"`
+}
+
+// SetMixerSchedule saves the schedule used by StartMixerScheduler, a nil
+// schedule clears it.
+func (asset *Asset) SetMixerSchedule(schedule *MixerSchedule) {
+	if schedule == nil {
+		asset.DeleteUserConfigValueForKey(sharedW.AccountMixerScheduleKey)
+		return
+	}
+	asset.SaveUserConfigValue(sharedW.AccountMixerScheduleKey, schedule)
+}
+
+// MixerSchedule returns the saved mixer schedule or nil if none was saved.
+func (asset *Asset) MixerSchedule() *MixerSchedule {
+	schedule := new(MixerSchedule)
+	if err := asset.ReadUserConfigValue(sharedW.AccountMixerScheduleKey, schedule); err != nil {
+		return nil
+	}
+	return schedule
+}
+
+// IsMixerSchedulerActive returns true if the account mixer is run by the
+// scheduler.
+func (asset *Asset) IsMixerSchedulerActive() bool {
+	asset.mixerScheduler.mu.Lock()
+	defer asset.mixerScheduler.mu.Unlock()
+	return asset.mixerScheduler.cancel != nil
+}
+
+// StartMixerScheduler starts and stops the account mixer following the saved
+// schedule until the schedule completes, StopMixerScheduler is called or the
+// wallet shuts down. The passphrase is kept in memory to restart the mixer
+// while the scheduler runs.
+func (asset *Asset) StartMixerScheduler(passphrase string) error {
+	schedule := asset.MixerSchedule()
+	if schedule == nil {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if asset.readCSPPConfig() == nil {
+		return utils.ErrStakingAccountsMissing
+	}
+
+	if err := asset.UnlockWallet(passphrase); err != nil {
+		return utils.TranslateError(err)
+	}
+	asset.LockWallet()
+
+	asset.mixerScheduler.mu.Lock()
+	defer asset.mixerScheduler.mu.Unlock()
+	if asset.mixerScheduler.cancel != nil {
+		return errors.New("mixer scheduler already running")
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	asset.mixerScheduler.cancel = cancel
+	go asset.runMixerScheduler(ctx, schedule, time.Now().Unix(), passphrase)
+	return nil
+}
+
+// StopMixerScheduler stops the scheduler and the account mixer it started.
+func (asset *Asset) StopMixerScheduler() {
+	asset.mixerScheduler.mu.Lock()
+	defer asset.mixerScheduler.mu.Unlock()
+
+	if asset.mixerScheduler.cancel != nil {
+		asset.mixerScheduler.cancel()
+		asset.mixerScheduler.cancel = nil
+	}
+}
+
+func (asset *Asset) runMixerScheduler(ctx context.Context, schedule *MixerSchedule, startedAt int64, passphrase string) {
+	log.Infof("[%d] Mixer scheduler started", asset.ID)
+	defer func() {
+		if ctx.Err() == nil {
+			// The schedule completed, the scheduler was not stopped.
+			asset.StopMixerScheduler()
+		}
+		if asset.IsAccountMixerActive() {
+			if err := asset.StopAccountMixer(); err != nil {
+				log.Errorf("[%d] Unable to stop the account mixer: %v", asset.ID, err)
+			}
+		}
+		log.Infof("[%d] Mixer scheduler stopped", asset.ID)
+	}()
+
+	ticker := time.NewTicker(mixerScheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if asset.applyMixerSchedule(schedule, startedAt, passphrase) {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// applyMixerSchedule starts or stops the account mixer as the schedule
+// requires, it returns true once the schedule is complete.
+func (asset *Asset) applyMixerSchedule(schedule *MixerSchedule, startedAt int64, passphrase string) bool {
+	if schedule.MaxRounds > 0 {
+		rounds, err := asset.mixRoundsSince(startedAt)
+		if err != nil {
+			log.Errorf("[%d] Unable to count the mix rounds: %v", asset.ID, err)
+		} else if rounds >= schedule.MaxRounds {
+			log.Infof("[%d] Mixer schedule completed %d rounds", asset.ID, rounds)
+			return true
+		}
+	}
+
+	hasMixableOutput := asset.accountHasMixableOutput(asset.UnmixedAccountNumber())
+	if schedule.StopWhenUnmixedEmpty && !hasMixableOutput {
+		log.Infof("[%d] Mixer schedule completed, nothing left to mix", asset.ID)
+		return true
+	}
+
+	canRun := schedule.canRun(time.Now())
+	isActive := asset.IsAccountMixerActive()
+	switch {
+	case canRun && !isActive && hasMixableOutput && asset.IsConnectedToDecredNetwork():
+		if err := asset.StartAccountMixer(passphrase); err != nil {
+			log.Errorf("[%d] Unable to start the scheduled account mixer: %v", asset.ID, err)
+		}
+	case !canRun && isActive:
+		if err := asset.StopAccountMixer(); err != nil {
+			log.Errorf("[%d] Unable to pause the scheduled account mixer: %v", asset.ID, err)
+		}
+	}
+	return false
+}
+
+// mixRoundsSince returns the number of mixed transactions of the wallet from
+// the unix timestamp since.
+func (asset *Asset) mixRoundsSince(since int64) (int, error) {
+	txs, err := asset.GetTransactionsRaw(0, 0, TxFilterMixed, true, "")
+	if err != nil {
+		return 0, err
+	}
+
+	var rounds int
+	for _, tx := range txs {
+		if tx.Timestamp < since {
+			break
+		}
+		rounds++
+	}
+	return rounds, nil
+}
+
+// MixerStats returns the rounds completed and the amount mixed per
+// denomination from the wallet's mixed transactions, with the balance of the
+// unmixed account left to mix.
+func (asset *Asset) MixerStats() (*MixerStats, error) {
+	txs, err := asset.GetTransactionsRaw(0, 0, TxFilterMixed, true, "")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MixerStats{Rounds: len(txs)}
+	denominations := make(map[int64]*MixDenominationStats)
+	for _, tx := range txs {
+		denom, ok := denominations[tx.MixDenomination]
+		if !ok {
+			denom = &MixDenominationStats{Denomination: tx.MixDenomination}
+			denominations[tx.MixDenomination] = denom
+			stats.Denominations = append(stats.Denominations, denom)
+		}
+		amount := tx.MixDenomination * int64(tx.MixCount)
+		denom.Outputs += int(tx.MixCount)
+		denom.Amount += amount
+		stats.MixedAmount += amount
+	}
+	sort.Slice(stats.Denominations, func(i, j int) bool {
+		return stats.Denominations[i].Denomination > stats.Denominations[j].Denomination
+	})
+
+	if unmixedAccount := asset.UnmixedAccountNumber(); unmixedAccount != -1 {
+		balance, err := asset.GetAccountBalance(unmixedAccount)
+		if err != nil {
+			return nil, err
+		}
+		stats.UnmixedBalance = balance.Total.ToInt()
+	}
+	return stats, nil
+}
@@ -0,0 +1,43 @@
+package dcr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMixerScheduleInWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name      string
+		startHour int
+		endHour   int
+		time      time.Time
+		expected  bool
+	}{
+		{name: "any hour", startHour: 5, endHour: 5, time: at(17, 0), expected: true},
+		{name: "any hour at start", startHour: 0, endHour: 0, time: at(0, 0), expected: true},
+		{name: "same day start", startHour: 9, endHour: 17, time: at(9, 0), expected: true},
+		{name: "same day inside", startHour: 9, endHour: 17, time: at(16, 59), expected: true},
+		{name: "same day end excluded", startHour: 9, endHour: 17, time: at(17, 0), expected: false},
+		{name: "same day before", startHour: 9, endHour: 17, time: at(8, 59), expected: false},
+		{name: "wrap before midnight", startHour: 22, endHour: 6, time: at(23, 30), expected: true},
+		{name: "wrap at midnight", startHour: 22, endHour: 6, time: at(0, 0), expected: true},
+		{name: "wrap after midnight", startHour: 22, endHour: 6, time: at(5, 59), expected: true},
+		{name: "wrap end excluded", startHour: 22, endHour: 6, time: at(6, 0), expected: false},
+		{name: "wrap outside", startHour: 22, endHour: 6, time: at(12, 0), expected: false},
+		{name: "wrap start", startHour: 22, endHour: 6, time: at(22, 0), expected: true},
+		{name: "wrap to midnight", startHour: 23, endHour: 0, time: at(0, 0), expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule := &MixerSchedule{StartHour: tc.startHour, EndHour: tc.endHour}
+			if got := schedule.InWindow(tc.time); got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,517 @@
+package dcr
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	w "decred.org/dcrwallet/v4/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+const (
+	// offlineTxVersion is the version of the OfflineTx file format.
+	offlineTxVersion = 1
+	// offlineTxDirName is the folder in the wallet's data directory where
+	// exported and signed transaction files are written.
+	offlineTxDirName = "offlinetx"
+	// signedTxSuffix is appended to the name of signed transaction files.
+	signedTxSuffix = "-signed"
+	// offlineTxMaxAddressGap is how far past the next unused address of a
+	// branch the address of an input may be when signing offline.
+	offlineTxMaxAddressGap = 10 * AddressGapLimit
+
+	// verifyFlags match the flags dcrwallet uses to sanity check the
+	// transactions it signs.
+	verifyFlags = txscript.ScriptDiscourageUpgradableNops |
+		txscript.ScriptVerifyCleanStack |
+		txscript.ScriptVerifyCheckLockTimeVerify |
+		txscript.ScriptVerifyCheckSequenceVerify |
+		txscript.ScriptVerifyTreasury
+)
+
+// OfflineTx is the portable form of a transaction authored by a watch-only
+// wallet. It carries everything an offline wallet restored from the same
+// seed needs to sign the transaction without access to the network.
+type OfflineTx struct {
+	Version int    `json:"version"`
+	Network string `json:"network"`
+	// Tx is the hex encoded transaction. It holds the signature scripts once
+	// Signed is set.
+	Tx          string             `json:"tx"`
+	Inputs      []*OfflineTxInput  `json:"inputs"`
+	Outputs     []*OfflineTxOutput `json:"outputs"`
+	ChangeIndex int                `json:"changeIndex"`
+	Fee         int64              `json:"fee"`
+	Signed      bool               `json:"signed"`
+	// PrevTxs are the hex encoded transactions the inputs spend. The offline
+	// wallet takes the scripts and amounts of the inputs from them after
+	// checking their hashes, so they cannot be forged to hide the fee.
+	PrevTxs []string `json:"prevTxs"`
+}
+
+// OfflineTxInput describes the previous output spent by an input and the
+// BIP0044 path of the key that controls it.
+type OfflineTxInput struct {
+	PreviousOutPoint string `json:"previousOutPoint"`
+	PkScript         string `json:"pkScript"`
+	Amount           int64  `json:"amount"`
+	Account          uint32 `json:"account"`
+	Branch           uint32 `json:"branch"`
+	Index            uint32 `json:"index"`
+}
+
+// OfflineTxOutput describes a transaction output. The offline wallet shows the
+// outputs decoded from the transaction itself rather than these.
+type OfflineTxOutput struct {
+	Address  string `json:"address"`
+	Amount   int64  `json:"amount"`
+	IsChange bool   `json:"isChange"`
+}
+
+// ExportUnsignedTx writes the transaction set up with NewUnsignedTx and
+// AddSendDestination to a file in the wallet's data directory and returns
+// the file path. The file can be signed with SignOfflineTx by a wallet
+// holding the private keys and broadcast afterwards with PublishOfflineTx.
+func (asset *Asset) ExportUnsignedTx() (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	unsignedTx, err := asset.unsignedTransaction()
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+
+	if unsignedTx.ChangeIndex >= 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	msgTx := unsignedTx.Tx
+	offlineTx := &OfflineTx{
+		Version:     offlineTxVersion,
+		Network:     string(asset.NetType()),
+		Inputs:      make([]*OfflineTxInput, 0, len(msgTx.TxIn)),
+		Outputs:     make([]*OfflineTxOutput, 0, len(msgTx.TxOut)),
+		ChangeIndex: unsignedTx.ChangeIndex,
+	}
+
+	var totalOutput int64
+	prevTxHashes := make([]*chainhash.Hash, 0, len(msgTx.TxIn))
+	seen := make(map[chainhash.Hash]bool, len(msgTx.TxIn))
+	for i, txIn := range msgTx.TxIn {
+		if hash := txIn.PreviousOutPoint.Hash; !seen[hash] {
+			seen[hash] = true
+			prevTxHashes = append(prevTxHashes, &hash)
+		}
+
+		pkScript := unsignedTx.PrevScripts[i]
+		input := &OfflineTxInput{
+			PreviousOutPoint: txIn.PreviousOutPoint.String(),
+			PkScript:         hex.EncodeToString(pkScript),
+			Amount:           txIn.ValueIn,
+		}
+
+		_, addrs := stdscript.ExtractAddrs(0, pkScript, asset.chainParams)
+		if len(addrs) != 1 {
+			return "", fmt.Errorf("unsupported script for input %d", i)
+		}
+		knownAddr, err := asset.Internal().DCR.KnownAddress(ctx, addrs[0])
+		if err != nil {
+			return "", err
+		}
+		bip44Addr, ok := knownAddr.(w.BIP0044Address)
+		if !ok {
+			return "", fmt.Errorf("input %d is not controlled by an HD key", i)
+		}
+		input.Account, input.Branch, input.Index = bip44Addr.Path()
+		offlineTx.Inputs = append(offlineTx.Inputs, input)
+	}
+
+	for i, txOut := range msgTx.TxOut {
+		output := &OfflineTxOutput{
+			Amount:   txOut.Value,
+			IsChange: i == unsignedTx.ChangeIndex,
+		}
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) > 0 {
+			output.Address = addrs[0].String()
+		}
+		totalOutput += txOut.Value
+		offlineTx.Outputs = append(offlineTx.Outputs, output)
+	}
+	offlineTx.Fee = int64(unsignedTx.TotalInput) - totalOutput
+
+	prevTxs, _, err := asset.Internal().DCR.GetTransactionsByHashes(ctx, prevTxHashes)
+	if err != nil {
+		log.Errorf("reading the spent transactions failed: %v", err)
+		return "", err
+	}
+	for _, prevTx := range prevTxs {
+		encoded, err := encodeTx(prevTx)
+		if err != nil {
+			return "", err
+		}
+		offlineTx.PrevTxs = append(offlineTx.PrevTxs, encoded)
+	}
+
+	if offlineTx.Tx, err = encodeTx(msgTx); err != nil {
+		return "", err
+	}
+
+	return asset.saveOfflineTx(offlineTx, msgTx.TxHash().String())
+}
+
+// ReviewOfflineTx decodes the contents of a transaction file created by
+// ExportUnsignedTx for review before it is signed with SignOfflineTx. The
+// destinations are decoded from the transaction itself and the fee from the
+// amounts of the spent transactions, which are checked against their hashes,
+// so the review cannot be fooled by the other fields of the file. Reviewing
+// doesn't change the wallet, the addresses of the inputs are only derived
+// when the transaction is signed.
+func (asset *Asset) ReviewOfflineTx(txJSON string) (*sharedW.UnsignedTxSummary, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	offlineTx, msgTx, err := asset.readOfflineTx(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	summary, _, err := verifyOfflineTx(ctx, asset.Internal().DCR, asset.chainParams, offlineTx, msgTx, false)
+	return summary, err
+}
+
+// SignOfflineTx signs the contents of a transaction file created by
+// ExportUnsignedTx on a watch-only wallet of the same seed. The wallet doesn't
+// need to be synced. reviewedTxHash is the hash of the transaction returned
+// by ReviewOfflineTx and confirmed by the user, a different transaction is
+// not signed. The signed transaction is written to a new file whose path is
+// returned.
+func (asset *Asset) SignOfflineTx(txJSON, reviewedTxHash, privatePassphrase string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	offlineTx, msgTx, err := asset.readOfflineTx(txJSON)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	summary, prevScripts, err := verifyOfflineTx(ctx, asset.Internal().DCR, asset.chainParams, offlineTx, msgTx, true)
+	if err != nil {
+		return "", err
+	}
+	if summary.TxHash != reviewedTxHash {
+		return "", errors.E(errors.Invalid, "transaction does not match the reviewed transaction")
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err = asset.Internal().DCR.Unlock(ctx, []byte(privatePassphrase), lock)
+	if err != nil {
+		log.Error(err)
+		return "", errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	invalidSigs, err := asset.Internal().DCR.SignTransaction(ctx, msgTx, txscript.SigHashAll, prevScripts, nil, nil)
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+	if len(invalidSigs) > 0 {
+		return "", fmt.Errorf("signing input %d failed: %v", invalidSigs[0].InputIndex, invalidSigs[0].Error)
+	}
+
+	if offlineTx.Tx, err = encodeTx(msgTx); err != nil {
+		return "", err
+	}
+	offlineTx.Signed = true
+
+	return asset.saveOfflineTx(offlineTx, msgTx.TxHash().String()+signedTxSuffix)
+}
+
+// offlineTxWallet is the part of the wallet used to verify the inputs of an
+// offline transaction.
+type offlineTxWallet interface {
+	AddressAtIdx(ctx context.Context, account, branch, childIdx uint32) (stdaddr.Address, error)
+	KnownAddress(ctx context.Context, a stdaddr.Address) (w.KnownAddress, error)
+	BIP0044BranchNextIndexes(ctx context.Context, account uint32) (extChild, intChild uint32, err error)
+	SyncLastReturnedAddress(ctx context.Context, account, branch, child uint32) error
+}
+
+// verifyOfflineTx checks that every input of an unsigned transaction spends
+// an output of dcrWallet, taking its script and amount from the spent
+// transaction in the file after checking its hash. It returns the summary of
+// the transaction and the scripts of the spent outputs. The wallet is only
+// made to watch the addresses of the inputs it doesn't know yet if
+// syncAddrs is set, so reviewing a file leaves the wallet untouched.
+func verifyOfflineTx(ctx context.Context, dcrWallet offlineTxWallet, chainParams *chaincfg.Params, offlineTx *OfflineTx,
+	msgTx *wire.MsgTx, syncAddrs bool) (*sharedW.UnsignedTxSummary, map[wire.OutPoint][]byte, error) {
+	if offlineTx.Signed {
+		return nil, nil, errors.E(errors.Invalid, "transaction is already signed")
+	}
+
+	if len(offlineTx.Inputs) != len(msgTx.TxIn) {
+		return nil, nil, errors.E(errors.Invalid, "transaction inputs do not match the file")
+	}
+
+	prevTxs := make(map[chainhash.Hash]*wire.MsgTx, len(offlineTx.PrevTxs))
+	for _, encoded := range offlineTx.PrevTxs {
+		prevTx, err := decodeTx(encoded)
+		if err != nil {
+			return nil, nil, err
+		}
+		prevTxs[prevTx.TxHash()] = prevTx
+	}
+
+	prevScripts := make(map[wire.OutPoint][]byte, len(msgTx.TxIn))
+	var totalInput int64
+	for i, txIn := range msgTx.TxIn {
+		input := offlineTx.Inputs[i]
+		if input.PreviousOutPoint != txIn.PreviousOutPoint.String() {
+			return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("input %d does not match the file", i))
+		}
+
+		prevTx, ok := prevTxs[txIn.PreviousOutPoint.Hash]
+		if !ok || int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("the transaction spent by input %d is missing", i))
+		}
+		prevOut := prevTx.TxOut[txIn.PreviousOutPoint.Index]
+		if txIn.ValueIn != prevOut.Value {
+			return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("amount of input %d does not match the spent output", i))
+		}
+
+		_, addrs := stdscript.ExtractAddrs(prevOut.Version, prevOut.PkScript, chainParams)
+		if len(addrs) != 1 {
+			return nil, nil, fmt.Errorf("unsupported script for input %d", i)
+		}
+
+		walletAddr, err := dcrWallet.AddressAtIdx(ctx, input.Account, input.Branch, input.Index)
+		if err != nil || walletAddr.String() != addrs[0].String() {
+			return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("input %d is not controlled by this wallet", i))
+		}
+
+		// An offline wallet only knows the addresses within the gap limit
+		// of its last returned address, so derive up to the input's key.
+		if _, err := dcrWallet.KnownAddress(ctx, addrs[0]); err != nil {
+			if err = syncOfflineTxAddress(ctx, dcrWallet, input, syncAddrs); err != nil {
+				log.Errorf("deriving address for input %d failed: %v", i, err)
+				return nil, nil, err
+			}
+		}
+
+		prevScripts[txIn.PreviousOutPoint] = prevOut.PkScript
+		totalInput += prevOut.Value
+	}
+
+	summary := &sharedW.UnsignedTxSummary{
+		TxHash:  msgTx.TxHash().String(),
+		Outputs: make([]*sharedW.UnsignedTxOutput, 0, len(msgTx.TxOut)),
+	}
+	var totalOutput int64
+	for _, txOut := range msgTx.TxOut {
+		output := &sharedW.UnsignedTxOutput{Amount: txOut.Value}
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, chainParams)
+		if len(addrs) > 0 {
+			output.Address = addrs[0].String()
+			_, err := dcrWallet.KnownAddress(ctx, addrs[0])
+			output.Internal = err == nil
+		}
+		totalOutput += txOut.Value
+		summary.Outputs = append(summary.Outputs, output)
+	}
+
+	summary.Fee = totalInput - totalOutput
+	if summary.Fee < 0 {
+		return nil, nil, errors.E(errors.Invalid, "transaction outputs exceed its inputs")
+	}
+	return summary, prevScripts, nil
+}
+
+// syncOfflineTxAddress makes the wallet watch the addresses up to the one
+// of input if sync is set. Addresses more than offlineTxMaxAddressGap past
+// the next unused address of the branch are refused rather than making the
+// wallet derive and watch every address up to an arbitrary index.
+func syncOfflineTxAddress(ctx context.Context, dcrWallet offlineTxWallet, input *OfflineTxInput, sync bool) error {
+	extNext, intNext, err := dcrWallet.BIP0044BranchNextIndexes(ctx, input.Account)
+	if err != nil {
+		return err
+	}
+
+	next := extNext
+	if input.Branch == 1 {
+		next = intNext
+	}
+	if input.Index >= next && input.Index-next >= offlineTxMaxAddressGap {
+		return errors.E(errors.Invalid, fmt.Sprintf("address index %d is too far past the last used address %d, "+
+			"sync or discover the wallet's address usage first", input.Index, next))
+	}
+	if !sync {
+		return nil
+	}
+	return dcrWallet.SyncLastReturnedAddress(ctx, input.Account, input.Branch, input.Index)
+}
+
+// PublishOfflineTx verifies the contents of a transaction file signed by
+// SignOfflineTx and broadcasts it. Only transactions exported by this wallet
+// are accepted and every signature is checked against the outputs the wallet
+// knows about.
+func (asset *Asset) PublishOfflineTx(txJSON, transactionLabel string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+
+	offlineTx, msgTx, err := asset.readOfflineTx(txJSON)
+	if err != nil {
+		return "", err
+	}
+
+	if err = checkSignedOfflineTx(asset.DataDir(), offlineTx, msgTx); err != nil {
+		return "", err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	for i, txIn := range msgTx.TxIn {
+		prevOut, err := asset.Internal().DCR.FetchOutput(ctx, &txIn.PreviousOutPoint)
+		if err != nil {
+			return "", fmt.Errorf("input %d: %v", i, err)
+		}
+
+		vm, err := txscript.NewEngine(prevOut.PkScript, msgTx, i, verifyFlags,
+			prevOut.Version, nil)
+		if err == nil {
+			err = vm.Execute()
+		}
+		if err != nil {
+			return "", errors.E(errors.Invalid, fmt.Sprintf("invalid signature for input %d: %v", i, err))
+		}
+	}
+
+	hash, err := asset.Internal().DCR.PublishTransaction(ctx, msgTx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return hash.String(), asset.updateTxLabel(hash, transactionLabel)
+}
+
+// checkSignedOfflineTx checks that a transaction file is signed and holds a
+// transaction exported by the wallet whose data directory is dataDir.
+func checkSignedOfflineTx(dataDir string, offlineTx *OfflineTx, msgTx *wire.MsgTx) error {
+	if !offlineTx.Signed {
+		return errors.E(errors.Invalid, "transaction is not signed")
+	}
+
+	// Signature scripts are not part of the transaction hash, so a matching
+	// export proves the inputs and outputs have not been changed.
+	exported := filepath.Join(dataDir, offlineTxDirName, msgTx.TxHash().String()+".json")
+	if _, err := os.Stat(exported); err != nil {
+		return errors.E(errors.Invalid, "transaction was not exported by this wallet")
+	}
+	return nil
+}
+
+// saveOfflineTx writes the transaction file and returns its path.
+func (asset *Asset) saveOfflineTx(offlineTx *OfflineTx, name string) (string, error) {
+	data, err := json.MarshalIndent(offlineTx, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(asset.DataDir(), offlineTxDirName)
+	if err = os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, name+".json")
+	if err = os.WriteFile(path, data, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ReadOfflineTxFile returns the contents of a transaction file written by
+// ExportUnsignedTx or SignOfflineTx.
+func ReadOfflineTxFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// readOfflineTx decodes the contents of a transaction file and the
+// transaction it holds. Files are read with ReadOfflineTxFile.
+func (asset *Asset) readOfflineTx(txJSON string) (*OfflineTx, *wire.MsgTx, error) {
+	offlineTx := new(OfflineTx)
+	if err := json.Unmarshal([]byte(strings.TrimSpace(txJSON)), offlineTx); err != nil {
+		return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("invalid transaction file: %v", err))
+	}
+
+	if offlineTx.Version != offlineTxVersion {
+		return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("unsupported transaction file version %d", offlineTx.Version))
+	}
+
+	if offlineTx.Network != string(asset.NetType()) {
+		return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("transaction file is for %s", offlineTx.Network))
+	}
+
+	msgTx, err := decodeTx(offlineTx.Tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return offlineTx, msgTx, nil
+}
+
+func decodeTx(encoded string) (*wire.MsgTx, error) {
+	txBytes, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, "invalid transaction encoding")
+	}
+
+	msgTx := new(wire.MsgTx)
+	if err = msgTx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid transaction: %v", err))
+	}
+	return msgTx, nil
+}
+
+func encodeTx(msgTx *wire.MsgTx) (string, error) {
+	var txBuf bytes.Buffer
+	txBuf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&txBuf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(txBuf.Bytes()), nil
+}
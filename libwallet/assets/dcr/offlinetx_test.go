@@ -0,0 +1,251 @@
+package dcr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"decred.org/dcrwallet/v4/errors"
+	w "decred.org/dcrwallet/v4/wallet"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+var testOfflineTxParams = chaincfg.TestNet3Params()
+
+// testOfflineTxAddr returns the address of the test wallet at a BIP0044 path.
+func testOfflineTxAddr(account, branch, index uint32) stdaddr.Address {
+	pkHash := dcrutil.Hash160([]byte(fmt.Sprintf("%d/%d/%d", account, branch, index)))
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(pkHash, testOfflineTxParams)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// testOfflineTxWallet derives the addresses of testOfflineTxAddr and records
+// the addresses it is made to watch.
+type testOfflineTxWallet struct {
+	known   map[string]bool
+	extNext uint32
+	synced  []uint32
+}
+
+func (tw *testOfflineTxWallet) AddressAtIdx(_ context.Context, account, branch, childIdx uint32) (stdaddr.Address, error) {
+	return testOfflineTxAddr(account, branch, childIdx), nil
+}
+
+func (tw *testOfflineTxWallet) KnownAddress(_ context.Context, a stdaddr.Address) (w.KnownAddress, error) {
+	if !tw.known[a.String()] {
+		return nil, errors.E(errors.NotExist, "unknown address")
+	}
+	return nil, nil
+}
+
+func (tw *testOfflineTxWallet) BIP0044BranchNextIndexes(_ context.Context, _ uint32) (uint32, uint32, error) {
+	return tw.extNext, 0, nil
+}
+
+func (tw *testOfflineTxWallet) SyncLastReturnedAddress(_ context.Context, _, _, child uint32) error {
+	tw.synced = append(tw.synced, child)
+	return nil
+}
+
+// newTestOfflineTx returns a transaction file spending 1 DCR received on the
+// external address at index, paying 0.9 DCR to another wallet.
+func newTestOfflineTx(t *testing.T, index uint32) (*OfflineTx, *wire.MsgTx) {
+	t.Helper()
+	_, inScript := testOfflineTxAddr(0, 0, index).PaymentScript()
+	prevTx := wire.NewMsgTx()
+	prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, 2e8, nil))
+	prevTx.AddTxOut(wire.NewTxOut(1e8, inScript))
+	prevHash := prevTx.TxHash()
+
+	_, outScript := testOfflineTxAddr(5, 0, 0).PaymentScript()
+	msgTx := wire.NewMsgTx()
+	prevOutPoint := wire.NewOutPoint(&prevHash, 0, wire.TxTreeRegular)
+	msgTx.AddTxIn(wire.NewTxIn(prevOutPoint, 1e8, nil))
+	msgTx.AddTxOut(wire.NewTxOut(9e7, outScript))
+
+	encodedPrevTx, err := encodeTx(prevTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedTx, err := encodeTx(msgTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &OfflineTx{
+		Version: offlineTxVersion,
+		Tx:      encodedTx,
+		Inputs: []*OfflineTxInput{{
+			PreviousOutPoint: prevOutPoint.String(),
+			Amount:           1e8,
+			Index:            index,
+		}},
+		PrevTxs: []string{encodedPrevTx},
+	}, msgTx
+}
+
+func TestVerifyOfflineTx(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       uint32
+		known       bool
+		syncAddrs   bool
+		modify      func(t *testing.T, offlineTx *OfflineTx, msgTx *wire.MsgTx)
+		expectedErr string
+		expectSync  []uint32
+	}{
+		{name: "review", index: 5},
+		{name: "sign derives the input address", index: 5, syncAddrs: true, expectSync: []uint32{5}},
+		{name: "sign with a known address", index: 5, known: true, syncAddrs: true},
+		{
+			name:  "spent tx hash mismatch",
+			index: 5,
+			modify: func(t *testing.T, offlineTx *OfflineTx, _ *wire.MsgTx) {
+				prevTx, err := decodeTx(offlineTx.PrevTxs[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+				prevTx.TxOut[0].Value = 5e8
+				if offlineTx.PrevTxs[0], err = encodeTx(prevTx); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedErr: "the transaction spent by input 0 is missing",
+		},
+		{
+			name:  "forged input amount",
+			index: 5,
+			modify: func(_ *testing.T, _ *OfflineTx, msgTx *wire.MsgTx) {
+				msgTx.TxIn[0].ValueIn = 5e8
+			},
+			expectedErr: "amount of input 0 does not match the spent output",
+		},
+		{
+			name:  "path does not match the script",
+			index: 5,
+			modify: func(_ *testing.T, offlineTx *OfflineTx, _ *wire.MsgTx) {
+				offlineTx.Inputs[0].Index = 6
+			},
+			expectedErr: "input 0 is not controlled by this wallet",
+		},
+		{
+			name:  "branch does not match the script",
+			index: 5,
+			modify: func(_ *testing.T, offlineTx *OfflineTx, _ *wire.MsgTx) {
+				offlineTx.Inputs[0].Branch = 1
+			},
+			expectedErr: "input 0 is not controlled by this wallet",
+		},
+		{name: "index past the address gap", index: offlineTxMaxAddressGap, expectedErr: "too far past the last used address"},
+		{
+			name:        "index past the address gap when signing",
+			index:       offlineTxMaxAddressGap,
+			syncAddrs:   true,
+			expectedErr: "too far past the last used address",
+		},
+		{name: "index within the address gap", index: offlineTxMaxAddressGap - 1, syncAddrs: true, expectSync: []uint32{offlineTxMaxAddressGap - 1}},
+		{
+			name:  "outputs exceed the inputs",
+			index: 5,
+			modify: func(_ *testing.T, _ *OfflineTx, msgTx *wire.MsgTx) {
+				msgTx.TxOut[0].Value = 2e8
+			},
+			expectedErr: "transaction outputs exceed its inputs",
+		},
+		{
+			name:  "already signed",
+			index: 5,
+			modify: func(_ *testing.T, offlineTx *OfflineTx, _ *wire.MsgTx) {
+				offlineTx.Signed = true
+			},
+			expectedErr: "transaction is already signed",
+		},
+		{
+			name:  "input does not match the file",
+			index: 5,
+			modify: func(_ *testing.T, offlineTx *OfflineTx, _ *wire.MsgTx) {
+				offlineTx.Inputs[0].PreviousOutPoint = wire.NewOutPoint(&chainhash.Hash{}, 0, wire.TxTreeRegular).String()
+			},
+			expectedErr: "input 0 does not match the file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			offlineTx, msgTx := newTestOfflineTx(t, tc.index)
+			if tc.modify != nil {
+				tc.modify(t, offlineTx, msgTx)
+			}
+			tw := &testOfflineTxWallet{known: make(map[string]bool)}
+			if tc.known {
+				tw.known[testOfflineTxAddr(0, 0, tc.index).String()] = true
+			}
+
+			summary, prevScripts, err := verifyOfflineTx(context.Background(), tw, testOfflineTxParams, offlineTx, msgTx, tc.syncAddrs)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("(%v), unexpected error: %v", tc.name, err)
+				}
+				if summary.Fee != 1e7 || summary.TxHash != msgTx.TxHash().String() {
+					t.Errorf("(%v), expected fee (%v) for (%v), got (%v) for (%v)",
+						tc.name, 1e7, msgTx.TxHash(), summary.Fee, summary.TxHash)
+				}
+				if len(summary.Outputs) != 1 || summary.Outputs[0].Address != testOfflineTxAddr(5, 0, 0).String() ||
+					summary.Outputs[0].Internal {
+					t.Errorf("(%v), unexpected outputs (%v)", tc.name, summary.Outputs)
+				}
+				if len(prevScripts) != 1 {
+					t.Errorf("(%v), expected (1) spent script, got (%v)", tc.name, len(prevScripts))
+				}
+			}
+
+			if fmt.Sprint(tw.synced) != fmt.Sprint(tc.expectSync) {
+				t.Errorf("(%v), expected synced addresses (%v), got (%v)", tc.name, tc.expectSync, tw.synced)
+			}
+		})
+	}
+}
+
+func TestCheckSignedOfflineTx(t *testing.T) {
+	dataDir := t.TempDir()
+	offlineTx, msgTx := newTestOfflineTx(t, 5)
+
+	if err := checkSignedOfflineTx(dataDir, offlineTx, msgTx); err == nil {
+		t.Fatal("accepted an unsigned transaction")
+	}
+
+	offlineTx.Signed = true
+	if err := checkSignedOfflineTx(dataDir, offlineTx, msgTx); err == nil {
+		t.Fatal("accepted a transaction this wallet did not export")
+	}
+
+	dir := filepath.Join(dataDir, offlineTxDirName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, msgTx.TxHash().String()+".json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSignedOfflineTx(dataDir, offlineTx, msgTx); err != nil {
+		t.Fatalf("rejected an exported transaction: %v", err)
+	}
+
+	// Changing an output changes the hash, so the export no longer matches.
+	msgTx.TxOut[0].Value--
+	if err := checkSignedOfflineTx(dataDir, offlineTx, msgTx); err == nil {
+		t.Fatal("accepted a transaction changed after it was exported")
+	}
+}
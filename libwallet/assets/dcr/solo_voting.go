@@ -0,0 +1,235 @@
+package dcr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/rpcclient/v8"
+)
+
+var (
+	// ErrSoloVotingNotConfigured is returned when solo voting is started
+	// without solo staking enabled or without a dcrd RPC connection
+	// configured.
+	ErrSoloVotingNotConfigured = errors.New("solo voting requires solo staking and a dcrd rpc connection")
+
+	errSoloVotingTLSRequired = errors.New("a dcrd rpc certificate is required, tls can only be disabled for a dcrd on this machine")
+)
+
+// SoloVotingConfig holds the dcrd RPC connection used to learn which tickets
+// were selected to vote. SPV peers do not announce the winning tickets of a
+// block, so solo voting needs a dcrd node the user trusts. The RPC password
+// is not saved, it is asked for each time solo voting starts.
+type SoloVotingConfig struct {
+	RPCHost     string
+	RPCUser     string
+	RPCCertFile string
+	// RPCNoTLS connects to dcrd without TLS when no certificate file is
+	// given. The RPC credentials are then sent in plaintext, so it is only
+	// honored for loopback hosts.
+	RPCNoTLS bool
+}
+
+// soloVoter keeps the connection to dcrd while solo voting is active.
+type soloVoter struct {
+	mu     sync.Mutex
+	client *rpcclient.Client
+	cancel context.CancelFunc
+}
+
+// SetSoloStaking enables or disables solo staking. Tickets bought while solo
+// staking is enabled keep their voting rights in the wallet instead of
+// delegating them to a VSP, so the wallet must be online and unlocked when
+// they are selected to vote.
+func (asset *Asset) SetSoloStaking(enabled bool) {
+	asset.SetBoolConfigValueForKey(sharedW.SoloStakingConfigKey, enabled)
+}
+
+// IsSoloStaking returns true if solo staking is enabled for the asset.
+func (asset *Asset) IsSoloStaking() bool {
+	return asset.ReadBoolConfigValueForKey(sharedW.SoloStakingConfigKey, false)
+}
+
+// SetSoloVotingConfig saves the dcrd RPC connection used for solo voting.
+func (asset *Asset) SetSoloVotingConfig(cfg *SoloVotingConfig) {
+	asset.SaveUserConfigValue(sharedW.SoloVotingConfigKey, cfg)
+}
+
+// SoloVotingConfig returns the dcrd RPC connection used for solo voting, or
+// nil if none was saved.
+func (asset *Asset) SoloVotingConfig() *SoloVotingConfig {
+	var saved struct {
+		SoloVotingConfig
+		// RPCPass was saved in plaintext by earlier versions.
+		RPCPass string
+	}
+	if err := asset.ReadUserConfigValue(sharedW.SoloVotingConfigKey, &saved); err != nil || saved.RPCHost == "" {
+		return nil
+	}
+	if saved.RPCPass != "" {
+		asset.SetSoloVotingConfig(&saved.SoloVotingConfig)
+	}
+	return &saved.SoloVotingConfig
+}
+
+// IsSoloVotingActive returns true if the wallet is waiting for its tickets to
+// be selected to vote.
+func (asset *Asset) IsSoloVotingActive() bool {
+	asset.soloVoter.mu.Lock()
+	defer asset.soloVoter.mu.Unlock()
+	return asset.soloVoter.client != nil
+}
+
+// StartSoloVoting connects to the configured dcrd node with rpcPass and votes
+// with the wallet's tickets whenever they are selected. The wallet is kept
+// unlocked with passphrase until StopSoloVoting is called or the wallet shuts
+// down. Votes are missed whenever the app is not running. The wallet is
+// locked again if solo voting fails to start.
+func (asset *Asset) StartSoloVoting(passphrase, rpcPass string) (err error) {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	cfg := asset.SoloVotingConfig()
+	if !asset.IsSoloStaking() || cfg == nil {
+		return ErrSoloVotingNotConfigured
+	}
+
+	if asset.IsSoloVotingActive() {
+		return errors.New("solo voting already running")
+	}
+
+	connCfg := &rpcclient.ConnConfig{
+		Host:     cfg.RPCHost,
+		Endpoint: "ws",
+		User:     cfg.RPCUser,
+		Pass:     rpcPass,
+	}
+	connCfg.DisableTLS, err = soloVotingDisableTLS(cfg)
+	if err != nil {
+		return err
+	}
+	if !connCfg.DisableTLS {
+		cert, err := os.ReadFile(cfg.RPCCertFile)
+		if err != nil {
+			return fmt.Errorf("unable to read the dcrd rpc certificate: %v", err)
+		}
+		connCfg.Certificates = cert
+	}
+
+	if err := asset.UnlockWallet(passphrase); err != nil {
+		return utils.TranslateError(err)
+	}
+
+	defer func() {
+		if err != nil && !asset.IsAutoTicketsPurchaseActive() {
+			asset.LockWallet()
+		}
+	}()
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	var client *rpcclient.Client
+	handlers := &rpcclient.NotificationHandlers{
+		OnClientConnected: func() {
+			// Notifications must be requested again after reconnecting.
+			if err := client.NotifyWinningTickets(ctx); err != nil {
+				log.Errorf("[%d] Unable to register for winning tickets: %v", asset.ID, err)
+			}
+		},
+		OnWinningTickets: func(blockHash *chainhash.Hash, blockHeight int64, tickets []*chainhash.Hash) {
+			asset.voteOnOwnedTickets(ctx, passphrase, blockHash, int32(blockHeight), tickets)
+		},
+	}
+
+	client, err = rpcclient.New(connCfg, handlers)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("unable to connect to dcrd: %v", err)
+	}
+
+	dcrdNet, err := client.GetCurrentNet(ctx)
+	if err != nil {
+		client.Shutdown()
+		cancel()
+		return fmt.Errorf("unable to connect to dcrd: %v", err)
+	}
+	if dcrdNet != asset.chainParams.Net {
+		client.Shutdown()
+		cancel()
+		return fmt.Errorf("dcrd is running on %v, the wallet is on %v", dcrdNet, asset.chainParams.Net)
+	}
+
+	asset.soloVoter.mu.Lock()
+	asset.soloVoter.client, asset.soloVoter.cancel = client, cancel
+	asset.soloVoter.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		asset.stopSoloVoting(client)
+	}()
+
+	log.Infof("[%d] Solo voting started using dcrd at %s", asset.ID, cfg.RPCHost)
+	return nil
+}
+
+// StopSoloVoting disconnects from dcrd and locks the wallet.
+func (asset *Asset) StopSoloVoting() {
+	asset.stopSoloVoting(nil)
+}
+
+// stopSoloVoting stops solo voting if it still uses client, or whatever the
+// connection if client is nil.
+func (asset *Asset) stopSoloVoting(client *rpcclient.Client) {
+	asset.soloVoter.mu.Lock()
+	if asset.soloVoter.client == nil || (client != nil && client != asset.soloVoter.client) {
+		asset.soloVoter.mu.Unlock()
+		return
+	}
+	client, cancel := asset.soloVoter.client, asset.soloVoter.cancel
+	asset.soloVoter.client, asset.soloVoter.cancel = nil, nil
+	asset.soloVoter.mu.Unlock()
+
+	cancel()
+	client.Shutdown()
+	if !asset.IsAutoTicketsPurchaseActive() {
+		asset.LockWallet()
+	}
+	log.Infof("[%d] Solo voting stopped", asset.ID)
+}
+
+// soloVotingDisableTLS returns whether the connection to dcrd must be made
+// without TLS. A certificate file is required unless TLS is disabled for a
+// loopback host, the RPC credentials would be sent in plaintext otherwise.
+func soloVotingDisableTLS(cfg *SoloVotingConfig) (bool, error) {
+	switch {
+	case cfg.RPCCertFile != "":
+		return false, nil
+	case cfg.RPCNoTLS && utils.IsLoopbackHost(cfg.RPCHost):
+		return true, nil
+	default:
+		return false, errSoloVotingTLSRequired
+	}
+}
+
+// voteOnOwnedTickets publishes the votes of the winning tickets owned by the
+// wallet. The wallet is unlocked again if something locked it meanwhile.
+func (asset *Asset) voteOnOwnedTickets(ctx context.Context, passphrase string, blockHash *chainhash.Hash,
+	blockHeight int32, tickets []*chainhash.Hash) {
+	if asset.IsLocked() {
+		if err := asset.UnlockWallet(passphrase); err != nil {
+			log.Errorf("[%d] Unable to unlock the wallet to vote: %v", asset.ID, err)
+			return
+		}
+	}
+
+	err := asset.Internal().DCR.VoteOnOwnedTickets(ctx, tickets, blockHash, blockHeight)
+	if err != nil {
+		log.Errorf("[%d] Voting on block %v failed: %v", asset.ID, blockHash, err)
+	}
+}
@@ -0,0 +1,71 @@
+package dcr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSoloVotingDisableTLS(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         SoloVotingConfig
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name: "certificate file",
+			cfg:  SoloVotingConfig{RPCHost: "dcrd.example.com:9109", RPCCertFile: "rpc.cert"},
+		},
+		{
+			name: "certificate file wins over no tls",
+			cfg:  SoloVotingConfig{RPCHost: "127.0.0.1:9109", RPCCertFile: "rpc.cert", RPCNoTLS: true},
+		},
+		{
+			name:     "no tls on localhost",
+			cfg:      SoloVotingConfig{RPCHost: "localhost:9109", RPCNoTLS: true},
+			expected: true,
+		},
+		{
+			name:     "no tls on ipv4 loopback",
+			cfg:      SoloVotingConfig{RPCHost: "127.0.0.1:9109", RPCNoTLS: true},
+			expected: true,
+		},
+		{
+			name:     "no tls on ipv6 loopback",
+			cfg:      SoloVotingConfig{RPCHost: "[::1]:9109", RPCNoTLS: true},
+			expected: true,
+		},
+		{
+			name:     "no tls on loopback without a port",
+			cfg:      SoloVotingConfig{RPCHost: "127.0.0.1", RPCNoTLS: true},
+			expected: true,
+		},
+		{
+			name:        "no tls on a remote host",
+			cfg:         SoloVotingConfig{RPCHost: "10.0.0.2:9109", RPCNoTLS: true},
+			expectedErr: errSoloVotingTLSRequired,
+		},
+		{
+			name:        "no tls on a name resolving elsewhere",
+			cfg:         SoloVotingConfig{RPCHost: "localhost.example.com:9109", RPCNoTLS: true},
+			expectedErr: errSoloVotingTLSRequired,
+		},
+		{
+			name:        "no certificate file on loopback",
+			cfg:         SoloVotingConfig{RPCHost: "127.0.0.1:9109"},
+			expectedErr: errSoloVotingTLSRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := soloVotingDisableTLS(&tc.cfg)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
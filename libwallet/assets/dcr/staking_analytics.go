@@ -0,0 +1,89 @@
+package dcr
+
+import (
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/stakeanalytics"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// StakingAnalytics returns the costs and rewards of every ticket bought by
+// the wallet, oldest first. Use stakeanalytics.Analyze to aggregate them.
+func (asset *Asset) StakingAnalytics() ([]*stakeanalytics.Ticket, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	txs, err := asset.GetTransactionsRaw(0, 0, TxFilterTickets, false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	dcrW := asset.Internal().DCR
+	tickets := make([]*stakeanalytics.Ticket, 0, len(txs))
+	for _, tx := range txs {
+		var investment int64
+		for _, input := range tx.Inputs {
+			if input.AccountNumber > -1 {
+				investment += input.Amount
+			}
+		}
+
+		ticket := &stakeanalytics.Ticket{
+			Hash:      tx.Hash,
+			Status:    stakeanalytics.StatusPending,
+			Purchased: time.Unix(tx.Timestamp, 0),
+			Price:     investment - tx.Fee,
+			TxFee:     tx.Fee,
+		}
+
+		// The VSP host and fee tx are read from the wallet db, solo tickets
+		// have neither.
+		ticketHash, err := chainhash.NewHashFromStr(tx.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if host, err := dcrW.VSPHostForTicket(ctx, ticketHash); err == nil {
+			ticket.VSP = host
+		}
+		if feeHash, err := dcrW.VSPFeeHashForTicket(ctx, ticketHash); err == nil && feeHash != (chainhash.Hash{}) {
+			if feeTx, err := asset.GetTransactionRaw(feeHash.String()); err == nil {
+				ticket.VSPFee = feeTx.Amount
+				ticket.TxFee += feeTx.Fee
+			}
+		}
+
+		if tx.TicketSpender != "" {
+			spender, err := asset.GetTransactionRaw(tx.TicketSpender)
+			if err != nil {
+				return nil, err
+			}
+			setTicketSpender(ticket, spender, tx.Fee)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+// setTicketSpender records the vote or revocation that spent the ticket.
+// ticketTxFee is the network fee of the ticket purchase.
+func setTicketSpender(ticket *stakeanalytics.Ticket, spender *sharedW.Transaction, ticketTxFee int64) {
+	ticket.Spent = time.Unix(spender.Timestamp, 0)
+	ticket.DaysToVote = spender.DaysToVoteOrRevoke
+	if spender.Type != TxTypeVote {
+		// A revocation only returns the ticket price, less its own fee.
+		ticket.Status = stakeanalytics.StatusRevoked
+		ticket.TxFee += spender.Fee
+		return
+	}
+
+	ticket.Status = stakeanalytics.StatusVoted
+	// VoteReward is net of the ticket tx fee, add it back to get the stake
+	// reward.
+	ticket.Reward = spender.VoteReward + ticketTxFee
+}
@@ -0,0 +1,56 @@
+package dcr
+
+import (
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/stakeanalytics"
+)
+
+func TestSetTicketSpender(t *testing.T) {
+	const ticketTxFee = 0.001e8
+
+	tests := []struct {
+		name           string
+		spender        *sharedW.Transaction
+		expectedStatus string
+		expectedReward int64
+		expectedTxFee  int64
+	}{
+		{
+			name: "vote",
+			// The vote returns the ticket price and a 0.1 DCR stake
+			// reward, VoteReward is net of the ticket tx fee.
+			spender:        &sharedW.Transaction{Type: TxTypeVote, VoteReward: 0.1e8 - ticketTxFee},
+			expectedStatus: stakeanalytics.StatusVoted,
+			expectedReward: 0.1e8,
+			expectedTxFee:  ticketTxFee,
+		},
+		{
+			name:           "revocation without fee",
+			spender:        &sharedW.Transaction{Type: TxTypeRevocation, VoteReward: -ticketTxFee},
+			expectedStatus: stakeanalytics.StatusRevoked,
+			expectedTxFee:  ticketTxFee,
+		},
+		{
+			name:           "revocation with fee",
+			spender:        &sharedW.Transaction{Type: TxTypeRevocation, VoteReward: -ticketTxFee - 0.0003e8, Fee: 0.0003e8},
+			expectedStatus: stakeanalytics.StatusRevoked,
+			expectedTxFee:  ticketTxFee + 0.0003e8,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticket := &stakeanalytics.Ticket{Price: 100e8, TxFee: ticketTxFee}
+			setTicketSpender(ticket, tc.spender, ticketTxFee)
+			if ticket.Status != tc.expectedStatus || ticket.Reward != tc.expectedReward || ticket.TxFee != tc.expectedTxFee {
+				t.Errorf("(%v), expected (%v, %v, %v), got (%v, %v, %v)", tc.name,
+					tc.expectedStatus, tc.expectedReward, tc.expectedTxFee, ticket.Status, ticket.Reward, ticket.TxFee)
+			}
+			if tc.expectedStatus == stakeanalytics.StatusRevoked && ticket.Profit() != -tc.expectedTxFee {
+				t.Errorf("(%v), expected a revoked ticket to lose its fees, got a profit of (%v)", tc.name, ticket.Profit())
+			}
+		})
+	}
+}
@@ -208,8 +208,9 @@ func (asset *Asset) SpvSync() error {
 	asset.syncing = true
 
 	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
-	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
+	addrManager := addrmgr.New(asset.DataDir(), utils.ProxyLookupIP)
 	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+	lp.SetDialFunc(utils.ProxyDialContext(utils.WalletIsolationID(asset.ID)))
 
 	// Set the node to only connect to remote peers whose advertised best block
 	// height is greater than the currently synced.
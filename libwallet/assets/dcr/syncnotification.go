@@ -651,6 +651,10 @@ func (asset *Asset) syncedWallet(synced bool) {
 					}
 				}
 			}
+
+			if synced {
+				asset.requestTicketHealthCheck()
+			}
 		}()
 	}
 
@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/vsp"
 	w "decred.org/dcrwallet/v4/wallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
 	vspd "github.com/decred/vspd/types/v3"
 )
 
@@ -100,15 +102,21 @@ func (asset *Asset) TicketPrice() (*TicketPriceResponse, error) {
 }
 
 // PurchaseTickets purchases tickets from the asset.
-// Returns a slice of hashes for tickets purchased.
+// Returns a slice of hashes for tickets purchased. If solo staking is enabled
+// and no vspHost is given, the voting rights of the tickets stay in the
+// wallet and no VSP fee is paid.
 func (asset *Asset) PurchaseTickets(account, numTickets int32, vspHost, passphrase string, vspPubKey []byte) ([]*chainhash.Hash, error) {
 	if !asset.WalletOpened() {
 		return nil, utils.ErrDCRNotInitialized
 	}
 
-	vspClient, err := asset.VSPClient(account, vspHost, vspPubKey)
-	if err != nil {
-		return nil, fmt.Errorf("VSP Server instance failed to start: %v", err)
+	var vspClient *vsp.Client
+	if vspHost != "" || !asset.IsSoloStaking() {
+		var err error
+		vspClient, err = asset.VSPClient(account, vspHost, vspPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("VSP Server instance failed to start: %v", err)
+		}
 	}
 
 	networkBackend, err := asset.Internal().DCR.NetworkBackend()
@@ -123,16 +131,18 @@ func (asset *Asset) PurchaseTickets(account, numTickets int32, vspHost, passphra
 	defer asset.LockWallet()
 
 	request := &w.PurchaseTicketsRequest{
-		Count:                int(numTickets),
-		SourceAccount:        uint32(account),
-		MinConf:              asset.RequiredConfirmations(),
-		VSPFeePercent:        vspClient.FeePercentage,
-		VSPFeePaymentProcess: vspClient.Process,
+		Count:         int(numTickets),
+		SourceAccount: uint32(account),
+		MinConf:       asset.RequiredConfirmations(),
 
 		// VotingAccount used to derive addresses for specifying voting rights.
 		// It is used when VotingAddress == nil, or Mixing == true
 		VotingAccount: uint32(account),
 	}
+	if vspClient != nil {
+		request.VSPFeePercent = vspClient.FeePercentage
+		request.VSPFeePaymentProcess = vspClient.Process
+	}
 
 	csppCfg := asset.readCSPPConfig()
 	if csppCfg == nil {
@@ -239,7 +249,7 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 	}
 
 	cfg := asset.AutoTicketsBuyerConfig()
-	if cfg.VspHost == "" {
+	if cfg.VspHost == "" && !asset.IsSoloStaking() {
 		return errors.New("ticket buyer config not set for this wallet")
 	}
 	if cfg.BalanceToMaintain < 0 {
@@ -263,22 +273,20 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 	asset.cancelAutoTicketBuyer = cancel
 	asset.cancelAutoTicketBuyerMu.Unlock()
 
-	// Check the VSP.
-	vspInfo, err := vspInfo(cfg.VspHost)
-	if err != nil {
+	// Check the VSPs, using the first that satisfies the policy.
+	tb := asset.newTicketBuyer(cfg)
+	if err := tb.connectVSP(0); err != nil {
+		cancel()
+		asset.cancelAutoTicketBuyerMu.Lock()
+		asset.cancelAutoTicketBuyer = nil
+		asset.cancelAutoTicketBuyerMu.Unlock()
 		return fmt.Errorf("error setting up vsp client: %v", err)
 	}
 
-	cfg.VspClient, err = asset.VSPClient(cfg.PurchaseAccount, cfg.VspHost, vspInfo.PubKey)
-	if err != nil {
-		log.Errorf("[%d] VSP Client instance failed error: %v", asset.ID, err)
-		return errors.New("VSP Client failed to start due to incorrect configuration")
-	}
-
 	go func() {
 		log.Infof("[%d] Running ticket buyer", asset.ID)
 
-		if err = asset.runTicketBuyer(ctx, passphrase, cfg); err != nil {
+		if err := asset.runTicketBuyer(ctx, passphrase, tb); err != nil {
 			if ctx.Err() != nil {
 				log.Errorf("[%d] Ticket buyer instance canceled", asset.ID)
 			} else {
@@ -286,7 +294,7 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 			}
 		}
 
-		if err = asset.StopAutoTicketsPurchase(); err != nil {
+		if err := asset.StopAutoTicketsPurchase(); err != nil {
 			log.Errorf("[%d] Stopping auto ticket purchase errored: %v", asset.ID, err)
 		}
 	}()
@@ -297,7 +305,9 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 // runTicketBuyer executes the ticket buyer. If the private passphrase is
 // incorrect, or ever becomes incorrect due to a wallet passphrase change,
 // runTicketBuyer exits with an errors.Passphrase error.
-func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, cfg *TicketBuyerConfig) error {
+func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, tb *ticketBuyer) error {
+	cfg := tb.cfg
+
 	if len(passphrase) > 0 && asset.IsLocked() {
 		err := asset.UnlockWallet(passphrase)
 		if err != nil {
@@ -365,6 +375,7 @@ func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, cfg *
 				intervalSize := int32(w.ChainParams().StakeDiffWindowSize)
 				currentInterval := height / intervalSize
 				nextIntervalStart = (currentInterval + 1) * intervalSize
+				tb.newInterval(currentInterval * intervalSize)
 
 				// Skip this purchase when no more tickets may be purchased in the interval and
 				// the next sdiff is unknown.  The earliest any ticket may be mined is two
@@ -394,6 +405,7 @@ func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, cfg *
 			spendable := bal.Spendable.ToInt()
 			if spendable < cfg.BalanceToMaintain {
 				log.Debugf("[%d] Skipping purchase: low available balance", asset.ID)
+				asset.logTicketBuyerDecision("skipped: balance below the amount to maintain")
 				continue
 			}
 
@@ -406,14 +418,21 @@ func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, cfg *
 			buy := int(dcrutil.Amount(spendable) / sdiff)
 			if buy == 0 {
 				log.Debugf("[%d] Skipping purchase: low available balance", asset.ID)
+				asset.logTicketBuyerDecision("skipped: balance too low for a ticket at %v", sdiff)
+				continue
+			}
+
+			buy, interval := tb.reserve(buy, sdiff)
+			if buy == 0 {
 				continue
 			}
 
 			cancelCtx, cancel := context.WithCancel(ctx)
 			cancels = append(cancels, cancel)
 			buyTicket := func() {
-				err := asset.buyTicket(cancelCtx, passphrase, sdiff, expiry, cfg)
+				err := asset.buyTicket(cancelCtx, passphrase, sdiff, expiry, tb)
 				if err != nil {
+					tb.release(interval)
 					switch {
 					// silence these errors
 					case errors.Is(err, errors.InsufficientBalance):
@@ -441,7 +460,9 @@ func (asset *Asset) runTicketBuyer(ctx context.Context, passphrase string, cfg *
 }
 
 // buyTicket purchases one ticket with the asset.
-func (asset *Asset) buyTicket(ctx context.Context, passphrase string, sdiff dcrutil.Amount, expiry int32, cfg *TicketBuyerConfig) error {
+// If the VSP fails to take the fee payment the ticket buyer fails over to the
+// next VSP.
+func (asset *Asset) buyTicket(ctx context.Context, passphrase string, sdiff dcrutil.Amount, expiry int32, tb *ticketBuyer) error {
 	ctx, task := trace.NewTask(ctx, "ticketbuyer.buy")
 	defer task.End()
 
@@ -461,22 +482,35 @@ func (asset *Asset) buyTicket(ctx context.Context, passphrase string, sdiff dcru
 		return utils.ErrTicketPurchaseAccMissing
 	}
 
+	cfg := tb.cfg
+
 	// Count is 1 to prevent combining multiple split outputs in one tx,
 	// which can be used to link the tickets eventually purchased with the
 	// split outputs.
 	request := &w.PurchaseTicketsRequest{
-		Count:                1,
-		SourceAccount:        uint32(cfg.PurchaseAccount),
-		Expiry:               expiry,
-		MinConf:              asset.RequiredConfirmations(),
-		VSPFeePercent:        cfg.VspClient.FeePercentage,
-		VSPFeePaymentProcess: cfg.VspClient.Process,
+		Count:         1,
+		SourceAccount: uint32(cfg.PurchaseAccount),
+		Expiry:        expiry,
+		MinConf:       asset.RequiredConfirmations(),
 
 		// VotingAccount used to derive addresses for specifying voting rights.
 		// It is used when VotingAddress == nil, or Mixing == true
 		VotingAccount: uint32(cfg.PurchaseAccount),
 	}
 
+	// Solo tickets keep their voting rights in the wallet, no VSP fee is paid.
+	vspHost, vspClient := tb.currentVSP()
+	var feeErr error
+	if vspClient != nil {
+		request.VSPFeePercent = vspClient.FeePercentage
+		request.VSPFeePaymentProcess = func(ctx context.Context, ticket *w.VSPTicket, feeTx *wire.MsgTx) error {
+			feeErr = vspClient.Process(ctx, ticket, feeTx)
+			return feeErr
+		}
+	} else {
+		vspHost = "solo voting"
+	}
+
 	csppCfg := asset.readCSPPConfig()
 	if csppCfg == nil {
 		return utils.ErrStakingAccountsMissing
@@ -493,9 +527,17 @@ func (asset *Asset) buyTicket(ctx context.Context, passphrase string, sdiff dcru
 	if tix != nil {
 		for _, hash := range tix.TicketHashes {
 			log.Infof("[%d] Purchased ticket %v at stake difficulty %v", asset.ID, hash, sdiff)
+			asset.logTicketBuyerDecision("bought ticket %v at %v via %s", hash, sdiff, vspHost)
 		}
 	}
 
+	// The ticket is bought even if the fee payment failed, the wallet retries
+	// the payment of unpaid tickets later.
+	if feeErr != nil && ctx.Err() == nil {
+		tb.failover(vspHost, feeErr)
+		return nil
+	}
+
 	return err
 }
 
@@ -538,11 +580,17 @@ func (asset *Asset) AutoTicketsBuyerConfig() *TicketBuyerConfig {
 		VspHost:           vspHost,
 		PurchaseAccount:   accNum,
 		BalanceToMaintain: btm,
+		VspHosts:          asset.autoTicketsBuyerVSPHosts(vspHost),
+		TicketBuyerPolicy: asset.autoTicketsBuyerPolicy(),
 	}
 }
 
 // TicketBuyerConfigIsSet checks if ticket buyer config is set for the asset.
+// Solo staking needs no VSP, only the purchase account.
 func (asset *Asset) TicketBuyerConfigIsSet() bool {
+	if asset.IsSoloStaking() {
+		return asset.IsTicketBuyerAccountSet()
+	}
 	return asset.ReadStringConfigValueForKey(sharedW.TicketBuyerVSPHostConfigKey, "") != ""
 }
 
@@ -556,6 +604,7 @@ func (asset *Asset) ClearTicketBuyerConfig(_ int) error {
 	asset.SetLongConfigValueForKey(sharedW.TicketBuyerATMConfigKey, -1)
 	asset.SetInt32ConfigValueForKey(sharedW.TicketBuyerAccountConfigKey, -1)
 	asset.SetStringConfigValueForKey(sharedW.TicketBuyerVSPHostConfigKey, "")
+	asset.clearTicketBuyerPolicy()
 
 	return nil
 }
@@ -0,0 +1,303 @@
+package dcr
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/vsp"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/decred/dcrd/dcrutil/v4"
+	vspd "github.com/decred/vspd/types/v2"
+)
+
+// maxTicketBuyerLogEntries is the number of decisions kept in the ticket
+// buyer decision log.
+const maxTicketBuyerLogEntries = 100
+
+// errNoUsableVSP is returned when none of the configured VSPs can be used.
+var errNoUsableVSP = errors.New("none of the configured VSPs can be used")
+
+// SetAutoTicketsBuyerPolicy sets the purchase limits and the fallback VSPs of
+// the ticket buyer. The fallback VSPs are tried in order after the VSP set
+// with SetAutoTicketsBuyerConfig.
+func (asset *Asset) SetAutoTicketsBuyerPolicy(policy *TicketBuyerPolicy, fallbackVSPHosts []string) {
+	asset.SetIntConfigValueForKey(sharedW.TicketBuyerMaxPerIntervalConfigKey, policy.MaxTicketsPerInterval)
+	asset.SetIntConfigValueForKey(sharedW.TicketBuyerMaxPerDayConfigKey, policy.MaxTicketsPerDay)
+	asset.SetLongConfigValueForKey(sharedW.TicketBuyerMaxTicketPriceConfigKey, policy.MaxTicketPrice)
+	asset.SetDoubleConfigValueForKey(sharedW.TicketBuyerMaxVSPFeePercentConfigKey, policy.MaxVSPFeePercent)
+	asset.SetDoubleConfigValueForKey(sharedW.TicketBuyerMinVSPUptimeConfigKey, policy.MinVSPUptime)
+	asset.SaveUserConfigValue(sharedW.TicketBuyerFallbackVSPsConfigKey, fallbackVSPHosts)
+}
+
+// autoTicketsBuyerPolicy returns the previously set ticket buyer policy.
+func (asset *Asset) autoTicketsBuyerPolicy() TicketBuyerPolicy {
+	return TicketBuyerPolicy{
+		MaxTicketsPerInterval: asset.ReadIntConfigValueForKey(sharedW.TicketBuyerMaxPerIntervalConfigKey, 0),
+		MaxTicketsPerDay:      asset.ReadIntConfigValueForKey(sharedW.TicketBuyerMaxPerDayConfigKey, 0),
+		MaxTicketPrice:        asset.ReadLongConfigValueForKey(sharedW.TicketBuyerMaxTicketPriceConfigKey, 0),
+		MaxVSPFeePercent:      asset.ReadDoubleConfigValueForKey(sharedW.TicketBuyerMaxVSPFeePercentConfigKey, 0),
+		MinVSPUptime:          asset.ReadDoubleConfigValueForKey(sharedW.TicketBuyerMinVSPUptimeConfigKey, 0),
+	}
+}
+
+// autoTicketsBuyerVSPHosts returns the ordered list of VSPs the ticket buyer
+// may use, starting with vspHost.
+func (asset *Asset) autoTicketsBuyerVSPHosts(vspHost string) []string {
+	var fallbacks []string
+	_ = asset.ReadUserConfigValue(sharedW.TicketBuyerFallbackVSPsConfigKey, &fallbacks)
+
+	hosts := []string{vspHost}
+	for _, host := range fallbacks {
+		if host != "" && host != vspHost {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// clearTicketBuyerPolicy removes the ticket buyer policy.
+func (asset *Asset) clearTicketBuyerPolicy() {
+	for _, key := range []string{
+		sharedW.TicketBuyerMaxPerIntervalConfigKey,
+		sharedW.TicketBuyerMaxPerDayConfigKey,
+		sharedW.TicketBuyerMaxTicketPriceConfigKey,
+		sharedW.TicketBuyerMaxVSPFeePercentConfigKey,
+		sharedW.TicketBuyerMinVSPUptimeConfigKey,
+		sharedW.TicketBuyerFallbackVSPsConfigKey,
+	} {
+		asset.DeleteUserConfigValueForKey(key)
+	}
+}
+
+// CheckVSP returns an error describing why the VSP does not satisfy the
+// policy, or nil if it does.
+func (policy *TicketBuyerPolicy) CheckVSP(info *vspd.VspInfoResponse) error {
+	if info.VspClosed {
+		return errors.New("VSP is closed")
+	}
+	if policy.MaxVSPFeePercent > 0 && info.FeePercentage > policy.MaxVSPFeePercent {
+		return fmt.Errorf("fee %.2f%% is above the %.2f%% limit", info.FeePercentage, policy.MaxVSPFeePercent)
+	}
+	if policy.MinVSPUptime > 0 && info.TotalVotingWallets > 0 {
+		uptime := float64(info.VotingWalletsOnline) * 100 / float64(info.TotalVotingWallets)
+		if uptime < policy.MinVSPUptime {
+			return fmt.Errorf("uptime %.2f%% is below the %.2f%% minimum", uptime, policy.MinVSPUptime)
+		}
+	}
+	return nil
+}
+
+// TicketBuyerLog returns the decisions of the ticket buyer, newest first. The
+// log is only kept in memory, it is empty each time the wallet is loaded.
+func (asset *Asset) TicketBuyerLog() []*TicketBuyerDecision {
+	asset.ticketBuyerLogMu.RLock()
+	defer asset.ticketBuyerLogMu.RUnlock()
+
+	decisions := make([]*TicketBuyerDecision, 0, len(asset.ticketBuyerLog))
+	for i := len(asset.ticketBuyerLog) - 1; i >= 0; i-- {
+		decision := *asset.ticketBuyerLog[i]
+		decisions = append(decisions, &decision)
+	}
+	return decisions
+}
+
+// logTicketBuyerDecision adds an entry to the ticket buyer decision log. A
+// decision repeating the previous one only refreshes its timestamp, so the
+// log is not flooded with a skip reason that holds for many blocks.
+func (asset *Asset) logTicketBuyerDecision(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	now := time.Now().Unix()
+
+	asset.ticketBuyerLogMu.Lock()
+	defer asset.ticketBuyerLogMu.Unlock()
+
+	if n := len(asset.ticketBuyerLog); n > 0 && asset.ticketBuyerLog[n-1].Message == msg {
+		asset.ticketBuyerLog[n-1].Timestamp = now
+		return
+	}
+
+	log.Infof("[%d] Ticket buyer: %s", asset.ID, msg)
+	asset.ticketBuyerLog = append(asset.ticketBuyerLog, &TicketBuyerDecision{Timestamp: now, Message: msg})
+	if len(asset.ticketBuyerLog) > maxTicketBuyerLogEntries {
+		asset.ticketBuyerLog = asset.ticketBuyerLog[len(asset.ticketBuyerLog)-maxTicketBuyerLogEntries:]
+	}
+}
+
+// ticketBuyer holds the state of a running ticket buyer: the VSP in use and
+// the tickets counted against the purchase limits.
+type ticketBuyer struct {
+	asset *Asset
+	cfg   *TicketBuyerConfig
+
+	mu        sync.Mutex
+	vspIndex  int
+	vspHost   string
+	vspClient *vsp.Client
+
+	// boughtInInterval counts the tickets bought or being bought in the
+	// stake difficulty interval starting at block interval.
+	interval         int32
+	boughtInInterval int
+	// purchases holds the unix times of the tickets bought or being bought in
+	// the last 24 hours.
+	purchases []int64
+}
+
+func (asset *Asset) newTicketBuyer(cfg *TicketBuyerConfig) *ticketBuyer {
+	tb := &ticketBuyer{asset: asset, cfg: cfg}
+
+	// Count the tickets bought earlier in the day against the daily limit.
+	if cfg.MaxTicketsPerDay > 0 {
+		tickets, err := asset.GetTransactionsRaw(0, 0, TxFilterTickets, true, "")
+		if err != nil {
+			log.Errorf("[%d] Unable to count the tickets bought today: %v", asset.ID, err)
+		}
+		dayAgo := time.Now().Add(-24 * time.Hour).Unix()
+		for _, ticket := range tickets {
+			if ticket.Timestamp > dayAgo {
+				tb.purchases = append(tb.purchases, ticket.Timestamp)
+			}
+		}
+	}
+
+	return tb
+}
+
+// connectVSP sets up a client for the first usable VSP of the configured
+// list, trying the VSPs in order from the one at index start. No VSP is used
+// when solo staking.
+func (tb *ticketBuyer) connectVSP(start int) error {
+	if tb.asset.IsSoloStaking() {
+		tb.asset.logTicketBuyerDecision("solo staking, no VSP used")
+		return nil
+	}
+
+	hosts := tb.cfg.VspHosts
+	for i := 0; i < len(hosts); i++ {
+		index := (start + i) % len(hosts)
+		host := hosts[index]
+
+		info, err := vspInfo(host)
+		if err != nil {
+			tb.asset.logTicketBuyerDecision("skipped VSP %s: %v", host, err)
+			continue
+		}
+		if err := tb.cfg.CheckVSP(info); err != nil {
+			tb.asset.logTicketBuyerDecision("skipped VSP %s: %v", host, err)
+			continue
+		}
+
+		client, err := tb.asset.VSPClient(tb.cfg.PurchaseAccount, host, info.PubKey)
+		if err != nil {
+			tb.asset.logTicketBuyerDecision("skipped VSP %s: %v", host, err)
+			continue
+		}
+
+		tb.mu.Lock()
+		tb.vspIndex, tb.vspHost, tb.vspClient = index, host, client
+		tb.mu.Unlock()
+		tb.asset.logTicketBuyerDecision("using VSP %s", host)
+		return nil
+	}
+
+	return errNoUsableVSP
+}
+
+// currentVSP returns the VSP currently used by the ticket buyer.
+func (tb *ticketBuyer) currentVSP() (string, *vsp.Client) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.vspHost, tb.vspClient
+}
+
+// failover switches to the next usable VSP after host failed. Nothing is done
+// if another purchase already moved the ticket buyer off host.
+func (tb *ticketBuyer) failover(host string, err error) {
+	tb.mu.Lock()
+	current, next := tb.vspHost, tb.vspIndex+1
+	tb.mu.Unlock()
+
+	tb.asset.logTicketBuyerDecision("VSP %s failed: %v", host, err)
+	if current != host || len(tb.cfg.VspHosts) < 2 {
+		return
+	}
+	if err := tb.connectVSP(next); err != nil {
+		tb.asset.logTicketBuyerDecision("kept VSP %s: %v", host, err)
+	}
+}
+
+// newInterval resets the interval ticket count when the stake difficulty
+// interval starting at block start begins.
+func (tb *ticketBuyer) newInterval(start int32) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if start != tb.interval {
+		tb.interval, tb.boughtInInterval = start, 0
+	}
+}
+
+// reserve applies the purchase limits to buy tickets at the ticket price
+// sdiff. It returns the number of tickets that may be bought and counts them
+// against the limits; release must be called for each of them that is not
+// bought. The reservation is made in the current interval, which is returned.
+func (tb *ticketBuyer) reserve(buy int, sdiff dcrutil.Amount) (int, int32) {
+	if tb.cfg.MaxTicketPrice > 0 && int64(sdiff) > tb.cfg.MaxTicketPrice {
+		tb.asset.logTicketBuyerDecision("skipped: price %v above the %v ceiling",
+			sdiff, dcrutil.Amount(tb.cfg.MaxTicketPrice))
+		return 0, 0
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if max := tb.cfg.MaxTicketsPerInterval; max > 0 {
+		if tb.boughtInInterval >= max {
+			tb.asset.logTicketBuyerDecision("skipped: %d tickets already bought this interval", tb.boughtInInterval)
+			return 0, tb.interval
+		}
+		if buy > max-tb.boughtInInterval {
+			buy = max - tb.boughtInInterval
+		}
+	}
+
+	if max := tb.cfg.MaxTicketsPerDay; max > 0 {
+		dayAgo := time.Now().Add(-24 * time.Hour).Unix()
+		recent := tb.purchases[:0]
+		for _, purchase := range tb.purchases {
+			if purchase > dayAgo {
+				recent = append(recent, purchase)
+			}
+		}
+		tb.purchases = recent
+
+		if len(tb.purchases) >= max {
+			tb.asset.logTicketBuyerDecision("skipped: %d tickets already bought today", len(tb.purchases))
+			return 0, tb.interval
+		}
+		if buy > max-len(tb.purchases) {
+			buy = max - len(tb.purchases)
+		}
+	}
+
+	tb.boughtInInterval += buy
+	now := time.Now().Unix()
+	for i := 0; i < buy; i++ {
+		tb.purchases = append(tb.purchases, now)
+	}
+	return buy, tb.interval
+}
+
+// release returns a ticket reserved in interval that was not bought.
+func (tb *ticketBuyer) release(interval int32) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if interval == tb.interval && tb.boughtInInterval > 0 {
+		tb.boughtInInterval--
+	}
+	if n := len(tb.purchases); n > 0 {
+		tb.purchases = tb.purchases[:n-1]
+	}
+}
@@ -0,0 +1,125 @@
+package dcr
+
+import (
+	"testing"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+func newTestTicketBuyer(policy TicketBuyerPolicy) *ticketBuyer {
+	asset := &Asset{Wallet: &sharedW.Wallet{}}
+	tb := &ticketBuyer{asset: asset, cfg: &TicketBuyerConfig{TicketBuyerPolicy: policy}}
+	tb.newInterval(100)
+	return tb
+}
+
+func TestTicketBuyerReserve(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           TicketBuyerPolicy
+		boughtInInterval int
+		purchases        []int64
+		buy              int
+		sdiff            dcrutil.Amount
+		expected         int
+	}{
+		{name: "no limits", buy: 5, sdiff: 2e8, expected: 5},
+		{name: "price at ceiling", policy: TicketBuyerPolicy{MaxTicketPrice: 2e8}, buy: 2, sdiff: 2e8, expected: 2},
+		{name: "price above ceiling", policy: TicketBuyerPolicy{MaxTicketPrice: 2e8}, buy: 2, sdiff: 2e8 + 1, expected: 0},
+		{name: "interval limit caps", policy: TicketBuyerPolicy{MaxTicketsPerInterval: 3}, boughtInInterval: 1, buy: 5, expected: 2},
+		{name: "interval limit reached", policy: TicketBuyerPolicy{MaxTicketsPerInterval: 3}, boughtInInterval: 3, buy: 1, expected: 0},
+		{
+			name:      "day limit caps",
+			policy:    TicketBuyerPolicy{MaxTicketsPerDay: 4},
+			purchases: []int64{time.Now().Add(-time.Hour).Unix(), time.Now().Add(-2 * time.Hour).Unix()},
+			buy:       5,
+			expected:  2,
+		},
+		{
+			name:      "day limit ignores older purchases",
+			policy:    TicketBuyerPolicy{MaxTicketsPerDay: 2},
+			purchases: []int64{time.Now().Add(-25 * time.Hour).Unix(), time.Now().Add(-time.Hour).Unix()},
+			buy:       5,
+			expected:  1,
+		},
+		{
+			name:      "day limit reached",
+			policy:    TicketBuyerPolicy{MaxTicketsPerDay: 1},
+			purchases: []int64{time.Now().Add(-time.Hour).Unix()},
+			buy:       1,
+			expected:  0,
+		},
+		{
+			name:             "tightest limit wins",
+			policy:           TicketBuyerPolicy{MaxTicketsPerInterval: 3, MaxTicketsPerDay: 10},
+			purchases:        []int64{time.Now().Unix()},
+			boughtInInterval: 1,
+			buy:              5,
+			expected:         2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := newTestTicketBuyer(tc.policy)
+			tb.boughtInInterval = tc.boughtInInterval
+			tb.purchases = tc.purchases
+
+			got, interval := tb.reserve(tc.buy, tc.sdiff)
+			if got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+			if got > 0 && interval != 100 {
+				t.Errorf("(%v), expected interval (%v), got (%v)", tc.name, 100, interval)
+			}
+			if tb.boughtInInterval != tc.boughtInInterval+got {
+				t.Errorf("(%v), expected (%v) tickets in the interval, got (%v)",
+					tc.name, tc.boughtInInterval+got, tb.boughtInInterval)
+			}
+		})
+	}
+}
+
+func TestTicketBuyerRelease(t *testing.T) {
+	tb := newTestTicketBuyer(TicketBuyerPolicy{MaxTicketsPerInterval: 2, MaxTicketsPerDay: 3})
+
+	bought, interval := tb.reserve(2, 1e8)
+	if bought != 2 {
+		t.Fatalf("expected (2) tickets reserved, got (%v)", bought)
+	}
+	if got, _ := tb.reserve(1, 1e8); got != 0 {
+		t.Fatalf("expected the interval limit to be reached, got (%v) tickets reserved", got)
+	}
+
+	// A ticket that failed to be bought frees its place in both limits.
+	tb.release(interval)
+	if tb.boughtInInterval != 1 || len(tb.purchases) != 1 {
+		t.Fatalf("expected (1) ticket counted, got (%v) in the interval and (%v) in the day",
+			tb.boughtInInterval, len(tb.purchases))
+	}
+	if got, _ := tb.reserve(1, 1e8); got != 1 {
+		t.Fatalf("expected (1) ticket reserved after a release, got (%v)", got)
+	}
+
+	// A release for a past interval leaves the current interval count alone
+	// but still frees the daily limit.
+	tb.newInterval(200)
+	if got, _ := tb.reserve(1, 1e8); got != 1 {
+		t.Fatalf("expected (1) ticket reserved in the new interval, got (%v)", got)
+	}
+	tb.release(interval)
+	if tb.boughtInInterval != 1 || len(tb.purchases) != 2 {
+		t.Fatalf("expected (1) ticket in the interval and (2) in the day, got (%v) and (%v)",
+			tb.boughtInInterval, len(tb.purchases))
+	}
+
+	// Releasing with nothing counted is a no-op.
+	tb = newTestTicketBuyer(TicketBuyerPolicy{})
+	tb.release(100)
+	if tb.boughtInInterval != 0 || len(tb.purchases) != 0 {
+		t.Fatalf("expected nothing counted, got (%v) in the interval and (%v) in the day",
+			tb.boughtInInterval, len(tb.purchases))
+	}
+}
@@ -0,0 +1,427 @@
+package dcr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	w "decred.org/dcrwallet/v4/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	vspd "github.com/decred/vspd/types/v3"
+)
+
+// ticketHealthCheckInterval is the time between two checks of the tickets
+// assigned to VSPs.
+const ticketHealthCheckInterval = 30 * time.Minute
+
+// TicketHealthIssue describes why a ticket may miss its vote.
+type TicketHealthIssue uint8
+
+const (
+	// TicketFeeErrored is reported for a ticket whose VSP fee could not be
+	// paid, even after retrying.
+	TicketFeeErrored TicketHealthIssue = iota
+	// TicketVSPUnreachable is reported for a ticket whose VSP cannot be
+	// reached.
+	TicketVSPUnreachable
+	// TicketVoteChoicesMismatch is reported for a ticket whose VSP votes
+	// differently from the vote preferences of the wallet.
+	TicketVoteChoicesMismatch
+)
+
+// String returns a human-readable interpretation of the ticket health issue.
+func (issue TicketHealthIssue) String() string {
+	switch issue {
+	case TicketFeeErrored:
+		return "fee payment errored"
+	case TicketVSPUnreachable:
+		return "vsp unreachable"
+	case TicketVoteChoicesMismatch:
+		return "vsp vote choices differ"
+	default:
+		return fmt.Sprintf("invalid ticket health issue %d", issue)
+	}
+}
+
+// TicketHealth is a problem found with a ticket assigned to a VSP.
+type TicketHealth struct {
+	TicketHash string
+	VSP        string
+	Issue      TicketHealthIssue
+	// Detail holds the error returned by the VSP, if any.
+	Detail string
+	// NeedsUnlock is set for fee payments that were not retried because the
+	// wallet was locked. They are retried by RetryTicketFees.
+	NeedsUnlock bool
+	Timestamp   int64
+}
+
+// TicketHealthListener is notified when the ticket health of the wallet
+// changes. OnTicketHealthIssues receives the problems that were not reported
+// before, OnTicketHealthResolved the hashes of the tickets whose problems
+// are gone.
+type TicketHealthListener struct {
+	OnTicketHealthIssues   func(walletID int, issues []*TicketHealth)
+	OnTicketHealthResolved func(walletID int, ticketHashes []string)
+}
+
+// AddTicketHealthListener registers a listener for ticket health issues.
+func (asset *Asset) AddTicketHealthListener(listener *TicketHealthListener, uniqueIdentifier string) error {
+	asset.notificationListenersMu.Lock()
+	defer asset.notificationListenersMu.Unlock()
+
+	if _, ok := asset.ticketHealthListeners[uniqueIdentifier]; ok {
+		return errors.New(utils.ErrListenerAlreadyExist)
+	}
+
+	asset.ticketHealthListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+// RemoveTicketHealthListener removes a previously registered listener.
+func (asset *Asset) RemoveTicketHealthListener(uniqueIdentifier string) {
+	asset.notificationListenersMu.Lock()
+	defer asset.notificationListenersMu.Unlock()
+
+	delete(asset.ticketHealthListeners, uniqueIdentifier)
+}
+
+// publishTicketHealthChanges notifies the listeners of the issues that were
+// not reported before and of the tickets whose issues were resolved.
+func (asset *Asset) publishTicketHealthChanges(newIssues []*TicketHealth, resolved []string) {
+	asset.notificationListenersMu.RLock()
+	defer asset.notificationListenersMu.RUnlock()
+
+	for _, listener := range asset.ticketHealthListeners {
+		if len(newIssues) > 0 && listener.OnTicketHealthIssues != nil {
+			listener.OnTicketHealthIssues(asset.ID, newIssues)
+		}
+		if len(resolved) > 0 && listener.OnTicketHealthResolved != nil {
+			listener.OnTicketHealthResolved(asset.ID, resolved)
+		}
+	}
+}
+
+// setTicketHealth replaces the known ticket health issues and notifies the
+// listeners of what changed.
+func (asset *Asset) setTicketHealth(issues []*TicketHealth) {
+	issueKey := func(issue *TicketHealth) string {
+		return fmt.Sprintf("%s:%d:%t", issue.TicketHash, issue.Issue, issue.NeedsUnlock)
+	}
+
+	asset.ticketHealthMu.Lock()
+	previous := asset.ticketHealth
+	asset.ticketHealth = issues
+	asset.ticketHealthMu.Unlock()
+
+	previousKeys := make(map[string]bool, len(previous))
+	for _, issue := range previous {
+		previousKeys[issueKey(issue)] = true
+	}
+	currentKeys := make(map[string]bool, len(issues))
+	currentTickets := make(map[string]bool, len(issues))
+	var newIssues []*TicketHealth
+	for _, issue := range issues {
+		currentKeys[issueKey(issue)] = true
+		currentTickets[issue.TicketHash] = true
+		if !previousKeys[issueKey(issue)] {
+			newIssues = append(newIssues, issue)
+		}
+	}
+
+	var resolved []string
+	seen := make(map[string]bool, len(previous))
+	for _, issue := range previous {
+		if !currentTickets[issue.TicketHash] && !seen[issue.TicketHash] {
+			seen[issue.TicketHash] = true
+			resolved = append(resolved, issue.TicketHash)
+		}
+	}
+
+	asset.publishTicketHealthChanges(newIssues, resolved)
+}
+
+// StartTicketHealthMonitor periodically checks the tickets assigned to VSPs
+// until the wallet is shut down or StopTicketHealthMonitor is called. Fee
+// payments that errored are retried; tickets that may still miss their vote
+// are reported to the ticket health listeners. Calling it while the monitor
+// is running does nothing.
+func (asset *Asset) StartTicketHealthMonitor() {
+	asset.ticketHealthMu.Lock()
+	defer asset.ticketHealthMu.Unlock()
+	if asset.cancelTicketHealthMonitor != nil {
+		return
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	checkNow := make(chan struct{}, 1)
+	asset.cancelTicketHealthMonitor = cancel
+	asset.ticketHealthCheckNow = checkNow
+
+	go func() {
+		ticker := time.NewTicker(ticketHealthCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if asset.IsSynced() {
+				if _, err := asset.CheckTicketHealth(ctx); err != nil && ctx.Err() == nil {
+					log.Errorf("[%d] Ticket health check failed: %v", asset.ID, err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-checkNow:
+			}
+		}
+	}()
+}
+
+// requestTicketHealthCheck makes a running ticket health monitor check the
+// tickets now rather than at its next interval, e.g. once the wallet synced.
+func (asset *Asset) requestTicketHealthCheck() {
+	asset.ticketHealthMu.RLock()
+	defer asset.ticketHealthMu.RUnlock()
+	if asset.ticketHealthCheckNow == nil {
+		return
+	}
+	select {
+	case asset.ticketHealthCheckNow <- struct{}{}:
+	default:
+	}
+}
+
+// StopTicketHealthMonitor stops the ticket health monitor.
+func (asset *Asset) StopTicketHealthMonitor() {
+	asset.ticketHealthMu.Lock()
+	defer asset.ticketHealthMu.Unlock()
+	if asset.cancelTicketHealthMonitor != nil {
+		asset.cancelTicketHealthMonitor()
+		asset.cancelTicketHealthMonitor = nil
+		asset.ticketHealthCheckNow = nil
+	}
+}
+
+// TicketHealthIssues returns the problems found by the last ticket health
+// check.
+func (asset *Asset) TicketHealthIssues() []*TicketHealth {
+	asset.ticketHealthMu.RLock()
+	defer asset.ticketHealthMu.RUnlock()
+
+	issues := make([]*TicketHealth, len(asset.ticketHealth))
+	copy(issues, asset.ticketHealth)
+	return issues
+}
+
+// CheckTicketHealth checks the unspent tickets of the wallet that are assigned
+// to a VSP and returns the tickets that may miss their vote. Fee payments that
+// errored are retried first. The vote preferences known to the VSP and fee
+// retries can only be checked while the wallet is unlocked, fee payments
+// left for later are reported with NeedsUnlock set.
+func (asset *Asset) CheckTicketHealth(ctx context.Context) ([]*TicketHealth, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	tickets, err := asset.UnspentUnexpiredTickets()
+	if err != nil {
+		return nil, err
+	}
+
+	dcrW := asset.Internal().DCR
+	unlocked := !asset.IsLocked()
+	vspInfos := make(map[string]error)
+	now := time.Now().Unix()
+
+	var issues []*TicketHealth
+	for _, tx := range tickets {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		ticketHash, err := chainhash.NewHashFromStr(tx.Hash)
+		if err != nil {
+			return nil, err
+		}
+		ticket, err := dcrW.NewVSPTicket(ctx, ticketHash)
+		if err != nil {
+			continue
+		}
+		info, err := ticket.VSPTicketInfo(ctx)
+		if err != nil || info.Host == "" {
+			// Solo tickets are not assigned to a VSP.
+			continue
+		}
+
+		issue := &TicketHealth{TicketHash: tx.Hash, VSP: info.Host, Timestamp: now}
+
+		infoErr, checked := vspInfos[info.Host]
+		if !checked {
+			_, infoErr = vspInfo(info.Host)
+			vspInfos[info.Host] = infoErr
+		}
+		if infoErr != nil {
+			issue.Issue, issue.Detail = TicketVSPUnreachable, infoErr.Error()
+			issues = append(issues, issue)
+			continue
+		}
+
+		if VSPFeeStatus(info.FeeTxStatus) == VSPFeeProcessErrored {
+			if !unlocked {
+				issue.Issue, issue.NeedsUnlock = TicketFeeErrored, true
+				issues = append(issues, issue)
+				continue
+			}
+			if err := asset.payVSPFee(ctx, ticket, info.Host, info.PubKey); err != nil {
+				issue.Issue, issue.Detail = TicketFeeErrored, err.Error()
+				issues = append(issues, issue)
+			}
+			continue
+		}
+
+		if !unlocked {
+			continue
+		}
+		mismatch, err := asset.vspVoteChoicesDiffer(ctx, ticket, info.Host, info.PubKey)
+		if err != nil {
+			log.Warnf("[%d] Unable to get the vsp status of ticket %s: %v", asset.ID, tx.Hash, err)
+			continue
+		}
+		if mismatch {
+			issue.Issue = TicketVoteChoicesMismatch
+			issues = append(issues, issue)
+		}
+	}
+
+	asset.setTicketHealth(issues)
+	return issues, nil
+}
+
+// vspVoteChoicesDiffer returns true if the VSP of the ticket does not vote
+// according to the vote preferences saved in the wallet.
+func (asset *Asset) vspVoteChoicesDiffer(ctx context.Context, ticket *w.VSPTicket, host string, pubKey []byte) (bool, error) {
+	client, err := asset.VSPClient(-1, host, pubKey)
+	if err != nil {
+		return false, err
+	}
+
+	req := vspd.TicketStatusRequest{TicketHash: ticket.Hash().String()}
+	status, err := client.TicketStatus(ctx, req, ticket.CommitmentAddr())
+	if err != nil {
+		return false, err
+	}
+
+	choices, err := ticket.AgendaChoices(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return choicesDiffer(choices, status.VoteChoices) ||
+		choicesDiffer(ticket.TSpendPolicy(), status.TSpendPolicy) ||
+		choicesDiffer(ticket.TreasuryKeyPolicy(), status.TreasuryPolicy), nil
+}
+
+// choicesDiffer returns true if the VSP does not hold the vote choices of the
+// wallet. A choice missing on either side means abstaining.
+func choicesDiffer(walletChoices, vspChoices map[string]string) bool {
+	for key, choice := range walletChoices {
+		if choice == "abstain" && vspChoices[key] == "" {
+			continue
+		}
+		if vspChoices[key] != choice {
+			return true
+		}
+	}
+	for key, choice := range vspChoices {
+		if _, ok := walletChoices[key]; !ok && choice != "abstain" {
+			return true
+		}
+	}
+	return false
+}
+
+// payVSPFee pays the fee of the ticket to the VSP at host, from the ticket
+// buyer account if it is set.
+func (asset *Asset) payVSPFee(ctx context.Context, ticket *w.VSPTicket, host string, pubKey []byte) error {
+	account := int32(-1)
+	if asset.IsTicketBuyerAccountSet() {
+		account = asset.AutoTicketsBuyerConfig().PurchaseAccount
+	}
+	client, err := asset.VSPClient(account, host, pubKey)
+	if err != nil {
+		return err
+	}
+	return client.Process(ctx, ticket, nil)
+}
+
+// ReregisterTicket assigns a live or immature ticket to the VSP at vspHost and
+// pays the new VSP fee, e.g. when the current VSP of the ticket went offline.
+func (asset *Asset) ReregisterTicket(ticketHash, vspHost, passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	hash, err := chainhash.NewHashFromStr(ticketHash)
+	if err != nil {
+		return err
+	}
+
+	info, err := vspInfo(vspHost)
+	if err != nil {
+		return fmt.Errorf("error getting vsp info: %v", err)
+	}
+
+	if asset.IsLocked() {
+		if err = asset.UnlockWallet(passphrase); err != nil {
+			return utils.TranslateError(err)
+		}
+		defer asset.LockWallet()
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	ticket, err := asset.Internal().DCR.NewVSPTicket(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	// Marking the fee as errored with the new VSP makes the fee payment
+	// start over with that VSP.
+	if err := ticket.UpdateFeeErrored(ctx, vspHost, info.PubKey); err != nil {
+		return err
+	}
+	if err := asset.payVSPFee(ctx, ticket, vspHost, info.PubKey); err != nil {
+		return err
+	}
+
+	var issues []*TicketHealth
+	for _, issue := range asset.TicketHealthIssues() {
+		if issue.TicketHash != ticketHash {
+			issues = append(issues, issue)
+		}
+	}
+	asset.setTicketHealth(issues)
+	return nil
+}
+
+// RetryTicketFees unlocks the wallet with passphrase to retry the VSP fee
+// payments that errored while it was locked, then locks it again. The
+// tickets that may still miss their vote are returned.
+func (asset *Asset) RetryTicketFees(passphrase string) ([]*TicketHealth, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	if asset.IsLocked() {
+		if err := asset.UnlockWallet(passphrase); err != nil {
+			return nil, utils.TranslateError(err)
+		}
+		defer asset.LockWallet()
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	return asset.CheckTicketHealth(ctx)
+}
@@ -0,0 +1,164 @@
+package dcr
+
+import (
+	"fmt"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+func TestChoicesDiffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		walletChoices map[string]string
+		vspChoices    map[string]string
+		expected      bool
+	}{
+		{name: "both empty"},
+		{
+			name:          "same choices",
+			walletChoices: map[string]string{"treasury": "yes", "maxblocksize": "no"},
+			vspChoices:    map[string]string{"treasury": "yes", "maxblocksize": "no"},
+		},
+		{
+			name:          "different choice",
+			walletChoices: map[string]string{"treasury": "yes"},
+			vspChoices:    map[string]string{"treasury": "no"},
+			expected:      true,
+		},
+		{
+			name:          "wallet abstains and vsp has no choice",
+			walletChoices: map[string]string{"treasury": "abstain"},
+		},
+		{
+			name:       "vsp abstains and wallet has no choice",
+			vspChoices: map[string]string{"treasury": "abstain"},
+		},
+		{
+			name:          "wallet abstains and vsp votes",
+			walletChoices: map[string]string{"treasury": "abstain"},
+			vspChoices:    map[string]string{"treasury": "yes"},
+			expected:      true,
+		},
+		{
+			name:          "wallet votes and vsp has no choice",
+			walletChoices: map[string]string{"treasury": "yes"},
+			expected:      true,
+		},
+		{
+			name:          "extra vsp choice",
+			walletChoices: map[string]string{"treasury": "yes"},
+			vspChoices:    map[string]string{"treasury": "yes", "maxblocksize": "no"},
+			expected:      true,
+		},
+		{
+			name:          "extra vsp abstain",
+			walletChoices: map[string]string{"treasury": "yes"},
+			vspChoices:    map[string]string{"treasury": "yes", "maxblocksize": "abstain"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := choicesDiffer(tc.walletChoices, tc.vspChoices); got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetTicketHealth(t *testing.T) {
+	feeErrored := func(hash string, needsUnlock bool) *TicketHealth {
+		return &TicketHealth{TicketHash: hash, Issue: TicketFeeErrored, NeedsUnlock: needsUnlock}
+	}
+	unreachable := func(hash string) *TicketHealth {
+		return &TicketHealth{TicketHash: hash, Issue: TicketVSPUnreachable}
+	}
+
+	tests := []struct {
+		name             string
+		previous         []*TicketHealth
+		current          []*TicketHealth
+		expectedNew      []string
+		expectedResolved []string
+	}{
+		{name: "no issues"},
+		{
+			name:        "first issues",
+			current:     []*TicketHealth{unreachable("a"), feeErrored("b", true)},
+			expectedNew: []string{"a", "b"},
+		},
+		{
+			name:     "issues unchanged",
+			previous: []*TicketHealth{unreachable("a"), feeErrored("b", true)},
+			current:  []*TicketHealth{unreachable("a"), feeErrored("b", true)},
+		},
+		{
+			name:        "fee retried once unlocked",
+			previous:    []*TicketHealth{feeErrored("a", true)},
+			current:     []*TicketHealth{feeErrored("a", false)},
+			expectedNew: []string{"a"},
+		},
+		{
+			name:        "issue of a ticket changed",
+			previous:    []*TicketHealth{unreachable("a")},
+			current:     []*TicketHealth{feeErrored("a", false)},
+			expectedNew: []string{"a"},
+		},
+		{
+			name:             "issue resolved",
+			previous:         []*TicketHealth{unreachable("a"), unreachable("b")},
+			current:          []*TicketHealth{unreachable("b")},
+			expectedResolved: []string{"a"},
+		},
+		{
+			name:             "one resolved notification per ticket",
+			previous:         []*TicketHealth{unreachable("a"), feeErrored("a", true), unreachable("b")},
+			expectedResolved: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			asset := &Asset{
+				Wallet:                &sharedW.Wallet{},
+				ticketHealth:          tc.previous,
+				ticketHealthListeners: make(map[string]*TicketHealthListener),
+			}
+
+			var newIssues, resolved []string
+			var issueCalls, resolvedCalls int
+			err := asset.AddTicketHealthListener(&TicketHealthListener{
+				OnTicketHealthIssues: func(_ int, issues []*TicketHealth) {
+					issueCalls++
+					for _, issue := range issues {
+						newIssues = append(newIssues, issue.TicketHash)
+					}
+				},
+				OnTicketHealthResolved: func(_ int, ticketHashes []string) {
+					resolvedCalls++
+					resolved = append(resolved, ticketHashes...)
+				},
+			}, "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			asset.setTicketHealth(tc.current)
+
+			if fmt.Sprint(newIssues) != fmt.Sprint(tc.expectedNew) {
+				t.Errorf("(%v), expected new issues (%v), got (%v)", tc.name, tc.expectedNew, newIssues)
+			}
+			if fmt.Sprint(resolved) != fmt.Sprint(tc.expectedResolved) {
+				t.Errorf("(%v), expected resolved tickets (%v), got (%v)", tc.name, tc.expectedResolved, resolved)
+			}
+			if issueCalls > 1 || resolvedCalls > 1 {
+				t.Errorf("(%v), expected one notification of each kind at most, got (%v) and (%v)",
+					tc.name, issueCalls, resolvedCalls)
+			}
+			if got := asset.TicketHealthIssues(); len(got) != len(tc.current) {
+				t.Errorf("(%v), expected (%v) issues kept, got (%v)", tc.name, len(tc.current), len(got))
+			}
+		})
+	}
+}
@@ -1,15 +1,22 @@
 package dcr
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
 	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/wire"
 )
 
 // SetTreasuryPolicy saves the voting policy for treasury spends by a particular
@@ -40,16 +47,9 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		return fmt.Errorf("treasury pikey must be %d bytes", secp256k1.PubKeyBytesLenCompressed)
 	}
 
-	var policy stake.TreasuryVoteT
-	switch newVotingPolicy {
-	case "abstain", "invalid", "":
-		policy = stake.TreasuryVoteInvalid
-	case "yes":
-		policy = stake.TreasuryVoteYes
-	case "no":
-		policy = stake.TreasuryVoteNo
-	default:
-		return fmt.Errorf("invalid policy: unknown policy %q", newVotingPolicy)
+	policy, err := treasuryVote(newVotingPolicy)
+	if err != nil {
+		return err
 	}
 
 	// The wallet will need to be unlocked to sign the API
@@ -80,6 +80,19 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		}
 	}()
 
+	// Update voting preferences on VSPs if required.
+	policyMap := map[string]string{
+		PiKey: newVotingPolicy,
+	}
+	err = asset.setVSPTreasuryChoices(ctx, ticketHash, nil, policyMap)
+	vspPreferenceUpdateSuccess = err == nil
+	return err
+}
+
+// setVSPTreasuryChoices updates the treasury vote choices with the VSP of the
+// ticket if ticketHash is set, otherwise with the VSPs of all unspent,
+// unexpired tickets. The wallet must be unlocked to sign the requests.
+func (asset *Asset) setVSPTreasuryChoices(ctx context.Context, ticketHash *chainhash.Hash, tspendPolicy, treasuryPolicy map[string]string) error {
 	// If a ticket hash is provided, set the specified vote policy with
 	// the VSP associated with the provided ticket. Otherwise, set the
 	// vote policy with the VSPs associated with all "votable" tickets.
@@ -87,7 +100,7 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 	if ticketHash != nil {
 		ticketHashes = append(ticketHashes, ticketHash)
 	} else {
-		err = asset.Internal().DCR.ForUnspentUnexpiredTickets(ctx, func(hash *chainhash.Hash) error {
+		err := asset.Internal().DCR.ForUnspentUnexpiredTickets(ctx, func(hash *chainhash.Hash) error {
 			ticketHashes = append(ticketHashes, hash)
 			return nil
 		})
@@ -99,11 +112,6 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 	// Never return errors from this for loop, so all tickets are tried.
 	// The first error will be returned to the caller.
 	var firstErr error
-	// Update voting preferences on VSPs if required.
-	policyMap := map[string]string{
-		PiKey: newVotingPolicy,
-	}
-
 	for _, tHash := range ticketHashes {
 		vspTicket, err := asset.Internal().DCR.NewVSPTicket(ctx, tHash)
 		if err != nil {
@@ -116,9 +124,9 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		}
 
 		vspTicketInfo, err := vspTicket.VSPTicketInfo(ctx)
-		if err != nil && firstErr == nil {
-			if err.Error() != utils.ErrWalletLocked {
-				// Ignore the wallet is locked error.
+		if err != nil {
+			// Ignore the wallet is locked error.
+			if firstErr == nil && err.Error() != utils.ErrWalletLocked {
 				firstErr = err
 			}
 			continue // try next tHash
@@ -127,19 +135,19 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		// Account being set to -1 means the default ticket purchase account will be
 		// used in the ticket policy configuration.
 		vspClient, err := asset.VSPClient(-1, vspTicketInfo.Host, vspTicketInfo.PubKey)
-		if err != nil && firstErr == nil {
-			firstErr = err
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
 			continue // try next tHash
 		}
 
-		err = vspClient.SetVoteChoice(ctx, vspTicket, nil, nil, policyMap)
+		err = vspClient.SetVoteChoice(ctx, vspTicket, nil, tspendPolicy, treasuryPolicy)
 		if err != nil && firstErr == nil {
 			firstErr = err
-			continue // try next tHash
 		}
 	}
 
-	vspPreferenceUpdateSuccess = firstErr == nil
 	return firstErr
 }
 
@@ -207,3 +215,318 @@ func (asset *Asset) TreasuryPolicies(PiKey, tixHash string) ([]*TreasuryKeyPolic
 	}
 	return res, nil
 }
+
+// knownTSpend is a treasury spend the wallet saw in the mempool or that one
+// of its tickets voted on.
+type knownTSpend struct {
+	// TSpend is nil while only a vote on the tspend is known.
+	TSpend *TSpend `json:"tspend,omitempty"`
+	// Expiry is the last block height the tspend can be mined at.
+	Expiry uint32 `json:"expiry"`
+	// Expired is set once the tspend expired without being mined.
+	Expired bool `json:"expired"`
+}
+
+// TSpends returns the treasury spends in the mempool that have not expired
+// yet, followed by the mined treasury spends found by SyncMinedTSpends, most
+// recent first, with the wallet's vote on each of them.
+func (asset *Asset) TSpends() ([]*TSpend, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	asset.tspendsMu.Lock()
+	defer asset.tspendsMu.Unlock()
+
+	known := asset.knownTSpends()
+	changed := false
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txs := asset.Internal().DCR.GetAllTSpends(ctx)
+	pending := make([]*TSpend, 0, len(txs))
+	inMempool := make(map[string]bool, len(txs))
+	for _, tx := range txs {
+		tspend, err := asset.decodeTSpend(tx)
+		if err != nil {
+			log.Warnf("Ignoring invalid tspend %s: %v", tx.TxHash(), err)
+			continue
+		}
+
+		inMempool[tspend.Hash] = true
+		pending = append(pending, tspend)
+		if k, ok := known[tspend.Hash]; !ok || k.TSpend == nil {
+			known[tspend.Hash] = &knownTSpend{TSpend: tspend, Expiry: tspend.Expiry}
+			changed = true
+		}
+	}
+	if changed {
+		asset.SaveUserConfigValue(sharedW.KnownTSpendsConfigKey, known)
+	}
+
+	var mined []*TSpend
+	for hash, k := range known {
+		if inMempool[hash] || k.TSpend == nil || k.TSpend.BlockHeight == 0 {
+			continue
+		}
+		chainHash, err := chainhash.NewHashFromStr(hash)
+		if err != nil {
+			continue
+		}
+		k.TSpend.Policy = treasuryPolicy(asset.Internal().DCR.TSpendPolicy(chainHash, nil))
+		mined = append(mined, k.TSpend)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Expiry < pending[j].Expiry
+	})
+	sort.Slice(mined, func(i, j int) bool {
+		return mined[i].BlockHeight > mined[j].BlockHeight
+	})
+	return append(pending, mined...), nil
+}
+
+// SyncMinedTSpends looks up on dcrdata the treasury spends the wallet saw in
+// the mempool or that its tickets voted on and records the ones that were
+// mined. SPV wallets don't download the blocks that mine treasury spends, so
+// they are only found this way. Mined and expired treasury spends are not
+// looked up again.
+func (asset *Asset) SyncMinedTSpends(service *ext.Service) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	asset.tspendsMu.Lock()
+	defer asset.tspendsMu.Unlock()
+
+	known := asset.knownTSpends()
+	if err := asset.addVotedTSpends(known); err != nil {
+		return err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	inMempool := make(map[string]bool)
+	for _, tx := range asset.Internal().DCR.GetAllTSpends(ctx) {
+		inMempool[tx.TxHash().String()] = true
+	}
+
+	bestHeight := uint32(asset.GetBestBlockHeight())
+	var err error
+	for hash, k := range known {
+		if k.Expired || inMempool[hash] || (k.TSpend != nil && k.TSpend.BlockHeight > 0) {
+			continue
+		}
+		if err = asset.lookupTSpend(service, hash, k, bestHeight); err != nil {
+			break
+		}
+	}
+
+	asset.SaveUserConfigValue(sharedW.KnownTSpendsConfigKey, known)
+	return err
+}
+
+// lookupTSpend sets the mined tspend with hash on k, or marks k expired if
+// the tspend was not mined by its expiry.
+func (asset *Asset) lookupTSpend(service *ext.Service, hash string, k *knownTSpend, bestHeight uint32) error {
+	tx, err := service.GetTx(hash)
+	if errors.Is(err, utils.ErrHTTPNotFound) || (err == nil && (tx.Block == nil || tx.Block.BlockHeight <= 0)) {
+		k.Expired = bestHeight > k.Expiry
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	txHex, err := service.GetTxHex(hash)
+	if err != nil {
+		return err
+	}
+	msgTx, err := decodeTx(txHex)
+	if err != nil {
+		return err
+	}
+	if msgTx.TxHash().String() != hash {
+		return fmt.Errorf("dcrdata returned a different transaction for tspend %s", hash)
+	}
+
+	tspend, err := asset.decodeTSpend(msgTx)
+	if err != nil {
+		return err
+	}
+	tspend.BlockHeight = int32(tx.Block.BlockHeight)
+	k.TSpend = tspend
+	return nil
+}
+
+// addVotedTSpends adds the treasury spends voted on by the wallet's tickets
+// to known.
+func (asset *Asset) addVotedTSpends(known map[string]*knownTSpend) error {
+	votes, err := asset.GetTransactionsRaw(0, 0, TxFilterVoted, true, "")
+	if err != nil {
+		return err
+	}
+	addTSpendVotes(known, votes, asset.chainParams)
+	return nil
+}
+
+// addTSpendVotes adds the treasury spends voted on by the mined votes to
+// known, expiring at the latest height they can have been voted on.
+func addTSpendVotes(known map[string]*knownTSpend, votes []*sharedW.Transaction, params *chaincfg.Params) {
+	// A tspend is voted on before its expiry, at most this many blocks before.
+	window := uint32(params.TreasuryVoteInterval*(params.TreasuryVoteIntervalMultiplier+1)) + 2
+	for _, vote := range votes {
+		if vote.BlockHeight <= 0 {
+			continue
+		}
+		msgTx, err := decodeTx(vote.Hex)
+		if err != nil || len(msgTx.TxOut) == 0 {
+			continue
+		}
+		// The treasury votes are in the last output of a vote, if any.
+		treasuryVotes, err := stake.GetSSGenTreasuryVotes(msgTx.TxOut[len(msgTx.TxOut)-1].PkScript)
+		if err != nil {
+			continue
+		}
+		for _, treasuryVote := range treasuryVotes {
+			hash := treasuryVote.Hash.String()
+			if _, ok := known[hash]; !ok {
+				known[hash] = &knownTSpend{Expiry: uint32(vote.BlockHeight) + window}
+			}
+		}
+	}
+}
+
+// knownTSpends returns the treasury spends known to the wallet by hash.
+func (asset *Asset) knownTSpends() map[string]*knownTSpend {
+	known := make(map[string]*knownTSpend)
+	_ = asset.ReadUserConfigValue(sharedW.KnownTSpendsConfigKey, &known)
+	if known == nil {
+		known = make(map[string]*knownTSpend)
+	}
+	return known
+}
+
+// decodeTSpend returns the treasury spend tx with the wallet's vote on it.
+func (asset *Asset) decodeTSpend(tx *wire.MsgTx) (*TSpend, error) {
+	tspend, err := parseTSpend(tx, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	hash := tx.TxHash()
+	tspend.Policy = treasuryPolicy(asset.Internal().DCR.TSpendPolicy(&hash, nil))
+	return tspend, nil
+}
+
+// parseTSpend returns the treasury spend tx, without a vote policy.
+func parseTSpend(tx *wire.MsgTx, params *chaincfg.Params) (*TSpend, error) {
+	_, pikey, err := stake.CheckTSpend(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tspend := &TSpend{
+		Hash:   tx.TxHash().String(),
+		PiKey:  hex.EncodeToString(pikey),
+		Expiry: tx.Expiry,
+	}
+
+	// The first output is the OP_RETURN, the payouts follow it and their
+	// scripts are prefixed with OP_TGEN.
+	for _, out := range tx.TxOut[1:] {
+		payout := &TSpendPayout{Amount: out.Value}
+		if len(out.PkScript) > 1 {
+			_, addrs := stdscript.ExtractAddrs(out.Version, out.PkScript[1:], params)
+			if len(addrs) > 0 {
+				payout.Address = addrs[0].String()
+			}
+		}
+		tspend.Amount += out.Value
+		tspend.Payouts = append(tspend.Payouts, payout)
+	}
+	return tspend, nil
+}
+
+// SetTSpendPolicy saves the voting policy for a single treasury spend. The
+// policy overrides the policy of the Pi key that signed the tspend.
+// If a ticket hash is provided, the voting policy is also updated with the VSP
+// controlling the ticket. If a ticket hash isn't provided, the vote choice is
+// saved to the local wallet database and the VSPs controlling all unspent,
+// unexpired tickets are updated to use the specified vote policy.
+func (asset *Asset) SetTSpendPolicy(tspendHash, newVotingPolicy, tixHash string, passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	hash, err := chainhash.NewHashFromStr(tspendHash)
+	if err != nil {
+		return fmt.Errorf("invalid tspend hash: %w", err)
+	}
+
+	var ticketHash *chainhash.Hash
+	if tixHash != "" {
+		ticketHash, err = chainhash.NewHashFromStr(tixHash)
+		if err != nil {
+			return fmt.Errorf("invalid ticket hash: %w", err)
+		}
+	}
+
+	policy, err := treasuryVote(newVotingPolicy)
+	if err != nil {
+		return err
+	}
+
+	// The wallet will need to be unlocked to sign the API
+	// request(s) for setting this voting policy with the VSP.
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	currentVotingPolicy := asset.Internal().DCR.TSpendPolicy(hash, ticketHash)
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	err = asset.Internal().DCR.SetTSpendPolicy(ctx, hash, policy, ticketHash)
+	if err != nil {
+		return err
+	}
+
+	policyMap := map[string]string{
+		tspendHash: newVotingPolicy,
+	}
+	err = asset.setVSPTreasuryChoices(ctx, ticketHash, policyMap, nil)
+	if err != nil {
+		// Updating the tspend voting preference with the vsp failed, revert
+		// the locally saved voting preference.
+		revertError := asset.Internal().DCR.SetTSpendPolicy(ctx, hash, currentVotingPolicy, ticketHash)
+		if revertError != nil {
+			log.Errorf("unable to revert locally saved voting preference: %v", revertError)
+		}
+	}
+	return err
+}
+
+// treasuryVote parses a yes, no or abstain voting policy.
+func treasuryVote(policy string) (stake.TreasuryVoteT, error) {
+	switch policy {
+	case "abstain", "invalid", "":
+		return stake.TreasuryVoteInvalid, nil
+	case "yes":
+		return stake.TreasuryVoteYes, nil
+	case "no":
+		return stake.TreasuryVoteNo, nil
+	default:
+		return 0, fmt.Errorf("invalid policy: unknown policy %q", policy)
+	}
+}
+
+// treasuryPolicy is the inverse of treasuryVote.
+func treasuryPolicy(vote stake.TreasuryVoteT) string {
+	switch vote {
+	case stake.TreasuryVoteYes:
+		return "yes"
+	case stake.TreasuryVoteNo:
+		return "no"
+	default:
+		return "abstain"
+	}
+}
@@ -0,0 +1,135 @@
+package dcr
+
+import (
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/wire"
+)
+
+// testnetTSpend is the treasury spend mined in block 560880 of testnet3. It
+// pays 1 DCR to a single address.
+const testnetTSpend = "03000000010000000000000000000000000000000000000000000000000000000000000000ffffffff00" +
+	"ffffffff0200000000000000000000226a20f6eaf505000000000c9ef1c885c62d089ff9988eb4ca4c2c620614eb75c620" +
+	"bc00e1f5050000000000001ac376a914bd15503ed7d24fc5b36ceba70ee8741a36fe3dca88ac00000000f28e080001f6ea" +
+	"f5050000000000000000ffffffff644054a36eb67457facef5a075f8ebba5d7a9342dd13b45c6d3dd43b22bead35428603" +
+	"fd805eea93674aa7cd120e46e3fa7a2c563926686909c8eb1b06b40237f3a52103beca9bbd227ca6bb5a58e03a36ba2b52" +
+	"fff09093bd7a50aee1193bccd257fb8ac2"
+
+func TestParseTSpend(t *testing.T) {
+	tx, err := decodeTx(testnetTSpend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tspend, err := parseTSpend(tx, chaincfg.TestNet3Params())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		expectedHash    = "47de5efe98f073b6f33fbffb1743bacb850fbd8be5723195a744086061f5f0f2"
+		expectedPiKey   = "03beca9bbd227ca6bb5a58e03a36ba2b52fff09093bd7a50aee1193bccd257fb8a"
+		expectedAddress = "TsiFuihcGfaffRH41mTK1FRoR4zRuH26Dov"
+	)
+	if tspend.Hash != expectedHash {
+		t.Errorf("expected hash (%v), got (%v)", expectedHash, tspend.Hash)
+	}
+	if tspend.PiKey != expectedPiKey {
+		t.Errorf("expected Pi key (%v), got (%v)", expectedPiKey, tspend.PiKey)
+	}
+	if tspend.Expiry != 560882 {
+		t.Errorf("expected expiry (%v), got (%v)", 560882, tspend.Expiry)
+	}
+	if tspend.Amount != 1e8 {
+		t.Errorf("expected amount (%v), got (%v)", 1e8, tspend.Amount)
+	}
+	if len(tspend.Payouts) != 1 {
+		t.Fatalf("expected (1) payout, got (%v)", len(tspend.Payouts))
+	}
+	if payout := tspend.Payouts[0]; payout.Address != expectedAddress || payout.Amount != 1e8 {
+		t.Errorf("expected a payout of (%v) to (%v), got (%v) to (%v)", 1e8, expectedAddress, payout.Amount, payout.Address)
+	}
+
+	// A tspend without its Pi key signature is rejected.
+	tx.TxIn[0].SignatureScript = []byte{txscript.OP_TSPEND}
+	if _, err := parseTSpend(tx, chaincfg.TestNet3Params()); err == nil {
+		t.Error("expected an unsigned tspend to be rejected")
+	}
+}
+
+// newTestVote returns a mined vote whose last output holds the provided
+// treasury votes.
+func newTestVote(t *testing.T, height int32, tspendHashes ...chainhash.Hash) *sharedW.Transaction {
+	t.Helper()
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN, txscript.OP_DATA_6, 0x01, 0x00, 0x0a, 0x00, 0x00, 0x00}))
+	if len(tspendHashes) > 0 {
+		data := []byte{'T', 'V'}
+		for _, hash := range tspendHashes {
+			data = append(data, hash[:]...)
+			data = append(data, 0x01)
+		}
+		script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddData(data).Script()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgTx.AddTxOut(wire.NewTxOut(0, script))
+	}
+	encoded, err := encodeTx(msgTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &sharedW.Transaction{Hex: encoded, BlockHeight: height}
+}
+
+func TestAddTSpendVotes(t *testing.T) {
+	// On mainnet, a tspend is voted on at most 288*13+2 blocks before it
+	// expires.
+	const window = 3746
+	params := chaincfg.MainNetParams()
+	tspendA, tspendB, tspendC := chainhash.Hash{1}, chainhash.Hash{2}, chainhash.Hash{3}
+
+	known := map[string]*knownTSpend{
+		tspendC.String(): {Expiry: 10, Expired: true},
+	}
+	votes := []*sharedW.Transaction{
+		newTestVote(t, 1000, tspendA, tspendB),
+		// A later vote does not move the expiry of a tspend already added.
+		newTestVote(t, 1500, tspendA),
+		// Nor that of a tspend that was known before.
+		newTestVote(t, 2000, tspendC),
+		// Unmined votes and votes without treasury votes are skipped.
+		newTestVote(t, -1, chainhash.Hash{4}),
+		newTestVote(t, 3000),
+		{Hex: "not a tx", BlockHeight: 3000},
+	}
+	addTSpendVotes(known, votes, params)
+
+	expected := map[string]uint32{
+		tspendA.String(): 1000 + window,
+		tspendB.String(): 1000 + window,
+		tspendC.String(): 10,
+	}
+	if len(known) != len(expected) {
+		t.Fatalf("expected (%v) known tspends, got (%v)", len(expected), len(known))
+	}
+	for hash, expiry := range expected {
+		k, ok := known[hash]
+		if !ok {
+			t.Fatalf("expected tspend (%v) to be known", hash)
+		}
+		if k.Expiry != expiry {
+			t.Errorf("(%v), expected expiry (%v), got (%v)", hash, expiry, k.Expiry)
+		}
+		if k.TSpend != nil {
+			t.Errorf("(%v), expected the tspend to be looked up later", hash)
+		}
+	}
+	if !known[tspendC.String()].Expired {
+		t.Errorf("expected the known tspend to be left unchanged")
+	}
+}
@@ -407,6 +407,10 @@ func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput
 			continue
 		}
 
+		if asset.IsOutputFrozen(output.TxID, output.Vout) {
+			continue
+		}
+
 		if !saneOutputValue(output.Amount.(Amount)) {
 			sourceErr = fmt.Errorf("impossible output amount `%v` in listunspent result", output.Amount)
 			break
@@ -91,7 +91,36 @@ type TicketBuyerConfig struct {
 	PurchaseAccount   int32
 	BalanceToMaintain int64
 
-	VspClient *vsp.Client
+	// VspHosts lists the VSPs the ticket buyer may use in order of
+	// preference, starting with VspHost. The next VSP is used when the
+	// current one cannot be reached, fails to take the fee payment or no
+	// longer satisfies the policy.
+	VspHosts []string
+
+	TicketBuyerPolicy
+}
+
+// TicketBuyerPolicy limits the tickets bought by the automatic ticket buyer.
+// Zero values disable the corresponding limit.
+type TicketBuyerPolicy struct {
+	// MaxTicketsPerInterval caps the tickets bought in a single stake
+	// difficulty interval.
+	MaxTicketsPerInterval int
+	// MaxTicketsPerDay caps the tickets bought in the last 24 hours.
+	MaxTicketsPerDay int
+	// MaxTicketPrice is the highest ticket price in atoms the buyer pays.
+	MaxTicketPrice int64
+	// MaxVSPFeePercent is the highest fee percentage a VSP may charge.
+	MaxVSPFeePercent float64
+	// MinVSPUptime is the lowest share, in percent, of the voting wallets of
+	// a VSP that must be online.
+	MinVSPUptime float64
+}
+
+// TicketBuyerDecision is an entry of the ticket buyer decision log.
+type TicketBuyerDecision struct {
+	Timestamp int64
+	Message   string
 }
 
 // VSPFeeStatus represents the current fee status of a ticket.
@@ -199,3 +228,24 @@ type TreasuryKeyPolicy struct {
 	TicketHash string `json:"ticket_hash"` // nil unless for per-ticket VSP policies
 	Policy     string `json:"policy"`
 }
+
+// TSpend is a treasury spend transaction, waiting to be voted on or mined.
+type TSpend struct {
+	Hash string `json:"hash"`
+	// PiKey is the hex encoded Pi key that signed the tspend.
+	PiKey   string          `json:"pi_key"`
+	Expiry  uint32          `json:"expiry"`
+	Amount  int64           `json:"amount"`
+	Payouts []*TSpendPayout `json:"payouts"`
+	// Policy is the wallet's vote on the tspend, yes, no or abstain.
+	Policy string `json:"policy"`
+	// BlockHeight is the height of the block that mined the tspend, 0 while
+	// it is in the mempool.
+	BlockHeight int32 `json:"block_height"`
+}
+
+// TSpendPayout is a payment made by a treasury spend.
+type TSpendPayout struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
@@ -44,7 +44,7 @@ func (asset *Asset) createVspClient(account int32, host string, pubKey []byte) (
 	cfg := vsp.Config{
 		URL:    host,
 		PubKey: base64.StdEncoding.EncodeToString(pubKey),
-		Dialer: nil, // optional, but consider providing a value
+		Dialer: utils.ProxyDialContext(utils.WalletIsolationID(asset.ID)),
 		Wallet: asset.Internal().DCR,
 		Params: asset.Internal().DCR.ChainParams(),
 	}
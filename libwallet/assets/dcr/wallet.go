@@ -26,9 +26,22 @@ type Asset struct {
 	chainParams *chaincfg.Params
 
 	cancelAccountMixer      context.CancelFunc `json:"-"`
+	mixerScheduler          mixerScheduler
 	cancelAutoTicketBuyer   context.CancelFunc `json:"-"`
 	cancelAutoTicketBuyerMu sync.RWMutex
 
+	ticketBuyerLog   []*TicketBuyerDecision
+	ticketBuyerLogMu sync.RWMutex
+
+	ticketHealth              []*TicketHealth
+	ticketHealthMu            sync.RWMutex
+	cancelTicketHealthMonitor context.CancelFunc
+	ticketHealthCheckNow      chan struct{}
+
+	soloVoter soloVoter
+
+	tspendsMu sync.Mutex
+
 	TxAuthoredInfo *TxAuthor
 
 	// VSP data
@@ -39,6 +52,7 @@ type Asset struct {
 	notificationListenersMu           sync.RWMutex
 	syncData                          *SyncData
 	accountMixerNotificationListeners map[string]*AccountMixerNotificationListener
+	ticketHealthListeners             map[string]*TicketHealthListener
 	txAndBlockNotificationListeners   map[string]*sharedW.TxAndBlockNotificationListener
 	blocksRescanProgressListener      *sharedW.BlocksRescanProgressListener
 
@@ -63,8 +77,10 @@ func initWalletLoader(chainParams *chaincfg.Params, rootdir, walletDbDriver stri
 		MixSplitLimit:           10,
 	}
 
+	// The SPV syncer never votes, enabling voting only lets solo voting
+	// publish votes for the tickets dcrd reports as winners.
 	stakeOptions := &dcr.StakeOptions{
-		VotingEnabled: false,
+		VotingEnabled: true,
 		VotingAddress: nil,
 	}
 
@@ -126,6 +142,7 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		ticketHealthListeners:             make(map[string]*TicketHealthListener),
 		vspClients:                        make(map[string]*vsp.Client),
 		dbMutex:                           &dbMutex,
 	}
@@ -165,6 +182,7 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		ticketHealthListeners:             make(map[string]*TicketHealthListener),
 		dbMutex:                           &dbMutex,
 	}
 
@@ -202,6 +220,7 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 		vspClients:                        make(map[string]*vsp.Client),
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		ticketHealthListeners:             make(map[string]*TicketHealthListener),
 		dbMutex:                           &dbMutex,
 	}
 
@@ -234,6 +253,7 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		ticketHealthListeners:             make(map[string]*TicketHealthListener),
 		dbMutex:                           &dbMutex,
 	}
 
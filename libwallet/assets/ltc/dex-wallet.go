@@ -63,6 +63,7 @@ type DEXWallet struct {
 	cl                *ChainService
 	btcParams         *chaincfg.Params
 	syncStatusChecker SyncStatusChecker
+	frozenOutputs     FrozenOutputChecker
 	*dexbtc.BlockFiltersScanner
 }
 
@@ -121,17 +122,24 @@ type SyncStatusChecker interface {
 	IsSynced() bool
 }
 
+// FrozenOutputChecker reports the outputs the user froze. Frozen outputs are
+// hidden from the DEX so they are never used to fund orders.
+type FrozenOutputChecker interface {
+	IsOutputFrozen(txID string, vout uint32) bool
+}
+
 var _ dexbtc.CustomWallet = (*DEXWallet)(nil)
 var _ dexbtc.BlockInfoReader = (*DEXWallet)(nil)
 
 // NewDEXWallet returns a new *DEXWallet.
-func NewDEXWallet(w *wallet.Wallet, acctNum int32, cl *ChainService, btcParams *chaincfg.Params, syncStatusChecker SyncStatusChecker) *DEXWallet {
+func NewDEXWallet(w *wallet.Wallet, acctNum int32, cl *ChainService, btcParams *chaincfg.Params, syncStatusChecker SyncStatusChecker, frozenOutputs FrozenOutputChecker) *DEXWallet {
 	dw := &DEXWallet{
 		w:                 w,
 		acctNum:           acctNum,
 		cl:                cl,
 		btcParams:         btcParams,
 		syncStatusChecker: syncStatusChecker,
+		frozenOutputs:     frozenOutputs,
 	}
 
 	dw.BlockFiltersScanner = dexbtc.NewBlockFiltersScanner(dw, dexLogger{Logger: log})
@@ -309,8 +317,12 @@ func (dw *DEXWallet) Balances() (*dexbtc.GetBalancesResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error listing unspent outputs: %w", err)
 	}
-	var trusted, untrusted ltcutil.Amount
+	var trusted, untrusted, frozen ltcutil.Amount
 	for _, txout := range unspents {
+		if dw.frozenOutputs.IsOutputFrozen(txout.TxID, txout.Vout) {
+			frozen += ltcutil.Amount(AmountLitoshi(txout.Amount))
+			continue
+		}
 		if txout.Confirmations > 0 || dw.ownsInputs(txout.TxID) {
 			trusted += ltcutil.Amount(AmountLitoshi(txout.Amount))
 			continue
@@ -326,8 +338,9 @@ func (dw *DEXWallet) Balances() (*dexbtc.GetBalancesResult, error) {
 	log.Tracef("Bals: spendable = %v (%v trusted, %v untrusted, %v assumed locked), immature = %v",
 		bals.Spendable, trusted, untrusted, bals.Spendable-trusted-untrusted, bals.ImmatureReward)
 	// Locked outputs would be in wallet.Balances.Spendable. Assume they would
-	// be considered trusted and add them back in.
-	if all := trusted + untrusted; bals.Spendable > all {
+	// be considered trusted and add them back in. Frozen outputs are not
+	// available to the DEX.
+	if all := trusted + untrusted + frozen; bals.Spendable > all {
 		trusted += bals.Spendable - all
 	}
 
@@ -356,6 +369,10 @@ func (dw *DEXWallet) ListUnspent() ([]*dexbtc.ListUnspentResult, error) {
 			continue
 		}
 
+		if dw.frozenOutputs.IsOutputFrozen(utxo.TxID, utxo.Vout) {
+			continue
+		}
+
 		// If the utxo is unconfirmed, we should determine whether it's "safe"
 		// by seeing if we control the inputs of its transaction.
 		safe := utxo.Confirmations > 0 || dw.ownsInputs(utxo.TxID)
@@ -0,0 +1,418 @@
+package ltc
+
+import (
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/wallet/txrules"
+	"github.com/dcrlabs/ltcwallet/wallet/txsizes"
+	"github.com/dcrlabs/ltcwallet/walletdb"
+	"github.com/dcrlabs/ltcwallet/wtxmgr"
+	"github.com/ltcsuite/ltcd/blockchain"
+	"github.com/ltcsuite/ltcd/btcjson"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// rbfSequence is the input sequence number used to signal that a transaction
+// may be replaced by one paying a higher fee, as defined in BIP 125.
+const rbfSequence = wire.MaxTxInSequenceNum - 2
+
+// wtxmgrNamespaceKey is the walletdb bucket holding the wallet's transactions.
+var wtxmgrNamespaceKey = []byte("wtxmgr")
+
+// IsRBFSignalingEnabled returns true if the transactions created by the wallet
+// signal that they can be replaced by fee.
+func (asset *Asset) IsRBFSignalingEnabled() bool {
+	return asset.ReadBoolConfigValueForKey(sharedW.RBFSignalingConfigKey, false)
+}
+
+// CanBumpFee returns true if the transaction is unconfirmed, signals
+// replaceability, spends only wallet outputs and has a change output the
+// higher fee can be deducted from.
+func (asset *Asset) CanBumpFee(txHash string) bool {
+	_, _, _, err := asset.replaceableTx(txHash)
+	return err == nil
+}
+
+// BumpFee replaces the unconfirmed transaction with a copy paying
+// feeRatePerkvB. The extra fee is deducted from the change output of the
+// original transaction. The hash of the replacement is returned.
+func (asset *Asset) BumpFee(txHash string, feeRatePerkvB int64, privatePassphrase string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	tx, msgTx, changeIndex, err := asset.replaceableTx(txHash)
+	if err != nil {
+		return "", err
+	}
+
+	var totalIn, totalOut int64
+	for _, input := range tx.Inputs {
+		totalIn += input.Amount
+	}
+	for _, txOut := range msgTx.TxOut {
+		totalOut += txOut.Value
+	}
+
+	// The replacement has the same inputs and outputs so its size matches the
+	// size of the original.
+	replacement := msgTx.Copy()
+	change := replacement.TxOut[changeIndex]
+	change.Value, err = replacementChange(change, totalIn-totalOut, txVirtualSize(msgTx), feeRatePerkvB)
+	if err != nil {
+		return "", err
+	}
+	for _, txIn := range replacement.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+
+	newHash, err := asset.signAndPublish(replacement, privatePassphrase, tx.Label)
+	if err != nil {
+		return "", err
+	}
+
+	// The wallet only drops the replaced tx once the replacement is mined,
+	// remove it now so it no longer counts towards the balance.
+	if err := asset.removeUnminedTx(msgTx); err != nil {
+		log.Errorf("removing replaced tx %s failed: %v", txHash, err)
+	}
+
+	return newHash, nil
+}
+
+// CanCPFP returns true if the transaction is unconfirmed and has unspent
+// outputs paying to the wallet that a child transaction can spend.
+func (asset *Asset) CanCPFP(txHash string) bool {
+	tx, err := asset.GetTransactionRaw(txHash)
+	if err != nil || tx == nil || tx.BlockHeight != sharedW.UnminedTxHeight {
+		return false
+	}
+
+	unspents, err := asset.unconfirmedOutputsOf(txHash)
+	return err == nil && len(unspents) > 0
+}
+
+// CPFP accelerates the unconfirmed transaction by spending its wallet outputs
+// back to the wallet in a child transaction (child-pays-for-parent). The child
+// pays enough fee for the parent and child together to pay feeRatePerkvB.
+// Only the outputs of one account are spent, see cpfpAccountOutputs, so that
+// the child does not move funds between accounts. The hash of the child
+// transaction is returned.
+func (asset *Asset) CPFP(txHash string, feeRatePerkvB int64, privatePassphrase string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	tx, err := asset.GetTransactionRaw(txHash)
+	if err != nil {
+		return "", err
+	}
+	if tx == nil {
+		return "", errors.New(utils.ErrNotExist)
+	}
+	if tx.BlockHeight != sharedW.UnminedTxHeight {
+		return "", errors.E(errors.Invalid, "transaction is already confirmed")
+	}
+
+	parentTx, err := asset.decodeTxHex(tx.Hex)
+	if err != nil {
+		return "", err
+	}
+
+	unspents, err := asset.unconfirmedOutputsOf(txHash)
+	if err != nil {
+		return "", err
+	}
+	if len(unspents) == 0 {
+		return "", errors.E(errors.Invalid, "transaction has no spendable wallet outputs")
+	}
+
+	account, unspents := cpfpAccountOutputs(unspents)
+	parentHash := parentTx.TxHash()
+	childTx := wire.NewMsgTx(wire.TxVersion)
+	var totalIn int64
+	for _, unspent := range unspents {
+		childTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&parentHash, unspent.vout), nil, nil))
+		totalIn += unspent.amount
+	}
+
+	address, err := asset.Internal().LTC.NewChangeAddress(account, GetScope())
+	if err != nil {
+		return "", fmt.Errorf("change address error: %v", err)
+	}
+	output, err := txhelper.MakeLTCTxOutput(address.String(), totalIn, asset.chainParams)
+	if err != nil {
+		return "", err
+	}
+	childTx.AddTxOut(output)
+
+	// The parent fee is only known if all its inputs belong to the wallet,
+	// otherwise the child pays for the whole package.
+	var parentFee int64
+	if allInputsFromWallet(tx) {
+		for _, input := range tx.Inputs {
+			parentFee += input.Amount
+		}
+		for _, txOut := range parentTx.TxOut {
+			parentFee -= txOut.Value
+		}
+	}
+
+	childVSize := int64(txsizes.EstimateVirtualSize(0, 0, len(childTx.TxIn), 0, childTx.TxOut, 0))
+	output.Value, err = cpfpChildValue(output.PkScript, totalIn, parentFee, txVirtualSize(parentTx), childVSize, feeRatePerkvB)
+	if err != nil {
+		return "", err
+	}
+
+	if asset.IsRBFSignalingEnabled() {
+		signalReplacement(childTx)
+	}
+
+	return asset.signAndPublish(childTx, privatePassphrase, "")
+}
+
+// replaceableTx returns the wallet transaction with the provided hash, its
+// decoded form and the index of its change output if it can be replaced by a
+// transaction paying a higher fee.
+func (asset *Asset) replaceableTx(txHash string) (*sharedW.Transaction, *wire.MsgTx, int, error) {
+	tx, err := asset.GetTransactionRaw(txHash)
+	if err != nil {
+		return nil, nil, -1, err
+	}
+	if tx == nil {
+		return nil, nil, -1, errors.New(utils.ErrNotExist)
+	}
+	if tx.BlockHeight != sharedW.UnminedTxHeight {
+		return nil, nil, -1, errors.E(errors.Invalid, "transaction is already confirmed")
+	}
+	if !allInputsFromWallet(tx) {
+		return nil, nil, -1, errors.E(errors.Invalid, "transaction spends outputs not owned by the wallet")
+	}
+
+	msgTx, err := asset.decodeTxHex(tx.Hex)
+	if err != nil {
+		return nil, nil, -1, err
+	}
+	if !signalsReplacement(msgTx) {
+		return nil, nil, -1, errors.E(errors.Invalid, "transaction does not signal replace-by-fee")
+	}
+
+	for _, output := range tx.Outputs {
+		if output.Internal && output.AccountNumber != -1 {
+			return tx, msgTx, int(output.Index), nil
+		}
+	}
+	return nil, nil, -1, errors.E(errors.Invalid, "transaction has no change output")
+}
+
+// walletOutput is an unspent wallet output of an unconfirmed transaction.
+type walletOutput struct {
+	vout    uint32
+	amount  int64
+	account uint32
+}
+
+// unconfirmedOutputsOf returns the spendable wallet outputs created by the
+// provided unconfirmed transaction. Frozen outputs are never returned, an
+// error is returned if every wallet output of the transaction is frozen.
+func (asset *Asset) unconfirmedOutputsOf(txHash string) ([]*walletOutput, error) {
+	unspents, err := asset.Internal().LTC.ListUnspent(0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	spendable, frozen := cpfpUnspents(unspents, txHash, asset.IsOutputFrozen)
+	if len(spendable) == 0 && frozen > 0 {
+		return nil, errors.E(errors.Invalid, "the wallet outputs of the transaction are frozen")
+	}
+
+	outputs := make([]*walletOutput, 0, len(spendable))
+	for _, unspent := range spendable {
+		amount, err := ltcutil.NewAmount(unspent.Amount)
+		if err != nil {
+			return nil, err
+		}
+		accountNumber, err := asset.AccountNumber(unspent.Account)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, &walletOutput{
+			vout:    unspent.Vout,
+			amount:  int64(amount),
+			account: uint32(accountNumber),
+		})
+	}
+	return outputs, nil
+}
+
+// cpfpAccountOutputs returns the account that receives the most from the
+// transaction and its outputs among the provided ones. Ties go to the lowest
+// account number.
+func cpfpAccountOutputs(outputs []*walletOutput) (uint32, []*walletOutput) {
+	totals := make(map[uint32]int64)
+	for _, output := range outputs {
+		totals[output.account] += output.amount
+	}
+
+	var account uint32
+	var best int64 = -1
+	for acct, total := range totals {
+		if total > best || (total == best && acct < account) {
+			account, best = acct, total
+		}
+	}
+
+	var accountOutputs []*walletOutput
+	for _, output := range outputs {
+		if output.account == account {
+			accountOutputs = append(accountOutputs, output)
+		}
+	}
+	return account, accountOutputs
+}
+
+// cpfpUnspents returns the spendable unspents of the transaction with txHash
+// that a child transaction may spend, and the number of them left out because
+// they are frozen.
+func cpfpUnspents(unspents []*btcjson.ListUnspentResult, txHash string,
+	isFrozen func(txID string, vout uint32) bool) (spendable []*btcjson.ListUnspentResult, frozen int) {
+	for _, unspent := range unspents {
+		if unspent.TxID != txHash || !unspent.Spendable {
+			continue
+		}
+		if isFrozen(unspent.TxID, unspent.Vout) {
+			frozen++
+			continue
+		}
+		spendable = append(spendable, unspent)
+	}
+	return spendable, frozen
+}
+
+// signAndPublish unlocks the wallet, signs msgTx and publishes it.
+func (asset *Asset) signAndPublish(msgTx *wire.MsgTx, privatePassphrase, label string) (string, error) {
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err := asset.Internal().LTC.Unlock([]byte(privatePassphrase), lock)
+	if err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return "", errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	if err := asset.signTransaction(msgTx); err != nil {
+		return "", err
+	}
+
+	if err := asset.Internal().LTC.PublishTransaction(msgTx, label); err != nil {
+		return "", utils.TranslateError(err)
+	}
+
+	// Force the tx cache to be refreshed on the next read.
+	asset.txs.mu.Lock()
+	asset.txs.blockHeight = -1
+	asset.txs.mu.Unlock()
+
+	return msgTx.TxHash().String(), nil
+}
+
+// removeUnminedTx removes an unconfirmed transaction and any transaction
+// spending its outputs from the wallet's tx store.
+func (asset *Asset) removeUnminedTx(msgTx *wire.MsgTx) error {
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(msgTx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	w := asset.Internal().LTC
+	return walletdb.Update(w.Database(), func(dbTx walletdb.ReadWriteTx) error {
+		return w.TxStore.RemoveUnminedTx(dbTx.ReadWriteBucket(wtxmgrNamespaceKey), rec)
+	})
+}
+
+// signalReplacement sets the sequence number of every input so that the
+// transaction can be replaced by fee.
+func signalReplacement(tx *wire.MsgTx) {
+	for _, txIn := range tx.TxIn {
+		txIn.Sequence = rbfSequence
+	}
+}
+
+// signalsReplacement returns true if any input of tx opts in to
+// replace-by-fee as defined in BIP 125.
+func signalsReplacement(tx *wire.MsgTx) bool {
+	for _, txIn := range tx.TxIn {
+		if txIn.Sequence < wire.MaxTxInSequenceNum-1 {
+			return true
+		}
+	}
+	return false
+}
+
+func allInputsFromWallet(tx *sharedW.Transaction) bool {
+	for _, input := range tx.Inputs {
+		if input.AccountNumber == -1 {
+			return false
+		}
+	}
+	return len(tx.Inputs) > 0
+}
+
+// txVirtualSize returns the virtual size of a signed transaction in vbytes.
+func txVirtualSize(tx *wire.MsgTx) int64 {
+	weight := blockchain.GetTransactionWeight(ltcutil.NewTx(tx))
+	return (weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+}
+
+// feeForVirtualSize returns the fee paid by a transaction of vSize vbytes at
+// a rate of feeRatePerkvB.
+func feeForVirtualSize(feeRatePerkvB, vSize int64) ltcutil.Amount {
+	return ltcutil.Amount(feeRatePerkvB * vSize / 1000)
+}
+
+// replacementChange returns the value left in the change output of a
+// replacement for a transaction of vSize vbytes that paid oldFee, once the
+// replacement pays feeRatePerkvB. BIP 125 requires the replacement to pay more
+// than the original plus the relay fee of its own size, and the change must
+// not become dust.
+func replacementChange(change *wire.TxOut, oldFee, vSize, feeRatePerkvB int64) (int64, error) {
+	newFee := feeForVirtualSize(feeRatePerkvB, vSize)
+	minFee := ltcutil.Amount(oldFee) + txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, int(vSize))
+	if newFee < minFee {
+		return 0, errors.E(errors.Invalid, fmt.Sprintf("fee rate too low, the replacement must pay at least %v", minFee))
+	}
+
+	value := change.Value - (int64(newFee) - oldFee)
+	if value <= 0 || txrules.IsDustOutput(wire.NewTxOut(value, change.PkScript), txrules.DefaultRelayFeePerKb) {
+		return 0, errors.New(utils.ErrInsufficientBalance)
+	}
+	return value, nil
+}
+
+// cpfpChildValue returns the value of the output paying pkScript of a child
+// transaction of childVSize vbytes spending totalIn from a parent of
+// parentVSize vbytes that paid parentFee. The child pays the fee of the
+// package at feeRatePerkvB minus the parent fee, and at least the fee of its
+// own size. The output must not be dust.
+func cpfpChildValue(pkScript []byte, totalIn, parentFee, parentVSize, childVSize, feeRatePerkvB int64) (int64, error) {
+	childFee := int64(feeForVirtualSize(feeRatePerkvB, parentVSize+childVSize)) - parentFee
+	if minChildFee := int64(feeForVirtualSize(feeRatePerkvB, childVSize)); childFee < minChildFee {
+		childFee = minChildFee
+	}
+
+	value := totalIn - childFee
+	if value <= 0 || txrules.IsDustOutput(wire.NewTxOut(value, pkScript), txrules.DefaultRelayFeePerKb) {
+		return 0, errors.New(utils.ErrInsufficientBalance)
+	}
+	return value, nil
+}
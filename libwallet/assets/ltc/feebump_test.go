@@ -0,0 +1,220 @@
+package ltc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ltcsuite/ltcd/btcjson"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+func TestCPFPUnspents(t *testing.T) {
+	const parent, other = "parent", "other"
+	unspents := []*btcjson.ListUnspentResult{
+		{TxID: parent, Vout: 0, Spendable: true},
+		{TxID: parent, Vout: 1, Spendable: true},
+		{TxID: parent, Vout: 2, Spendable: false},
+		{TxID: other, Vout: 0, Spendable: true},
+	}
+
+	tests := []struct {
+		name       string
+		frozen     map[uint32]bool
+		wantVouts  []uint32
+		wantFrozen int
+	}{{
+		name:      "nothing frozen",
+		wantVouts: []uint32{0, 1},
+	}, {
+		name:       "frozen output left out",
+		frozen:     map[uint32]bool{1: true},
+		wantVouts:  []uint32{0},
+		wantFrozen: 1,
+	}, {
+		name:       "all outputs frozen",
+		frozen:     map[uint32]bool{0: true, 1: true},
+		wantFrozen: 2,
+	}, {
+		name:      "unspendable output not counted as frozen",
+		frozen:    map[uint32]bool{2: true},
+		wantVouts: []uint32{0, 1},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			isFrozen := func(txID string, vout uint32) bool {
+				return txID == parent && test.frozen[vout]
+			}
+			spendable, frozen := cpfpUnspents(unspents, parent, isFrozen)
+			if frozen != test.wantFrozen {
+				t.Fatalf("frozen = %d, want %d", frozen, test.wantFrozen)
+			}
+			if len(spendable) != len(test.wantVouts) {
+				t.Fatalf("got %d spendable outputs, want %d", len(spendable), len(test.wantVouts))
+			}
+			for i, unspent := range spendable {
+				if unspent.TxID != parent || unspent.Vout != test.wantVouts[i] {
+					t.Fatalf("spendable[%d] = %s:%d, want %s:%d", i, unspent.TxID, unspent.Vout, parent, test.wantVouts[i])
+				}
+			}
+		})
+	}
+}
+
+// p2wpkhScript is the script of a pay-to-witness-pubkey-hash output.
+var p2wpkhScript = append([]byte{0x00, 0x14}, bytes.Repeat([]byte{0x01}, 20)...)
+
+func TestReplacementChange(t *testing.T) {
+	// The original paid 10 sat/vB for 200 vbytes. At the default relay fee,
+	// the replacement must pay at least 200 sats more.
+	const oldFee, vSize = 2000, 200
+
+	tests := []struct {
+		name          string
+		change        int64
+		feeRatePerkvB int64
+		wantChange    int64
+		wantErr       bool
+	}{{
+		name:          "higher fee deducted from the change",
+		change:        10000,
+		feeRatePerkvB: 20000,
+		wantChange:    8000,
+	}, {
+		name:          "minimum relay increment",
+		change:        10000,
+		feeRatePerkvB: 11000,
+		wantChange:    9800,
+	}, {
+		name:          "below the minimum relay increment",
+		change:        10000,
+		feeRatePerkvB: 10999,
+		wantErr:       true,
+	}, {
+		name:          "same fee rate",
+		change:        10000,
+		feeRatePerkvB: 10000,
+		wantErr:       true,
+	}, {
+		name:          "change becomes dust",
+		change:        2100,
+		feeRatePerkvB: 20000,
+		wantErr:       true,
+	}, {
+		name:          "change used up",
+		change:        2000,
+		feeRatePerkvB: 20000,
+		wantErr:       true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			change := wire.NewTxOut(test.change, p2wpkhScript)
+			value, err := replacementChange(change, oldFee, vSize, test.feeRatePerkvB)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err = %v, want error %v", err, test.wantErr)
+			}
+			if value != test.wantChange {
+				t.Fatalf("change = %d, want %d", value, test.wantChange)
+			}
+			if change.Value != test.change {
+				t.Fatalf("the change output was modified")
+			}
+		})
+	}
+}
+
+func TestCPFPChildValue(t *testing.T) {
+	// At 10 sat/vB the package of a 200 vbytes parent and a 110 vbytes child
+	// pays 3100 sats, the child alone 1100 sats.
+	const parentVSize, childVSize, feeRatePerkvB = 200, 110, 10000
+
+	tests := []struct {
+		name      string
+		totalIn   int64
+		parentFee int64
+		wantValue int64
+		wantErr   bool
+	}{{
+		name:      "package fee minus parent fee",
+		totalIn:   10000,
+		parentFee: 1000,
+		wantValue: 7900,
+	}, {
+		name:      "unknown parent fee",
+		totalIn:   10000,
+		wantValue: 6900,
+	}, {
+		name:      "parent pays the rate already",
+		totalIn:   10000,
+		parentFee: 5000,
+		wantValue: 8900,
+	}, {
+		name:      "output becomes dust",
+		totalIn:   2200,
+		parentFee: 1000,
+		wantErr:   true,
+	}, {
+		name:      "fee exceeds the inputs",
+		totalIn:   2000,
+		parentFee: 1000,
+		wantErr:   true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := cpfpChildValue(p2wpkhScript, test.totalIn, test.parentFee, parentVSize, childVSize, feeRatePerkvB)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("err = %v, want error %v", err, test.wantErr)
+			}
+			if value != test.wantValue {
+				t.Fatalf("value = %d, want %d", value, test.wantValue)
+			}
+		})
+	}
+}
+
+func TestCPFPAccountOutputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		outputs     []*walletOutput
+		wantAccount uint32
+		wantVouts   []uint32
+	}{{
+		name:        "single account",
+		outputs:     []*walletOutput{{vout: 0, amount: 100, account: 2}, {vout: 1, amount: 50, account: 2}},
+		wantAccount: 2,
+		wantVouts:   []uint32{0, 1},
+	}, {
+		name: "account receiving the most",
+		outputs: []*walletOutput{
+			{vout: 0, amount: 150, account: 0},
+			{vout: 1, amount: 100, account: 1},
+			{vout: 2, amount: 100, account: 1},
+		},
+		wantAccount: 1,
+		wantVouts:   []uint32{1, 2},
+	}, {
+		name:        "tie goes to the lowest account",
+		outputs:     []*walletOutput{{vout: 0, amount: 100, account: 3}, {vout: 1, amount: 100, account: 1}},
+		wantAccount: 1,
+		wantVouts:   []uint32{1},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			account, outputs := cpfpAccountOutputs(test.outputs)
+			if account != test.wantAccount {
+				t.Fatalf("account = %d, want %d", account, test.wantAccount)
+			}
+			if len(outputs) != len(test.wantVouts) {
+				t.Fatalf("got %d outputs, want %d", len(outputs), len(test.wantVouts))
+			}
+			for i, output := range outputs {
+				if output.account != account || output.vout != test.wantVouts[i] {
+					t.Fatalf("outputs[%d] = %d in account %d, want %d", i, output.vout, output.account, test.wantVouts[i])
+				}
+			}
+		})
+	}
+}
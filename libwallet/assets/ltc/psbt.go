@@ -0,0 +1,386 @@
+package ltc
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/wallet"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// psbtDirName is the folder in the wallet's data directory where exported
+// PSBT files are written.
+const psbtDirName = "psbt"
+
+// psbtMagic prefixes every binary serialized PSBT.
+var psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// CreateUnsignedPSBT returns the transaction set up with NewUnsignedTx and
+// AddSendDestination as a base64 encoded BIP174 partially signed transaction.
+// The inputs carry the UTXO and key derivation data an external signer needs,
+// so this also works for watch-only wallets.
+func (asset *Asset) CreateUnsignedPSBT() (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	unsignedTx, err := asset.unsignedTransaction()
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+
+	// If the change output is the only one, no need to change position.
+	if unsignedTx.ChangeIndex > 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+
+	msgTx := unsignedTx.Tx.Copy()
+	// To discourage fee sniping, LockTime is explicitly set in the raw tx.
+	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
+
+	packet, err := psbt.NewFromUnsignedTx(msgTx)
+	if err != nil {
+		log.Errorf("creating psbt failed: %v", err)
+		return "", err
+	}
+
+	if err = asset.Internal().LTC.DecorateInputs(packet, true); err != nil {
+		log.Errorf("adding input info to the psbt failed: %v", err)
+		return "", err
+	}
+
+	return packet.B64Encode()
+}
+
+// ReviewPSBT decodes a PSBT for review before it is signed with SignPSBT. The
+// destinations are decoded from the unsigned transaction itself. The fee is
+// computed from the amounts the wallet has recorded for its own inputs and,
+// for other inputs, from the previous transactions in the packet after
+// checking their hashes, or from their witness UTXOs, whose amounts segwit
+// signatures commit to.
+func (asset *Asset) ReviewPSBT(psbtStr string) (*sharedW.UnsignedTxSummary, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrLTCNotInitialized
+	}
+
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		return nil, err
+	}
+	return asset.psbtSummary(packet)
+}
+
+// SignPSBT adds a partial signature to every input of the PSBT that spends an
+// output of this wallet and returns the updated packet base64 encoded. Inputs
+// belonging to other signers are left untouched so the packet can be passed
+// on to them; it is finalized with FinalizePSBT or PublishPSBT once every
+// signer has signed.
+// reviewedTxHash is the hash of the transaction returned by ReviewPSBT and
+// confirmed by the user, a different transaction is not signed.
+func (asset *Asset) SignPSBT(psbtStr, reviewedTxHash, privatePassphrase string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	if asset.IsWatchingOnlyWallet() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		return "", err
+	}
+
+	summary, err := asset.psbtSummary(packet)
+	if err != nil {
+		return "", err
+	}
+	if summary.TxHash != reviewedTxHash {
+		return "", errors.E(errors.Invalid, "transaction does not match the reviewed transaction")
+	}
+
+	w := asset.Internal().LTC
+	// Fill in the UTXO data of our inputs in case the creator left it out.
+	if err = w.DecorateInputs(packet, false); err != nil {
+		log.Errorf("adding input info to the psbt failed: %v", err)
+		return "", err
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err = w.Unlock([]byte(privatePassphrase), lock)
+	if err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return "", errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	tx := packet.UnsignedTx
+	sigHashes := txscript.NewTxSigHashes(tx, wallet.PsbtPrevOutputFetcher(packet))
+	var signed int
+	for index, txIn := range tx.TxIn {
+		// Inputs the wallet cannot find are for other signers.
+		_, previousTXout, _, _, err := w.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			continue
+		}
+
+		witness, sigScript, err := w.ComputeInputScript(
+			tx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
+		)
+		if err != nil {
+			log.Errorf("generating input signatures failed: %v", err)
+			return "", err
+		}
+
+		outcome, err := addInputSignature(packet, index, witness, sigScript)
+		if err != nil {
+			log.Errorf("adding input signature to the psbt failed: %v", err)
+			return "", err
+		}
+		if outcome == psbt.SignSuccesful {
+			signed++
+		}
+	}
+
+	if signed == 0 {
+		return "", errors.E(errors.Invalid, "psbt has no inputs this wallet can sign")
+	}
+
+	return packet.B64Encode()
+}
+
+// FinalizePSBT builds the final scripts of every input of a PSBT from the
+// partial signatures added by its signers and returns the packet base64
+// encoded. The packet can then be broadcast with PublishPSBT or by any other
+// BIP174 tool.
+func (asset *Asset) FinalizePSBT(psbtStr string) (string, error) {
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		return "", err
+	}
+
+	if err = finalizePSBT(packet); err != nil {
+		return "", err
+	}
+	return packet.B64Encode()
+}
+
+// PublishPSBT finalizes a fully signed PSBT, either one returned by SignPSBT
+// or one signed by an external signer, checks its signatures and broadcasts
+// the extracted transaction.
+func (asset *Asset) PublishPSBT(psbtStr, transactionLabel string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		return "", err
+	}
+
+	if err = finalizePSBT(packet); err != nil {
+		return "", err
+	}
+
+	msgTx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", errors.E(errors.Invalid, fmt.Sprintf("extracting psbt transaction failed: %v", err))
+	}
+
+	if err = verifyPSBTSignatures(packet, msgTx); err != nil {
+		log.Errorf("psbt signature check failed: %v", err)
+		return "", err
+	}
+
+	err = asset.Internal().LTC.PublishTransaction(msgTx, transactionLabel)
+	txHash := msgTx.TxHash()
+	return txHash.String(), utils.TranslateError(err)
+}
+
+// ExportPSBTFile writes the PSBT in binary form to the wallet's data directory
+// and returns the file path.
+func (asset *Asset) ExportPSBTFile(psbtStr string) (string, error) {
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = packet.Serialize(&buf); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(asset.DataDir(), psbtDirName)
+	if err = os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, packet.UnsignedTx.TxHash().String()+".psbt")
+	if err = os.WriteFile(path, buf.Bytes(), utils.UserFilePerm); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// psbtSummary returns the outputs and fee of the unsigned transaction of the
+// packet. An error is returned if the amount of an input cannot be verified.
+func (asset *Asset) psbtSummary(packet *psbt.Packet) (*sharedW.UnsignedTxSummary, error) {
+	w := asset.Internal().LTC
+	tx := packet.UnsignedTx
+	if len(packet.Inputs) != len(tx.TxIn) {
+		return nil, errors.E(errors.Invalid, "psbt inputs do not match its transaction")
+	}
+
+	var totalInput int64
+	for index, txIn := range tx.TxIn {
+		in := &packet.Inputs[index]
+		switch _, prevOut, _, _, err := w.FetchInputInfo(&txIn.PreviousOutPoint); {
+		case err == nil:
+			totalInput += prevOut.Value
+		case in.NonWitnessUtxo != nil:
+			outPoint := txIn.PreviousOutPoint
+			if in.NonWitnessUtxo.TxHash() != outPoint.Hash || int(outPoint.Index) >= len(in.NonWitnessUtxo.TxOut) {
+				return nil, errors.E(errors.Invalid, fmt.Sprintf("previous transaction of input %d does not match", index))
+			}
+			totalInput += in.NonWitnessUtxo.TxOut[outPoint.Index].Value
+		case in.WitnessUtxo != nil:
+			totalInput += in.WitnessUtxo.Value
+		default:
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("amount of input %d is unknown", index))
+		}
+	}
+
+	summary := &sharedW.UnsignedTxSummary{
+		TxHash:  tx.TxHash().String(),
+		Outputs: make([]*sharedW.UnsignedTxOutput, 0, len(tx.TxOut)),
+	}
+	var totalOutput int64
+	for _, txOut := range tx.TxOut {
+		output := &sharedW.UnsignedTxOutput{Amount: txOut.Value}
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) > 0 {
+			output.Address = addrs[0].String()
+			output.Internal, _ = w.HaveAddress(addrs[0])
+		}
+		totalOutput += txOut.Value
+		summary.Outputs = append(summary.Outputs, output)
+	}
+
+	summary.Fee = totalInput - totalOutput
+	if summary.Fee < 0 {
+		return nil, errors.E(errors.Invalid, "transaction outputs exceed its inputs")
+	}
+	return summary, nil
+}
+
+// ReadPSBTFile reads a PSBT file in either base64 or binary form and returns
+// the packet base64 encoded.
+func ReadPSBTFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	isBase64 := !bytes.HasPrefix(data, psbtMagic)
+	if isBase64 {
+		data = bytes.TrimSpace(data)
+	}
+
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(data), isBase64)
+	if err != nil {
+		return "", errors.E(errors.Invalid, fmt.Sprintf("invalid psbt file: %v", err))
+	}
+	return packet.B64Encode()
+}
+
+// decodePSBT decodes a base64 encoded PSBT. Files are read with ReadPSBTFile.
+func decodePSBT(str string) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(str)), true)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid psbt: %v", err))
+	}
+	return packet, nil
+}
+
+// addInputSignature adds the signature the wallet computed for an input to the
+// partial signatures of the packet, leaving the final scripts to the
+// finalizer. An input that is already finalized is left untouched.
+func addInputSignature(packet *psbt.Packet, index int, witness wire.TxWitness, sigScript []byte) (psbt.SignOutcome, error) {
+	pushes, err := txscript.PushedData(sigScript)
+	if err != nil {
+		return psbt.SignInvalid, err
+	}
+
+	var sig, pubKey, redeemScript []byte
+	switch {
+	case len(witness) == 2:
+		// P2WKH, or P2SH-P2WKH whose signature script pushes the redeem
+		// script.
+		sig, pubKey = witness[0], witness[1]
+		if len(pushes) == 1 {
+			redeemScript = pushes[0]
+		}
+	case len(witness) == 0 && len(pushes) == 2:
+		// P2PKH.
+		sig, pubKey = pushes[0], pushes[1]
+	default:
+		return psbt.SignInvalid, errors.E(errors.Invalid, fmt.Sprintf("unsupported script for psbt input %d", index))
+	}
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return psbt.SignInvalid, err
+	}
+	return updater.Sign(index, sig, pubKey, redeemScript, nil)
+}
+
+// finalizePSBT builds the final scripts of the packet inputs from their
+// partial signatures unless the packet is already complete.
+func finalizePSBT(packet *psbt.Packet) error {
+	if packet.IsComplete() {
+		return nil
+	}
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return errors.E(errors.Invalid, fmt.Sprintf("psbt is not fully signed: %v", err))
+	}
+	return nil
+}
+
+// verifyPSBTSignatures executes the script pair of every input of the
+// extracted transaction against the previous outputs recorded in the packet.
+func verifyPSBTSignatures(packet *psbt.Packet, msgTx *wire.MsgTx) error {
+	prevOutFetcher := wallet.PsbtPrevOutputFetcher(packet)
+	sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
+	flags := txscript.StandardVerifyFlags
+	for index, txIn := range msgTx.TxIn {
+		prevOut := prevOutFetcher.FetchPrevOutput(txIn.PreviousOutPoint)
+		if prevOut == nil {
+			return fmt.Errorf("psbt input %d has no previous output data", index)
+		}
+
+		vm, err := txscript.NewEngine(prevOut.PkScript, msgTx, index, flags, nil,
+			sigHashes, prevOut.Value, prevOutFetcher)
+		if err != nil {
+			return err
+		}
+		if err = vm.Execute(); err != nil {
+			return fmt.Errorf("psbt input %d: %w", index, err)
+		}
+	}
+	return nil
+}
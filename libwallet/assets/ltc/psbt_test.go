@@ -0,0 +1,198 @@
+package ltc
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ltcsuite/ltcd/btcec/v2"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// testSigner is a P2WKH key owned by one of the signers of a PSBT.
+type testSigner struct {
+	key      *btcec.PrivateKey
+	pkScript []byte
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	key, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyHash := ltcutil.Hash160(key.PubKey().SerializeCompressed())
+	addr, err := ltcutil.NewAddressWitnessPubKeyHash(pubKeyHash, &chaincfg.RegressionNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testSigner{key: key, pkScript: pkScript}
+}
+
+// sign adds the signature of the signer for input index of the base64
+// encoded packet and returns the updated packet, as a wallet handing the
+// packet on to the next signer would.
+func (s *testSigner) sign(t *testing.T, psbtStr string, index int) string {
+	t.Helper()
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := packet.UnsignedTx
+	prevOut := packet.Inputs[index].WitnessUtxo
+	sigHashes := txscript.NewTxSigHashes(tx, txscript.NewCannedPrevOutputFetcher(prevOut.PkScript, prevOut.Value))
+	witness, err := txscript.WitnessSignature(tx, sigHashes, index, prevOut.Value, prevOut.PkScript,
+		txscript.SigHashAll, s.key, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outcome, err := addInputSignature(packet, index, witness, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome != psbt.SignSuccesful {
+		t.Fatalf("signing input %d: outcome %d", index, outcome)
+	}
+
+	in := packet.Inputs[index]
+	if len(in.PartialSigs) != 1 || len(in.FinalScriptWitness) != 0 || len(in.FinalScriptSig) != 0 {
+		t.Fatalf("input %d: want one partial signature and no final scripts", index)
+	}
+
+	psbtStr, err = packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return psbtStr
+}
+
+// newTestPSBT returns a base64 encoded PSBT spending one output of each
+// signer, with the UTXO data the creator adds to its inputs.
+func newTestPSBT(t *testing.T, signers ...*testSigner) string {
+	t.Helper()
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+	for _, signer := range signers {
+		prevTx.AddTxOut(wire.NewTxOut(1e6, signer.pkScript))
+	}
+	prevHash := prevTx.TxHash()
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for i := range signers {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, uint32(i)), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(int64(len(signers))*1e6-1000, signers[0].pkScript))
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range packet.Inputs {
+		packet.Inputs[i].NonWitnessUtxo = prevTx
+		packet.Inputs[i].WitnessUtxo = prevTx.TxOut[i]
+	}
+
+	psbtStr, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return psbtStr
+}
+
+func TestPSBTSignAndFinalize(t *testing.T) {
+	alice, bob := newTestSigner(t), newTestSigner(t)
+	psbtStr := newTestPSBT(t, alice, bob)
+
+	psbtStr = alice.sign(t, psbtStr, 0)
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = finalizePSBT(packet); err == nil {
+		t.Fatal("finalized a psbt missing a signature")
+	}
+
+	psbtStr = bob.sign(t, psbtStr, 1)
+	packet, err = decodePSBT(psbtStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = finalizePSBT(packet); err != nil {
+		t.Fatalf("finalizing the signed psbt: %v", err)
+	}
+	if !packet.IsComplete() {
+		t.Fatal("finalized psbt is not complete")
+	}
+
+	msgTx, err := psbt.Extract(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = verifyPSBTSignatures(packet, msgTx); err != nil {
+		t.Fatalf("verifying the finalized psbt: %v", err)
+	}
+
+	// A finalized input is not signed again.
+	outcome, err := addInputSignature(packet, 0, msgTx.TxIn[0].Witness, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome != psbt.SignFinalized {
+		t.Fatalf("signing a finalized input: outcome %d", outcome)
+	}
+}
+
+func TestAddInputSignatureUnsupportedScript(t *testing.T) {
+	signer := newTestSigner(t)
+	packet, err := decodePSBT(newTestPSBT(t, signer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = addInputSignature(packet, 0, wire.TxWitness{{0x01}, {0x02}, {0x03}}, nil); err == nil {
+		t.Fatal("added a signature from an unsupported witness")
+	}
+}
+
+func TestReadPSBTFile(t *testing.T) {
+	psbtStr := newTestPSBT(t, newTestSigner(t))
+	packet, err := decodePSBT(psbtStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var binary bytes.Buffer
+	if err = packet.Serialize(&binary); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"base64.psbt": []byte(psbtStr + "\n"),
+		"binary.psbt": binary.Bytes(),
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err = os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ReadPSBTFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got != psbtStr {
+			t.Fatalf("%s: read a different psbt", name)
+		}
+	}
+}
@@ -338,7 +338,9 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 		ConnectPeers:  validPeerAddresses,
 		AddPeers:      asset.setSeedPeers(),
 		// Dailer function helps to better control the dailer functionality.
-		Dialer: utils.DialerFunc(asset.dailerCtx),
+		Dialer: utils.DialerFunc(asset.dailerCtx, utils.WalletIsolationID(asset.ID)),
+		// Resolve the DNS seeds through the proxy when one is set.
+		NameResolver: utils.ProxyLookupIP,
 		// WARNING: PublishTransaction currently uses the entire duration
 		// because if an external bug, but even if the resolved, a typical
 		// inv/getdata round trip is ~4 seconds, so we set this so neutrino does
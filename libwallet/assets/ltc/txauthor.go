@@ -270,7 +270,6 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		unsignedTx.RandomizeChangePosition()
 	}
 
-	// Test encode and decode the tx to check its validity after being signed.
 	msgTx := unsignedTx.Tx
 
 	lock := make(chan time.Time, 1)
@@ -289,16 +288,27 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
 	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
 
+	if err := asset.signTransaction(msgTx); err != nil {
+		return "", err
+	}
+
+	err = asset.Internal().LTC.PublishTransaction(msgTx, transactionLabel)
+	txHash := msgTx.TxHash()
+	return txHash.String(), utils.TranslateError(err)
+}
+
+// signTransaction signs every input of msgTx, which must all be spending
+// wallet outputs, and checks the signed tx for validity. The wallet must be
+// unlocked.
+func (asset *Asset) signTransaction(msgTx *wire.MsgTx) error {
 	for index, txIn := range msgTx.TxIn {
 		_, previousTXout, _, _, err := asset.Internal().LTC.FetchInputInfo(&txIn.PreviousOutPoint)
 		if err != nil {
 			log.Errorf("fetch previous outpoint txout failed: %v", err)
-			return "", err
+			return err
 		}
 
-		prevOutScript := unsignedTx.PrevScripts[index]
-		prevOutAmount := int64(asset.TxAuthoredInfo.inputValues[index])
-		prevOutFetcher := txscript.NewCannedPrevOutputFetcher(prevOutScript, prevOutAmount)
+		prevOutFetcher := txscript.NewCannedPrevOutputFetcher(previousTXout.PkScript, previousTXout.Value)
 		sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
 
 		witness, signature, err := asset.Internal().LTC.ComputeInputScript(
@@ -306,7 +316,7 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		)
 		if err != nil {
 			log.Errorf("generating input signatures failed: %v", err)
-			return "", err
+			return err
 		}
 
 		msgTx.TxIn[index].Witness = witness
@@ -316,36 +326,33 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		// script pair.
 		flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
 			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
-		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, 0, flags, nil, nil,
-			prevOutAmount, prevOutFetcher)
+		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, index, flags, nil, nil,
+			previousTXout.Value, prevOutFetcher)
 		if err != nil {
 			log.Errorf("creating validation engine failed: %v", err)
-			return "", err
+			return err
 		}
 		if err := vm.Execute(); err != nil {
 			log.Errorf("executing the validation engine failed: %v", err)
-			return "", err
+			return err
 		}
 	}
 
+	// Test encode and decode the tx to check its validity after being signed.
 	var serializedTransaction bytes.Buffer
 	serializedTransaction.Grow(msgTx.SerializeSize())
-	err = msgTx.Serialize(&serializedTransaction)
-	if err != nil {
+	if err := msgTx.Serialize(&serializedTransaction); err != nil {
 		log.Errorf("encoding the tx to test its validity failed: %v", err)
-		return "", err
+		return err
 	}
 
-	err = msgTx.Deserialize(bytes.NewReader(serializedTransaction.Bytes()))
-	if err != nil {
+	if err := msgTx.Deserialize(bytes.NewReader(serializedTransaction.Bytes())); err != nil {
 		// Invalid tx
 		log.Errorf("decoding the tx to test its validity failed: %v", err)
-		return "", err
+		return err
 	}
 
-	err = asset.Internal().LTC.PublishTransaction(msgTx, transactionLabel)
-	txHash := msgTx.TxHash()
-	return txHash.String(), utils.TranslateError(err)
+	return nil
 }
 
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
@@ -444,6 +451,10 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 		return nil, fmt.Errorf("change txOut validation failed %v", err)
 	}
 
+	if asset.IsRBFSignalingEnabled() {
+		signalReplacement(unsignedTx.Tx)
+	}
+
 	return unsignedTx, nil
 }
 
@@ -529,8 +540,8 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 	// validates the utxo amounts and if an invalid amount is discovered an
 	// error is returned.
 	for _, output := range outputs {
-		// Ignore unspendable utxos
-		if !output.Spendable {
+		// Ignore unspendable and frozen utxos
+		if !output.Spendable || asset.IsOutputFrozen(output.TxID, output.Vout) {
 			continue
 		}
 
@@ -2,7 +2,9 @@ package wallet
 
 import (
 	"context"
+	"io"
 
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
@@ -76,6 +78,18 @@ type Asset interface {
 	GetWalletBalance() (*Balance, error)
 	UnspentOutputs(account int32) ([]*UnspentOutput, error)
 
+	FrozenOutputs() []*FrozenOutput
+	FrozenOutput(txID string, vout uint32) *FrozenOutput
+	IsOutputFrozen(txID string, vout uint32) bool
+	FreezeOutput(txID string, vout uint32, label, reason string) error
+	UnfreezeOutput(txID string, vout uint32) error
+
+	SetLabel(labelType walletdata.LabelType, ref, label string) error
+	Label(labelType walletdata.LabelType, ref string) string
+	Labels(labelType walletdata.LabelType) ([]*walletdata.Label, error)
+	ExportLabels(w io.Writer) error
+	ImportLabels(r io.Reader) (int, error)
+
 	AddSyncProgressListener(syncProgressListener *SyncProgressListener, uniqueIdentifier string) error
 	RemoveSyncProgressListener(uniqueIdentifier string)
 	AddTxAndBlockNotificationListener(txAndBlockNotificationListener *TxAndBlockNotificationListener, uniqueIdentifier string) error
@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// OutputLabelRef returns the BIP329 reference of a transaction output.
+func OutputLabelRef(txID string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txID, vout)
+}
+
+// SetLabel attaches a label to a transaction, address, output or xpub of the
+// wallet. An empty label removes the existing label. Transaction labels are
+// also written to the indexed transaction so the transaction list shows them.
+func (wallet *Wallet) SetLabel(labelType walletdata.LabelType, ref, label string) error {
+	if wallet.walletDataDB == nil {
+		return errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	if err := wallet.walletDataDB.SetLabel(labelType, ref, label); err != nil {
+		return err
+	}
+
+	if labelType == walletdata.LabelTypeTx {
+		err := wallet.walletDataDB.UpdateTxLabel(&Transaction{}, walletdata.NormalizeLabelRef(labelType, ref), strings.TrimSpace(label))
+		if err != nil && err != storm.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Label returns the label of a transaction, address, output or xpub of the
+// wallet. An empty string is returned if the item has no label.
+func (wallet *Wallet) Label(labelType walletdata.LabelType, ref string) string {
+	if wallet.walletDataDB == nil {
+		return ""
+	}
+
+	label := wallet.walletDataDB.Label(labelType, ref)
+	if label == "" && labelType == walletdata.LabelTypeTx {
+		// Labels set when broadcasting predate the labels store.
+		tx := new(Transaction)
+		if err := wallet.walletDataDB.FindOne("Hash", walletdata.NormalizeLabelRef(labelType, ref), tx); err == nil {
+			label = tx.Label
+		}
+	}
+	return label
+}
+
+// Labels returns the labels of the provided type, or all the labels of the
+// wallet if labelType is empty.
+func (wallet *Wallet) Labels(labelType walletdata.LabelType) ([]*walletdata.Label, error) {
+	if wallet.walletDataDB == nil {
+		return nil, errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	labels, err := wallet.walletDataDB.Labels(labelType)
+	if err != nil {
+		return nil, err
+	}
+
+	if labelType != "" && labelType != walletdata.LabelTypeTx {
+		return labels, nil
+	}
+
+	// Include the labels given to transactions when they were broadcast.
+	labelled := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		labelled[label.ID] = true
+	}
+
+	var txs []*Transaction
+	if err := wallet.walletDataDB.Find(q.Not(q.Eq("Label", "")), &txs); err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		id := walletdata.LabelID(walletdata.LabelTypeTx, tx.Hash)
+		if labelled[id] {
+			continue
+		}
+		labels = append(labels, &walletdata.Label{
+			ID:    id,
+			Type:  walletdata.LabelTypeTx,
+			Ref:   tx.Hash,
+			Label: tx.Label,
+		})
+	}
+	return labels, nil
+}
+
+// ExportLabels writes all the labels of the wallet to w as BIP329 JSON Lines.
+func (wallet *Wallet) ExportLabels(w io.Writer) error {
+	labels, err := wallet.Labels("")
+	if err != nil {
+		return err
+	}
+	return walletdata.WriteBIP329(w, labels)
+}
+
+// ImportLabels reads BIP329 JSON Lines from r and stores the labels in the
+// wallet, replacing the existing labels of the same items. It returns the
+// number of labels imported.
+func (wallet *Wallet) ImportLabels(r io.Reader) (int, error) {
+	if wallet.walletDataDB == nil {
+		return 0, errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	labels, err := walletdata.ReadBIP329(r)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := wallet.walletDataDB.ImportLabels(labels)
+	if err != nil {
+		return count, err
+	}
+
+	for _, label := range labels {
+		if label.Type != walletdata.LabelTypeTx || strings.TrimSpace(label.Label) == "" {
+			continue
+		}
+		err := wallet.walletDataDB.UpdateTxLabel(&Transaction{}, walletdata.NormalizeLabelRef(label.Type, label.Ref), label.Label)
+		if err != nil && err != storm.ErrNotFound {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// AccountLabel returns the label of an account. Account labels are stored
+// against the xpub of the account as BIP329 defines.
+func AccountLabel(asset Asset, account int32) string {
+	xpub, err := asset.GetExtendedPubKey(account)
+	if err != nil {
+		return ""
+	}
+	return asset.Label(walletdata.LabelTypeXpub, xpub)
+}
+
+// SetAccountLabel attaches a label to an account of the asset.
+func SetAccountLabel(asset Asset, account int32, label string) error {
+	xpub, err := asset.GetExtendedPubKey(account)
+	if err != nil {
+		return err
+	}
+	return asset.SetLabel(walletdata.LabelTypeXpub, xpub, label)
+}
@@ -21,6 +21,24 @@ type AssetAmount interface {
 	ToInt() int64
 }
 
+// UnsignedTxSummary is what a transaction about to be signed pays, decoded
+// from the transaction itself so it can be reviewed before it is signed.
+type UnsignedTxSummary struct {
+	TxHash  string
+	Outputs []*UnsignedTxOutput
+	// Fee is the total of the inputs, as verified by the wallet, less the
+	// total of the outputs.
+	Fee int64
+}
+
+// UnsignedTxOutput is an output of an UnsignedTxSummary.
+type UnsignedTxOutput struct {
+	Address string
+	Amount  int64
+	// Internal is true if the output pays to the wallet itself.
+	Internal bool
+}
+
 // WConfig defines options for configuring wallet behaviour.
 // This is a subset of the config used by dcrwallet.
 type WConfig struct {
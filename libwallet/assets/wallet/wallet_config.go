@@ -11,6 +11,7 @@ const (
 	AccountMixerMixedAccount   = "account_mixer_mixed_account"
 	AccountMixerUnmixedAccount = "account_mixer_unmixed_account"
 	AccountMixerMixTxChange    = "account_mixer_mix_tx_change"
+	AccountMixerScheduleKey    = "account_mixer_schedule"
 
 	walletsMetadataBucketName = "metadata" // Wallet level bucket.
 
@@ -18,6 +19,7 @@ const (
 	PrivacyModeConfigKey        = "privacy_mode"
 	SpendUnconfirmedConfigKey   = "spend_unconfirmed"
 	CurrencyConversionConfigKey = "currency_conversion_option"
+	FiatCurrencyConfigKey       = "fiat_currency"
 
 	IsStartupSecuritySetConfigKey = "startup_security_set"
 	StartupSecurityTypeConfigKey  = "startup_security_type"
@@ -30,6 +32,8 @@ const (
 	NetworkModeConfigKey                = "network_mode"
 	SpvPersistentPeerAddressesConfigKey = "spv_peer_addresses"
 	UserAgentConfigKey                  = "user_agent"
+	ProxyConfigKey                      = "proxy_config"
+	BackendConfigKey                    = "backend_config"
 
 	PoliteiaNotificationConfigKey = "politeia_notification"
 
@@ -42,6 +46,16 @@ const (
 	TicketBuyerAccountConfigKey = "tb_account_number"
 	TicketBuyerATMConfigKey     = "tb_amount_to_maintain"
 
+	TicketBuyerFallbackVSPsConfigKey     = "tb_fallback_vsp_hosts"
+	TicketBuyerMaxPerIntervalConfigKey   = "tb_max_tickets_per_interval"
+	TicketBuyerMaxPerDayConfigKey        = "tb_max_tickets_per_day"
+	TicketBuyerMaxTicketPriceConfigKey   = "tb_max_ticket_price"
+	TicketBuyerMaxVSPFeePercentConfigKey = "tb_max_vsp_fee_percent"
+	TicketBuyerMinVSPUptimeConfigKey     = "tb_min_vsp_uptime"
+
+	SoloStakingConfigKey = "solo_staking"
+	SoloVotingConfigKey  = "solo_voting_dcrd_rpc"
+
 	ExchangeSourceDstnTypeConfigKey = "exchange_source_destination_key"
 
 	HideBalanceConfigKey             = "hide_balance"
@@ -55,6 +69,9 @@ const (
 	DarkModeConfigKey                = "dark_mode"
 	HideTotalBalanceConfigKey        = "hideTotalUSDBalance"
 	IsCEXFirstVisitConfigKey         = "is_cex_first_visit"
+	RBFSignalingConfigKey            = "rbf_signaling"
+	FrozenOutputsConfigKey           = "frozen_outputs"
+	KnownTSpendsConfigKey            = "known_tspends"
 
 	PassphraseTypePin  int32 = 0
 	PassphraseTypePass int32 = 1
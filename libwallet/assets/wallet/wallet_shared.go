@@ -60,6 +60,11 @@ type Wallet struct {
 	isCancelDone chan struct{} // waits until all cancelFuncs functions run.
 	cancelFuncs  []context.CancelFunc
 
+	// frozenOutputs caches the outputs excluded from coin selection, keyed
+	// by outpoint. It is loaded from the wallet config on first use.
+	frozenOutputs   map[string]*FrozenOutput
+	frozenOutputsMu sync.RWMutex
+
 	mu sync.RWMutex
 }
 
@@ -0,0 +1,229 @@
+package walletdata
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// LabelType is the kind of wallet item a label is attached to. The values
+// match the record types defined by BIP329.
+// See: https://github.com/bitcoin/bips/blob/master/bip-0329.mediawiki
+type LabelType string
+
+const (
+	LabelTypeTx      LabelType = "tx"
+	LabelTypeAddress LabelType = "addr"
+	LabelTypePubKey  LabelType = "pubkey"
+	LabelTypeInput   LabelType = "input"
+	LabelTypeOutput  LabelType = "output"
+	// LabelTypeXpub labels an extended public key. Account labels are stored
+	// under the xpub of the account so they survive a move to another wallet.
+	LabelTypeXpub LabelType = "xpub"
+)
+
+// maxBIP329LineSize is the largest BIP329 record that is accepted on import.
+const maxBIP329LineSize = 1 << 20
+
+// ErrInvalidLabelType is returned for a label type not defined by BIP329.
+var ErrInvalidLabelType = errors.New("invalid label type")
+
+// IsValid returns true if the label type is defined by BIP329.
+func (t LabelType) IsValid() bool {
+	switch t {
+	case LabelTypeTx, LabelTypeAddress, LabelTypePubKey, LabelTypeInput,
+		LabelTypeOutput, LabelTypeXpub:
+		return true
+	}
+	return false
+}
+
+// Label is a user note attached to a transaction, address, output or xpub.
+type Label struct {
+	ID   string    `storm:"id" json:"id"`
+	Type LabelType `storm:"index" json:"type"`
+	Ref  string    `json:"ref"`
+	// Label is the user note. It is only empty for a stored label that
+	// records whether an output is spendable.
+	Label string `json:"label"`
+	// Origin is the key origin (descriptor) of the wallet the label was
+	// imported from, if any.
+	Origin string `json:"origin,omitempty"`
+	// Spendable is only meaningful for output labels, nil means unknown.
+	Spendable *bool `json:"spendable,omitempty"`
+}
+
+// bip329Record is a single line of a BIP329 labels file.
+type bip329Record struct {
+	Type      LabelType `json:"type"`
+	Ref       string    `json:"ref"`
+	Label     string    `json:"label,omitempty"`
+	Origin    string    `json:"origin,omitempty"`
+	Spendable *bool     `json:"spendable,omitempty"`
+}
+
+// LabelID returns the storage key of the label of the provided item.
+func LabelID(labelType LabelType, ref string) string {
+	return string(labelType) + ":" + NormalizeLabelRef(labelType, ref)
+}
+
+// NormalizeLabelRef lowercases hex refs so the same item always maps to the
+// same label. Addresses and xpubs are case sensitive and kept as they are.
+func NormalizeLabelRef(labelType LabelType, ref string) string {
+	ref = strings.TrimSpace(ref)
+	switch labelType {
+	case LabelTypeTx, LabelTypeInput, LabelTypeOutput, LabelTypePubKey:
+		return strings.ToLower(ref)
+	}
+	return ref
+}
+
+// SetLabel attaches label to the provided item, replacing any existing
+// label. An empty label removes the existing label. The origin and spendable
+// flag of an imported label are kept.
+func (db *DB) SetLabel(labelType LabelType, ref, label string) error {
+	newLabel := &Label{Type: labelType, Ref: ref, Label: label}
+	var existing Label
+	if err := db.walletDataDB.One("ID", LabelID(labelType, ref), &existing); err == nil {
+		newLabel.Origin = existing.Origin
+		newLabel.Spendable = existing.Spendable
+	}
+	return db.saveLabel(newLabel)
+}
+
+func (db *DB) saveLabel(label *Label) error {
+	if !label.Type.IsValid() {
+		return ErrInvalidLabelType
+	}
+
+	label.Ref = NormalizeLabelRef(label.Type, label.Ref)
+	if label.Ref == "" {
+		return errors.New("label reference is required")
+	}
+
+	label.ID = LabelID(label.Type, label.Ref)
+	label.Label = strings.TrimSpace(label.Label)
+	if label.Label == "" && label.Spendable == nil {
+		err := db.walletDataDB.DeleteStruct(&Label{ID: label.ID})
+		if err != nil && err != storm.ErrNotFound {
+			return fmt.Errorf("error deleting label: %w", err)
+		}
+		return nil
+	}
+
+	if err := db.walletDataDB.Save(label); err != nil {
+		return fmt.Errorf("error saving label: %w", err)
+	}
+	return nil
+}
+
+// Label returns the label of the provided item or an empty string if the
+// item has no label.
+func (db *DB) Label(labelType LabelType, ref string) string {
+	var label Label
+	if err := db.walletDataDB.One("ID", LabelID(labelType, ref), &label); err != nil {
+		return ""
+	}
+	return label.Label
+}
+
+// Labels returns the stored labels of the provided type sorted by reference.
+// All labels are returned if labelType is empty.
+func (db *DB) Labels(labelType LabelType) ([]*Label, error) {
+	var labels []*Label
+	var err error
+	if labelType == "" {
+		err = db.walletDataDB.All(&labels)
+	} else {
+		err = db.walletDataDB.Select(q.Eq("Type", labelType)).Find(&labels)
+	}
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].ID < labels[j].ID
+	})
+	return labels, nil
+}
+
+// WriteBIP329 writes labels to w in the BIP329 JSON Lines format.
+func WriteBIP329(w io.Writer, labels []*Label) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, label := range labels {
+		record := bip329Record{
+			Type:      label.Type,
+			Ref:       label.Ref,
+			Label:     label.Label,
+			Origin:    label.Origin,
+			Spendable: label.Spendable,
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBIP329 reads the labels of a BIP329 JSON Lines file. Records of types
+// not defined by BIP329 and records without a reference are skipped, as the
+// specification requires. The label is optional, records without one are
+// kept, e.g. outputs that are only marked as spendable or not.
+func ReadBIP329(r io.Reader) ([]*Label, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBIP329LineSize)
+
+	labels := make([]*Label, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record bip329Record
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("invalid label record on line %d: %w", line, err)
+		}
+
+		if !record.Type.IsValid() || strings.TrimSpace(record.Ref) == "" {
+			continue
+		}
+
+		labels = append(labels, &Label{
+			Type:      record.Type,
+			Ref:       record.Ref,
+			Label:     record.Label,
+			Origin:    record.Origin,
+			Spendable: record.Spendable,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// ImportLabels stores the provided labels, replacing the existing labels of
+// the same items. Labels with neither a note nor a spendable flag carry
+// nothing to store and are skipped. It returns the number of labels stored.
+func (db *DB) ImportLabels(labels []*Label) (int, error) {
+	var count int
+	for _, label := range labels {
+		if strings.TrimSpace(label.Label) == "" && label.Spendable == nil {
+			continue
+		}
+		if err := db.saveLabel(label); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,152 @@
+package walletdata
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testTx struct {
+	Hash      string `storm:"id,unique"`
+	Timestamp int64  `storm:"index"`
+	Label     string
+}
+
+func TestBIP329RoundTrip(t *testing.T) {
+	spendable := false
+	labels := []*Label{
+		{Type: LabelTypeTx, Ref: "f91d0a8a78462bc59398f2c5d7a84fcff491c26ba54c4833478b202796c8aafd", Label: "Transaction", Origin: "wpkh([d34db33f/84'/0'/0'])"},
+		{Type: LabelTypeAddress, Ref: "bc1q34aq5drpuwy3wgl9lhup9892qp6svr8ldzyy7c", Label: "Address"},
+		{Type: LabelTypeOutput, Ref: "f91d0a8a78462bc59398f2c5d7a84fcff491c26ba54c4833478b202796c8aafd:0", Label: "Output", Spendable: &spendable},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBIP329(&buf, labels); err != nil {
+		t.Fatalf("WriteBIP329: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(labels) {
+		t.Fatalf("expected %d lines, got %d", len(labels), len(lines))
+	}
+	if strings.Contains(lines[0], `"id"`) {
+		t.Fatalf("storage id must not be exported: %s", lines[0])
+	}
+
+	read, err := ReadBIP329(&buf)
+	if err != nil {
+		t.Fatalf("ReadBIP329: %v", err)
+	}
+	if len(read) != len(labels) {
+		t.Fatalf("expected %d labels, got %d", len(labels), len(read))
+	}
+	for i, label := range read {
+		want := labels[i]
+		if label.Type != want.Type || label.Ref != want.Ref || label.Label != want.Label || label.Origin != want.Origin {
+			t.Fatalf("label %d: got %+v, want %+v", i, label, want)
+		}
+	}
+	if read[2].Spendable == nil || *read[2].Spendable {
+		t.Fatalf("spendable flag was not preserved")
+	}
+}
+
+func TestReadBIP329SkipsInvalidRecords(t *testing.T) {
+	input := `{"type":"tx","ref":"abc","label":"kept"}
+
+{"type":"unknown","ref":"abc","label":"skipped"}
+{"type":"addr","ref":"addr1"}
+{"type":"addr","ref":" ","label":"no ref"}
+`
+	labels, err := ReadBIP329(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadBIP329: %v", err)
+	}
+	if len(labels) != 2 || labels[0].Label != "kept" || labels[1].Ref != "addr1" || labels[1].Label != "" {
+		t.Fatalf("unexpected labels: %+v", labels)
+	}
+
+	if _, err := ReadBIP329(strings.NewReader("not json\n")); err == nil {
+		t.Fatalf("expected an error for a malformed record")
+	}
+}
+
+func TestSetLabel(t *testing.T) {
+	db, err := Initialize(filepath.Join(t.TempDir(), "labels.db"), &testTx{})
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.SetLabel("bogus", "ref", "label"); err != ErrInvalidLabelType {
+		t.Fatalf("expected ErrInvalidLabelType, got %v", err)
+	}
+
+	if err := db.SetLabel(LabelTypeTx, "ABCDEF", " Rent "); err != nil {
+		t.Fatalf("SetLabel: %v", err)
+	}
+	if label := db.Label(LabelTypeTx, "abcdef"); label != "Rent" {
+		t.Fatalf("expected label Rent, got %q", label)
+	}
+
+	if err := db.SetLabel(LabelTypeAddress, "addr1", "Savings"); err != nil {
+		t.Fatalf("SetLabel: %v", err)
+	}
+	labels, err := db.Labels(LabelTypeAddress)
+	if err != nil || len(labels) != 1 {
+		t.Fatalf("expected one address label, got %d (%v)", len(labels), err)
+	}
+
+	if err := db.SetLabel(LabelTypeTx, "abcdef", ""); err != nil {
+		t.Fatalf("SetLabel: %v", err)
+	}
+	if label := db.Label(LabelTypeTx, "abcdef"); label != "" {
+		t.Fatalf("expected label to be removed, got %q", label)
+	}
+
+	all, err := db.Labels("")
+	if err != nil || len(all) != 1 {
+		t.Fatalf("expected one label, got %d (%v)", len(all), err)
+	}
+}
+
+func TestImportSpendableOnlyLabel(t *testing.T) {
+	db, err := Initialize(filepath.Join(t.TempDir(), "labels.db"), &testTx{})
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer db.Close()
+
+	input := `{"type":"output","ref":"ABCDEF:1","spendable":false}
+{"type":"addr","ref":"addr1"}
+`
+	labels, err := ReadBIP329(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadBIP329: %v", err)
+	}
+	count, err := db.ImportLabels(labels)
+	if err != nil || count != 1 {
+		t.Fatalf("expected one label imported, got %d (%v)", count, err)
+	}
+
+	outputs, err := db.Labels(LabelTypeOutput)
+	if err != nil || len(outputs) != 1 {
+		t.Fatalf("expected one output label, got %d (%v)", len(outputs), err)
+	}
+	if outputs[0].Ref != "abcdef:1" || outputs[0].Spendable == nil || *outputs[0].Spendable {
+		t.Fatalf("unexpected output label: %+v", outputs[0])
+	}
+
+	// Setting and clearing a note keeps the spendable flag.
+	if err := db.SetLabel(LabelTypeOutput, "abcdef:1", "Change"); err != nil {
+		t.Fatalf("SetLabel: %v", err)
+	}
+	if err := db.SetLabel(LabelTypeOutput, "abcdef:1", ""); err != nil {
+		t.Fatalf("SetLabel: %v", err)
+	}
+	outputs, err = db.Labels(LabelTypeOutput)
+	if err != nil || len(outputs) != 1 || outputs[0].Spendable == nil || outputs[0].Label != "" {
+		t.Fatalf("expected the spendable flag to be kept, got %+v (%v)", outputs, err)
+	}
+}
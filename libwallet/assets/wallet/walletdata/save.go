@@ -42,6 +42,18 @@ func (db *DB) SaveOrUpdate(emptyTxPointer, record interface{}) (overwritten bool
 	return
 }
 
+// UpdateTxLabel replaces the label of the indexed transaction with the
+// provided hash. storm.ErrNotFound is returned if the transaction has not
+// been indexed yet.
+func (db *DB) UpdateTxLabel(emptyTxPointer interface{}, txHash, label string) error {
+	if err := db.walletDataDB.One("Hash", txHash, emptyTxPointer); err != nil {
+		return err
+	}
+
+	reflect.ValueOf(emptyTxPointer).Elem().FieldByName("Label").SetString(label)
+	return db.walletDataDB.Save(emptyTxPointer)
+}
+
 func (db *DB) SaveOrUpdateVspdRecord(emptyTxPointer, record interface{}) (updated bool, err error) {
 	v := reflect.ValueOf(record)
 	txHash := reflect.Indirect(v).FieldByName("Hash").String()
@@ -5,6 +5,7 @@ import (
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 
@@ -174,6 +175,39 @@ func (mgr *AssetsManager) ExchangeRateFetchingEnabled() bool {
 	return xc != "" && xc != values.DefaultExchangeValue
 }
 
+// FiatCurrency returns the fiat currency balances and rates are displayed in.
+func (mgr *AssetsManager) FiatCurrency() string {
+	var currency string
+	mgr.ReadAppConfigValue(sharedW.FiatCurrencyConfigKey, &currency)
+	if !ext.IsFiatCurrency(currency) {
+		return ext.USDCurrency
+	}
+	return currency
+}
+
+// SetFiatCurrency sets the fiat currency balances and rates are displayed in.
+func (mgr *AssetsManager) SetFiatCurrency(currency string) {
+	if !ext.IsFiatCurrency(currency) {
+		log.Errorf("fiat currency %s is not supported", currency)
+		return
+	}
+	mgr.SaveAppConfigValue(sharedW.FiatCurrencyConfigKey, currency)
+
+	if mgr.ExchangeRateFetchingEnabled() {
+		go mgr.RateSource.Refresh(false)
+	}
+}
+
+// FiatMarket returns the market of assetType quoted in the selected fiat
+// currency.
+func (mgr *AssetsManager) FiatMarket(assetType utils.AssetType) (values.Market, error) {
+	usdtMarket, exist := values.AssetExchangeMarketValue[assetType]
+	if !exist {
+		return values.UnknownMarket, fmt.Errorf("unsupported asset type: %s", assetType)
+	}
+	return values.NewMarket(usdtMarket.AssetString(), mgr.FiatCurrency()), nil
+}
+
 // GetLanguagePreference returns the language preference.
 func (mgr *AssetsManager) GetLanguagePreference() string {
 	var lang string
@@ -217,6 +251,7 @@ func (mgr *AssetsManager) SetPrivacyMode(isActive bool) {
 	if !isActive && mgr.GetCurrencyConversionExchange() != values.DefaultExchangeValue {
 		go mgr.RateSource.Refresh(true)
 	}
+	mgr.updateTicketHealthMonitors()
 }
 
 // IsPrivacyModeOn checks if the privacy mode is set.
@@ -231,6 +266,9 @@ func (mgr *AssetsManager) IsPrivacyModeOn() bool {
 func (mgr *AssetsManager) SetHTTPAPIPrivacyMode(apiType utils.HTTPAPIType, isActive bool) {
 	dataKey := genKey(sharedW.PrivacyModeConfigKey, apiType)
 	mgr.SaveAppConfigValue(dataKey, isActive)
+	if apiType == utils.VspAPI {
+		mgr.updateTicketHealthMonitors()
+	}
 }
 
 // IsHTTPAPIPrivacyModeOff returns true if the given API type is enabled and false
@@ -242,6 +280,53 @@ func (mgr *AssetsManager) IsHTTPAPIPrivacyModeOff(apiType utils.HTTPAPIType) boo
 	return data && !mgr.IsPrivacyModeOn()
 }
 
+// ProxyConfig returns the saved SOCKS5 proxy configuration or nil if no proxy
+// is set.
+func (mgr *AssetsManager) ProxyConfig() *utils.ProxyConfig {
+	cfg := new(utils.ProxyConfig)
+	mgr.ReadAppConfigValue(sharedW.ProxyConfigKey, cfg)
+	if cfg.Host == "" {
+		return nil
+	}
+	return cfg
+}
+
+// SetProxyConfig saves the SOCKS5 proxy all the network traffic is sent
+// through, a nil cfg removes it. It applies to the connections made from now
+// on, the DEX client uses it after a restart.
+func (mgr *AssetsManager) SetProxyConfig(cfg *utils.ProxyConfig) {
+	if cfg == nil {
+		mgr.appConfigDelete(sharedW.ProxyConfigKey)
+	} else {
+		mgr.SaveAppConfigValue(sharedW.ProxyConfigKey, cfg)
+	}
+	utils.SetProxyConfig(cfg)
+}
+
+// BackendConfig returns the saved self-hosted block explorers.
+func (mgr *AssetsManager) BackendConfig() utils.BackendConfig {
+	var cfg utils.BackendConfig
+	mgr.ReadAppConfigValue(sharedW.BackendConfigKey, &cfg)
+	return cfg
+}
+
+// SetBackendConfig checks that the block explorers in cfg are reachable and
+// saves them. They are used for the fee rates, the dcrdata API and the
+// transaction links of their assets instead of the public explorers.
+func (mgr *AssetsManager) SetBackendConfig(cfg utils.BackendConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.IsEmpty() {
+		mgr.appConfigDelete(sharedW.BackendConfigKey)
+	} else {
+		mgr.SaveAppConfigValue(sharedW.BackendConfigKey, cfg)
+	}
+	utils.SetBackendConfig(cfg)
+	return nil
+}
+
 // GetLogLevels returns the log levels.
 func (mgr *AssetsManager) GetLogLevels() string {
 	var logLevel string
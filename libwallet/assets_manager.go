@@ -19,11 +19,13 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/values"
 	bolt "go.etcd.io/bbolt"
 
+	"github.com/crypto-power/cryptopower/libwallet/addressbook"
 	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
@@ -67,6 +69,8 @@ type AssetsManager struct {
 	ConsensusAgenda *dcr.ConsensusAgenda
 	Politeia        *politeia.Politeia
 	InstantSwap     *instantswap.InstantSwap
+	AddressBook     *addressbook.AddressBook
+	PriceHistory    *pricehistory.Store
 	ExternalService *ext.Service
 	RateSource      ext.RateSource
 	rateMutex       sync.Mutex
@@ -184,8 +188,22 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 
 	mgr.params.DB = mwDB
 	mgr.Politeia = politeia
+
+	// Apply the saved proxy and block explorers before any connection is made.
+	utils.SetProxyConfig(mgr.ProxyConfig())
+	utils.SetBackendConfig(mgr.BackendConfig())
 	mgr.InstantSwap = instantSwap
 
+	mgr.AddressBook, err = addressbook.New(mwDB, netType, mgr.IsAddressValid)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.PriceHistory, err = pricehistory.New(mwDB, mgr.fetchDailyPrices)
+	if err != nil {
+		return nil, err
+	}
+
 	// initialize the ExternalService. ExternalService provides assetsManager
 	// with the functionalities to retrieve data from some 3rd party services.
 	mgr.ExternalService = ext.NewService(string(netType))
@@ -206,6 +224,9 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 	}
 
 	mgr.listenForShutdown()
+	mgr.startProposalWatcher()
+	mgr.startAgendaTallyTracker()
+	mgr.updateTicketHealthMonitors()
 
 	return mgr, nil
 }
@@ -758,8 +779,12 @@ func (mgr *AssetsManager) AllAssetTypes() []utils.AssetType {
 }
 
 // BlockExplorerURLForTx returns a URL for viewing a transaction on the block
-// explorer of the specified asset.
+// explorer of the specified asset, the self-hosted one if it is set.
 func (mgr *AssetsManager) BlockExplorerURLForTx(assetType utils.AssetType, txHash string) string {
+	if explorerURL := utils.GetBackendConfig().ExplorerURL(assetType); explorerURL != "" {
+		return explorerURL + "/tx/" + txHash
+	}
+
 	var isMainnet bool
 	switch mgr.NetType() {
 	case utils.Mainnet:
@@ -860,12 +885,14 @@ func (mgr *AssetsManager) CalculateTotalAssetsBalance(includeWatchWallet bool) (
 	return assetsTotalBalance, nil
 }
 
-func (mgr *AssetsManager) CalculateAssetsUSDBalance(balances map[utils.AssetType]sharedW.AssetAmount) (map[utils.AssetType]float64, error) {
+// CalculateAssetsFiatBalance converts the balances to the selected fiat
+// currency.
+func (mgr *AssetsManager) CalculateAssetsFiatBalance(balances map[utils.AssetType]sharedW.AssetAmount) (map[utils.AssetType]float64, error) {
 	if !mgr.ExchangeRateFetchingEnabled() {
-		return nil, fmt.Errorf("the USD exchange rate is disabled")
+		return nil, fmt.Errorf("the fiat exchange rate is disabled")
 	}
 
-	usdBalance := func(bal sharedW.AssetAmount, market values.Market) (float64, error) {
+	fiatBalance := func(bal sharedW.AssetAmount, market values.Market) (float64, error) {
 		rate := mgr.RateSource.GetTicker(market, true)
 		if rate == nil || rate.LastTradePrice <= 0 {
 			return 0, fmt.Errorf("no rate information available")
@@ -874,20 +901,20 @@ func (mgr *AssetsManager) CalculateAssetsUSDBalance(balances map[utils.AssetType
 		return bal.MulF64(rate.LastTradePrice).ToCoin(), nil
 	}
 
-	assetsTotalUSDBalance := make(map[utils.AssetType]float64)
+	assetsTotalFiatBalance := make(map[utils.AssetType]float64)
 	for assetType, balance := range balances {
-		marketValue, exist := values.AssetExchangeMarketValue[assetType]
-		if !exist {
-			return nil, fmt.Errorf("unsupported asset type: %s", assetType)
+		market, err := mgr.FiatMarket(assetType)
+		if err != nil {
+			return nil, err
 		}
-		usdBal, err := usdBalance(balance, marketValue)
+		fiatBal, err := fiatBalance(balance, market)
 		if err != nil {
 			return nil, err
 		}
-		assetsTotalUSDBalance[assetType] = usdBal
+		assetsTotalFiatBalance[assetType] = fiatBal
 	}
 
-	return assetsTotalUSDBalance, nil
+	return assetsTotalFiatBalance, nil
 }
 
 // DexClient returns a dexc client that MUST never be modified.
@@ -928,8 +955,15 @@ func (mgr *AssetsManager) InitializeDEX(ctx context.Context) {
 	// wallets when it starts.
 	setDEXWalletLoader(mgr.WalletWithID)
 
+	// The DEX client cannot send proxy credentials.
+	torProxy, torIsolation, err := utils.GetProxyConfig().NoAuthProxy()
+	if err != nil {
+		log.Errorf("Error starting dex client: %v", err)
+		return
+	}
+
 	logDir := filepath.Dir(mgr.LogFile())
-	dexClient, err := dexc.Start(ctx, mgr.RootDir(), mgr.GetLanguagePreference(), logDir, mgr.GetLogLevels(), mgr.NetType(), 0 /* TODO: Make configurable */)
+	dexClient, err := dexc.Start(ctx, mgr.RootDir(), mgr.GetLanguagePreference(), logDir, mgr.GetLogLevels(), mgr.NetType(), 0 /* TODO: Make configurable */, torProxy, torIsolation)
 	if err != nil {
 		log.Errorf("Error starting dex client: %v", err)
 		return
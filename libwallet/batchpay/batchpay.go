@@ -0,0 +1,175 @@
+// Package batchpay reads the payments of a batch send from a CSV file with
+// one "address,amount[,label]" row per recipient. Amounts are in coins, e.g.
+// 0.5 for half a DCR, BTC or LTC.
+package batchpay
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crypto-power/cryptopower/libwallet/paymenturi"
+)
+
+// MaxPayments is the largest number of payments accepted in a single batch,
+// it keeps the resulting transaction well under the standard size limit.
+const MaxPayments = 500
+
+var (
+	// ErrNoPayments is returned for a file without any payment row.
+	ErrNoPayments = errors.New("the file has no payments")
+	// ErrTooManyPayments is returned for a file with more than MaxPayments
+	// payment rows.
+	ErrTooManyPayments = fmt.Errorf("a batch can have at most %d payments", MaxPayments)
+
+	ErrMissingAddress   = errors.New("address is missing")
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrDuplicateAddress = errors.New("address is paid more than once")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrAmountTooSmall   = errors.New("amount must be greater than zero")
+	ErrAmountTooLarge   = errors.New("amount is too large")
+	ErrInvalidRow       = errors.New("a row must have an address, an amount and an optional label")
+)
+
+// Payment is a single row of a batch payment file.
+type Payment struct {
+	// Line is the line of the file the payment was read from.
+	Line    int
+	Address string
+	// Amount is in the smallest unit of the asset.
+	Amount int64
+	Label  string
+	// Err is set if the row failed validation.
+	Err error
+}
+
+// Options configures the validation of the payments.
+type Options struct {
+	// IsAddressValid reports whether the address belongs to the network of
+	// the sending wallet.
+	IsAddressValid func(address string) bool
+	// MaxAmount is the largest amount allowed for a single payment in the
+	// smallest unit of the asset. Zero means no limit.
+	MaxAmount int64
+}
+
+// Read parses and validates the payments of a CSV file. An optional header
+// row is skipped. Rows failing validation are returned with Err set so that
+// every problem can be reported at once. A non-nil error is only returned if
+// the file itself cannot be used.
+func Read(r io.Reader, opts Options) ([]*Payment, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	payments := make([]*Payment, 0)
+	seen := make(map[string]bool)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isEmptyRow(row) || (len(payments) == 0 && isHeader(row)) {
+			continue
+		}
+
+		payment := parseRow(row, line, opts)
+		if payment.Err == nil {
+			if seen[payment.Address] {
+				payment.Err = ErrDuplicateAddress
+			}
+			seen[payment.Address] = true
+		}
+
+		payments = append(payments, payment)
+		if len(payments) > MaxPayments {
+			return nil, ErrTooManyPayments
+		}
+	}
+
+	if len(payments) == 0 {
+		return nil, ErrNoPayments
+	}
+	return payments, nil
+}
+
+func parseRow(row []string, line int, opts Options) *Payment {
+	payment := &Payment{Line: line}
+	if len(row) < 2 || len(row) > 3 {
+		payment.Err = ErrInvalidRow
+		return payment
+	}
+
+	payment.Address = strings.TrimSpace(row[0])
+	if len(row) == 3 {
+		payment.Label = strings.TrimSpace(row[2])
+	}
+
+	switch {
+	case payment.Address == "":
+		payment.Err = ErrMissingAddress
+		return payment
+	case opts.IsAddressValid != nil && !opts.IsAddressValid(payment.Address):
+		payment.Err = ErrInvalidAddress
+		return payment
+	}
+
+	amount, err := paymenturi.ParseAmount(strings.TrimSpace(row[1]))
+	switch {
+	case err != nil:
+		payment.Err = ErrInvalidAmount
+	case amount <= 0:
+		payment.Err = ErrAmountTooSmall
+	case opts.MaxAmount > 0 && amount > opts.MaxAmount:
+		payment.Err = ErrAmountTooLarge
+	}
+	payment.Amount = amount
+	return payment
+}
+
+func isEmptyRow(row []string) bool {
+	for _, field := range row {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isHeader returns true for a header row such as "address,amount,label".
+func isHeader(row []string) bool {
+	if len(row) < 2 {
+		return false
+	}
+	_, err := paymenturi.ParseAmount(strings.TrimSpace(row[1]))
+	return err != nil && strings.EqualFold(strings.TrimSpace(row[0]), "address")
+}
+
+// Valid returns true if none of the payments failed validation.
+func Valid(payments []*Payment) bool {
+	for _, payment := range payments {
+		if payment.Err != nil {
+			return false
+		}
+	}
+	return len(payments) > 0
+}
+
+// Total returns the sum of the amounts of the valid payments.
+func Total(payments []*Payment) int64 {
+	var total int64
+	for _, payment := range payments {
+		if payment.Err == nil {
+			total += payment.Amount
+		}
+	}
+	return total
+}
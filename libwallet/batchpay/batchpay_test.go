@@ -0,0 +1,83 @@
+package batchpay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func isAddressValid(address string) bool {
+	return strings.HasPrefix(address, "addr")
+}
+
+func TestRead(t *testing.T) {
+	input := `address,amount,label
+addr1,0.5,Alice
+
+# bonus payouts
+addr2, 1.25 ,"Bob, bounty"
+addr3,0.000000001
+bogus,1
+addr1,2
+addr4,0
+addr5,100
+addr6,1,label,extra
+`
+	payments, err := Read(strings.NewReader(input), Options{IsAddressValid: isAddressValid, MaxAmount: 50 * 1e8})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	tests := []struct {
+		line    int
+		address string
+		amount  int64
+		label   string
+		err     error
+	}{
+		{2, "addr1", 50000000, "Alice", nil},
+		{5, "addr2", 125000000, "Bob, bounty", nil},
+		{6, "addr3", 0, "", ErrInvalidAmount},
+		{7, "bogus", 0, "", ErrInvalidAddress},
+		{8, "addr1", 200000000, "", ErrDuplicateAddress},
+		{9, "addr4", 0, "", ErrAmountTooSmall},
+		{10, "addr5", 10000000000, "", ErrAmountTooLarge},
+		{11, "", 0, "", ErrInvalidRow},
+	}
+	if len(payments) != len(tests) {
+		t.Fatalf("expected %d payments, got %d", len(tests), len(payments))
+	}
+	for i, test := range tests {
+		p := payments[i]
+		if p.Line != test.line || p.Address != test.address || p.Amount != test.amount || p.Label != test.label || !errors.Is(p.Err, test.err) {
+			t.Errorf("payment %d: got %+v, want %+v", i, p, test)
+		}
+	}
+
+	if Valid(payments) {
+		t.Fatalf("payments with errors must not be valid")
+	}
+	if total := Total(payments); total != 175000000 {
+		t.Fatalf("expected a total of 175000000, got %d", total)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	if _, err := Read(strings.NewReader("address,amount\n\n"), Options{}); err != ErrNoPayments {
+		t.Fatalf("expected ErrNoPayments, got %v", err)
+	}
+
+	var sb strings.Builder
+	for i := 0; i <= MaxPayments; i++ {
+		fmt.Fprintf(&sb, "addr%d,1\n", i)
+	}
+	if _, err := Read(strings.NewReader(sb.String()), Options{}); err != ErrTooManyPayments {
+		t.Fatalf("expected ErrTooManyPayments, got %v", err)
+	}
+
+	payments, err := Read(strings.NewReader("addr1,1\naddr2,2"), Options{IsAddressValid: isAddressValid})
+	if err != nil || !Valid(payments) {
+		t.Fatalf("expected valid payments, got %v", err)
+	}
+}
@@ -40,6 +40,7 @@ func (mgr *AssetsManager) CreateNewDCRWallet(walletName, privatePassphrase strin
 
 	// Allow spending from the default account by default.
 	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
+	mgr.updateTicketHealthMonitor(wallet)
 
 	return wallet, nil
 }
@@ -55,6 +56,7 @@ func (mgr *AssetsManager) CreateNewDCRWatchOnlyWallet(walletName, extendedPublic
 
 	// Allow spending from the default account by default.
 	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
+	mgr.updateTicketHealthMonitor(wallet)
 
 	return wallet, nil
 }
@@ -76,6 +78,7 @@ func (mgr *AssetsManager) RestoreDCRWallet(walletName, seedMnemonic, privatePass
 
 	// Allow spending from the default account by default.
 	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
+	mgr.updateTicketHealthMonitor(wallet)
 
 	return wallet, nil
 }
@@ -199,9 +199,9 @@ func btcCloneWalletConstructor(isLtc bool) func(settings map[string]string, chai
 		}
 
 		if isLtc {
-			return ltc.NewDEXWallet(wallet.Internal().LTC, accountNumber, wallet.(*ltc.Asset).NeutrinoClient(), chainParams, wallet), nil
+			return ltc.NewDEXWallet(wallet.Internal().LTC, accountNumber, wallet.(*ltc.Asset).NeutrinoClient(), chainParams, wallet, wallet), nil
 		}
 
-		return btc.NewDEXWallet(wallet.Internal().BTC, accountNumber, wallet.(*btc.Asset).NeutrinoClient(), wallet), nil
+		return btc.NewDEXWallet(wallet.Internal().BTC, accountNumber, wallet.(*btc.Asset).NeutrinoClient(), wallet, wallet), nil
 	}
 }
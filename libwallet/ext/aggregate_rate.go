@@ -0,0 +1,247 @@
+package ext
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	// maxSourceDeviation is the largest relative difference from the median
+	// price a source may return without its price being discarded.
+	maxSourceDeviation = 0.03
+
+	// MinTickerConfidence is the lowest confidence a ticker must have for its
+	// price to be acted on, e.g. to check the rate of an exchange order.
+	MinTickerConfidence = 0.5
+)
+
+// aggregateSources are the rate sources queried by the aggregate rate source.
+var aggregateSources = []string{
+	binance,
+	binanceUS,
+	kucoinExchange,
+	coinpaprika,
+	messari,
+}
+
+// aggregateSourceGroup returns the group of an aggregate source. Sources of
+// the same group serve different regions, e.g. binance and binanceUS, and are
+// counted once in the confidence of a ticker.
+func aggregateSourceGroup(source string) string {
+	if source == binanceUS {
+		return binance
+	}
+	return source
+}
+
+// aggregateSourceGroupCount is the number of groups of the aggregate
+// sources. The confidence of a ticker is measured against every group, so
+// a source that cannot be reached lowers it rather than leaving fewer
+// sources to agree with.
+var aggregateSourceGroupCount = func() int {
+	groups := make(map[string]bool)
+	for _, source := range aggregateSources {
+		groups[aggregateSourceGroup(source)] = true
+	}
+	return len(groups)
+}()
+
+// SourceStatus is the state of a rate source queried by the aggregate rate
+// source for a market.
+type SourceStatus struct {
+	Source string
+	// LastPrice is the last price returned by the source.
+	LastPrice float64
+	// LastUpdate is when the source last returned a price that was not
+	// discarded.
+	LastUpdate time.Time
+	// Outlier is true if LastPrice deviated too much from the median price.
+	Outlier bool
+	// Err is the error of the last request to the source, if it failed.
+	Err error
+}
+
+// Stale returns true if the source has not returned a price that was used
+// since the rates expired.
+func (s *SourceStatus) Stale() bool {
+	return time.Since(s.LastUpdate) > rateExpiry
+}
+
+// LowConfidence returns true if too few sources agree with the price of the
+// ticker for it to be acted on.
+func (t *Ticker) LowConfidence() bool {
+	return t.Confidence < MinTickerConfidence
+}
+
+// SourceStatuses returns the state of the sources queried for the market by
+// the aggregate rate source. Nothing is returned for other rate sources.
+func (cs *CommonRateSource) SourceStatuses(market values.Market) []*SourceStatus {
+	marketName, ok := isSupportedMarket(market, cs.source)
+	if !ok {
+		return nil
+	}
+
+	cs.sourceStatusMtx.RLock()
+	defer cs.sourceStatusMtx.RUnlock()
+	statuses := make([]*SourceStatus, 0, len(cs.sourceStatuses[marketName]))
+	for _, source := range aggregateSources {
+		if status, ok := cs.sourceStatuses[marketName][source]; ok {
+			statusCopy := *status
+			statuses = append(statuses, &statusCopy)
+		}
+	}
+	return statuses
+}
+
+// aggregateTickerFunc returns the function to fetch the ticker of a source
+// queried by the aggregate rate source. Unlike sourceGetTickerFunc, none of
+// the functions caches tickers.
+func aggregateTickerFunc(source string) tickerFunc {
+	switch source {
+	case binance:
+		return binanceGetTicker
+	case binanceUS:
+		return binanceUSGetTicker
+	case kucoinExchange:
+		return kucoinGetTicker
+	case coinpaprika:
+		return coinpaprikaGetMarketTicker
+	case messari:
+		return messariGetTicker
+	default:
+		return nil
+	}
+}
+
+// aggregateGetTicker queries all the aggregate sources concurrently and
+// returns a ticker with the median price of the sources that agree with each
+// other. An error is only returned if no source returned a price.
+func (cs *CommonRateSource) aggregateGetTicker(market values.Market) (*Ticker, error) {
+	tickers := make([]*Ticker, len(aggregateSources))
+	errs := make([]error, len(aggregateSources))
+	var wg sync.WaitGroup
+	for i, source := range aggregateSources {
+		wg.Add(1)
+		go func(i int, getTicker tickerFunc) {
+			defer wg.Done()
+			tickers[i], errs[i] = getTicker(market)
+			if errs[i] == nil && (tickers[i] == nil || tickers[i].LastTradePrice <= 0) {
+				errs[i] = fmt.Errorf("no %s price", market)
+			}
+		}(i, aggregateTickerFunc(source))
+	}
+	wg.Wait()
+
+	sourceTickers := make(map[string]*Ticker, len(tickers))
+	for i, source := range aggregateSources {
+		if errs[i] == nil {
+			sourceTickers[source] = tickers[i]
+		}
+	}
+
+	ticker, outliers := combineTickers(market, sourceTickers, aggregateSourceGroupCount)
+	cs.updateSourceStatuses(market, sourceTickers, outliers, errs)
+	if ticker == nil {
+		return nil, fmt.Errorf("%s: no source returned a price for %s", aggregate, market)
+	}
+	return ticker, nil
+}
+
+// updateSourceStatuses records the result of querying the aggregate sources
+// for the market.
+func (cs *CommonRateSource) updateSourceStatuses(market values.Market, tickers map[string]*Ticker, outliers map[string]bool, errs []error) {
+	cs.sourceStatusMtx.Lock()
+	defer cs.sourceStatusMtx.Unlock()
+
+	statuses, ok := cs.sourceStatuses[market]
+	if !ok {
+		statuses = make(map[string]*SourceStatus, len(aggregateSources))
+		cs.sourceStatuses[market] = statuses
+	}
+
+	now := time.Now()
+	for i, source := range aggregateSources {
+		status, ok := statuses[source]
+		if !ok {
+			status = &SourceStatus{Source: source}
+			statuses[source] = status
+		}
+
+		status.Err = errs[i]
+		if ticker, ok := tickers[source]; ok {
+			status.LastPrice = ticker.LastTradePrice
+			status.Outlier = outliers[source]
+			if !status.Outlier {
+				status.LastUpdate = now
+			}
+		}
+
+		if status.Err != nil {
+			log.Warnf("%s: %s: %v", aggregate, source, status.Err)
+		} else if status.Outlier {
+			log.Warnf("%s: discarded %s price %f from %s", aggregate, market, status.LastPrice, source)
+		}
+	}
+}
+
+// combineTickers returns a ticker with the median price and change of the
+// tickers within maxSourceDeviation of their median price, and the sources
+// whose tickers were discarded. The confidence of the ticker is the share of
+// the source groups whose tickers were used, out of groups. Returns a nil
+// ticker if there are no tickers.
+func combineTickers(market values.Market, tickers map[string]*Ticker, groups int) (*Ticker, map[string]bool) {
+	if len(tickers) == 0 || groups == 0 {
+		return nil, nil
+	}
+
+	prices := make([]float64, 0, len(tickers))
+	for _, ticker := range tickers {
+		prices = append(prices, ticker.LastTradePrice)
+	}
+	median := medianOf(prices)
+
+	outliers := make(map[string]bool)
+	acceptedGroups := make(map[string]bool)
+	var accepted, changes []float64
+	for source, ticker := range tickers {
+		if math.Abs(ticker.LastTradePrice-median)/median > maxSourceDeviation {
+			outliers[source] = true
+			continue
+		}
+		acceptedGroups[aggregateSourceGroup(source)] = true
+		accepted = append(accepted, ticker.LastTradePrice)
+		if ticker.PriceChangePercent != nil {
+			changes = append(changes, *ticker.PriceChangePercent)
+		}
+	}
+
+	ticker := &Ticker{
+		Market:         market.String(),
+		LastTradePrice: median,
+		Confidence:     math.Min(1, float64(len(acceptedGroups))/float64(groups)),
+		lastUpdate:     time.Now(),
+	}
+	if len(accepted) > 0 {
+		ticker.LastTradePrice = medianOf(accepted)
+	}
+	if len(changes) > 0 {
+		change := medianOf(changes)
+		ticker.PriceChangePercent = &change
+	}
+	return ticker, outliers
+}
+
+// medianOf returns the median of the values, values is sorted in place.
+func medianOf(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
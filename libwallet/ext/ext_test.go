@@ -1,8 +1,10 @@
 package ext
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	apiTypes "github.com/decred/dcrdata/v8/api/types"
 	"github.com/decred/dcrdata/v8/db/dbtypes"
@@ -608,3 +611,299 @@ func TestGetAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFiatTicker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result":"success","base_code":"USD","rates":{"USD":1,"EUR":0.9,"NGN":1500}}`))
+	}))
+	defer server.Close()
+	defaultOpenERAPIURL := openERAPIURL
+	t.Cleanup(func() { openERAPIURL = defaultOpenERAPIURL })
+	openERAPIURL = server.URL
+
+	rateSource, err := NewCommonRateSource(context.Background(), binance, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rateSource.tickers[values.DCRUSDTMarket] = &Ticker{
+		Market:         values.DCRUSDTMarket.String(),
+		LastTradePrice: 20,
+		lastUpdate:     time.Now(),
+	}
+
+	tests := []struct {
+		name          string
+		market        values.Market
+		cacheOnly     bool
+		expectedPrice float64
+	}{
+		{name: "usd", market: values.NewMarket("DCR", USDCurrency), cacheOnly: true, expectedPrice: 20},
+		{name: "fiat rates not fetched", market: values.NewMarket("DCR", "EUR"), cacheOnly: true},
+		{name: "eur", market: values.NewMarket("DCR", "EUR"), expectedPrice: 18},
+		{name: "ngn cached", market: values.NewMarket("DCR", "NGN"), cacheOnly: true, expectedPrice: 30000},
+		{name: "unsupported currency", market: values.NewMarket("DCR", "XYZ"), cacheOnly: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := rateSource.GetTicker(tc.market, tc.cacheOnly)
+			if tc.expectedPrice == 0 {
+				if ticker != nil {
+					t.Errorf("(%v), expected no ticker, got (%v)", tc.name, ticker.LastTradePrice)
+				}
+				return
+			}
+			if ticker == nil {
+				t.Fatalf("(%v), expected a ticker", tc.name)
+			}
+			if math.Abs(ticker.LastTradePrice-tc.expectedPrice) > 1e-9 {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expectedPrice, ticker.LastTradePrice)
+			}
+			if ticker.Market != tc.market.String() {
+				t.Errorf("(%v), expected market (%v), got (%v)", tc.name, tc.market, ticker.Market)
+			}
+		})
+	}
+}
+
+func TestGetDailyFiatRates(t *testing.T) {
+	var requestURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURL = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"base":"USD","rates":{"2024-01-08":{"EUR":0.92},"2024-01-04":{"EUR":0.9},"2024-01-05":{"EUR":0.91}}}`))
+	}))
+	defer server.Close()
+	defaultSeriesURL := frankfurterSeriesURL
+	t.Cleanup(func() { frankfurterSeriesURL = defaultSeriesURL })
+	frankfurterSeriesURL = server.URL + "/%s..%s?from=USD&to=%s"
+
+	rateSource, err := NewCommonRateSource(context.Background(), binance, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(date string) int64 {
+		d, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.Unix()
+	}
+
+	tests := []struct {
+		name            string
+		from, to        string
+		expectedRequest string
+		expected        map[int64]float64
+	}{
+		{
+			name:            "weekend gets the friday rate",
+			from:            "2024-01-05",
+			to:              "2024-01-09",
+			expectedRequest: "/2023-12-29..2024-01-09?from=USD&to=EUR",
+			expected: map[int64]float64{
+				day("2024-01-05"): 0.91, day("2024-01-06"): 0.91, day("2024-01-07"): 0.91,
+				day("2024-01-08"): 0.92, day("2024-01-09"): 0.92,
+			},
+		},
+		{
+			name:            "days before the first rate are skipped",
+			from:            "2024-01-03",
+			to:              "2024-01-04",
+			expectedRequest: "/2023-12-27..2024-01-04?from=USD&to=EUR",
+			expected:        map[int64]float64{day("2024-01-04"): 0.9},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := time.Unix(day(tc.from), 0), time.Unix(day(tc.to), 0)
+			prices, err := rateSource.GetDailyOHLC(FiatRateMarket("eur"), from, to)
+			if err != nil {
+				t.Fatalf("(%v), unexpected error: %v", tc.name, err)
+			}
+			if requestURL != tc.expectedRequest {
+				t.Errorf("(%v), expected request (%v), got (%v)", tc.name, tc.expectedRequest, requestURL)
+			}
+			if len(prices) != len(tc.expected) {
+				t.Fatalf("(%v), expected (%v) days, got (%v)", tc.name, len(tc.expected), len(prices))
+			}
+			for i, price := range prices {
+				if i > 0 && price.Time <= prices[i-1].Time {
+					t.Errorf("(%v), expected the days in order", tc.name)
+				}
+				if rate, ok := tc.expected[price.Time]; !ok || price.Close != rate || price.Open != rate {
+					t.Errorf("(%v), expected rate (%v) on (%v), got (%v)", tc.name, rate, price.Time, price.Close)
+				}
+			}
+		})
+	}
+
+	if _, err := rateSource.GetDailyOHLC(FiatRateMarket("NGN"), time.Now(), time.Now()); err == nil {
+		t.Error("expected no rate history for a currency the ECB does not publish")
+	}
+}
+
+func TestCombineTickers(t *testing.T) {
+	newTicker := func(price float64) *Ticker {
+		return &Ticker{Market: values.DCRUSDTMarket.String(), LastTradePrice: price}
+	}
+
+	tests := []struct {
+		name               string
+		tickers            map[string]*Ticker
+		expectedPrice      float64
+		expectedConfidence float64
+		expectedOutliers   []string
+	}{
+		{
+			name: "all agree",
+			tickers: map[string]*Ticker{
+				binance: newTicker(20), kucoinExchange: newTicker(20.2), coinpaprika: newTicker(19.9), messari: newTicker(20.1),
+			},
+			expectedPrice:      20.05,
+			expectedConfidence: 1,
+		},
+		{
+			name: "regional sources counted once",
+			tickers: map[string]*Ticker{
+				binance: newTicker(20), binanceUS: newTicker(20.1), kucoinExchange: newTicker(20.2),
+			},
+			expectedPrice:      20.1,
+			expectedConfidence: 0.5,
+		},
+		{
+			name: "outlier discarded",
+			tickers: map[string]*Ticker{
+				binance: newTicker(20), kucoinExchange: newTicker(20.2), coinpaprika: newTicker(2), messari: newTicker(20.1),
+			},
+			expectedPrice:      20.1,
+			expectedConfidence: 0.75,
+			expectedOutliers:   []string{coinpaprika},
+		},
+		{
+			name: "single source",
+			tickers: map[string]*Ticker{
+				kucoinExchange: newTicker(20),
+			},
+			expectedPrice:      20,
+			expectedConfidence: 0.25,
+		},
+		{
+			name: "single region of binance",
+			tickers: map[string]*Ticker{
+				binance: newTicker(20), binanceUS: newTicker(20.1),
+			},
+			expectedPrice:      20.05,
+			expectedConfidence: 0.25,
+		},
+		{
+			name: "sources disagree",
+			tickers: map[string]*Ticker{
+				binance: newTicker(10), messari: newTicker(20),
+			},
+			expectedPrice:    15,
+			expectedOutliers: []string{binance, messari},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker, outliers := combineTickers(values.DCRUSDTMarket, tc.tickers, aggregateSourceGroupCount)
+			if ticker == nil {
+				t.Fatalf("(%v), expected a ticker", tc.name)
+			}
+			if math.Abs(ticker.LastTradePrice-tc.expectedPrice) > 1e-9 {
+				t.Errorf("(%v), expected price (%v), got (%v)", tc.name, tc.expectedPrice, ticker.LastTradePrice)
+			}
+			if math.Abs(ticker.Confidence-tc.expectedConfidence) > 1e-9 {
+				t.Errorf("(%v), expected confidence (%v), got (%v)", tc.name, tc.expectedConfidence, ticker.Confidence)
+			}
+			if ticker.LowConfidence() != (tc.expectedConfidence < MinTickerConfidence) {
+				t.Errorf("(%v), expected low confidence (%v), got (%v)", tc.name, tc.expectedConfidence < MinTickerConfidence, ticker.LowConfidence())
+			}
+			if len(outliers) != len(tc.expectedOutliers) {
+				t.Errorf("(%v), expected outliers (%v), got (%v)", tc.name, tc.expectedOutliers, outliers)
+			}
+			for _, source := range tc.expectedOutliers {
+				if !outliers[source] {
+					t.Errorf("(%v), expected %s to be an outlier", tc.name, source)
+				}
+			}
+		})
+	}
+
+	if ticker, _ := combineTickers(values.DCRUSDTMarket, nil, aggregateSourceGroupCount); ticker != nil {
+		t.Errorf("expected no ticker without source tickers")
+	}
+}
+
+func TestSelfHostedDcrdata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/block/best/height" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`700000`))
+	}))
+	defer server.Close()
+
+	cfg := utils.BackendConfig{DCRData: server.URL + "/"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected the dcrdata instance to pass the health check, got (%v)", err)
+	}
+	if err := (utils.BackendConfig{DCRData: server.URL + "/missing"}).Validate(); err == nil {
+		t.Errorf("expected the health check to fail")
+	}
+	if err := (utils.BackendConfig{BTCEsplora: "ftp://example.org"}).Validate(); err == nil {
+		t.Errorf("expected a non-http URL to be rejected")
+	}
+
+	utils.SetBackendConfig(cfg)
+	defer utils.SetBackendConfig(utils.BackendConfig{})
+	if height := service.GetBestBlock(); height != 700000 {
+		t.Errorf("expected the best block from the dcrdata instance, got (%v)", height)
+	}
+}
+
+func TestSelfHostedBlockBook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2":
+			w.Write([]byte(`{"blockbook":{"bestHeight":700000}}`))
+		case "/api/v2/address/DsTxPUVFxXeNgu5fzozr4mTR4tqqMaKcvpY":
+			w.Write([]byte(`{"address":"DsTxPUVFxXeNgu5fzozr4mTR4tqqMaKcvpY","txs":17}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	defer utils.SetBackendConfig(utils.BackendConfig{})
+
+	// Only dcrdata is self-hosted, addresses must not reach the public
+	// BlockBook instance.
+	utils.SetBackendConfig(utils.BackendConfig{DCRData: server.URL})
+	if _, err := service.GetAddress("DsTxPUVFxXeNgu5fzozr4mTR4tqqMaKcvpY"); !errors.Is(err, utils.ErrNoSelfHostedBlockBook) {
+		t.Errorf("expected (%v), got (%v)", utils.ErrNoSelfHostedBlockBook, err)
+	}
+	if _, err := service.GetXpub("dpubZF6ScrXjYgqT9AZS1RgjmdNVtRv5Zd3LUjBrPqjXtRUQ6tFhDXvP1mfFh3kQm6dRX9pmB6aLxAWc2NavkGqQNvkWnCPiUmLx5nvkyhwDZPS"); !errors.Is(err, utils.ErrNoSelfHostedBlockBook) {
+		t.Errorf("expected (%v), got (%v)", utils.ErrNoSelfHostedBlockBook, err)
+	}
+
+	cfg := utils.BackendConfig{DCRData: server.URL, DCRBlockBook: server.URL + "/"}
+	if err := (utils.BackendConfig{DCRBlockBook: server.URL + "/missing"}).Validate(); err == nil {
+		t.Errorf("expected the BlockBook health check to fail")
+	}
+	utils.SetBackendConfig(cfg)
+	state, err := service.GetAddress("DsTxPUVFxXeNgu5fzozr4mTR4tqqMaKcvpY")
+	if err != nil {
+		t.Fatalf("expected the address from the BlockBook instance, got (%v)", err)
+	}
+	if state.Txs != 17 {
+		t.Errorf("expected 17 transactions, got (%v)", state.Txs)
+	}
+}
@@ -0,0 +1,266 @@
+package ext
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// USDCurrency is the default fiat currency. The crypto-currency rates are
+// USDT prices and USDT is taken at par with USD.
+const USDCurrency = "USD"
+
+// SupportedFiatCurrencies lists the fiat currencies balances can be displayed
+// in.
+var SupportedFiatCurrencies = []string{
+	USDCurrency, "EUR", "GBP", "JPY", "CNY", "CAD", "AUD", "CHF", "INR", "BRL",
+	"MXN", "NGN", "ZAR", "KES", "KRW", "TRY",
+}
+
+var (
+	// ExchangeRate-API's open access endpoint needs no API key and is updated
+	// once a day, which is good enough for display. See:
+	// https://www.exchangerate-api.com/docs/free
+	openERAPIURL = "https://open.er-api.com/v6/latest/USD"
+	// Frankfurter publishes the ECB reference rates, it is used when the
+	// first source fails but does not cover every supported currency. See:
+	// https://www.frankfurter.app/docs/
+	frankfurterURL = "https://api.frankfurter.app/latest?from=USD"
+	// The dated ECB rates of the business days from the first to the second
+	// date.
+	frankfurterSeriesURL = "https://api.frankfurter.app/%s..%s?from=USD&to=%s"
+
+	// Fiat exchange rates change slowly and the sources above refresh daily,
+	// there is no need to fetch them as often as the crypto rates.
+	fiatRateExpiry = 6 * time.Hour
+)
+
+// fiatRateHistoryCurrencies are the supported fiat currencies the ECB
+// publishes reference rates for, the only ones past values can be converted
+// to.
+var fiatRateHistoryCurrencies = []string{
+	"EUR", "GBP", "JPY", "CNY", "CAD", "AUD", "CHF", "INR", "BRL", "MXN",
+	"ZAR", "KRW", "TRY",
+}
+
+// fiatRateLookback is how far before the first day the dated rates are
+// requested, so that a range starting on a weekend or a holiday gets the
+// rate of the business day before.
+const fiatRateLookback = 7 * oneDay
+
+type fiatRatesFunc func() (map[string]float64, error)
+
+// IsFiatCurrency returns true if currency is one of the supported fiat
+// currencies.
+func IsFiatCurrency(currency string) bool {
+	for _, c := range SupportedFiatCurrencies {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFiatRateHistory returns true if the past USD exchange rates of currency
+// are available, see FiatRateMarket.
+func HasFiatRateHistory(currency string) bool {
+	if strings.EqualFold(currency, USDCurrency) {
+		return true
+	}
+	for _, c := range fiatRateHistoryCurrencies {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// FiatRateMarket returns the market whose daily prices, as returned by
+// GetDailyOHLC, are the USD exchange rates of currency.
+func FiatRateMarket(currency string) values.Market {
+	return values.NewMarket(USDCurrency, strings.ToUpper(currency))
+}
+
+// fiatRateCurrency returns the currency of a market returned by
+// FiatRateMarket. ok is false for any other market.
+func fiatRateCurrency(market values.Market) (currency string, ok bool) {
+	currencies := strings.Split(strings.ToUpper(market.String()), MktSep)
+	if len(currencies) != 2 || currencies[0] != USDCurrency || currencies[1] == USDCurrency ||
+		!HasFiatRateHistory(currencies[1]) {
+		return "", false
+	}
+	return currencies[1], true
+}
+
+// fiatCross splits a market quoted in a fiat currency, e.g. DCR-EUR, into the
+// USDT market the crypto price is fetched from and the fiat currency. ok is
+// false if market is not quoted in a fiat currency.
+func fiatCross(market values.Market) (usdtMarket values.Market, currency string, ok bool) {
+	currencies := strings.Split(strings.ToUpper(market.String()), MktSep)
+	if len(currencies) != 2 || !IsFiatCurrency(currencies[1]) {
+		return "", "", false
+	}
+	return values.NewMarket(currencies[0], "USDT"), currencies[1], true
+}
+
+// getFiatTicker returns the ticker of a market quoted in a fiat currency from
+// the USDT ticker of the asset and the USD exchange rate of the currency.
+func (cs *CommonRateSource) getFiatTicker(usdtMarket values.Market, currency string, cacheOnly bool) *Ticker {
+	usdtTicker := cs.GetTicker(usdtMarket, cacheOnly)
+	if usdtTicker == nil {
+		return nil
+	}
+
+	fxRate, err := cs.fiatRate(currency, cacheOnly)
+	if err != nil {
+		if !cacheOnly {
+			cs.fail("Error fetching fiat rate", err)
+		}
+		return nil
+	}
+
+	ticker := *usdtTicker
+	ticker.Market = values.NewMarket(usdtMarket.AssetString(), currency).String()
+	ticker.LastTradePrice *= fxRate
+	return &ticker
+}
+
+// fiatRate returns how many units of currency one USD buys. Cached rates are
+// returned unless they expired, cacheOnly prevents fetching them.
+func (cs *CommonRateSource) fiatRate(currency string, cacheOnly bool) (float64, error) {
+	currency = strings.ToUpper(currency)
+	if currency == USDCurrency {
+		return 1, nil
+	}
+
+	cs.fiatMtx.RLock()
+	rate, ok := cs.fiatRates[currency]
+	expired := time.Since(cs.fiatLastUpdate) > fiatRateExpiry
+	cs.fiatMtx.RUnlock()
+
+	if (!ok || expired) && !cacheOnly {
+		if err := cs.refreshFiatRates(); err == nil {
+			cs.fiatMtx.RLock()
+			rate, ok = cs.fiatRates[currency]
+			cs.fiatMtx.RUnlock()
+		} else if !ok {
+			return 0, err
+		}
+	}
+
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no USD exchange rate available for %s", currency)
+	}
+	return rate, nil
+}
+
+// refreshFiatRates fetches the USD exchange rates of the fiat currencies,
+// trying every fiat rate source in order.
+func (cs *CommonRateSource) refreshFiatRates() error {
+	var err error
+	for _, getRates := range []fiatRatesFunc{openERAPIFiatRates, frankfurterFiatRates} {
+		var rates map[string]float64
+		rates, err = getRates()
+		if err != nil {
+			continue
+		}
+
+		cs.fiatMtx.Lock()
+		cs.fiatRates = rates
+		cs.fiatLastUpdate = time.Now()
+		cs.fiatMtx.Unlock()
+		return nil
+	}
+	return err
+}
+
+func openERAPIFiatRates() (map[string]float64, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: openERAPIURL,
+		Method:  "GET",
+	}
+	var res struct {
+		Result string             `json:"result"`
+		Rates  map[string]float64 `json:"rates"`
+	}
+	_, err := utils.HTTPRequest(reqCfg, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fiat rates: %w", err)
+	}
+	if res.Result != "success" || len(res.Rates) == 0 {
+		return nil, fmt.Errorf("failed to fetch fiat rates: unexpected result %q", res.Result)
+	}
+	return res.Rates, nil
+}
+
+func frankfurterFiatRates() (map[string]float64, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: frankfurterURL,
+		Method:  "GET",
+	}
+	var res struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	_, err := utils.HTTPRequest(reqCfg, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fiat rates: %w", err)
+	}
+	if len(res.Rates) == 0 {
+		return nil, fmt.Errorf("failed to fetch fiat rates: no rates returned")
+	}
+	return res.Rates, nil
+}
+
+// dailyFiatRates returns the USD exchange rates of currency for every day
+// from from to to, as daily prices whose open, high, low and close are the
+// rate. The ECB publishes rates on business days, the other days get the rate
+// of the business day before.
+func dailyFiatRates(currency string, from, to time.Time) ([]*OHLC, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: fmt.Sprintf(frankfurterSeriesURL, from.Add(-fiatRateLookback).Format(time.DateOnly),
+			to.Format(time.DateOnly), currency),
+		Method: "GET",
+	}
+	var res struct {
+		Rates map[string]map[string]float64 `json:"rates"`
+	}
+	_, err := utils.HTTPRequest(reqCfg, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s rate history: %w", currency, err)
+	}
+
+	type datedRate struct {
+		time int64
+		rate float64
+	}
+	rates := make([]datedRate, 0, len(res.Rates))
+	for date, dayRates := range res.Rates {
+		t, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s rate history: invalid date %q", currency, date)
+		}
+		if rate := dayRates[currency]; rate > 0 {
+			rates = append(rates, datedRate{time: t.Unix(), rate: rate})
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].time < rates[j].time })
+
+	var prices []*OHLC
+	next := 0
+	var rate float64
+	for d := from; !d.After(to); d = d.Add(oneDay) {
+		for next < len(rates) && rates[next].time <= d.Unix() {
+			rate = rates[next].rate
+			next++
+		}
+		if rate == 0 {
+			continue
+		}
+		prices = append(prices, &OHLC{Time: d.Unix(), Open: rate, High: rate, Low: rate, Close: rate})
+	}
+	return prices, nil
+}
@@ -0,0 +1,276 @@
+package ext
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// oneDay is the period of the daily prices.
+const oneDay = 24 * time.Hour
+
+var (
+	// See: https://binance-docs.github.io/apidocs/spot/en/#kline-candlestick-data
+	binanceDailyKlinesURL   = "https://api.binance.com/api/v3/klines?symbol=%s&interval=1d&startTime=%d&endTime=%d&limit=1000"
+	binanceUSDailyKlinesURL = "https://api.binance.us/api/v3/klines?symbol=%s&interval=1d&startTime=%d&endTime=%d&limit=1000"
+	// See: https://www.kucoin.com/docs/rest/spot-trading/market-data/get-klines
+	kucoinCandlesURL = "https://api.kucoin.com/api/v1/market/candles?type=1day&symbol=%s&startAt=%d&endAt=%d"
+	// See: https://api.coinpaprika.com/#tag/Coins/operation/getCoinOHLCHistorical
+	coinpaprikaOHLCURL = "https://api.coinpaprika.com/v1/coins/%s/ohlcv/historical?start=%d&end=%d&limit=366"
+	// See: https://messari.io/api/docs#tag/Timeseries
+	messariTimeseriesURL = "https://data.messari.io/api/v1/assets/%s/metrics/price/time-series?start=%s&end=%s&interval=1d"
+
+	// coinpaprikaCoinIDs maps the assets to their Coinpaprika coin IDs.
+	coinpaprikaCoinIDs = map[string]string{
+		"DCR": "dcr-decred",
+		"BTC": "btc-bitcoin",
+		"LTC": "ltc-litecoin",
+	}
+)
+
+// Maximum number of days each source returns per request.
+const (
+	binanceMaxDays     = 1000
+	kucoinMaxDays      = 1500
+	coinpaprikaMaxDays = 366
+	messariMaxDays     = 2016
+)
+
+// OHLC is the open, high, low and close price of a market on a UTC day.
+type OHLC struct {
+	// Time is the unix timestamp of the start of the day.
+	Time  int64   `json:"time"`
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+type ohlcFunc func(market values.Market, from, to time.Time) ([]*OHLC, error)
+
+// GetDailyOHLC returns the daily prices of the market from the UTC day that
+// contains from to the one that contains to, oldest first. The source
+// matching the selected rate source is tried first, then the others. The
+// price of the current day is that of its open candle. The prices of a
+// FiatRateMarket are the USD exchange rates of its fiat currency.
+func (cs *CommonRateSource) GetDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	if cs.isDisabled() {
+		return nil, fmt.Errorf("rate source is disabled")
+	}
+
+	from, to = from.UTC().Truncate(oneDay), to.UTC().Truncate(oneDay)
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid date range")
+	}
+
+	if currency, ok := fiatRateCurrency(market); ok {
+		return dailyFiatRates(currency, from, to)
+	}
+
+	marketName, ok := isSupportedMarket(market, cs.source)
+	if !ok {
+		return nil, fmt.Errorf("market %s is not supported by %s", market, cs.source)
+	}
+
+	var err error
+	for _, getOHLC := range cs.ohlcFuncs() {
+		var prices []*OHLC
+		prices, err = getOHLC(marketName, from, to)
+		if err == nil && len(prices) > 0 {
+			sort.Slice(prices, func(i, j int) bool { return prices[i].Time < prices[j].Time })
+			return prices, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no %s prices available from %s", marketName, from.Format(time.DateOnly))
+	}
+	return nil, err
+}
+
+// ohlcFuncs returns the daily price sources to try in order, starting with
+// the one matching the selected rate source.
+func (cs *CommonRateSource) ohlcFuncs() []ohlcFunc {
+	switch cs.source {
+	case binanceUS:
+		return []ohlcFunc{binanceUSDailyOHLC, binanceDailyOHLC, kucoinDailyOHLC, coinpaprikaDailyOHLC, messariDailyOHLC}
+	case kucoinExchange:
+		return []ohlcFunc{kucoinDailyOHLC, binanceDailyOHLC, coinpaprikaDailyOHLC, messariDailyOHLC}
+	case coinpaprika:
+		return []ohlcFunc{coinpaprikaDailyOHLC, binanceDailyOHLC, kucoinDailyOHLC, messariDailyOHLC}
+	case messari:
+		return []ohlcFunc{messariDailyOHLC, binanceDailyOHLC, kucoinDailyOHLC, coinpaprikaDailyOHLC}
+	default:
+		return []ohlcFunc{binanceDailyOHLC, kucoinDailyOHLC, coinpaprikaDailyOHLC, messariDailyOHLC}
+	}
+}
+
+// inChunks calls fetch for consecutive ranges of at most maxDays days
+// covering from to to and joins the results.
+func inChunks(from, to time.Time, maxDays int, fetch func(from, to time.Time) ([]*OHLC, error)) ([]*OHLC, error) {
+	var prices []*OHLC
+	for start := from; !start.After(to); start = start.Add(time.Duration(maxDays) * oneDay) {
+		end := start.Add(time.Duration(maxDays-1) * oneDay)
+		if end.After(to) {
+			end = to
+		}
+		chunk, err := fetch(start, end)
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, chunk...)
+	}
+	return prices, nil
+}
+
+func binanceDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	return binanceDailyKlines(binanceDailyKlinesURL, market, from, to)
+}
+
+func binanceUSDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	return binanceDailyKlines(binanceUSDailyKlinesURL, market, from, to)
+}
+
+func binanceDailyKlines(url string, market values.Market, from, to time.Time) ([]*OHLC, error) {
+	return inChunks(from, to, binanceMaxDays, func(from, to time.Time) ([]*OHLC, error) {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(url, market.MarketWithoutSep(), from.UnixMilli(), to.Add(oneDay).UnixMilli()-1),
+			Method:  "GET",
+		}
+
+		// Each kline is [openTime, open, high, low, close, ...].
+		var res [][]interface{}
+		_, err := utils.HTTPRequest(reqCfg, &res)
+		if err != nil {
+			return nil, fmt.Errorf("binance failed to fetch daily prices for %s: %w", market, err)
+		}
+
+		prices := make([]*OHLC, 0, len(res))
+		for _, kline := range res {
+			if len(kline) < 5 {
+				continue
+			}
+			openTime, _ := kline[0].(float64)
+			price := &OHLC{Time: int64(openTime) / 1000}
+			for i, v := range []*float64{&price.Open, &price.High, &price.Low, &price.Close} {
+				str, _ := kline[i+1].(string)
+				*v, _ = strconv.ParseFloat(str, 64)
+			}
+			prices = append(prices, price)
+		}
+		return prices, nil
+	})
+}
+
+func kucoinDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	return inChunks(from, to, kucoinMaxDays, func(from, to time.Time) ([]*OHLC, error) {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(kucoinCandlesURL, market.String(), from.Unix(), to.Add(oneDay).Unix()),
+			Method:  "GET",
+		}
+
+		// Each candle is [time, open, close, high, low, volume, turnover].
+		var res struct {
+			Data [][]string `json:"data"`
+		}
+		_, err := utils.HTTPRequest(reqCfg, &res)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to fetch daily prices for %s: %w", kucoinExchange, market, err)
+		}
+
+		prices := make([]*OHLC, 0, len(res.Data))
+		for _, candle := range res.Data {
+			if len(candle) < 5 {
+				continue
+			}
+			openTime, err := strconv.ParseInt(candle[0], 10, 64)
+			if err != nil || openTime >= to.Add(oneDay).Unix() {
+				continue
+			}
+			price := &OHLC{Time: openTime}
+			price.Open, _ = strconv.ParseFloat(candle[1], 64)
+			price.Close, _ = strconv.ParseFloat(candle[2], 64)
+			price.High, _ = strconv.ParseFloat(candle[3], 64)
+			price.Low, _ = strconv.ParseFloat(candle[4], 64)
+			prices = append(prices, price)
+		}
+		return prices, nil
+	})
+}
+
+func coinpaprikaDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	coinID, ok := coinpaprikaCoinIDs[strings.ToUpper(market.AssetString())]
+	if !ok {
+		return nil, fmt.Errorf("%s does not support %s", coinpaprika, market)
+	}
+
+	return inChunks(from, to, coinpaprikaMaxDays, func(from, to time.Time) ([]*OHLC, error) {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(coinpaprikaOHLCURL, coinID, from.Unix(), to.Unix()),
+			Method:  "GET",
+		}
+
+		var res []struct {
+			TimeOpen time.Time `json:"time_open"`
+			Open     float64   `json:"open"`
+			High     float64   `json:"high"`
+			Low      float64   `json:"low"`
+			Close    float64   `json:"close"`
+		}
+		_, err := utils.HTTPRequest(reqCfg, &res)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to fetch daily prices for %s: %w", coinpaprika, market, err)
+		}
+
+		prices := make([]*OHLC, 0, len(res))
+		for _, r := range res {
+			prices = append(prices, &OHLC{
+				Time:  r.TimeOpen.UTC().Truncate(oneDay).Unix(),
+				Open:  r.Open,
+				High:  r.High,
+				Low:   r.Low,
+				Close: r.Close,
+			})
+		}
+		return prices, nil
+	})
+}
+
+func messariDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error) {
+	return inChunks(from, to, messariMaxDays, func(from, to time.Time) ([]*OHLC, error) {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(messariTimeseriesURL, market.AssetString(), from.Format(time.DateOnly), to.Format(time.DateOnly)),
+			Method:  "GET",
+		}
+
+		// Each value is [timestamp, open, high, low, close, volume].
+		var res struct {
+			Data struct {
+				Values [][]float64 `json:"values"`
+			} `json:"data"`
+		}
+		_, err := utils.HTTPRequest(reqCfg, &res)
+		if err != nil {
+			return nil, fmt.Errorf("%s failed to fetch daily prices for %s: %w", messari, market, err)
+		}
+
+		prices := make([]*OHLC, 0, len(res.Data.Values))
+		for _, v := range res.Data.Values {
+			if len(v) < 5 {
+				continue
+			}
+			prices = append(prices, &OHLC{
+				Time:  int64(v[0]) / 1000,
+				Open:  v[1],
+				High:  v[2],
+				Low:   v[3],
+				Close: v[4],
+			})
+		}
+		return prices, nil
+	})
+}
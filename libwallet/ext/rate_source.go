@@ -24,6 +24,7 @@ const (
 	coinpaprika    = values.Coinpaprika
 	messari        = values.Messari
 	kucoinExchange = values.KucoinExchange
+	aggregate      = values.AggregateExchange
 	none           = values.DefaultExchangeValue
 
 	// MktSep is used repo wide to separate market symbols.
@@ -114,6 +115,8 @@ type RateSource interface {
 	Refreshing() bool
 	LastUpdate() time.Time
 	GetTicker(market values.Market, cacheOnly bool) *Ticker
+	GetDailyOHLC(market values.Market, from, to time.Time) ([]*OHLC, error)
+	SourceStatuses(market values.Market) []*SourceStatus
 	ToggleStatus(disable bool)
 	ToggleSource(newSource string) error
 	AddRateListener(listener *RateListener, uniqueIdentifier string) error
@@ -148,6 +151,15 @@ type CommonRateSource struct {
 
 	notificationListenersMu sync.RWMutex
 	ratesListeners          map[string]*RateListener
+
+	fiatMtx        sync.RWMutex
+	fiatRates      map[string]float64
+	fiatLastUpdate time.Time
+
+	// sourceStatuses holds the state of the sources queried by the
+	// aggregate rate source for each market.
+	sourceStatusMtx sync.RWMutex
+	sourceStatuses  map[values.Market]map[string]*SourceStatus
 }
 
 // Used to initialize a rate source.
@@ -163,6 +175,7 @@ func NewCommonRateSource(ctx context.Context, source string, disableConversionEx
 		sourceChanged:             make(chan *struct{}),
 		disableConversionExchange: disableConversionExchange,
 		ratesListeners:            make(map[string]*RateListener),
+		sourceStatuses:            make(map[values.Market]map[string]*SourceStatus),
 	}
 	s.getTicker = s.sourceGetTickerFunc(source)
 	s.cond = sync.NewCond(&s.mtx)
@@ -338,14 +351,28 @@ func (cs *CommonRateSource) Refresh(force bool) {
 	cs.mtx.Lock()
 	cs.tickers = tickers
 	cs.mtx.Unlock()
+
+	cs.fiatMtx.RLock()
+	fiatExpired := force || time.Since(cs.fiatLastUpdate) > fiatRateExpiry
+	cs.fiatMtx.RUnlock()
+	if fiatExpired {
+		if err := cs.refreshFiatRates(); err != nil {
+			cs.fail("Error fetching fiat rates", err)
+		}
+	}
 }
 
 // GetTicker retrieves ticker information for the provided market. Data will be
 // retrieved from cache if its available and still valid. Returns nil if valid,
 // cached isn't available and cacheOnly is true. If cacheOnly is false and no
 // valid, cached data is available, a network call will be made to fetch the
-// latest ticker information and update the cache.
+// latest ticker information and update the cache. Markets quoted in a fiat
+// currency, e.g. DCR-EUR, are derived from the USDT market of the asset.
 func (cs *CommonRateSource) GetTicker(market values.Market, cacheOnly bool) *Ticker {
+	if usdtMarket, currency, ok := fiatCross(market); ok {
+		return cs.getFiatTicker(usdtMarket, currency, cacheOnly)
+	}
+
 	marketName, ok := isSupportedMarket(market, cs.source)
 	if !ok {
 		return nil
@@ -402,6 +429,10 @@ func (cs *CommonRateSource) retryGetTicker(market values.Market) (*Ticker, error
 			return newTicker, nil
 		}
 	}
+	// The aggregate rate source already queried every exchange.
+	if cs.source == aggregate {
+		return nil, err
+	}
 	// fetch ticker from available exchanges
 	log.Infof("fetching from other exchanges")
 	for _, source := range sources {
@@ -429,19 +460,24 @@ func (cs *CommonRateSource) retryGetTicker(market values.Market) (*Ticker, error
 	return nil, err
 }
 
-func (cs *CommonRateSource) binanceGetTicker(market values.Market) (*Ticker, error) {
+func binanceGetTicker(market values.Market) (*Ticker, error) {
+	return binanceTicker(binance, binanceURLs.price, market)
+}
+
+func binanceUSGetTicker(market values.Market) (*Ticker, error) {
+	return binanceTicker(binanceUS, binanceUSURLs.price, market)
+}
+
+func binanceTicker(source, url string, market values.Market) (*Ticker, error) {
 	reqCfg := &utils.ReqConfig{
-		HTTPURL: fmt.Sprintf(binanceURLs.price, market.MarketWithoutSep()),
+		HTTPURL: fmt.Sprintf(url, market.MarketWithoutSep()),
 		Method:  "GET",
 	}
-	if cs.source == binanceUS {
-		reqCfg.HTTPURL = fmt.Sprintf(binanceUSURLs.price, market.MarketWithoutSep())
-	}
 
 	resp := new(BinanceTickerResponse)
 	_, err := utils.HTTPRequest(reqCfg, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", cs.source, market, err)
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", source, market, err)
 	}
 
 	percentChange := resp.PriceChangePercent
@@ -449,14 +485,50 @@ func (cs *CommonRateSource) binanceGetTicker(market values.Market) (*Ticker, err
 		Market:             market.String(),
 		LastTradePrice:     resp.LastPrice,
 		PriceChangePercent: &percentChange,
+		Confidence:         1,
 		lastUpdate:         time.Now(),
 	}
 
 	return ticker, nil
 }
 
-// coinpaprikaGetTicker don't need market param, but need it to satisfy the format of the getrate function
+// coinpaprikaGetTicker fetches the tickers of all the supported markets in one
+// call and caches them, it returns the ticker of market.
 func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker, error) {
+	tickers, err := coinpaprikaTickers()
+	if err != nil {
+		return nil, err
+	}
+
+	cs.mtx.Lock()
+	for m, ticker := range tickers {
+		cs.tickers[m] = ticker
+	}
+	cs.mtx.Unlock()
+
+	return coinpaprikaMarketTicker(tickers, market)
+}
+
+// coinpaprikaGetMarketTicker returns the ticker of market without caching the
+// tickers of the other markets.
+func coinpaprikaGetMarketTicker(market values.Market) (*Ticker, error) {
+	tickers, err := coinpaprikaTickers()
+	if err != nil {
+		return nil, err
+	}
+	return coinpaprikaMarketTicker(tickers, market)
+}
+
+func coinpaprikaMarketTicker(tickers map[values.Market]*Ticker, market values.Market) (*Ticker, error) {
+	ticker, ok := tickers[market]
+	if !ok {
+		return nil, fmt.Errorf("%s returned no ticker for %s", coinpaprika, market)
+	}
+	return ticker, nil
+}
+
+// coinpaprikaTickers fetches the tickers of all the supported markets.
+func coinpaprikaTickers() (map[values.Market]*Ticker, error) {
 	reqCfg := &utils.ReqConfig{
 		HTTPURL: coinpaprikaURLs.price,
 		Method:  "GET",
@@ -476,7 +548,7 @@ func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker,
 		return nil, fmt.Errorf("%s failed to fetch ticker: %w", coinpaprika, err)
 	}
 
-	cs.mtx.Lock()
+	tickers := make(map[values.Market]*Ticker)
 	for _, coinInfo := range res {
 		market := values.NewMarket(coinInfo.Symbol, "USDT")
 		_, found := supportedMarkets[market]
@@ -489,17 +561,17 @@ func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker,
 			log.Errorf("zero-price returned from coinpaprika for asset with ticker %s", coinInfo.Symbol)
 			continue
 		}
-		ticker := &Ticker{
+		percentChange := coinInfo.Quotes.USD.PercentChange
+		tickers[market] = &Ticker{
 			Market:             market.String(), // Ok: e.g BTC-USDT
 			LastTradePrice:     price,
 			lastUpdate:         time.Now(),
-			PriceChangePercent: &coinInfo.Quotes.USD.PercentChange,
+			PriceChangePercent: &percentChange,
+			Confidence:         1,
 		}
-		cs.tickers[market] = ticker
 	}
-	cs.mtx.Unlock()
 
-	return cs.tickers[market], nil
+	return tickers, nil
 }
 
 func messariGetTicker(market values.Market) (*Ticker, error) {
@@ -526,6 +598,7 @@ func messariGetTicker(market values.Market) (*Ticker, error) {
 		LastTradePrice:     res.Data.MarketData.Price,
 		lastUpdate:         time.Now(),
 		PriceChangePercent: &res.Data.MarketData.PercentChange,
+		Confidence:         1,
 	}
 
 	return ticker, nil
@@ -576,6 +649,7 @@ func kucoinGetTicker(market values.Market) (*Ticker, error) {
 		LastTradePrice:     rate,
 		lastUpdate:         time.Now(),
 		PriceChangePercent: &changeRate,
+		Confidence:         1,
 	}
 
 	return ticker, nil
@@ -611,7 +685,7 @@ func isSupportedMarket(market values.Market, rateSource string) (values.Market,
 
 func isValidSource(source string) bool {
 	switch source {
-	case binance, binanceUS, coinpaprika, messari, kucoinExchange, none:
+	case binance, binanceUS, coinpaprika, messari, kucoinExchange, aggregate, none:
 		return true
 	default:
 		return false
@@ -620,14 +694,18 @@ func isValidSource(source string) bool {
 
 func (cs *CommonRateSource) sourceGetTickerFunc(source string) func(values.Market) (*Ticker, error) {
 	switch source {
-	case binance, binanceUS:
-		return cs.binanceGetTicker
+	case binance:
+		return binanceGetTicker
+	case binanceUS:
+		return binanceUSGetTicker
 	case messari:
 		return messariGetTicker
 	case kucoinExchange:
 		return kucoinGetTicker
 	case coinpaprika:
 		return cs.coinpaprikaGetTicker
+	case aggregate:
+		return cs.aggregateGetTicker
 	case none:
 		return dummyGetTickerFunc
 	default:
@@ -61,6 +61,13 @@ func setBackend(backend, net, rawURL string) string {
 		return rawURL
 	}
 
+	// Use the self-hosted dcrdata instance if one is set.
+	if backend == DcrData {
+		if authority := utils.GetBackendConfig().ExplorerURL(utils.DCRWalletAsset); authority != "" {
+			return fmt.Sprintf("%s/%s", authority, rawURL)
+		}
+	}
+
 	// Prepend URL scheme and authority to the URL.
 	if authority, ok := backendURL[net][backend]; ok {
 		rawURL = fmt.Sprintf("%s%s", authority, rawURL)
@@ -68,6 +75,17 @@ func setBackend(backend, net, rawURL string) string {
 	return rawURL
 }
 
+// blockBookURL returns the URL of path on the BlockBook instance, the
+// self-hosted one if it is set. Addresses and xpubs are not sent to the
+// public instance while dcrdata is self-hosted.
+func (s *Service) blockBookURL(path string) (string, error) {
+	reqURL, err := utils.GetBackendConfig().BlockBookURL(path)
+	if err != nil || reqURL != "" {
+		return reqURL, err
+	}
+	return setBackend(BlockBook, s.network, path), nil
+}
+
 // GetBestBlock returns the best block height as int32.
 func (s *Service) GetBestBlock() int32 {
 	reqConf := &utils.ReqConfig{
@@ -162,6 +180,34 @@ func (s *Service) GetTreasuryDetails() (treasuryDetails *TreasuryDetails, err er
 	return treasuryDetails, err
 }
 
+// GetTx returns the transaction with txHash and the block that mined it, if
+// any. The error wraps utils.ErrHTTPNotFound if dcrdata doesn't know the
+// transaction.
+func (s *Service) GetTx(txHash string) (tx *apiTypes.Tx, err error) {
+	reqConf := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: setBackend(DcrData, s.network, "api/tx/"+txHash),
+	}
+	tx = &apiTypes.Tx{}
+	_, err = utils.HTTPRequest(reqConf, tx)
+	return tx, err
+}
+
+// GetTxHex returns the hex encoded serialized transaction with txHash.
+func (s *Service) GetTxHex(txHash string) (string, error) {
+	reqConf := &utils.ReqConfig{
+		Method:    http.MethodGet,
+		HTTPURL:   setBackend(DcrData, s.network, "api/tx/hex/"+txHash),
+		IsRetByte: true,
+	}
+
+	var resp []byte
+	if _, err := utils.HTTPRequest(reqConf, &resp); err != nil {
+		return "", err
+	}
+	return strings.Trim(strings.TrimSpace(string(resp)), `"`), nil
+}
+
 // GetExchangeRate fetches exchange rate data summary.
 func (s *Service) GetExchangeRate() (rates *ExchangeRates, err error) {
 	reqConf := &utils.ReqConfig{
@@ -265,9 +311,13 @@ func (s *Service) GetAddress(address string) (addressState *AddressState, err er
 		return nil, errors.New("net is mainnet and xpub is not in mainnet format")
 	}
 
+	reqURL, err := s.blockBookURL("api/v2/address/" + address)
+	if err != nil {
+		return nil, err
+	}
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(BlockBook, s.network, "api/v2/address/"+address),
+		HTTPURL: reqURL,
 	}
 	addressState = &AddressState{}
 	_, err = utils.HTTPRequest(reqConf, addressState)
@@ -290,9 +340,13 @@ func (s *Service) GetXpub(xPub string) (xPubBalAndTxs *XpubBalAndTxs, err error)
 		return nil, errors.New("net is mainnet and xpub is not in mainnet format")
 	}
 
+	reqURL, err := s.blockBookURL("api/v2/xpub/" + xPub)
+	if err != nil {
+		return nil, err
+	}
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(BlockBook, s.network, "api/v2/xpub/"+xPub),
+		HTTPURL: reqURL,
 	}
 	xPubBalAndTxs = &XpubBalAndTxs{}
 	_, err = utils.HTTPRequest(reqConf, xPubBalAndTxs)
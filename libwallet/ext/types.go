@@ -111,6 +111,10 @@ type (
 		Market             string
 		LastTradePrice     float64
 		PriceChangePercent *float64
+		// Confidence is the share, from 0 to 1, of the sources queried by
+		// the aggregate rate source that agree with LastTradePrice. Tickers
+		// from a single selected source have a confidence of 1.
+		Confidence float64
 
 		lastUpdate time.Time
 	}
@@ -130,6 +130,10 @@ func (mgr *AssetsManager) StartScheduler(ctx context.Context, params instantswap
 		if ticker == nil {
 			return errors.E(op, fmt.Errorf("unable to get market rate from %s", source))
 		}
+		if ticker.LowConfidence() {
+			log.Errorf("the %s rate from %s is unreliable, confidence: %.2f", market, source, ticker.Confidence)
+			return errors.E(op, fmt.Errorf("the %s rate from %s is unreliable", market, source))
+		}
 
 		exchangeServerRate := res.EstimatedAmount // estimated receivable value for libwallet.DefaultRateRequestAmount (1)
 		rateSourceRate := ticker.LastTradePrice
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"unicode"
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/asdine/storm"
@@ -32,6 +33,8 @@ type Politeia struct {
 
 	syncCallbacksMtx *sync.RWMutex // Pointer required to avoid copying literal values.
 	syncCallbacks    map[string]proposalSyncCallback
+
+	watchlistCallbacks map[string]watchlistCallback
 }
 
 const (
@@ -44,9 +47,11 @@ const (
 )
 
 func New(host string, db *storm.DB) (*Politeia, error) {
-	if err := db.Init(&Proposal{}); err != nil {
-		log.Errorf("Error initializing politeia database: %s", err.Error())
-		return nil, err
+	for _, data := range []interface{}{&Proposal{}, &ProposalComment{}, &proposalCommentsSync{}, &watchedProposal{}} {
+		if err := db.Init(data); err != nil {
+			log.Errorf("Error initializing politeia database: %s", err.Error())
+			return nil, err
+		}
 	}
 
 	return &Politeia{
@@ -56,7 +61,8 @@ func New(host string, db *storm.DB) (*Politeia, error) {
 		mu:               &sync.RWMutex{},
 		syncCallbacksMtx: &sync.RWMutex{},
 
-		syncCallbacks: make(map[string]proposalSyncCallback),
+		syncCallbacks:      make(map[string]proposalSyncCallback),
+		watchlistCallbacks: make(map[string]watchlistCallback),
 	}, nil
 }
 
@@ -169,6 +175,56 @@ func (p *Politeia) GetProposal(censorshipToken string) (string, error) {
 	return p.marshalResult(p.GetProposalRaw(censorshipToken))
 }
 
+// ProposalsMentioningRaw returns the cached proposals whose description or
+// comments contain any of terms as a whole word, e.g. the hash or payout
+// addresses of a treasury spend. A term within a longer hash or address is
+// not a mention.
+func (p *Politeia) ProposalsMentioningRaw(terms ...string) ([]Proposal, error) {
+	termSet := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			termSet[term] = true
+		}
+	}
+	mentions := func(text string) bool {
+		words := strings.FieldsFunc(text, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			if termSet[word] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var proposals []Proposal
+	err := p.db.All(&proposals)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	var comments []ProposalComment
+	err = p.db.All(&comments)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	commentedTokens := make(map[string]bool)
+	for _, comment := range comments {
+		if mentions(comment.Comment) {
+			commentedTokens[comment.Token] = true
+		}
+	}
+
+	var result []Proposal
+	for _, proposal := range proposals {
+		if commentedTokens[proposal.Token] || mentions(proposal.IndexFile) {
+			result = append(result, proposal)
+		}
+	}
+	return result, nil
+}
+
 // GetProposalByIDRaw fetches and returns a single proposal specified by it's ID
 func (p *Politeia) GetProposalByIDRaw(proposalID int) (*Proposal, error) {
 	var proposal Proposal
@@ -240,12 +296,15 @@ func (p *Politeia) Overview() (*ProposalOverview, error) {
 }
 
 func (p *Politeia) ClearSavedProposals() error {
-	err := p.db.Drop(&Proposal{})
-	if err != nil {
-		return translateError(err)
+	for _, data := range []interface{}{&Proposal{}, &ProposalComment{}, &proposalCommentsSync{}} {
+		if err := p.db.Drop(data); err != nil && err != storm.ErrNotFound {
+			return translateError(err)
+		}
+		if err := p.db.Init(data); err != nil {
+			return err
+		}
 	}
-
-	return p.db.Init(&Proposal{})
+	return nil
 }
 
 func (p *Politeia) marshalResult(result interface{}, err error) (string, error) {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	cmv1 "github.com/decred/politeia/politeiawww/api/comments/v1"
 	tkv1 "github.com/decred/politeia/politeiawww/api/ticketvote/v1"
 	www "github.com/decred/politeia/politeiawww/api/www/v1"
 	"github.com/decred/politeia/politeiawww/client"
@@ -232,6 +233,21 @@ func (c *politeiaClient) voteResults(token string) (*tkv1.ResultsReply, error) {
 	return &resultReply, nil
 }
 
+func (c *politeiaClient) comments(token string) ([]cmv1.Comment, error) {
+	requestBody, err := json.Marshal(&cmv1.Comments{Token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	var commentsReply cmv1.CommentsReply
+	err = c.makeRequest(http.MethodPost, cmv1.APIRoute, cmv1.RouteComments, requestBody, &commentsReply)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentsReply.Comments, nil
+}
+
 func (c *politeiaClient) batchVoteSummary(tokens []string) (map[string]www.VoteSummary, error) {
 	b, err := json.Marshal(&www.BatchVoteSummary{Tokens: tokens})
 	if err != nil {
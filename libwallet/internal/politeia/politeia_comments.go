@@ -0,0 +1,152 @@
+package politeia
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// commentsStaleAfter is how long cached comments are used before they are
+// fetched again. Votes on comments and censored comments do not change the
+// number of comments of the proposal.
+const commentsStaleAfter = 10 * time.Minute
+
+// SyncProposalComments fetches the comments of the proposal identified by
+// token if their number changed or they were cached more than
+// commentsStaleAfter ago, and returns the cached comment threads. The cached comments are returned along with the error if
+// fetching fails.
+func (p *Politeia) SyncProposalComments(token string) ([]*CommentThread, error) {
+	proposal, err := p.GetProposalRaw(token)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	var synced proposalCommentsSync
+	err = p.db.One("Token", token, &synced)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	isStale := time.Since(time.Unix(synced.SyncedAt, 0)) > commentsStaleAfter
+	if err == storm.ErrNotFound || synced.NumComments != proposal.NumComments || isStale {
+		if err := p.fetchProposalComments(proposal); err != nil {
+			threads, _ := p.ProposalCommentsRaw(token)
+			return threads, err
+		}
+	}
+
+	return p.ProposalCommentsRaw(token)
+}
+
+// fetchProposalComments replaces the cached comments of proposal with the
+// comments on the server.
+func (p *Politeia) fetchProposalComments(proposal *Proposal) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if err := p.getClient(); err != nil {
+		return err
+	}
+
+	comments, err := p.client.comments(proposal.Token)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.Select(q.Eq("Token", proposal.Token)).Delete(&ProposalComment{})
+	if err != nil && err != storm.ErrNotFound {
+		return fmt.Errorf("error deleting cached comments: %s", err.Error())
+	}
+
+	for _, c := range comments {
+		comment := &ProposalComment{
+			ID:        fmt.Sprintf("%s:%d", c.Token, c.CommentID),
+			Token:     c.Token,
+			CommentID: c.CommentID,
+			ParentID:  c.ParentID,
+			Username:  c.Username,
+			Comment:   c.Comment,
+			Upvotes:   c.Upvotes,
+			Downvotes: c.Downvotes,
+			CreatedAt: c.CreatedAt,
+			Timestamp: c.Timestamp,
+			Deleted:   c.Deleted,
+			Reason:    c.Reason,
+		}
+		if err := tx.Save(comment); err != nil {
+			return fmt.Errorf("error saving comment: %s", err.Error())
+		}
+	}
+
+	err = tx.Save(&proposalCommentsSync{
+		Token:       proposal.Token,
+		NumComments: proposal.NumComments,
+		SyncedAt:    time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ProposalCommentsRaw returns the cached comments of the proposal identified
+// by token as threads. Top level comments are sorted by score, highest first,
+// and replies oldest first.
+func (p *Politeia) ProposalCommentsRaw(token string) ([]*CommentThread, error) {
+	var comments []ProposalComment
+	err := p.db.Find("Token", token, &comments)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching comments: %s", err.Error())
+	}
+
+	return commentThreads(comments), nil
+}
+
+// ProposalComments returns the result of ProposalCommentsRaw as a JSON string.
+func (p *Politeia) ProposalComments(token string) (string, error) {
+	return p.marshalResult(p.ProposalCommentsRaw(token))
+}
+
+// commentThreads nests the comments under the comments they reply to. A reply
+// whose parent is missing is shown as a top level comment.
+func commentThreads(comments []ProposalComment) []*CommentThread {
+	threads := make(map[uint32]*CommentThread, len(comments))
+	for i := range comments {
+		threads[comments[i].CommentID] = &CommentThread{ProposalComment: &comments[i]}
+	}
+
+	var roots []*CommentThread
+	for _, c := range comments {
+		thread := threads[c.CommentID]
+		if parent, ok := threads[c.ParentID]; ok && c.ParentID != 0 {
+			parent.Replies = append(parent.Replies, thread)
+		} else {
+			roots = append(roots, thread)
+		}
+	}
+
+	sort.SliceStable(roots, func(i, j int) bool {
+		if roots[i].Score() != roots[j].Score() {
+			return roots[i].Score() > roots[j].Score()
+		}
+		return roots[i].CreatedAt < roots[j].CreatedAt
+	})
+	for _, thread := range threads {
+		sort.SliceStable(thread.Replies, func(i, j int) bool {
+			return thread.Replies[i].CreatedAt < thread.Replies[j].CreatedAt
+		})
+	}
+	return roots
+}
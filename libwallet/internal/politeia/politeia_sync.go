@@ -187,6 +187,7 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 			batchProposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 			batchProposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 			batchProposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+			batchProposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 			batchProposals[i].YesVotes, batchProposals[i].NoVotes = getVotesCount(voteSummary.Results)
 		}
 
@@ -214,20 +215,21 @@ func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal)
 	}
 
 	var callback func(*Proposal)
+	var status utils.ProposalStatus
 
 	if oldProposal.Status != updatedProposal.Status && www.PropStatusT(updatedProposal.Status) == www.PropStatusAbandoned {
 		updatedProposal.Category = ProposalCategoryAbandoned
 	} else if oldProposal.VoteStatus != updatedProposal.VoteStatus {
 		switch www.PropVoteStatusT(updatedProposal.VoteStatus) {
 		case www.PropVoteStatusFinished:
-			callback = p.publishVoteFinished
+			callback, status = p.publishVoteFinished, utils.ProposalStatusVoteFinished
 			if updatedProposal.VoteApproved {
 				updatedProposal.Category = ProposalCategoryApproved
 			} else {
 				updatedProposal.Category = ProposalCategoryRejected
 			}
 		case www.PropVoteStatusStarted:
-			callback = p.publishVoteStarted
+			callback, status = p.publishVoteStarted, utils.ProposalStatusVoteStarted
 			updatedProposal.Category = ProposalCategoryActive
 		default:
 			updatedProposal.Category = ProposalCategoryPre
@@ -241,6 +243,9 @@ func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal)
 
 	if callback != nil {
 		callback(&updatedProposal)
+		if p.IsProposalWatched(updatedProposal.Token) {
+			p.publishWatchlistUpdate(&updatedProposal, status, 0)
+		}
 	}
 
 	return nil
@@ -340,6 +345,7 @@ func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcas
 				proposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 				proposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 				proposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+				proposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 				proposals[i].YesVotes, proposals[i].NoVotes = getVotesCount(voteSummary.Results)
 			}
 
@@ -0,0 +1,150 @@
+package politeia
+
+import (
+	"errors"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// VoteEndingBlocks is how many blocks before the end of a watched proposal's
+// vote the owner of unvoted eligible tickets is reminded to vote, about a
+// day on mainnet.
+const VoteEndingBlocks = 288
+
+// watchedProposal is a proposal on the user's watchlist.
+type watchedProposal struct {
+	Token   string `storm:"id"`
+	AddedAt int64
+	// EndingNotified is set once the vote ending reminder was checked so it
+	// is not repeated.
+	EndingNotified bool
+}
+
+// WatchProposal adds the proposal identified by token to the watchlist.
+func (p *Politeia) WatchProposal(token string) error {
+	if _, err := p.GetProposalRaw(token); err != nil {
+		return err
+	}
+	return p.db.Save(&watchedProposal{Token: token, AddedAt: time.Now().Unix()})
+}
+
+// UnwatchProposal removes the proposal identified by token from the watchlist.
+func (p *Politeia) UnwatchProposal(token string) error {
+	err := p.db.DeleteStruct(&watchedProposal{Token: token})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// IsProposalWatched returns true if the proposal identified by token is on
+// the watchlist.
+func (p *Politeia) IsProposalWatched(token string) bool {
+	var watched watchedProposal
+	return p.db.One("Token", token, &watched) == nil
+}
+
+// HasWatchedProposals returns true if the watchlist is not empty.
+func (p *Politeia) HasWatchedProposals() bool {
+	count, err := p.db.Count(&watchedProposal{})
+	return err == nil && count > 0
+}
+
+// WatchedProposalsRaw returns the proposals on the watchlist.
+func (p *Politeia) WatchedProposalsRaw() ([]*Proposal, error) {
+	var watched []watchedProposal
+	err := p.db.All(&watched)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	proposals := make([]*Proposal, 0, len(watched))
+	for _, w := range watched {
+		proposal, err := p.GetProposalRaw(w.Token)
+		if err != nil {
+			log.Errorf("Error reading watched proposal %s: %v", w.Token, err)
+			continue
+		}
+		proposals = append(proposals, proposal)
+	}
+	return proposals, nil
+}
+
+// WatchedProposals returns the result of WatchedProposalsRaw as a JSON string.
+func (p *Politeia) WatchedProposals() (string, error) {
+	return p.marshalResult(p.WatchedProposalsRaw())
+}
+
+// CheckWatchedVotesEnding publishes a utils.ProposalStatusVoteEnding update
+// for every watched proposal whose vote ends within VoteEndingBlocks of
+// bestBlock and for which unvotedTickets reports eligible tickets that have
+// not voted yet. Each proposal is only checked once.
+func (p *Politeia) CheckWatchedVotesEnding(bestBlock int32, unvotedTickets func(token string) (int, error)) error {
+	var watched []watchedProposal
+	err := p.db.All(&watched)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	for _, w := range watched {
+		if w.EndingNotified {
+			continue
+		}
+
+		proposal, err := p.GetProposalRaw(w.Token)
+		if err != nil {
+			continue
+		}
+		blocksLeft := proposal.EndBlockHeight - bestBlock
+		if proposal.Category != ProposalCategoryActive || blocksLeft <= 0 || blocksLeft > VoteEndingBlocks {
+			continue
+		}
+
+		unvoted, err := unvotedTickets(w.Token)
+		if err != nil {
+			log.Errorf("Error checking unvoted tickets of %s: %v", w.Token, err)
+			continue
+		}
+
+		w.EndingNotified = true
+		if err := p.db.Update(&w); err != nil {
+			return err
+		}
+		if unvoted > 0 {
+			p.publishWatchlistUpdate(proposal, utils.ProposalStatusVoteEnding, unvoted)
+		}
+	}
+	return nil
+}
+
+// AddWatchlistCallback registers a callback for the vote updates of watched
+// proposals.
+func (p *Politeia) AddWatchlistCallback(callback watchlistCallback, uniqueIdentifier string) error {
+	p.syncCallbacksMtx.Lock()
+	defer p.syncCallbacksMtx.Unlock()
+
+	if _, ok := p.watchlistCallbacks[uniqueIdentifier]; ok {
+		return errors.New(ErrListenerAlreadyExist)
+	}
+
+	p.watchlistCallbacks[uniqueIdentifier] = callback
+	return nil
+}
+
+func (p *Politeia) RemoveWatchlistCallback(uniqueIdentifier string) {
+	p.syncCallbacksMtx.Lock()
+	defer p.syncCallbacksMtx.Unlock()
+
+	delete(p.watchlistCallbacks, uniqueIdentifier)
+}
+
+func (p *Politeia) publishWatchlistUpdate(proposal *Proposal, status utils.ProposalStatus, unvotedTickets int) {
+	p.syncCallbacksMtx.Lock()
+	defer p.syncCallbacksMtx.Unlock()
+
+	for _, callback := range p.watchlistCallbacks {
+		callback(proposal.Token, proposal.Name, status, unvotedTickets)
+	}
+}
@@ -0,0 +1,215 @@
+package politeia
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testBestBlock = 1000
+
+func newTestPoliteia(t *testing.T) *Politeia {
+	t.Helper()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	p, err := New(PoliteiaMainnetHost, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// watchTestProposal saves a proposal and adds it to the watchlist.
+func watchTestProposal(t *testing.T, p *Politeia, token string, category, endBlock int32) {
+	t.Helper()
+	proposal := &Proposal{Token: token, Name: "proposal " + token, Category: category, EndBlockHeight: endBlock}
+	if err := p.saveOrOverwiteProposal(proposal); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.WatchProposal(token); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recordVoteEnding returns the unvoted tickets published per token as vote
+// ending updates.
+func recordVoteEnding(t *testing.T, p *Politeia) map[string][]int {
+	t.Helper()
+	published := make(map[string][]int)
+	err := p.AddWatchlistCallback(func(token, _ string, status utils.ProposalStatus, unvotedTickets int) {
+		if status == utils.ProposalStatusVoteEnding {
+			published[token] = append(published[token], unvotedTickets)
+		}
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return published
+}
+
+func isEndingNotified(t *testing.T, p *Politeia, token string) bool {
+	t.Helper()
+	var watched watchedProposal
+	if err := p.db.One("Token", token, &watched); err != nil {
+		t.Fatal(err)
+	}
+	return watched.EndingNotified
+}
+
+func TestCheckWatchedVotesEnding(t *testing.T) {
+	tests := []struct {
+		name              string
+		category          int32
+		endBlock          int32
+		unvoted           int
+		expectedPublished bool
+		expectedNotified  bool
+	}{
+		{
+			name:     "vote ended at the best block",
+			category: ProposalCategoryActive,
+			endBlock: testBestBlock,
+			unvoted:  3,
+		},
+		{
+			name:              "one block left",
+			category:          ProposalCategoryActive,
+			endBlock:          testBestBlock + 1,
+			unvoted:           3,
+			expectedPublished: true,
+			expectedNotified:  true,
+		},
+		{
+			name:              "first block of the window",
+			category:          ProposalCategoryActive,
+			endBlock:          testBestBlock + VoteEndingBlocks,
+			unvoted:           3,
+			expectedPublished: true,
+			expectedNotified:  true,
+		},
+		{
+			name:     "one block before the window",
+			category: ProposalCategoryActive,
+			endBlock: testBestBlock + VoteEndingBlocks + 1,
+			unvoted:  3,
+		},
+		{
+			name:     "vote not active",
+			category: ProposalCategoryApproved,
+			endBlock: testBestBlock + 1,
+			unvoted:  3,
+		},
+		{
+			name:             "all tickets voted",
+			category:         ProposalCategoryActive,
+			endBlock:         testBestBlock + 1,
+			expectedNotified: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestPoliteia(t)
+			watchTestProposal(t, p, "a", tc.category, tc.endBlock)
+			published := recordVoteEnding(t, p)
+
+			var unvotedCalls int
+			err := p.CheckWatchedVotesEnding(testBestBlock, func(string) (int, error) {
+				unvotedCalls++
+				return tc.unvoted, nil
+			})
+			if err != nil {
+				t.Fatalf("(%v), unexpected error: %v", tc.name, err)
+			}
+
+			if got := len(published["a"]) == 1; got != tc.expectedPublished {
+				t.Errorf("(%v), expected published (%v), got (%v)", tc.name, tc.expectedPublished, published["a"])
+			}
+			if tc.expectedPublished && published["a"][0] != tc.unvoted {
+				t.Errorf("(%v), expected (%v) unvoted tickets, got (%v)", tc.name, tc.unvoted, published["a"][0])
+			}
+			if got := isEndingNotified(t, p, "a"); got != tc.expectedNotified {
+				t.Errorf("(%v), expected notified (%v), got (%v)", tc.name, tc.expectedNotified, got)
+			}
+			// Tickets are only counted for proposals inside the window.
+			expectedCalls := 0
+			if tc.expectedNotified {
+				expectedCalls = 1
+			}
+			if unvotedCalls != expectedCalls {
+				t.Errorf("(%v), expected (%v) unvoted ticket checks, got (%v)", tc.name, expectedCalls, unvotedCalls)
+			}
+		})
+	}
+}
+
+func TestCheckWatchedVotesEndingNotifiesOnce(t *testing.T) {
+	p := newTestPoliteia(t)
+	watchTestProposal(t, p, "a", ProposalCategoryActive, testBestBlock+10)
+	watchTestProposal(t, p, "b", ProposalCategoryActive, testBestBlock+10)
+	published := recordVoteEnding(t, p)
+
+	unvoted := map[string]int{"a": 2}
+	for i := 0; i < 3; i++ {
+		err := p.CheckWatchedVotesEnding(testBestBlock+int32(i), func(token string) (int, error) {
+			return unvoted[token], nil
+		})
+		if err != nil {
+			t.Fatalf("(check %v), unexpected error: %v", i, err)
+		}
+		// Unvoted tickets found after the first check are not reported.
+		unvoted["b"] = 5
+	}
+
+	if len(published["a"]) != 1 || published["a"][0] != 2 {
+		t.Errorf("(a), expected one update with (2) unvoted tickets, got (%v)", published["a"])
+	}
+	if len(published["b"]) != 0 {
+		t.Errorf("(b), expected no update, got (%v)", published["b"])
+	}
+}
+
+func TestCheckWatchedVotesEndingCallbackError(t *testing.T) {
+	p := newTestPoliteia(t)
+	watchTestProposal(t, p, "a", ProposalCategoryActive, testBestBlock+10)
+	watchTestProposal(t, p, "b", ProposalCategoryActive, testBestBlock+10)
+	published := recordVoteEnding(t, p)
+
+	// An error for one proposal does not stop the others from being checked
+	// and the failed proposal is checked again the next time.
+	failing := map[string]bool{"a": true}
+	unvotedTickets := func(token string) (int, error) {
+		if failing[token] {
+			return 0, errors.New("vote details unavailable")
+		}
+		return 1, nil
+	}
+
+	if err := p.CheckWatchedVotesEnding(testBestBlock, unvotedTickets); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(published["a"]) != 0 || isEndingNotified(t, p, "a") {
+		t.Errorf("(a), expected no update and not notified after an error, got (%v)", published["a"])
+	}
+	if len(published["b"]) != 1 {
+		t.Errorf("(b), expected one update, got (%v)", published["b"])
+	}
+
+	failing["a"] = false
+	if err := p.CheckWatchedVotesEnding(testBestBlock, unvotedTickets); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(published["a"]) != 1 || !isEndingNotified(t, p, "a") {
+		t.Errorf("(a), expected one update once the error is gone, got (%v)", published["a"])
+	}
+	if len(published["b"]) != 1 {
+		t.Errorf("(b), expected no second update, got (%v)", published["b"])
+	}
+}
@@ -32,9 +32,48 @@ type Proposal struct {
 	EligibleTickets  int32  `json:"eligibletickets"`
 	QuorumPercentage int32  `json:"quorumpercentage"`
 	PassPercentage   int32  `json:"passpercentage"`
+	EndBlockHeight   int32  `json:"endblockheight"`
 	Type             ProposalType
 }
 
+// ProposalComment is a comment on a proposal as cached in the db.
+type ProposalComment struct {
+	// ID is the proposal token and the comment ID joined by a colon.
+	ID        string `storm:"id"`
+	Token     string `json:"token" storm:"index"`
+	CommentID uint32 `json:"commentid"`
+	// ParentID is the ID of the comment replied to, 0 for top level
+	// comments.
+	ParentID  uint32 `json:"parentid"`
+	Username  string `json:"username"`
+	Comment   string `json:"comment"`
+	Upvotes   uint64 `json:"upvotes"`
+	Downvotes uint64 `json:"downvotes"`
+	CreatedAt int64  `json:"createdat"`
+	Timestamp int64  `json:"timestamp"`
+	Deleted   bool   `json:"deleted"`
+	Reason    string `json:"reason"`
+}
+
+// Score returns the upvotes minus the downvotes of the comment.
+func (c *ProposalComment) Score() int64 {
+	return int64(c.Upvotes) - int64(c.Downvotes)
+}
+
+// CommentThread is a comment with its replies.
+type CommentThread struct {
+	*ProposalComment
+	Replies []*CommentThread
+}
+
+// proposalCommentsSync records how many comments the proposal had when its
+// comments were last fetched and when.
+type proposalCommentsSync struct {
+	Token       string `storm:"id"`
+	NumComments int32
+	SyncedAt    int64
+}
+
 type ProposalOverview struct {
 	All        int32
 	Discussion int32
@@ -62,3 +101,7 @@ type ProposalVote struct {
 }
 
 type proposalSyncCallback func(propName string, status utils.ProposalStatus)
+
+// watchlistCallback is called with the updates of watched proposals.
+// unvotedTickets is only set for utils.ProposalStatusVoteEnding.
+type watchlistCallback func(token, propName string, status utils.ProposalStatus, unvotedTickets int)
@@ -0,0 +1,48 @@
+package libwallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ExportLabels writes the labels of the wallet to a BIP329 JSON Lines file
+// in the exports folder and returns its path.
+func (mgr *AssetsManager) ExportLabels(wallet sharedW.Asset) (string, error) {
+	dir := filepath.Join(mgr.RootDir(), exportsDirName)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	fileName := filepath.Join(dir, fmt.Sprintf("labels_%d_%d.jsonl", wallet.GetWalletID(), time.Now().Unix()))
+	f, err := os.Create(fileName)
+	if err != nil {
+		return "", fmt.Errorf("os.Create error: %w", err)
+	}
+
+	err = wallet.ExportLabels(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return "", err
+	}
+	return fileName, nil
+}
+
+// ImportLabels stores the labels of a BIP329 JSON Lines file in the wallet
+// and returns the number of labels imported.
+func (mgr *AssetsManager) ImportLabels(wallet sharedW.Asset, fileName string) (int, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return wallet.ImportLabels(f)
+}
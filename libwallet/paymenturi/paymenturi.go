@@ -0,0 +1,198 @@
+// Package paymenturi parses and builds BIP21 style payment URIs such as
+// bitcoin:<address>?amount=0.1&label=Shop, litecoin:<address> and
+// decred:<address>.
+package paymenturi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// unitsPerCoin is the number of atoms, satoshis or litoshis in a single
+	// DCR, BTC or LTC.
+	unitsPerCoin = 100000000
+	// maxDecimals is the number of fractional digits allowed in an amount.
+	maxDecimals = 8
+
+	paramAmount  = "amount"
+	paramLabel   = "label"
+	paramMessage = "message"
+	// reqPrefix marks parameters that must be understood by the parser. BIP21
+	// requires URIs carrying unknown req- parameters to be rejected.
+	reqPrefix = "req-"
+)
+
+var (
+	// ErrNotPaymentURI is returned when the input does not start with a
+	// supported payment scheme.
+	ErrNotPaymentURI = errors.New("not a payment uri")
+	// ErrMissingAddress is returned when the URI has no address.
+	ErrMissingAddress = errors.New("payment uri has no address")
+	// ErrInvalidAmount is returned when the amount parameter is malformed.
+	ErrInvalidAmount = errors.New("invalid payment uri amount")
+)
+
+// schemes maps the URI scheme of each supported asset to its type.
+var schemes = map[string]utils.AssetType{
+	"bitcoin":  utils.BTCWalletAsset,
+	"decred":   utils.DCRWalletAsset,
+	"litecoin": utils.LTCWalletAsset,
+}
+
+// PaymentURI is a decoded payment request.
+type PaymentURI struct {
+	AssetType utils.AssetType
+	Address   string
+	// Amount is the requested amount in the smallest unit of the asset. A
+	// zero value means no amount was requested.
+	Amount  int64
+	Label   string
+	Message string
+}
+
+// Scheme returns the URI scheme used for the provided asset type or an empty
+// string if the asset has none.
+func Scheme(assetType utils.AssetType) string {
+	for scheme, asset := range schemes {
+		if asset == assetType {
+			return scheme
+		}
+	}
+	return ""
+}
+
+// IsPaymentURI returns true if str starts with a supported payment scheme.
+func IsPaymentURI(str string) bool {
+	scheme, _, found := strings.Cut(strings.TrimSpace(str), ":")
+	if !found {
+		return false
+	}
+	_, ok := schemes[strings.ToLower(scheme)]
+	return ok
+}
+
+// Parse decodes a payment URI. The address is not validated against any
+// network; callers should check it with the wallet that will be used.
+func Parse(str string) (*PaymentURI, error) {
+	str = strings.TrimSpace(str)
+	scheme, rest, found := strings.Cut(str, ":")
+	if !found {
+		return nil, ErrNotPaymentURI
+	}
+	assetType, ok := schemes[strings.ToLower(scheme)]
+	if !ok {
+		return nil, ErrNotPaymentURI
+	}
+
+	// Some encoders emit bitcoin://<address>.
+	rest = strings.TrimPrefix(rest, "//")
+	address, query, _ := strings.Cut(rest, "?")
+	address, err := url.PathUnescape(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri address: %w", err)
+	}
+	if address == "" {
+		return nil, ErrMissingAddress
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment uri parameters: %w", err)
+	}
+
+	uri := &PaymentURI{
+		AssetType: assetType,
+		Address:   address,
+	}
+	for key, vals := range params {
+		if len(vals) > 1 {
+			return nil, fmt.Errorf("payment uri parameter %q set more than once", key)
+		}
+		val := vals[0]
+		switch key {
+		case paramAmount:
+			uri.Amount, err = ParseAmount(val)
+			if err != nil {
+				return nil, err
+			}
+		case paramLabel:
+			uri.Label = val
+		case paramMessage:
+			uri.Message = val
+		default:
+			if strings.HasPrefix(key, reqPrefix) {
+				return nil, fmt.Errorf("unsupported required payment uri parameter %q", key)
+			}
+		}
+	}
+
+	return uri, nil
+}
+
+// String encodes the payment request as a URI. Parameters that are not set
+// are omitted.
+func (uri *PaymentURI) String() string {
+	params := url.Values{}
+	if uri.Amount > 0 {
+		params.Set(paramAmount, formatAmount(uri.Amount))
+	}
+	if uri.Label != "" {
+		params.Set(paramLabel, uri.Label)
+	}
+	if uri.Message != "" {
+		params.Set(paramMessage, uri.Message)
+	}
+
+	str := Scheme(uri.AssetType) + ":" + uri.Address
+	if len(params) > 0 {
+		// BIP21 uses %20 rather than + for spaces.
+		str += "?" + strings.ReplaceAll(params.Encode(), "+", "%20")
+	}
+	return str
+}
+
+// ParseAmount converts a decimal coin amount to the smallest unit without
+// going through a float so no precision is lost.
+func ParseAmount(str string) (int64, error) {
+	whole, frac, _ := strings.Cut(str, ".")
+	if whole == "" && frac == "" || len(frac) > maxDecimals {
+		return 0, ErrInvalidAmount
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	w, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil || w > math.MaxInt64/unitsPerCoin {
+		return 0, ErrInvalidAmount
+	}
+
+	var f uint64
+	if frac != "" {
+		frac += strings.Repeat("0", maxDecimals-len(frac))
+		f, err = strconv.ParseUint(frac, 10, 63)
+		if err != nil {
+			return 0, ErrInvalidAmount
+		}
+	}
+
+	amount := int64(w)*unitsPerCoin + int64(f)
+	if amount < 0 {
+		return 0, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// formatAmount is the inverse of ParseAmount. Trailing zeros are dropped.
+func formatAmount(amount int64) string {
+	str := fmt.Sprintf("%d.%08d", amount/unitsPerCoin, amount%unitsPerCoin)
+	str = strings.TrimRight(str, "0")
+	return strings.TrimSuffix(str, ".")
+}
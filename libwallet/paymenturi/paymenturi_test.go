@@ -0,0 +1,105 @@
+package paymenturi
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    *PaymentURI
+		wantErr bool
+	}{{
+		name: "address only",
+		uri:  "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+		want: &PaymentURI{AssetType: utils.BTCWalletAsset, Address: "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"},
+	}, {
+		name: "all fields",
+		uri:  "decred:DsQxuVRvS4eaJ42dhQEsCXauMWjvopWgrVg?amount=20.3&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+		want: &PaymentURI{
+			AssetType: utils.DCRWalletAsset,
+			Address:   "DsQxuVRvS4eaJ42dhQEsCXauMWjvopWgrVg",
+			Amount:    2030000000,
+			Label:     "Luke-Jr",
+			Message:   "Donation for project xyz",
+		},
+	}, {
+		name: "uppercase scheme and smallest amount",
+		uri:  "LITECOIN:ltc1qg82tjmyycqvj2gkwkhwu3pfxyyzzq2n5ssqtsa?amount=.00000001",
+		want: &PaymentURI{AssetType: utils.LTCWalletAsset, Address: "ltc1qg82tjmyycqvj2gkwkhwu3pfxyyzzq2n5ssqtsa", Amount: 1},
+	}, {
+		name: "unknown optional param ignored",
+		uri:  "bitcoin:addr?somethingyoudontunderstand=50",
+		want: &PaymentURI{AssetType: utils.BTCWalletAsset, Address: "addr"},
+	}, {
+		name:    "unknown required param",
+		uri:     "bitcoin:addr?req-somethingyoudontunderstand=50",
+		wantErr: true,
+	}, {
+		name:    "unsupported scheme",
+		uri:     "ethereum:addr",
+		wantErr: true,
+	}, {
+		name:    "missing address",
+		uri:     "bitcoin:?amount=1",
+		wantErr: true,
+	}, {
+		name:    "too many decimals",
+		uri:     "bitcoin:addr?amount=0.000000001",
+		wantErr: true,
+	}, {
+		name:    "negative amount",
+		uri:     "bitcoin:addr?amount=-1",
+		wantErr: true,
+	}, {
+		name:    "exponent amount",
+		uri:     "bitcoin:addr?amount=1e3",
+		wantErr: true,
+	}, {
+		name:    "duplicate amount",
+		uri:     "bitcoin:addr?amount=1&amount=2",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse(test.uri)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != *test.want {
+				t.Fatalf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	uri := &PaymentURI{
+		AssetType: utils.BTCWalletAsset,
+		Address:   "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq",
+		Amount:    150000000,
+		Label:     "Coffee & cake",
+	}
+	want := "bitcoin:bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq?amount=1.5&label=Coffee%20%26%20cake"
+	if got := uri.String(); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	parsed, err := Parse(uri.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsed != *uri {
+		t.Fatalf("want %+v, got %+v", uri, parsed)
+	}
+}
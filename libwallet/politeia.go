@@ -71,6 +71,12 @@ type ProposalVote struct {
 	politeia.ProposalVote
 }
 
+// ProposalComment is a cached comment on a proposal.
+type ProposalComment = politeia.ProposalComment
+
+// CommentThread is a proposal comment with its replies.
+type CommentThread = politeia.CommentThread
+
 // WrapVote, wraps vote type of politeia.ProposalVote into libwallet.ProposalVote
 func WrapVote(hash, address, bit string) *ProposalVote {
 	return &ProposalVote{
@@ -0,0 +1,174 @@
+package libwallet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// PortfolioPoint is the value of the wallets at the end of a day in the
+// selected fiat currency.
+type PortfolioPoint struct {
+	// Time is the unix timestamp of the start of the day.
+	Time  int64
+	Value float64
+}
+
+// fetchDailyPrices gets the daily prices missing from the price history from
+// the rate source.
+func (mgr *AssetsManager) fetchDailyPrices(market string, from, to time.Time) ([]*pricehistory.DailyPrice, error) {
+	if !mgr.ExchangeRateFetchingEnabled() {
+		return nil, errors.New("the exchange rate is disabled")
+	}
+
+	ohlc, err := mgr.RateSource.GetDailyOHLC(values.Market(market), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]*pricehistory.DailyPrice, 0, len(ohlc))
+	for _, p := range ohlc {
+		prices = append(prices, &pricehistory.DailyPrice{
+			Time:  p.Time,
+			Open:  p.Open,
+			High:  p.High,
+			Low:   p.Low,
+			Close: p.Close,
+		})
+	}
+	return prices, nil
+}
+
+// usdFiatRates returns the USD exchange rates of the selected fiat currency
+// from the day that contains from to the day that contains to, oldest first.
+// They convert the USDT daily prices of the same days, USDT being taken at
+// par with USD. No rates are returned if the selected currency is USD. As
+// with the daily prices, the rates already saved are returned along with a
+// fetch error.
+func (mgr *AssetsManager) usdFiatRates(from, to time.Time) ([]*pricehistory.DailyPrice, error) {
+	currency := mgr.FiatCurrency()
+	if currency == ext.USDCurrency {
+		return nil, nil
+	}
+	if !ext.HasFiatRateHistory(currency) {
+		return nil, fmt.Errorf("no %s exchange rate history available", currency)
+	}
+	return mgr.PriceHistory.DailyPrices(ext.FiatRateMarket(currency).String(), from, to)
+}
+
+// fiatRateOn returns the rate of the day that starts at dayTime, or of the
+// last day before it that has a rate. Days before the first rate get it.
+// rates must not be empty and be oldest first.
+func fiatRateOn(rates []*pricehistory.DailyPrice, dayTime int64) float64 {
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Time > dayTime })
+	if i == 0 {
+		return rates[0].Close
+	}
+	return rates[i-1].Close
+}
+
+// PortfolioHistory returns the value of all the wallets at the end of every
+// day since from, in the selected fiat currency. A zero from starts at the
+// day of the first transaction. The balance of past days is reconstructed
+// from the transaction history and valued at the daily closing prices, which
+// are USDT prices converted at the rate of the fiat currency on the same day.
+func (mgr *AssetsManager) PortfolioHistory(from time.Time) ([]*PortfolioPoint, error) {
+	if !mgr.ExchangeRateFetchingEnabled() {
+		return nil, errors.New("the exchange rate is disabled")
+	}
+
+	balances, err := mgr.CalculateTotalAssetsBalance(true)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[utils.AssetType][]pricehistory.BalanceChange)
+	var firstTx int64
+	for _, wal := range mgr.AllWallets() {
+		txs, err := wal.GetTransactionsRaw(0, math.MaxInt32, utils.TxFilterAll, true, "")
+		if err != nil {
+			return nil, fmt.Errorf("wallet.GetTransactionsRaw error: %w", err)
+		}
+
+		assetType := wal.GetAssetType()
+		for _, tx := range txs {
+			changes[assetType] = append(changes[assetType], pricehistory.BalanceChange{
+				Timestamp: tx.Timestamp,
+				Amount:    wal.ToAmount(txBalanceChange(tx)).ToCoin(),
+			})
+			if tx.Timestamp > 0 && (firstTx == 0 || tx.Timestamp < firstTx) {
+				firstTx = tx.Timestamp
+			}
+		}
+	}
+
+	now := time.Now()
+	if from.IsZero() {
+		from = now
+		if firstTx > 0 {
+			from = time.Unix(firstTx, 0)
+		}
+	}
+
+	fiatRates, err := mgr.usdFiatRates(from, now)
+	if err != nil {
+		if len(fiatRates) == 0 {
+			return nil, err
+		}
+		log.Warnf("Some %s rates are missing: %v", mgr.FiatCurrency(), err)
+	}
+
+	var history []*PortfolioPoint
+	for assetType, balance := range balances {
+		usdtMarket, ok := values.AssetExchangeMarketValue[assetType]
+		if !ok {
+			continue
+		}
+
+		prices, err := mgr.PriceHistory.DailyPrices(usdtMarket.String(), from, now)
+		if err != nil {
+			if len(prices) == 0 {
+				return nil, err
+			}
+			log.Warnf("Some %s prices are missing: %v", usdtMarket, err)
+		}
+
+		points := pricehistory.Timeline(balance.ToCoin(), changes[assetType], prices, from, now)
+		if history == nil {
+			history = make([]*PortfolioPoint, len(points))
+			for i, p := range points {
+				history[i] = &PortfolioPoint{Time: p.Time}
+			}
+		}
+		for i := 0; i < len(points) && i < len(history); i++ {
+			value := points[i].Value
+			if len(fiatRates) > 0 {
+				value *= fiatRateOn(fiatRates, points[i].Time)
+			}
+			history[i].Value += value
+		}
+	}
+	return history, nil
+}
+
+// txBalanceChange returns the amount the transaction added to or removed
+// from the wallet balance.
+func txBalanceChange(tx *sharedW.Transaction) int64 {
+	switch tx.Direction {
+	case txhelper.TxDirectionReceived:
+		return tx.Amount
+	case txhelper.TxDirectionSent:
+		return -(tx.Amount + tx.Fee)
+	default:
+		return -tx.Fee
+	}
+}
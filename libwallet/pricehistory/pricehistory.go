@@ -0,0 +1,197 @@
+// Package pricehistory caches the daily prices of the markets in the app-level
+// database and reconstructs the value of a holding over time from its balance
+// changes.
+package pricehistory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// Day is the period of the prices.
+const Day = 24 * time.Hour
+
+// DailyPrice is the open, high, low and close price of a market on a UTC day.
+type DailyPrice struct {
+	// ID is the market and the day, e.g. DCR-USDT/1700006400.
+	ID     string `storm:"id" json:"id"`
+	Market string `storm:"index" json:"market"`
+	// Time is the unix timestamp of the start of the day.
+	Time  int64   `storm:"index" json:"time"`
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+// FetchFunc fetches the daily prices of market from the day that contains
+// from to the day that contains to.
+type FetchFunc func(market string, from, to time.Time) ([]*DailyPrice, error)
+
+// Store serves daily prices from the database and fetches the days missing.
+type Store struct {
+	db    *storm.DB
+	fetch FetchFunc
+	// now is replaced in tests.
+	now func() time.Time
+
+	mtx sync.Mutex
+}
+
+// New returns a price store backed by db that gets the prices it does not
+// have with fetch.
+func New(db *storm.DB, fetch FetchFunc) (*Store, error) {
+	if err := db.Init(&DailyPrice{}); err != nil {
+		return nil, fmt.Errorf("error initializing price history database: %w", err)
+	}
+
+	return &Store{
+		db:    db,
+		fetch: fetch,
+		now:   time.Now,
+	}, nil
+}
+
+// StartOfDay returns the start of the UTC day that contains t.
+func StartOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(Day)
+}
+
+func priceID(market string, dayTime int64) string {
+	return fmt.Sprintf("%s/%d", market, dayTime)
+}
+
+// DailyPrices returns the daily prices of market from the day that contains
+// from to the day that contains to, oldest first. The days not in the
+// database are fetched, those that ended are saved so they are only fetched
+// once. If fetching fails, the prices already saved are returned along with
+// the error.
+func (s *Store) DailyPrices(market string, from, to time.Time) ([]*DailyPrice, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	from, to = StartOfDay(from), StartOfDay(to)
+	if to.Before(from) {
+		return nil, errors.New("invalid date range")
+	}
+
+	var saved []*DailyPrice
+	err := s.db.Select(q.Eq("Market", market), q.Gte("Time", from.Unix()), q.Lte("Time", to.Unix())).Find(&saved)
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return nil, fmt.Errorf("error reading saved prices: %w", err)
+	}
+
+	prices := make(map[int64]*DailyPrice, len(saved))
+	for _, price := range saved {
+		prices[price.Time] = price
+	}
+
+	// Fetch the range from the first missing day to the last one.
+	var firstMissing, lastMissing time.Time
+	for d := from; !d.After(to); d = d.Add(Day) {
+		if _, ok := prices[d.Unix()]; ok {
+			continue
+		}
+		if firstMissing.IsZero() {
+			firstMissing = d
+		}
+		lastMissing = d
+	}
+
+	var fetchErr error
+	if !firstMissing.IsZero() {
+		var fetched []*DailyPrice
+		fetched, fetchErr = s.fetch(market, firstMissing, lastMissing)
+		today := StartOfDay(s.now()).Unix()
+		for _, price := range fetched {
+			price.Market = market
+			price.Time = StartOfDay(time.Unix(price.Time, 0)).Unix()
+			price.ID = priceID(market, price.Time)
+			if price.Time < firstMissing.Unix() || price.Time > lastMissing.Unix() || price.Close <= 0 {
+				continue
+			}
+			prices[price.Time] = price
+
+			// The price of the current day is not final.
+			if price.Time >= today {
+				continue
+			}
+			if err := s.db.Save(price); err != nil {
+				return nil, fmt.Errorf("error saving price: %w", err)
+			}
+		}
+	}
+
+	result := make([]*DailyPrice, 0, len(prices))
+	for _, price := range prices {
+		result = append(result, price)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Time < result[j].Time })
+	return result, fetchErr
+}
+
+// BalanceChange is the change a transaction made to a balance.
+type BalanceChange struct {
+	Timestamp int64
+	Amount    float64
+}
+
+// ValuePoint is the balance held at the end of a day and its value at the
+// closing price of the day.
+type ValuePoint struct {
+	Time    int64
+	Balance float64
+	Value   float64
+}
+
+// Timeline returns the value of a holding at the end of every day from the
+// day that contains from to the day that contains to. The balance of each
+// day is reconstructed from currentBalance by reverting the changes made
+// after the day ended. A day without a price is valued at the last price
+// known, days before the first price are valued at it.
+func Timeline(currentBalance float64, changes []BalanceChange, prices []*DailyPrice, from, to time.Time) []*ValuePoint {
+	from, to = StartOfDay(from), StartOfDay(to)
+	if to.Before(from) || len(prices) == 0 {
+		return nil
+	}
+
+	changes = append([]BalanceChange(nil), changes...)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Timestamp > changes[j].Timestamp })
+
+	prices = append([]*DailyPrice(nil), prices...)
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Time < prices[j].Time })
+
+	// Revert the changes made after the last day.
+	balance := currentBalance
+	next := 0
+	revertAfter := func(end int64) {
+		for next < len(changes) && changes[next].Timestamp >= end {
+			balance -= changes[next].Amount
+			next++
+		}
+	}
+
+	days := int(to.Sub(from)/Day) + 1
+	points := make([]*ValuePoint, days)
+	priceIndex := len(prices) - 1
+	for i := days - 1; i >= 0; i-- {
+		d := from.Add(time.Duration(i) * Day)
+		revertAfter(d.Add(Day).Unix())
+
+		for priceIndex > 0 && prices[priceIndex].Time > d.Unix() {
+			priceIndex--
+		}
+		points[i] = &ValuePoint{
+			Time:    d.Unix(),
+			Balance: balance,
+			Value:   balance * prices[priceIndex].Close,
+		}
+	}
+	return points
+}
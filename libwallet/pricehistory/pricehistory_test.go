@@ -0,0 +1,126 @@
+package pricehistory
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm"
+)
+
+var testNow = time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+
+func dayOf(n int) time.Time {
+	return StartOfDay(testNow).Add(time.Duration(n) * Day)
+}
+
+func newTestStore(t *testing.T, fetch FetchFunc) *Store {
+	t.Helper()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	s, err := New(db, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func TestDailyPrices(t *testing.T) {
+	type fetchCall struct{ from, to time.Time }
+	var calls []fetchCall
+	fail := false
+	fetch := func(_ string, from, to time.Time) ([]*DailyPrice, error) {
+		calls = append(calls, fetchCall{from, to})
+		if fail {
+			return nil, errors.New("offline")
+		}
+		var prices []*DailyPrice
+		for d := from; !d.After(to); d = d.Add(Day) {
+			// Sources return the time the candle opened.
+			prices = append(prices, &DailyPrice{Time: d.Unix(), Close: float64(d.Day())})
+		}
+		return prices, nil
+	}
+	s := newTestStore(t, fetch)
+
+	prices, err := s.DailyPrices("DCR-USDT", dayOf(-4), testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 5 || prices[0].Time != dayOf(-4).Unix() || prices[4].Close != 10 {
+		t.Fatalf("unexpected prices %v", prices)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 fetch, got %d", len(calls))
+	}
+
+	// Only today is fetched again, the days that ended were saved.
+	calls = nil
+	if _, err = s.DailyPrices("DCR-USDT", dayOf(-4), testNow); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || !calls[0].from.Equal(dayOf(0)) || !calls[0].to.Equal(dayOf(0)) {
+		t.Fatalf("expected today to be fetched, got %v", calls)
+	}
+
+	// Saved prices are returned when fetching fails.
+	fail = true
+	prices, err = s.DailyPrices("DCR-USDT", dayOf(-6), dayOf(-1))
+	if err == nil {
+		t.Fatal("expected the fetch error")
+	}
+	if len(prices) != 4 {
+		t.Fatalf("expected the 4 saved prices, got %d", len(prices))
+	}
+
+	// Prices are saved per market.
+	fail = false
+	calls = nil
+	if _, err = s.DailyPrices("BTC-USDT", dayOf(-2), dayOf(-1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected BTC-USDT to be fetched, got %v", calls)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	prices := []*DailyPrice{
+		{Time: dayOf(-3).Unix(), Close: 10},
+		{Time: dayOf(-1).Unix(), Close: 20},
+		{Time: dayOf(0).Unix(), Close: 30},
+	}
+	changes := []BalanceChange{
+		{Timestamp: dayOf(-3).Add(time.Hour).Unix(), Amount: 5},
+		{Timestamp: dayOf(-1).Add(time.Hour).Unix(), Amount: -2},
+		{Timestamp: dayOf(0).Add(time.Hour).Unix(), Amount: 4},
+	}
+
+	points := Timeline(7, changes, prices, dayOf(-4), testNow)
+	want := []ValuePoint{
+		{Time: dayOf(-4).Unix(), Balance: 0, Value: 0},
+		{Time: dayOf(-3).Unix(), Balance: 5, Value: 50},
+		// No price on day -2, the last price known is used.
+		{Time: dayOf(-2).Unix(), Balance: 5, Value: 50},
+		{Time: dayOf(-1).Unix(), Balance: 3, Value: 60},
+		{Time: dayOf(0).Unix(), Balance: 7, Value: 210},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("expected %d points, got %d", len(want), len(points))
+	}
+	for i, p := range points {
+		if *p != want[i] {
+			t.Errorf("point %d: expected %+v, got %+v", i, want[i], *p)
+		}
+	}
+
+	if points := Timeline(7, changes, nil, dayOf(-4), testNow); points != nil {
+		t.Errorf("expected no points without prices, got %d", len(points))
+	}
+}
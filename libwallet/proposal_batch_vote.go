@@ -0,0 +1,122 @@
+package libwallet
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+)
+
+// WalletProposalVotes holds the tickets of a DCR wallet that are eligible to
+// vote on a proposal and have not voted yet.
+type WalletProposalVotes struct {
+	Wallet          *dcr.Asset
+	EligibleTickets []*politeia.EligibleTicket
+	// Err is set if the eligible tickets of the wallet could not be read.
+	Err error
+}
+
+// WalletVoteResult is the outcome of casting the votes of a wallet.
+type WalletVoteResult struct {
+	Wallet   *dcr.Asset
+	YesVotes int
+	NoVotes  int
+	Err      error
+}
+
+// ProposalVotesForAllWallets returns the unvoted eligible tickets of every
+// DCR wallet that can vote on the proposal identified by token. Wallets
+// without eligible tickets are left out.
+func (mgr *AssetsManager) ProposalVotesForAllWallets(ctx context.Context, token string) []*WalletProposalVotes {
+	var walletVotes []*WalletProposalVotes
+	for _, wallet := range mgr.AllDCRWallets() {
+		if wallet.IsWatchingOnlyWallet() || !wallet.WalletOpened() {
+			continue
+		}
+
+		dcrWallet := wallet.(*dcr.Asset)
+		details, err := mgr.Politeia.ProposalVoteDetailsRaw(ctx, dcrWallet.Internal().DCR, token)
+		if err != nil {
+			walletVotes = append(walletVotes, &WalletProposalVotes{Wallet: dcrWallet, Err: err})
+			continue
+		}
+		if len(details.EligibleTickets) == 0 {
+			continue
+		}
+
+		walletVotes = append(walletVotes, &WalletProposalVotes{
+			Wallet:          dcrWallet,
+			EligibleTickets: details.EligibleTickets,
+		})
+	}
+	return walletVotes
+}
+
+// CastVotesForAllWallets votes on the proposal identified by token with the
+// eligible tickets of every wallet in walletVotes. yesPercent of all the
+// tickets vote yes and the rest vote no, the yes votes are taken from the
+// wallets in order. passphrases maps the wallet IDs to their passphrases.
+// A wallet failing to vote does not stop the other wallets from voting.
+func (mgr *AssetsManager) CastVotesForAllWallets(ctx context.Context, token string, walletVotes []*WalletProposalVotes,
+	yesPercent int, passphrases map[int]string) []*WalletVoteResult {
+	var totalTickets int
+	for _, wv := range walletVotes {
+		if wv.Err == nil {
+			totalTickets += len(wv.EligibleTickets)
+		}
+	}
+	yesLeft := YesVotesCount(totalTickets, yesPercent)
+
+	results := make([]*WalletVoteResult, 0, len(walletVotes))
+	for _, wv := range walletVotes {
+		if wv.Err != nil {
+			continue
+		}
+
+		yes := len(wv.EligibleTickets)
+		if yes > yesLeft {
+			yes = yesLeft
+		}
+		yesLeft -= yes
+
+		result := &WalletVoteResult{
+			Wallet:   wv.Wallet,
+			YesVotes: yes,
+			NoVotes:  len(wv.EligibleTickets) - yes,
+		}
+		results = append(results, result)
+
+		passphrase, ok := passphrases[wv.Wallet.GetWalletID()]
+		if !ok {
+			result.Err = errors.New(politeia.ErrInvalidPassphrase)
+			continue
+		}
+
+		votes := make([]*politeia.ProposalVote, len(wv.EligibleTickets))
+		for i, ticket := range wv.EligibleTickets {
+			bit := VoteBitNo
+			if i < yes {
+				bit = VoteBitYes
+			}
+			votes[i] = &politeia.ProposalVote{Ticket: ticket, Bit: bit}
+		}
+
+		result.Err = mgr.Politeia.CastVotes(ctx, wv.Wallet.Internal().DCR, votes, token, passphrase)
+	}
+
+	return results
+}
+
+// YesVotesCount returns how many of totalTickets vote yes when yesPercent
+// percent of the tickets should vote yes.
+func YesVotesCount(totalTickets, yesPercent int) int {
+	switch {
+	case yesPercent <= 0:
+		return 0
+	case yesPercent >= 100:
+		return totalTickets
+	}
+	return int(math.Round(float64(totalTickets) * float64(yesPercent) / 100))
+}
@@ -0,0 +1,34 @@
+package libwallet
+
+import "testing"
+
+func TestYesVotesCount(t *testing.T) {
+	tests := []struct {
+		name         string
+		totalTickets int
+		yesPercent   int
+		expected     int
+	}{
+		{name: "no tickets", totalTickets: 0, yesPercent: 50, expected: 0},
+		{name: "all no", totalTickets: 10, yesPercent: 0, expected: 0},
+		{name: "negative percent", totalTickets: 10, yesPercent: -5, expected: 0},
+		{name: "all yes", totalTickets: 10, yesPercent: 100, expected: 10},
+		{name: "percent above 100", totalTickets: 10, yesPercent: 150, expected: 10},
+		{name: "exact split", totalTickets: 10, yesPercent: 30, expected: 3},
+		{name: "half rounds up", totalTickets: 3, yesPercent: 50, expected: 2},
+		{name: "quarter of ten rounds up", totalTickets: 10, yesPercent: 25, expected: 3},
+		{name: "rounds down", totalTickets: 4, yesPercent: 10, expected: 0},
+		{name: "rounds to nearest", totalTickets: 3, yesPercent: 33, expected: 1},
+		{name: "one ticket below half", totalTickets: 1, yesPercent: 49, expected: 0},
+		{name: "one ticket at half", totalTickets: 1, yesPercent: 50, expected: 1},
+		{name: "almost all yes", totalTickets: 7, yesPercent: 99, expected: 7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := YesVotesCount(tc.totalTickets, tc.yesPercent); got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// proposalWatchInterval is how often the proposals are synced while the
+// watchlist is not empty.
+const proposalWatchInterval = 30 * time.Minute
+
+// startProposalWatcher syncs the proposals right away and then periodically
+// while there are watched proposals so that their vote updates are published
+// even when no governance page is open.
+func (mgr *AssetsManager) startProposalWatcher() {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+
+	go func() {
+		// A vote may end before the first tick, check the watchlist at
+		// startup too.
+		mgr.checkWatchedProposals(ctx)
+
+		ticker := time.NewTicker(proposalWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				mgr.checkWatchedProposals(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (mgr *AssetsManager) checkWatchedProposals(ctx context.Context) {
+	if !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) || !mgr.Politeia.HasWatchedProposals() {
+		return
+	}
+
+	if !mgr.Politeia.IsSyncing() {
+		if err := mgr.Politeia.Sync(ctx); err != nil {
+			log.Errorf("Error syncing watched proposals: %v", err)
+			return
+		}
+	}
+
+	var wallets []*dcr.Asset
+	var bestBlock int32
+	for _, wallet := range mgr.AllDCRWallets() {
+		if !wallet.WalletOpened() || !wallet.IsSynced() {
+			continue
+		}
+		wallets = append(wallets, wallet.(*dcr.Asset))
+		if height := wallet.GetBestBlockHeight(); height > bestBlock {
+			bestBlock = height
+		}
+	}
+	if len(wallets) == 0 {
+		return
+	}
+
+	unvotedTickets := func(token string) (int, error) {
+		var unvoted int
+		for _, wallet := range wallets {
+			details, err := mgr.Politeia.ProposalVoteDetailsRaw(ctx, wallet.Internal().DCR, token)
+			if err != nil {
+				return 0, err
+			}
+			unvoted += len(details.EligibleTickets)
+		}
+		return unvoted, nil
+	}
+	if err := mgr.Politeia.CheckWatchedVotesEnding(bestBlock, unvotedTickets); err != nil {
+		log.Errorf("Error checking watched proposals: %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+// Package stakeanalytics computes the costs, rewards and return on investment
+// of staking tickets, per ticket and aggregated by month and by VSP.
+package stakeanalytics
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// atomsPerCoin is the number of atoms in one DCR.
+const atomsPerCoin = 1e8
+
+// SoloVSP is the VSP key of tickets that were not bought through a VSP.
+const SoloVSP = "solo"
+
+// Ticket status values.
+const (
+	StatusVoted   = "voted"
+	StatusRevoked = "revoked"
+	StatusPending = "pending"
+)
+
+// Ticket holds the figures of a single ticket. Amounts are in atoms.
+type Ticket struct {
+	Hash string
+	// VSP is the host of the VSP the ticket was registered with, or empty for
+	// solo tickets.
+	VSP       string
+	Status    string
+	Purchased time.Time
+	// Spent is the time of the vote or revocation, zero while the ticket is
+	// pending.
+	Spent time.Time
+	// DaysToVote is the number of days from purchase to vote or revocation.
+	DaysToVote int32
+
+	// Price is the ticket price locked by the ticket.
+	Price int64
+	// TxFee is the sum of the network fees of the ticket, VSP fee and
+	// revocation transactions.
+	TxFee int64
+	// VSPFee is the fee paid to the VSP.
+	VSPFee int64
+	// Reward is the stake reward returned on top of the ticket price when the
+	// ticket voted, it is zero for revoked tickets.
+	Reward int64
+}
+
+// IsSpent returns true if the ticket voted or was revoked.
+func (t *Ticket) IsSpent() bool {
+	return t.Status == StatusVoted || t.Status == StatusRevoked
+}
+
+// Cost returns the total amount spent to buy the ticket.
+func (t *Ticket) Cost() int64 {
+	return t.Price + t.TxFee + t.VSPFee
+}
+
+// Profit returns the reward minus all fees paid for the ticket.
+func (t *Ticket) Profit() int64 {
+	return t.Reward - t.TxFee - t.VSPFee
+}
+
+// ROI returns the realized return on investment of a spent ticket in percent.
+func (t *Ticket) ROI() float64 {
+	if !t.IsSpent() || t.Cost() == 0 {
+		return 0
+	}
+	return float64(t.Profit()) * 100 / float64(t.Cost())
+}
+
+// AnnualizedROI returns the ROI of a spent ticket scaled to a year, from the
+// number of days the funds were locked.
+func (t *Ticket) AnnualizedROI() float64 {
+	if !t.IsSpent() {
+		return 0
+	}
+	return t.ROI() * 365 / float64(lockedDays(t))
+}
+
+// lockedDays returns the days the ticket locked its funds, at least one.
+func lockedDays(t *Ticket) int32 {
+	if t.DaysToVote < 1 {
+		return 1
+	}
+	return t.DaysToVote
+}
+
+// Figures aggregates the figures of a set of tickets. The ROI fields only
+// account for the spent tickets.
+type Figures struct {
+	Tickets int
+	Voted   int
+	Revoked int
+
+	Cost    int64
+	TxFees  int64
+	VSPFees int64
+	Rewards int64
+	Profit  int64
+
+	// ROI is the realized return on investment in percent.
+	ROI float64
+	// AnnualizedROI weights the ROI by the time each ticket locked its
+	// funds.
+	AnnualizedROI float64
+	// AvgDaysToVote is the average number of days spent tickets took to vote
+	// or be revoked.
+	AvgDaysToVote float64
+}
+
+// Breakdown holds the figures of the tickets sharing the same key, a month
+// formatted as 2006-01 or a VSP host.
+type Breakdown struct {
+	Key string
+	Figures
+}
+
+// Report holds the staking figures of a wallet.
+type Report struct {
+	Total Figures
+	// ByMonth groups the spent tickets by the month they voted or were
+	// revoked, oldest first.
+	ByMonth []*Breakdown
+	// ByVSP groups all tickets by VSP, sorted by host.
+	ByVSP []*Breakdown
+}
+
+// Analyze computes the aggregate figures of tickets.
+func Analyze(tickets []*Ticket) *Report {
+	return &Report{
+		Total: aggregate(tickets),
+		ByMonth: breakdown(tickets, func(t *Ticket) string {
+			if !t.IsSpent() {
+				return ""
+			}
+			return t.Spent.UTC().Format("2006-01")
+		}),
+		ByVSP: breakdown(tickets, func(t *Ticket) string {
+			if t.VSP == "" {
+				return SoloVSP
+			}
+			return t.VSP
+		}),
+	}
+}
+
+// breakdown aggregates the tickets sharing the same key, skipping the tickets
+// with an empty key. The breakdowns are sorted by key.
+func breakdown(tickets []*Ticket, key func(*Ticket) string) []*Breakdown {
+	groups := make(map[string][]*Ticket)
+	for _, t := range tickets {
+		if k := key(t); k != "" {
+			groups[k] = append(groups[k], t)
+		}
+	}
+
+	breakdowns := make([]*Breakdown, 0, len(groups))
+	for k, group := range groups {
+		breakdowns = append(breakdowns, &Breakdown{Key: k, Figures: aggregate(group)})
+	}
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].Key < breakdowns[j].Key
+	})
+	return breakdowns
+}
+
+func aggregate(tickets []*Ticket) Figures {
+	var f Figures
+	var spentCost, spentProfit, spent int64
+	var lockedCostDays float64
+	var days int64
+	for _, t := range tickets {
+		f.Tickets++
+		f.Cost += t.Cost()
+		f.TxFees += t.TxFee
+		f.VSPFees += t.VSPFee
+		f.Rewards += t.Reward
+		f.Profit += t.Profit()
+
+		switch t.Status {
+		case StatusVoted:
+			f.Voted++
+		case StatusRevoked:
+			f.Revoked++
+		}
+		if !t.IsSpent() {
+			continue
+		}
+		spent++
+		spentCost += t.Cost()
+		spentProfit += t.Profit()
+		lockedCostDays += float64(t.Cost()) * float64(lockedDays(t))
+		days += int64(t.DaysToVote)
+	}
+
+	if spentCost > 0 {
+		f.ROI = float64(spentProfit) * 100 / float64(spentCost)
+		f.AnnualizedROI = float64(spentProfit) * 100 * 365 / lockedCostDays
+	}
+	if spent > 0 {
+		f.AvgDaysToVote = float64(days) / float64(spent)
+	}
+	return f
+}
+
+// csvHeader lists the CSV columns in the order written by WriteCSV.
+var csvHeader = []string{
+	"hash", "vsp", "status", "purchased", "spent", "days_to_vote", "price",
+	"tx_fee", "vsp_fee", "reward", "profit", "roi_percent", "annualized_roi_percent",
+}
+
+// WriteCSV writes the per-ticket figures as CSV with a header row. Amounts
+// are in DCR, times are RFC3339 in UTC and the spent time is empty for
+// pending tickets.
+func WriteCSV(w io.Writer, tickets []*Ticket, useCRLF bool) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = useCRLF
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, t := range tickets {
+		vsp, spent := t.VSP, ""
+		if vsp == "" {
+			vsp = SoloVSP
+		}
+		if t.IsSpent() {
+			spent = t.Spent.UTC().Format(time.RFC3339)
+		}
+		err := writer.Write([]string{
+			t.Hash,
+			vsp,
+			t.Status,
+			t.Purchased.UTC().Format(time.RFC3339),
+			spent,
+			strconv.FormatInt(int64(t.DaysToVote), 10),
+			formatCoin(t.Price),
+			formatCoin(t.TxFee),
+			formatCoin(t.VSPFee),
+			formatCoin(t.Reward),
+			formatCoin(t.Profit()),
+			strconv.FormatFloat(t.ROI(), 'f', 4, 64),
+			strconv.FormatFloat(t.AnnualizedROI(), 'f', 4, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatCoin(atoms int64) string {
+	return strconv.FormatFloat(float64(atoms)/atomsPerCoin, 'f', 8, 64)
+}
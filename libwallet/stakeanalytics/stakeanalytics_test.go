@@ -0,0 +1,112 @@
+package stakeanalytics
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTickets() []*Ticket {
+	return []*Ticket{{
+		Hash:       "a",
+		VSP:        "https://vsp.one",
+		Status:     StatusVoted,
+		Purchased:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Spent:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		DaysToVote: 30,
+		Price:      100e8,
+		TxFee:      0.5e8,
+		VSPFee:     0.5e8,
+		Reward:     3e8,
+	}, {
+		Hash:       "b",
+		Status:     StatusVoted,
+		Purchased:  time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		Spent:      time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC),
+		DaysToVote: 30,
+		Price:      99e8,
+		TxFee:      1e8,
+		Reward:     3e8,
+	}, {
+		Hash:      "c",
+		VSP:       "https://vsp.one",
+		Status:    StatusPending,
+		Purchased: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Price:     100e8,
+		VSPFee:    1e8,
+	}}
+}
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTicketFigures(t *testing.T) {
+	ticket := testTickets()[0]
+	if ticket.Cost() != 101e8 || ticket.Profit() != 2e8 {
+		t.Fatalf("unexpected cost %d or profit %d", ticket.Cost(), ticket.Profit())
+	}
+	if roi := ticket.ROI(); !approx(roi, 200.0/101) {
+		t.Fatalf("unexpected roi %v", roi)
+	}
+	if roi := ticket.AnnualizedROI(); !approx(roi, 200.0/101*365/30) {
+		t.Fatalf("unexpected annualized roi %v", roi)
+	}
+
+	pending := testTickets()[2]
+	if pending.ROI() != 0 || pending.AnnualizedROI() != 0 {
+		t.Fatal("pending tickets have no realized roi")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	report := Analyze(testTickets())
+
+	total := report.Total
+	if total.Tickets != 3 || total.Voted != 2 || total.Revoked != 0 {
+		t.Fatalf("unexpected ticket counts %+v", total)
+	}
+	if total.Cost != 302e8 || total.VSPFees != 1.5e8 || total.Rewards != 6e8 || total.Profit != 3e8 {
+		t.Fatalf("unexpected totals %+v", total)
+	}
+	// Only the two spent tickets, costing 101 and 100, count towards the ROI.
+	if !approx(total.ROI, 400.0/201) || !approx(total.AnnualizedROI, 400.0/201*365/30) {
+		t.Fatalf("unexpected roi %v, annualized %v", total.ROI, total.AnnualizedROI)
+	}
+	if total.AvgDaysToVote != 30 {
+		t.Fatalf("unexpected average days to vote %v", total.AvgDaysToVote)
+	}
+
+	if len(report.ByMonth) != 2 || report.ByMonth[0].Key != "2024-01" || report.ByMonth[1].Key != "2024-02" {
+		t.Fatalf("unexpected months %+v", report.ByMonth)
+	}
+	if report.ByMonth[1].Rewards != 3e8 || report.ByMonth[1].Tickets != 1 {
+		t.Fatalf("unexpected february %+v", report.ByMonth[1])
+	}
+
+	if len(report.ByVSP) != 2 || report.ByVSP[0].Key != "https://vsp.one" || report.ByVSP[1].Key != SoloVSP {
+		t.Fatalf("unexpected vsps %+v", report.ByVSP)
+	}
+	if report.ByVSP[0].Tickets != 2 || report.ByVSP[0].VSPFees != 1.5e8 {
+		t.Fatalf("unexpected vsp figures %+v", report.ByVSP[0])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testTickets()[1:], false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"hash,vsp,status,purchased,spent,days_to_vote,price,tx_fee,vsp_fee,reward,profit,roi_percent,annualized_roi_percent",
+		"b,solo,voted,2024-01-10T00:00:00Z,2024-02-09T00:00:00Z,30,99.00000000,1.00000000,0.00000000,3.00000000,2.00000000,2.0000,24.3333",
+		"c,https://vsp.one,pending,2024-02-01T00:00:00Z,,0,100.00000000,0.00000000,1.00000000,0.00000000,-1.00000000,0.0000,0.0000",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", got, want)
+	}
+}
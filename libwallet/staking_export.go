@@ -0,0 +1,44 @@
+package libwallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/stakeanalytics"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ExportStakingAnalytics writes the per-ticket staking figures of the wallet
+// to a new CSV file in the exports folder and returns its path.
+func (mgr *AssetsManager) ExportStakingAnalytics(wallet *dcr.Asset) (string, error) {
+	tickets, err := wallet.StakingAnalytics()
+	if err != nil {
+		return "", fmt.Errorf("wallet.StakingAnalytics error: %w", err)
+	}
+
+	dir := filepath.Join(mgr.RootDir(), exportsDirName)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	fileName := filepath.Join(dir, fmt.Sprintf("staking_export_%d.csv", time.Now().Unix()))
+	f, err := os.Create(fileName)
+	if err != nil {
+		return "", fmt.Errorf("os.Create error: %w", err)
+	}
+
+	err = stakeanalytics.WriteCSV(f, tickets, runtime.GOOS == "windows")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return "", fmt.Errorf("writing staking export failed: %w", err)
+	}
+
+	return fileName, nil
+}
@@ -0,0 +1,31 @@
+package libwallet
+
+import (
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// updateTicketHealthMonitors starts the ticket health monitor of every loaded
+// DCR wallet while the VSP API is enabled and stops them otherwise. Tickets
+// are then checked whether or not their wallet is opened in the UI, and when
+// running as a daemon. The monitors only check synced wallets.
+func (mgr *AssetsManager) updateTicketHealthMonitors() {
+	for _, wallet := range mgr.AllDCRWallets() {
+		mgr.updateTicketHealthMonitor(wallet)
+	}
+}
+
+// updateTicketHealthMonitor starts or stops the ticket health monitor of the
+// wallet if it is a DCR wallet.
+func (mgr *AssetsManager) updateTicketHealthMonitor(wallet sharedW.Asset) {
+	dcrW, ok := wallet.(*dcr.Asset)
+	if !ok {
+		return
+	}
+	if mgr.IsHTTPAPIPrivacyModeOff(utils.VspAPI) {
+		dcrW.StartTicketHealthMonitor()
+	} else {
+		dcrW.StopTicketHealthMonitor()
+	}
+}
@@ -0,0 +1,172 @@
+package libwallet
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/pricehistory"
+	"github.com/crypto-power/cryptopower/libwallet/txexport"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	// exportsDirName is the folder in the root directory where exported
+	// files are written.
+	exportsDirName = "exports"
+)
+
+// ExportTransactions writes the transactions of the provided wallets that
+// match txFilter to a new CSV or JSON file in the exports folder and returns
+// its path. If includeFiat is true, every transaction is valued in the
+// selected fiat currency at the closing price of its day, or in USD if the
+// past rates of the currency are not available. Transactions whose price
+// could not be fetched are exported without a fiat value.
+func (mgr *AssetsManager) ExportTransactions(wallets []sharedW.Asset, txFilter int32, format txexport.Format, includeFiat bool) (string, error) {
+	if includeFiat && !mgr.ExchangeRateFetchingEnabled() {
+		return "", fmt.Errorf("the exchange rate is disabled")
+	}
+
+	var records []*txexport.Record
+	recordAssets := make(map[*txexport.Record]utils.AssetType)
+	for _, w := range wallets {
+		txs, err := w.GetTransactionsRaw(0, math.MaxInt32, txFilter, true, "")
+		if err != nil {
+			return "", fmt.Errorf("wallet.GetTransactionsRaw error: %w", err)
+		}
+
+		assetType := w.GetAssetType()
+		_, hasMarket := values.AssetExchangeMarketValue[assetType]
+		for _, tx := range txs {
+			record := &txexport.Record{
+				Wallet:      w.GetWalletName(),
+				Asset:       assetType.String(),
+				Hash:        tx.Hash,
+				Timestamp:   time.Unix(tx.Timestamp, 0),
+				BlockHeight: tx.BlockHeight,
+				Direction:   txhelper.TxDirectionString(tx.Direction),
+				Type:        tx.Type,
+				Amount:      w.ToAmount(tx.Amount).ToCoin(),
+				Fee:         w.ToAmount(tx.Fee).ToCoin(),
+				Label:       tx.Label,
+			}
+			if hasMarket {
+				recordAssets[record] = assetType
+			}
+			records = append(records, record)
+		}
+	}
+
+	if includeFiat {
+		mgr.addExportFiatValues(records, recordAssets)
+	}
+
+	dir := filepath.Join(mgr.RootDir(), exportsDirName)
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	fileName := filepath.Join(dir, fmt.Sprintf("transaction_export_%d.%s", time.Now().Unix(), format))
+	f, err := os.Create(fileName)
+	if err != nil {
+		return "", fmt.Errorf("os.Create error: %w", err)
+	}
+
+	if format == txexport.FormatCSV {
+		err = txexport.WriteCSV(f, records, runtime.GOOS == "windows")
+	} else {
+		err = txexport.Write(f, format, records)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(fileName)
+		return "", fmt.Errorf("writing %s export failed: %w", format, err)
+	}
+
+	return fileName, nil
+}
+
+// addExportFiatValues values every record in the selected fiat currency at
+// the closing price of its day in the price history. The USDT prices of each
+// asset are read with one request for the range of days of its records and
+// converted at the exchange rate of the fiat currency on the same day. The
+// rates of the whole export are read with one request. If they are not
+// available, the records are valued in USD.
+func (mgr *AssetsManager) addExportFiatValues(records []*txexport.Record, recordAssets map[*txexport.Record]utils.AssetType) {
+	type dayRange struct{ from, to time.Time }
+	ranges := make(map[utils.AssetType]*dayRange)
+	var exportRange *dayRange
+	for record, assetType := range recordAssets {
+		r, ok := ranges[assetType]
+		if !ok {
+			r = &dayRange{from: record.Timestamp, to: record.Timestamp}
+			ranges[assetType] = r
+		}
+		if exportRange == nil {
+			exportRange = &dayRange{from: record.Timestamp, to: record.Timestamp}
+		}
+		for _, r := range []*dayRange{r, exportRange} {
+			if record.Timestamp.Before(r.from) {
+				r.from = record.Timestamp
+			}
+			if record.Timestamp.After(r.to) {
+				r.to = record.Timestamp
+			}
+		}
+	}
+	if exportRange == nil {
+		return
+	}
+
+	fiatCurrency := mgr.FiatCurrency()
+	fiatRates, err := mgr.usdFiatRates(exportRange.from, exportRange.to)
+	if err != nil && len(fiatRates) > 0 {
+		log.Warnf("Some %s rates are missing: %v", fiatCurrency, err)
+	}
+	if fiatCurrency != ext.USDCurrency && len(fiatRates) == 0 {
+		log.Warnf("No %s rates, valuing the exported transactions in %s: %v", fiatCurrency, ext.USDCurrency, err)
+		fiatCurrency = ext.USDCurrency
+	}
+
+	closePrices := make(map[utils.AssetType]map[int64]float64, len(ranges))
+	for assetType, r := range ranges {
+		market := values.AssetExchangeMarketValue[assetType]
+		prices, err := mgr.PriceHistory.DailyPrices(market.String(), r.from, r.to)
+		if err != nil {
+			log.Warnf("Some %s prices are missing: %v", market, err)
+		}
+		closePrices[assetType] = make(map[int64]float64, len(prices))
+		for _, price := range prices {
+			rate := price.Close
+			if len(fiatRates) > 0 {
+				rate *= fiatRateOn(fiatRates, price.Time)
+			}
+			closePrices[assetType][price.Time] = rate
+		}
+	}
+
+	for _, record := range records {
+		assetType, ok := recordAssets[record]
+		if !ok || closePrices[assetType] == nil {
+			continue
+		}
+		rate, ok := closePrices[assetType][pricehistory.StartOfDay(record.Timestamp).Unix()]
+		if !ok {
+			log.Warnf("No %s price for tx %s", assetType, record.Hash)
+			continue
+		}
+		value := record.Amount * rate
+		record.FiatCurrency = fiatCurrency
+		record.FiatRate = &rate
+		record.FiatValue = &value
+	}
+}
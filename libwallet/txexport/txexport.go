@@ -0,0 +1,112 @@
+// Package txexport writes wallet transaction history to CSV or JSON files
+// for bookkeeping and tax reporting.
+package txexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Format is the file format of a transaction export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// Record is a single exported transaction. Amounts are in whole coins.
+type Record struct {
+	Wallet      string    `json:"wallet"`
+	Asset       string    `json:"asset"`
+	Hash        string    `json:"hash"`
+	Timestamp   time.Time `json:"timestamp"`
+	BlockHeight int32     `json:"blockHeight"`
+	Direction   string    `json:"direction"`
+	Type        string    `json:"type"`
+	Amount      float64   `json:"amount"`
+	Fee         float64   `json:"fee"`
+	Label       string    `json:"label,omitempty"`
+	// The fiat fields are only set when the export includes
+	// fiat values and a price was available for the transaction's day.
+	FiatCurrency string   `json:"fiatCurrency,omitempty"`
+	FiatRate     *float64 `json:"fiatRate,omitempty"`
+	FiatValue    *float64 `json:"fiatValue,omitempty"`
+}
+
+// csvHeader lists the CSV columns in the order written by WriteCSV.
+var csvHeader = []string{
+	"wallet", "asset", "hash", "timestamp", "block_height", "direction", "type",
+	"amount", "fee", "label", "fiat_currency", "fiat_rate", "fiat_value",
+}
+
+// Write encodes the records in the given format.
+func Write(w io.Writer, format Format, records []*Record) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, records, false)
+	case FormatJSON:
+		return WriteJSON(w, records)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteCSV writes the records as CSV with a header row. Timestamps are RFC3339
+// in UTC and empty fiat columns mean no price was available.
+func WriteCSV(w io.Writer, records []*Record, useCRLF bool) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = useCRLF
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		err := writer.Write([]string{
+			r.Wallet,
+			r.Asset,
+			r.Hash,
+			r.Timestamp.UTC().Format(time.RFC3339),
+			strconv.FormatInt(int64(r.BlockHeight), 10),
+			r.Direction,
+			r.Type,
+			formatFloat(r.Amount, 8),
+			formatFloat(r.Fee, 8),
+			r.Label,
+			r.FiatCurrency,
+			formatOptional(r.FiatRate, 8),
+			formatOptional(r.FiatValue, 2),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the records as an indented JSON array.
+func WriteJSON(w io.Writer, records []*Record) error {
+	if records == nil {
+		records = []*Record{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+func formatFloat(f float64, prec int) string {
+	return strconv.FormatFloat(f, 'f', prec, 64)
+}
+
+func formatOptional(f *float64, prec int) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f, prec)
+}
@@ -0,0 +1,90 @@
+package txexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecords() []*Record {
+	rate, value := 15.5, 31.0
+	return []*Record{{
+		Wallet:       "main",
+		Asset:        "DCR",
+		Hash:         "abc",
+		Timestamp:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		BlockHeight:  100,
+		Direction:    "Received",
+		Type:         "regular",
+		Amount:       2,
+		Fee:          0,
+		Label:        "salary, january",
+		FiatCurrency: "USD",
+		FiatRate:     &rate,
+		FiatValue:    &value,
+	}, {
+		Wallet:      "main",
+		Asset:       "DCR",
+		Hash:        "def",
+		Timestamp:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		BlockHeight: -1,
+		Direction:   "Sent",
+		Type:        "ticket",
+		Amount:      -1.5,
+		Fee:         0.0001,
+	}}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testRecords()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"wallet,asset,hash,timestamp,block_height,direction,type,amount,fee,label,fiat_currency,fiat_rate,fiat_value",
+		`main,DCR,abc,2024-01-02T03:04:05Z,100,Received,regular,2.00000000,0.00000000,"salary, january",USD,15.50000000,31.00`,
+		"main,DCR,def,2024-01-03T00:00:00Z,-1,Sent,ticket,-1.50000000,0.00010000,,,,",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected csv:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testRecords()); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	if got[0]["fiatValue"] != 31.0 || got[0]["label"] != "salary, january" {
+		t.Fatalf("unexpected first record: %v", got[0])
+	}
+	if _, ok := got[1]["fiatValue"]; ok {
+		t.Fatal("fiat value should be omitted when unknown")
+	}
+
+	buf.Reset()
+	if err := Write(&buf, FormatJSON, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Fatalf("expected empty array, got %q", buf.String())
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, Format("xml"), nil); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
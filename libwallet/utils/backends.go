@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BackendConfig holds the base URLs of the self-hosted block explorers used
+// instead of the public ones. An empty URL uses the public explorer.
+type BackendConfig struct {
+	// DCRData is the URL of a dcrdata instance, e.g. https://dcrdata.example.org.
+	DCRData string `json:"dcrdata"`
+	// DCRBlockBook is the URL of a BlockBook instance used to look up the
+	// balances and transactions of DCR addresses and xpubs, e.g.
+	// https://blockbook.example.org. While dcrdata is self-hosted these
+	// lookups fail unless it is set, so they never reach the public instance.
+	DCRBlockBook string `json:"dcr_blockbook"`
+	// BTCEsplora and LTCEsplora are the URLs of Esplora or mempool.space
+	// instances, e.g. https://mempool.example.org. Their API is served under
+	// /api. BTC and LTC wallets sync over SPV, so only fee estimates and
+	// transaction links use them.
+	BTCEsplora string `json:"btc_esplora"`
+	LTCEsplora string `json:"ltc_esplora"`
+}
+
+// ErrNoSelfHostedBlockBook is returned by DCR address and xpub lookups while
+// dcrdata is self-hosted and no BlockBook instance is set.
+var ErrNoSelfHostedBlockBook = errors.New("set a self-hosted BlockBook URL to look up addresses and xpubs")
+
+var (
+	backendMtx sync.RWMutex
+	backendCfg BackendConfig
+)
+
+// SetBackendConfig sets the block explorers used from now on.
+func SetBackendConfig(cfg BackendConfig) {
+	backendMtx.Lock()
+	defer backendMtx.Unlock()
+	backendCfg = cfg
+}
+
+// GetBackendConfig returns the block explorers configuration.
+func GetBackendConfig() BackendConfig {
+	backendMtx.RLock()
+	defer backendMtx.RUnlock()
+	return backendCfg
+}
+
+// IsEmpty returns true if no self-hosted block explorer is set.
+func (cfg BackendConfig) IsEmpty() bool {
+	return cfg.DCRData == "" && cfg.DCRBlockBook == "" && cfg.BTCEsplora == "" && cfg.LTCEsplora == ""
+}
+
+// BlockBookURL returns the URL of path on the self-hosted BlockBook instance.
+// An empty string is returned if no instance is set and dcrdata is not
+// self-hosted either, the public instance may then be used.
+// ErrNoSelfHostedBlockBook is returned if only dcrdata is self-hosted.
+func (cfg BackendConfig) BlockBookURL(path string) (string, error) {
+	if cfg.DCRBlockBook != "" {
+		return strings.TrimSuffix(cfg.DCRBlockBook, "/") + "/" + strings.TrimPrefix(path, "/"), nil
+	}
+	if cfg.DCRData != "" {
+		return "", ErrNoSelfHostedBlockBook
+	}
+	return "", nil
+}
+
+// ExplorerURL returns the URL of the self-hosted block explorer of the asset
+// without a trailing slash, or an empty string if none is set.
+func (cfg BackendConfig) ExplorerURL(assetType AssetType) string {
+	var explorerURL string
+	switch assetType {
+	case DCRWalletAsset:
+		explorerURL = cfg.DCRData
+	case BTCWalletAsset:
+		explorerURL = cfg.BTCEsplora
+	case LTCWalletAsset:
+		explorerURL = cfg.LTCEsplora
+	}
+	return strings.TrimSuffix(explorerURL, "/")
+}
+
+// EsploraAPIURL returns the URL of path on the Esplora API of the asset, or
+// an empty string if no Esplora instance is set for it.
+func (cfg BackendConfig) EsploraAPIURL(assetType AssetType, path string) string {
+	if assetType == DCRWalletAsset {
+		return ""
+	}
+	explorerURL := cfg.ExplorerURL(assetType)
+	if explorerURL == "" {
+		return ""
+	}
+	return explorerURL + "/api/" + strings.TrimPrefix(path, "/")
+}
+
+// Validate checks that the URLs set are http(s) URLs and that the explorers
+// respond with their best block height.
+func (cfg BackendConfig) Validate() error {
+	for _, assetType := range []AssetType{DCRWalletAsset, BTCWalletAsset, LTCWalletAsset} {
+		explorerURL := cfg.ExplorerURL(assetType)
+		if explorerURL == "" {
+			continue
+		}
+
+		u, err := url.ParseRequestURI(explorerURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid %s explorer URL: %s", assetType, explorerURL)
+		}
+
+		heightPath := "/api/blocks/tip/height"
+		if assetType == DCRWalletAsset {
+			heightPath = "/api/block/best/height"
+		}
+		if err := checkBestHeight(explorerURL + heightPath); err != nil {
+			return fmt.Errorf("%s explorer health check failed: %w", assetType, err)
+		}
+	}
+
+	if cfg.DCRBlockBook != "" {
+		blockBookURL := strings.TrimSuffix(cfg.DCRBlockBook, "/")
+		u, err := url.ParseRequestURI(blockBookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid BlockBook URL: %s", cfg.DCRBlockBook)
+		}
+		if err := checkBlockBook(blockBookURL + "/api/v2"); err != nil {
+			return fmt.Errorf("BlockBook health check failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkBlockBook requests the status of the BlockBook instance at statusURL.
+func checkBlockBook(statusURL string) error {
+	reqConf := &ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: statusURL,
+	}
+
+	var status struct {
+		BlockBook struct {
+			BestHeight int64 `json:"bestHeight"`
+		} `json:"blockbook"`
+	}
+	if _, err := HTTPRequest(reqConf, &status); err != nil {
+		return err
+	}
+	if status.BlockBook.BestHeight <= 0 {
+		return errors.New("unexpected status response")
+	}
+	return nil
+}
+
+// checkBestHeight requests the best block height at heightURL.
+func checkBestHeight(heightURL string) error {
+	reqConf := &ReqConfig{
+		Method:    http.MethodGet,
+		HTTPURL:   heightURL,
+		IsRetByte: true,
+	}
+
+	var resp []byte
+	if _, err := HTTPRequest(reqConf, &resp); err != nil {
+		return err
+	}
+
+	height, err := strconv.ParseInt(strings.TrimSpace(string(resp)), 10, 64)
+	if err != nil || height <= 0 {
+		return errors.New("unexpected best block height response")
+	}
+	return nil
+}
@@ -24,6 +24,8 @@ const (
 	ProposalStatusNewProposal
 	ProposalStatusVoteStarted
 	ProposalStatusVoteFinished
+	// ProposalStatusVoteEnding is only published for watched proposals.
+	ProposalStatusVoteEnding
 )
 
 type (
@@ -177,6 +179,19 @@ func NormalizeAddress(addr string, defaultPort string) (string, error) {
 	return addr, nil
 }
 
+// IsLoopbackHost returns true if host, with or without a port, is this
+// machine. Names other than localhost are not resolved.
+func IsLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 // TrimNonAphanumeric removes all the characters that don't include the following:
 // `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-`
 func TrimNonAphaNumeric(text string) string {
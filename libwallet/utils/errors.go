@@ -62,6 +62,9 @@ var (
 	ErrStakingAccountsMissing  = errors.New("Mixing and Unmixing Accounts are not set")
 
 	ErrTicketPurchaseAccMissing = errors.New("ticket purchase account is not set")
+
+	// ErrHTTPNotFound is wrapped by the error returned for 404 responses.
+	ErrHTTPNotFound = errors.New("error: status: 404 Not Found")
 )
 
 // todo, should update this method to translate more error kinds.
@@ -70,13 +70,12 @@ var (
 // DialerFunc returns a customized dialer function that is make it easier to
 // control node level tcp connections especially after a shutdown. It also
 // includes a timeout value preventing a connection waiting forever for a
-// response to be returned.
-func DialerFunc(ctx context.Context) Dailer {
-	d := &net.Dialer{
-		Timeout: defaultHTTPClientTimeout,
-	}
+// response to be returned. The connections go through the proxy if one is set,
+// isolated by isolationID in Tor mode.
+func DialerFunc(ctx context.Context, isolationID string) Dailer {
+	dial := ProxyDialContext(isolationID)
 	return func(addr net.Addr) (net.Conn, error) {
-		return d.DialContext(ctx, addr.Network(), addr.String())
+		return dial(ctx, addr.Network(), addr.String())
 	}
 }
 
@@ -88,17 +87,23 @@ func init() {
 	activeAPIs = make(map[string]*Client)
 }
 
-// newClient configures and returns a new client
-func newClient() (c *Client) {
+// newClient configures and returns a new client for host. The requests go
+// through the proxy if one is set.
+func newClient(host string) (c *Client) {
 	// Initialize context use to cancel all pending requests when shutdown request is made.
 	ctx, cancel := context.WithCancel(context.Background())
 
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// The app proxy replaces the proxy set in the environment.
+	transport.Proxy = nil
+	transport.DialContext = ProxyDialContext("api-" + host)
+
 	return &Client{
 		context:    ctx,
 		cancelFunc: cancel,
 		HTTPClient: &http.Client{
 			Timeout:   defaultHTTPClientTimeout,
-			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+			Transport: transport,
 		},
 	}
 }
@@ -186,6 +191,9 @@ func (c *Client) query(reqConfig *ReqConfig) (rawData []byte, resp *http.Respons
 		return nil, nil, err
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, resp, fmt.Errorf("%w resp: %s", ErrHTTPNotFound, body)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, resp, fmt.Errorf("error: status: %v resp: %s", resp.Status, body)
 	}
@@ -208,7 +216,7 @@ func HTTPRequest(reqConfig *ReqConfig, respObj interface{}) (*http.Response, err
 	apiMtx.Lock()
 	client, ok := activeAPIs[urlPath.Host]
 	if !ok {
-		client = newClient()
+		client = newClient(urlPath.Host)
 	}
 	apiMtx.Unlock()
 
@@ -249,8 +257,19 @@ func IsOnline() bool {
 		return netC.isConnected
 	}
 
-	// DNS lookup failed if err != nil.
-	_, err := net.LookupHost(addressToLookUp)
+	var err error
+	if cfg := GetProxyConfig(); cfg != nil {
+		// A DNS lookup would leak outside the proxy, check that the proxy
+		// can be reached instead.
+		var conn net.Conn
+		conn, err = net.DialTimeout("tcp", cfg.Host, defaultHTTPClientTimeout)
+		if err == nil {
+			conn.Close()
+		}
+	} else {
+		// DNS lookup failed if err != nil.
+		_, err = net.LookupHost(addressToLookUp)
+	}
 
 	// if err == nil, the internet link is up.
 	netC.isConnected = err == nil
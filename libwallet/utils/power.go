@@ -0,0 +1,17 @@
+package utils
+
+// OnACPower returns false if the machine is known to be running on battery.
+// True is returned on machines without a battery and where the power source
+// cannot be detected, see CanDetectPowerSource.
+func OnACPower() bool {
+	onAC, detected := acPowerStatus()
+	return onAC || !detected
+}
+
+// CanDetectPowerSource returns true if OnACPower can tell when the machine
+// runs on battery. Settings that depend on the power source should not be
+// offered otherwise.
+func CanDetectPowerSource() bool {
+	_, detected := acPowerStatus()
+	return detected
+}
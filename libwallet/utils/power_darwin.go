@@ -0,0 +1,29 @@
+//go:build darwin
+// +build darwin
+
+package utils
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// acPowerStatus reads the power source reported by pmset, whose first line
+// is e.g. "Now drawing from 'AC Power'". pmset is not available on iOS, the
+// power source is not detected there.
+func acPowerStatus() (onAC, detected bool) {
+	out, err := exec.Command("pmset", "-g", "batt").Output()
+	if err != nil {
+		return false, false
+	}
+
+	firstLine, _, _ := strings.Cut(string(out), "\n")
+	switch {
+	case strings.Contains(firstLine, "'AC Power'"):
+		return true, true
+	case strings.Contains(firstLine, "'Battery Power'"):
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// linuxPowerSupplyDir lists the power supplies of the machine on linux and
+// android.
+const linuxPowerSupplyDir = "/sys/class/power_supply"
+
+// acPowerStatus reads the state of the power supplies. Chargers are reported
+// as Mains on most machines, phones and USB-C laptops may report them as USB
+// or Wireless supplies. If no charger is listed, the battery status tells
+// whether it is discharging.
+func acPowerStatus() (onAC, detected bool) {
+	supplies, err := os.ReadDir(linuxPowerSupplyDir)
+	if err != nil {
+		return false, false
+	}
+
+	readValue := func(dir, name string) string {
+		value, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(value))
+	}
+
+	var hasCharger bool
+	var batteryStatus string
+	for _, supply := range supplies {
+		dir := filepath.Join(linuxPowerSupplyDir, supply.Name())
+		switch readValue(dir, "type") {
+		case "Mains", "USB", "Wireless":
+			online := readValue(dir, "online")
+			if online == "" {
+				continue
+			}
+			hasCharger = true
+			if online == "1" {
+				return true, true
+			}
+		case "Battery":
+			if status := readValue(dir, "status"); status != "" {
+				batteryStatus = status
+			}
+		}
+	}
+
+	if hasCharger {
+		return false, true
+	}
+	if batteryStatus != "" {
+		return batteryStatus != "Discharging", true
+	}
+	return false, false
+}
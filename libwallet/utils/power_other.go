@@ -0,0 +1,10 @@
+//go:build !linux && !windows && !darwin
+// +build !linux,!windows,!darwin
+
+package utils
+
+// acPowerStatus reports that the power source is not detected on this
+// platform.
+func acPowerStatus() (onAC, detected bool) {
+	return false, false
+}
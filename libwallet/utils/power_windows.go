@@ -0,0 +1,44 @@
+//go:build windows
+// +build windows
+
+package utils
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var procGetSystemPowerStatus = syscall.NewLazyDLL("kernel32.dll").NewProc("GetSystemPowerStatus")
+
+// systemPowerStatus is the SYSTEM_POWER_STATUS structure. See:
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-system_power_status
+type systemPowerStatus struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+// acPowerStatus asks Windows whether the AC line is online.
+func acPowerStatus() (onAC, detected bool) {
+	if err := procGetSystemPowerStatus.Find(); err != nil {
+		return false, false
+	}
+
+	var status systemPowerStatus
+	if ret, _, _ := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status))); ret == 0 {
+		return false, false
+	}
+
+	switch status.ACLineStatus {
+	case 0:
+		return false, true
+	case 1:
+		return true, true
+	default:
+		// 255 is returned if the status is unknown.
+		return false, false
+	}
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/connmgr/v3"
+	"github.com/decred/go-socks/socks"
+)
+
+// ErrProxyRequired is returned in proxy only mode for connections that cannot
+// be sent through the proxy.
+var ErrProxyRequired = errors.New("connection refused: it cannot be sent through the proxy")
+
+// ProxyConfig is the SOCKS5 proxy all the network traffic of the app is sent
+// through.
+type ProxyConfig struct {
+	// Host is the host:port of the SOCKS5 proxy.
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// TorMode isolates the streams of every wallet and every API host by
+	// sending them with different SOCKS credentials, Tor then uses a
+	// different circuit for each of them. Username and Password are not used
+	// in Tor mode. Host names are also resolved through Tor.
+	TorMode bool `json:"tor_mode"`
+	// ProxyOnly refuses the connections and name lookups that cannot be sent
+	// through the proxy instead of making them in the clear.
+	ProxyOnly bool `json:"proxy_only"`
+}
+
+// DialContextFunc dials a network connection.
+type DialContextFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
+var (
+	proxyMtx sync.RWMutex
+	proxyCfg *ProxyConfig
+
+	proxyDefaultTransport sync.Once
+
+	// torSession is the password of the Tor isolation credentials, a new
+	// one every time the app starts gives fresh circuits.
+	torSession = newTorSession()
+)
+
+func newTorSession() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SetProxyConfig sets the proxy used by the connections made from now on, a
+// nil cfg disables the proxy. The HTTP clients are recreated to use it.
+func SetProxyConfig(cfg *ProxyConfig) {
+	if cfg != nil && strings.TrimSpace(cfg.Host) == "" {
+		cfg = nil
+	}
+
+	proxyMtx.Lock()
+	proxyCfg = cfg
+	proxyMtx.Unlock()
+
+	if cfg != nil {
+		// Libraries using the default HTTP client are proxied as well.
+		proxyDefaultTransport.Do(func() {
+			transport := http.DefaultTransport.(*http.Transport)
+			transport.Proxy = nil
+			transport.DialContext = ProxyDialContext("default")
+		})
+	}
+
+	apiMtx.Lock()
+	activeAPIs = make(map[string]*Client)
+	apiMtx.Unlock()
+}
+
+// GetProxyConfig returns a copy of the proxy configuration or nil if no proxy
+// is set.
+func GetProxyConfig() *ProxyConfig {
+	proxyMtx.RLock()
+	defer proxyMtx.RUnlock()
+
+	if proxyCfg == nil {
+		return nil
+	}
+	cfg := *proxyCfg
+	return &cfg
+}
+
+// NoAuthProxy returns the proxy host for clients that cannot send proxy
+// credentials but can isolate Tor streams, and whether to isolate them. cfg
+// may be nil, no proxy is then used. ErrProxyRequired is returned if the
+// proxy requires credentials outside Tor mode, such clients must not connect
+// rather than connect in the clear.
+func (cfg *ProxyConfig) NoAuthProxy() (host string, torIsolation bool, err error) {
+	switch {
+	case cfg == nil:
+		return "", false, nil
+	case cfg.TorMode || cfg.Username == "":
+		return cfg.Host, cfg.TorMode, nil
+	default:
+		return "", false, ErrProxyRequired
+	}
+}
+
+// WalletIsolationID returns the Tor isolation ID of the connections made for
+// the wallet identified by walletID.
+func WalletIsolationID(walletID int) string {
+	return "wallet-" + strconv.Itoa(walletID)
+}
+
+// ProxyDialContext returns a dial function that sends the connections through
+// the proxy when one is set. In Tor mode the connections sharing isolationID
+// share Tor circuits, connections with different isolation IDs do not.
+func ProxyDialContext(isolationID string) DialContextFunc {
+	d := &net.Dialer{Timeout: defaultHTTPClientTimeout}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		cfg := GetProxyConfig()
+		if cfg == nil {
+			return d.DialContext(ctx, network, addr)
+		}
+
+		if !strings.HasPrefix(network, "tcp") {
+			if cfg.ProxyOnly {
+				return nil, ErrProxyRequired
+			}
+			return d.DialContext(ctx, network, addr)
+		}
+
+		proxy := &socks.Proxy{
+			Addr:     cfg.Host,
+			Username: cfg.Username,
+			Password: cfg.Password,
+		}
+		if cfg.TorMode {
+			proxy.Username, proxy.Password = isolationID, torSession
+		}
+		return proxy.DialContext(ctx, network, addr)
+	}
+}
+
+// ProxyLookupIP resolves host through Tor in Tor mode. Connections dialed
+// through any proxy send it the host name to resolve (SOCKS5h), but SOCKS5
+// has no command to only resolve a name, Tor adds one. With other proxies
+// host is resolved in the clear, unless the proxy only mode forbids it.
+func ProxyLookupIP(host string) ([]net.IP, error) {
+	cfg := GetProxyConfig()
+	switch {
+	case cfg == nil:
+		return net.LookupIP(host)
+	case cfg.TorMode:
+		return connmgr.TorLookupIP(context.Background(), host, cfg.Host)
+	case cfg.ProxyOnly:
+		return nil, ErrProxyRequired
+	}
+	return net.LookupIP(host)
+}
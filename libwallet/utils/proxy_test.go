@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// socksRequest is what a client sent to the fake SOCKS5 proxy.
+type socksRequest struct {
+	username string
+	password string
+	command  byte
+	host     string
+}
+
+// newFakeSOCKSProxy starts a SOCKS5 proxy that records the requests it
+// receives and grants them. Resolve requests, which only Tor supports, are
+// answered with 127.0.0.1. Connections are closed once granted.
+func newFakeSOCKSProxy(t *testing.T) (string, <-chan *socksRequest) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	requests := make(chan *socksRequest, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if req, err := serveSOCKS(conn); err == nil {
+				requests <- req
+			}
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String(), requests
+}
+
+func serveSOCKS(conn net.Conn) (*socksRequest, error) {
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	readBytes := func(n int) ([]byte, error) {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(conn, buf)
+		return buf, err
+	}
+
+	greeting, err := readBytes(2)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := readBytes(int(greeting[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(socksRequest)
+	if len(methods) > 1 && methods[1] == 2 {
+		if _, err = conn.Write([]byte{5, 2}); err != nil {
+			return nil, err
+		}
+		userLen, err := readBytes(2)
+		if err != nil {
+			return nil, err
+		}
+		username, err := readBytes(int(userLen[1]))
+		if err != nil {
+			return nil, err
+		}
+		passLen, err := readBytes(1)
+		if err != nil {
+			return nil, err
+		}
+		password, err := readBytes(int(passLen[0]))
+		if err != nil {
+			return nil, err
+		}
+		req.username, req.password = string(username), string(password)
+		if _, err = conn.Write([]byte{1, 0}); err != nil {
+			return nil, err
+		}
+	} else if _, err = conn.Write([]byte{5, 0}); err != nil {
+		return nil, err
+	}
+
+	header, err := readBytes(5)
+	if err != nil {
+		return nil, err
+	}
+	host, err := readBytes(int(header[4]))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = readBytes(2); err != nil {
+		return nil, err
+	}
+	req.command, req.host = header[1], string(host)
+
+	_, err = conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0, 0})
+	return req, err
+}
+
+func TestNoAuthProxy(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           *ProxyConfig
+		expectedHost  string
+		expectedTor   bool
+		expectedError error
+	}{
+		{name: "no proxy"},
+		{name: "proxy", cfg: &ProxyConfig{Host: "127.0.0.1:1080"}, expectedHost: "127.0.0.1:1080"},
+		{name: "tor", cfg: &ProxyConfig{Host: "127.0.0.1:9050", TorMode: true}, expectedHost: "127.0.0.1:9050", expectedTor: true},
+		{name: "tor ignores credentials", cfg: &ProxyConfig{Host: "127.0.0.1:9050", Username: "user", TorMode: true}, expectedHost: "127.0.0.1:9050", expectedTor: true},
+		{name: "credentials", cfg: &ProxyConfig{Host: "127.0.0.1:1080", Username: "user", Password: "pass"}, expectedError: ErrProxyRequired},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host, tor, err := tc.cfg.NoAuthProxy()
+			if host != tc.expectedHost || tor != tc.expectedTor || !errors.Is(err, tc.expectedError) {
+				t.Errorf("(%v), expected (%v, %v, %v), got (%v, %v, %v)", tc.name,
+					tc.expectedHost, tc.expectedTor, tc.expectedError, host, tor, err)
+			}
+		})
+	}
+}
+
+func TestProxyDialContext(t *testing.T) {
+	t.Cleanup(func() { SetProxyConfig(nil) })
+	proxyAddr, requests := newFakeSOCKSProxy(t)
+
+	tests := []struct {
+		name             string
+		cfg              ProxyConfig
+		network          string
+		expectedUsername string
+		expectedPassword string
+		expectedError    error
+	}{
+		{
+			name:             "credentials",
+			cfg:              ProxyConfig{Host: proxyAddr, Username: "user", Password: "pass"},
+			network:          "tcp",
+			expectedUsername: "user",
+			expectedPassword: "pass",
+		},
+		{
+			name:             "tor isolation",
+			cfg:              ProxyConfig{Host: proxyAddr, Username: "user", Password: "pass", TorMode: true},
+			network:          "tcp",
+			expectedUsername: WalletIsolationID(1),
+			expectedPassword: torSession,
+		},
+		{
+			name:          "proxy only refuses udp",
+			cfg:           ProxyConfig{Host: proxyAddr, ProxyOnly: true},
+			network:       "udp",
+			expectedError: ErrProxyRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			SetProxyConfig(&cfg)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			conn, err := ProxyDialContext(WalletIsolationID(1))(ctx, tc.network, "example.com:443")
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Fatalf("(%v), expected (%v), got (%v)", tc.name, tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("(%v), unexpected error (%v)", tc.name, err)
+			}
+			conn.Close()
+
+			req := <-requests
+			// The proxy resolves the host name.
+			if req.command != 1 || req.host != "example.com" {
+				t.Errorf("(%v), expected a connect request to example.com, got (%d, %v)", tc.name, req.command, req.host)
+			}
+			if req.username != tc.expectedUsername || req.password != tc.expectedPassword {
+				t.Errorf("(%v), expected credentials (%v, %v), got (%v, %v)", tc.name,
+					tc.expectedUsername, tc.expectedPassword, req.username, req.password)
+			}
+		})
+	}
+}
+
+func TestProxyLookupIP(t *testing.T) {
+	t.Cleanup(func() { SetProxyConfig(nil) })
+	proxyAddr, requests := newFakeSOCKSProxy(t)
+
+	SetProxyConfig(&ProxyConfig{Host: proxyAddr, TorMode: true})
+	ips, err := ProxyLookupIP("example.com")
+	if err != nil {
+		t.Fatalf("tor lookup: %v", err)
+	}
+	if len(ips) != 1 || !ips[0].Equal(net.IPv4(127, 0, 0, 1)) {
+		t.Errorf("tor lookup: expected 127.0.0.1, got %v", ips)
+	}
+	// 0xF0 is the Tor resolve command.
+	if req := <-requests; req.command != 0xF0 || req.host != "example.com" {
+		t.Errorf("tor lookup: expected a resolve request for example.com, got (%d, %v)", req.command, req.host)
+	}
+
+	SetProxyConfig(&ProxyConfig{Host: proxyAddr, ProxyOnly: true})
+	if _, err = ProxyLookupIP("example.com"); !errors.Is(err, ErrProxyRequired) {
+		t.Errorf("proxy only lookup: expected (%v), got (%v)", ErrProxyRequired, err)
+	}
+
+	SetProxyConfig(nil)
+	if _, err = ProxyLookupIP("localhost"); err != nil {
+		t.Errorf("lookup without a proxy: %v", err)
+	}
+}
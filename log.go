@@ -18,6 +18,7 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/logger"
+	"github.com/crypto-power/cryptopower/rpcserver"
 	"github.com/crypto-power/cryptopower/ui"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
@@ -100,6 +101,7 @@ var (
 	dlwlLog      = backendLog.Logger("DLWL")
 	extLog       = backendLog.Logger("EXT")
 	amgrLog      = backendLog.Logger("AMGR")
+	rpcsLog      = backendLog.Logger("RPCS")
 	cmgrLog      = backendLog.Logger("CMGR")
 	dcrLog       = dcrBackendLog.Logger("DCR")
 	syncLog      = dcrBackendLog.Logger("SYNC")
@@ -151,6 +153,7 @@ func init() {
 	account.UseLogger(winLog)
 	wallet.UseLogger(winLog)
 	receive.UseLogger(winLog)
+	rpcserver.UseLogger(rpcsLog)
 
 	logger.New(subsystemSLoggers, subsystemBLoggers)
 	// Neutrino loglevel will always be set to error to control excessive logging.
@@ -173,6 +176,7 @@ var subsystemSLoggers = map[string]slog.Logger{
 	"TKBY": tkbyLog,
 	"WLLT": dcrWalletLog,
 	"SHWL": sharedWLog,
+	"RPCS": rpcsLog,
 }
 
 var subsystemBLoggers = map[string]btclog.Logger{
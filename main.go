@@ -101,6 +101,14 @@ func main() {
 		return
 	}
 
+	if cfg.Daemon {
+		if err := runDaemon(cfg, Version, appInfo.AssetsManager); err != nil {
+			log.Errorf("Daemon error: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	win, err := ui.CreateWindow(appInfo)
 	if err != nil {
 		log.Errorf("Could not initialize window: %s\ns", err)
@@ -0,0 +1,831 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// handlerFunc processes the params of a single rpc method and returns the
+// result that is sent back to the client.
+type handlerFunc func(ctx context.Context, s *Server, params json.RawMessage) (interface{}, error)
+
+// rpcHandlers maps every supported method to its handler. It is populated in
+// init to avoid an initialization cycle with the help method.
+var rpcHandlers map[string]handlerFunc
+
+func init() {
+	rpcHandlers = map[string]handlerFunc{
+		"help":    handleHelp,
+		"version": handleVersion,
+
+		// Wallet management.
+		"listwallets":           handleListWallets,
+		"openwallets":           handleOpenWallets,
+		"createwallet":          handleCreateWallet,
+		"restorewallet":         handleRestoreWallet,
+		"createwatchonlywallet": handleCreateWatchOnlyWallet,
+		"startsync":             handleStartSync,
+		"stopsync":              handleStopSync,
+		"syncstatus":            handleSyncStatus,
+
+		// Methods shared by all assets.
+		"getbalance":       handleGetBalance,
+		"listaccounts":     handleListAccounts,
+		"getnewaddress":    handleGetNewAddress,
+		"validateaddress":  handleValidateAddress,
+		"listtransactions": handleListTransactions,
+		"gettransaction":   handleGetTransaction,
+		"listunspent":      handleListUnspent,
+		"estimatefee":      handleEstimateFee,
+		"sendtransaction":  handleSendTransaction,
+		"signmessage":      handleSignMessage,
+		"verifymessage":    handleVerifyMessage,
+
+		// DCR specific methods.
+		"ticketprice":      handleTicketPrice,
+		"stakinginfo":      handleStakingInfo,
+		"purchasetickets":  handlePurchaseTickets,
+		"startticketbuyer": handleStartTicketBuyer,
+		"stopticketbuyer":  handleStopTicketBuyer,
+		"startmixer":       handleStartMixer,
+		"stopmixer":        handleStopMixer,
+		"setsolostaking":   handleSetSoloStaking,
+		"startsolovoting":  handleStartSoloVoting,
+		"stopsolovoting":   handleStopSoloVoting,
+
+		// BTC and LTC specific methods.
+		"getfeerates": handleGetFeeRates,
+		"setfeerate":  handleSetFeeRate,
+
+		// Instant exchange order scheduler.
+		"startscheduler":  handleStartScheduler,
+		"stopscheduler":   handleStopScheduler,
+		"schedulerstatus": handleSchedulerStatus,
+	}
+}
+
+// parseParams decodes the raw params into v, returning an invalid params
+// error if they cannot be decoded.
+func parseParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return newError(ErrCodeInvalidParams, "missing params")
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return newError(ErrCodeInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
+
+func (s *Server) wallet(walletID int) (sharedW.Asset, error) {
+	wallet := s.mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return nil, newError(ErrCodeWalletNotFound, "wallet %d not found", walletID)
+	}
+	return wallet, nil
+}
+
+func (s *Server) dcrWallet(walletID int) (*dcr.Asset, error) {
+	wallet, err := s.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	dcrAsset, ok := wallet.(*dcr.Asset)
+	if !ok {
+		return nil, newError(ErrCodeUnsupported, "wallet %d is not a DCR wallet", walletID)
+	}
+	return dcrAsset, nil
+}
+
+// feeRateAsset is implemented by the assets that use a configurable fee rate.
+type feeRateAsset interface {
+	GetAPIFeeEstimateRate() ([]sharedW.FeeEstimate, error)
+	SetUserFeeRate(feeRatePerkvB sharedW.AssetAmount) error
+	GetUserFeeRate() sharedW.AssetAmount
+}
+
+func parseAssetType(asset string) (utils.AssetType, error) {
+	assetType := utils.AssetType(strings.ToUpper(asset))
+	switch assetType {
+	case utils.DCRWalletAsset, utils.BTCWalletAsset, utils.LTCWalletAsset:
+		return assetType, nil
+	}
+	return utils.NilAsset, newError(ErrCodeInvalidParams, "unsupported asset %q", asset)
+}
+
+func handleHelp(_ context.Context, _ *Server, _ json.RawMessage) (interface{}, error) {
+	methods := make([]string, 0, len(rpcHandlers))
+	for method := range rpcHandlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods, nil
+}
+
+func handleVersion(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	return map[string]string{
+		"version": s.cfg.Version,
+		"network": string(s.mgr.NetType()),
+	}, nil
+}
+
+func handleListWallets(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	wallets := s.mgr.AllWallets()
+	result := make([]*walletInfo, 0, len(wallets))
+	for _, wallet := range wallets {
+		info := &walletInfo{
+			ID:             wallet.GetWalletID(),
+			Name:           wallet.GetWalletName(),
+			Asset:          wallet.GetAssetType().String(),
+			WatchingOnly:   wallet.IsWatchingOnlyWallet(),
+			Opened:         wallet.WalletOpened(),
+			Synced:         wallet.IsSynced(),
+			Syncing:        wallet.IsSyncing(),
+			ConnectedPeers: wallet.ConnectedPeers(),
+		}
+		if info.Opened {
+			info.BestBlock = wallet.GetBestBlockHeight()
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func handleOpenWallets(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p openWalletsParams
+	if len(params) > 0 {
+		if err := parseParams(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.mgr.OpenWallets(p.StartupPassphrase); err != nil {
+		return nil, err
+	}
+	for _, wallet := range s.mgr.AllWallets() {
+		s.listenForWalletNotifications(wallet)
+	}
+	return nil, nil
+}
+
+func handleCreateWallet(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p createWalletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	assetType, err := parseAssetType(p.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if p.Passphrase == "" {
+		return nil, newError(ErrCodeInvalidParams, "passphrase is required")
+	}
+
+	var wallet sharedW.Asset
+	seedType := sharedW.WordSeedType(p.SeedWords)
+	switch assetType {
+	case utils.DCRWalletAsset:
+		wallet, err = s.mgr.CreateNewDCRWallet(p.Name, p.Passphrase, sharedW.PassphraseTypePass, seedType)
+	case utils.BTCWalletAsset:
+		wallet, err = s.mgr.CreateNewBTCWallet(p.Name, p.Passphrase, sharedW.PassphraseTypePass, seedType)
+	case utils.LTCWalletAsset:
+		wallet, err = s.mgr.CreateNewLTCWallet(p.Name, p.Passphrase, sharedW.PassphraseTypePass, seedType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.listenForWalletNotifications(wallet)
+	return wallet.GetWalletID(), nil
+}
+
+func handleRestoreWallet(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p createWalletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	assetType, err := parseAssetType(p.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if p.Passphrase == "" || p.Seed == "" {
+		return nil, newError(ErrCodeInvalidParams, "seed and passphrase are required")
+	}
+
+	wallet, err := s.mgr.RestoreWallet(assetType, p.Name, p.Seed, p.Passphrase,
+		sharedW.PassphraseTypePass, sharedW.WordSeedType(p.SeedWords))
+	if err != nil {
+		return nil, err
+	}
+
+	s.listenForWalletNotifications(wallet)
+	return wallet.GetWalletID(), nil
+}
+
+func handleCreateWatchOnlyWallet(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p createWatchOnlyWalletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	assetType, err := parseAssetType(p.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet sharedW.Asset
+	switch assetType {
+	case utils.DCRWalletAsset:
+		wallet, err = s.mgr.CreateNewDCRWatchOnlyWallet(p.Name, p.XPub)
+	case utils.BTCWalletAsset:
+		wallet, err = s.mgr.CreateNewBTCWatchOnlyWallet(p.Name, p.XPub)
+	case utils.LTCWalletAsset:
+		wallet, err = s.mgr.CreateNewLTCWatchOnlyWallet(p.Name, p.XPub)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.listenForWalletNotifications(wallet)
+	return wallet.GetWalletID(), nil
+}
+
+func handleStartSync(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	s.listenForWalletNotifications(wallet)
+	return nil, wallet.SpvSync()
+}
+
+func handleStopSync(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	wallet.CancelSync()
+	return nil, nil
+}
+
+func handleSyncStatus(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return &walletInfo{
+		ID:             wallet.GetWalletID(),
+		Name:           wallet.GetWalletName(),
+		Asset:          wallet.GetAssetType().String(),
+		WatchingOnly:   wallet.IsWatchingOnlyWallet(),
+		Opened:         wallet.WalletOpened(),
+		Synced:         wallet.IsSynced(),
+		Syncing:        wallet.IsSyncing(),
+		ConnectedPeers: wallet.ConnectedPeers(),
+		BestBlock:      wallet.GetBestBlockHeight(),
+	}, nil
+}
+
+func handleGetBalance(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		WalletID int    `json:"walletid"`
+		Account  *int32 `json:"account"`
+	}
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance *sharedW.Balance
+	if p.Account != nil {
+		balance, err = wallet.GetAccountBalance(*p.Account)
+	} else {
+		balance, err = wallet.GetWalletBalance()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toBalanceResult(balance), nil
+}
+
+func handleListAccounts(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := wallet.GetAccountsRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*accountResult, 0, len(accounts.Accounts))
+	for _, account := range accounts.Accounts {
+		result = append(result, &accountResult{
+			Number:  account.Number,
+			Name:    account.Name,
+			Balance: toBalanceResult(account.Balance),
+		})
+	}
+	return result, nil
+}
+
+func handleGetNewAddress(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p accountParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.NextAddress(p.Account)
+}
+
+func handleValidateAddress(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p addressParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	valid := wallet.IsAddressValid(p.Address)
+	return map[string]bool{
+		"isvalid": valid,
+		"ismine":  valid && wallet.HaveAddress(p.Address),
+	}, nil
+}
+
+func handleListTransactions(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p listTransactionsParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.GetTransactionsRaw(p.Offset, p.Limit, p.Filter, p.NewestFirst, p.Search)
+}
+
+func handleGetTransaction(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p getTransactionParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.GetTransactionRaw(p.Hash)
+}
+
+func handleListUnspent(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p accountParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	utxos, err := wallet.UnspentOutputs(p.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*unspentResult, 0, len(utxos))
+	for _, utxo := range utxos {
+		result = append(result, &unspentResult{
+			TxID:          utxo.TxID,
+			Vout:          utxo.Vout,
+			Address:       utxo.Address,
+			Amount:        amountToInt(utxo.Amount),
+			Confirmations: utxo.Confirmations,
+			Spendable:     utxo.Spendable,
+			Tree:          utxo.Tree,
+		})
+	}
+	return result, nil
+}
+
+// prepareUnsignedTx creates a new unsigned tx on the wallet with the provided
+// outputs added as destinations. The caller must hold the lock returned by
+// lockTxAuthor.
+func prepareUnsignedTx(wallet sharedW.Asset, account int32, outputs []sendOutput) error {
+	if len(outputs) == 0 {
+		return newError(ErrCodeInvalidParams, "no outputs provided")
+	}
+
+	if err := wallet.NewUnsignedTx(account, nil); err != nil {
+		return err
+	}
+
+	for i, output := range outputs {
+		if !wallet.IsAddressValid(output.Address) {
+			return newError(ErrCodeInvalidParams, "invalid address %q", output.Address)
+		}
+		if err := wallet.AddSendDestination(i, output.Address, output.Amount, output.SendMax); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func handleEstimateFee(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p sendParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	unlock := s.lockTxAuthor(p.WalletID)
+	defer unlock()
+	if err := prepareUnsignedTx(wallet, p.Account, p.Outputs); err != nil {
+		return nil, err
+	}
+
+	feeAndSize, err := wallet.EstimateFeeAndSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &feeEstimateResult{
+		FeeRate:             feeAndSize.FeeRate,
+		EstimatedSignedSize: feeAndSize.EstimatedSignedSize,
+	}
+	if feeAndSize.Fee != nil {
+		result.Fee = feeAndSize.Fee.UnitValue
+	}
+	if feeAndSize.Change != nil {
+		result.Change = feeAndSize.Change.UnitValue
+	}
+	return result, nil
+}
+
+func handleSendTransaction(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p sendParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.IsWatchingOnlyWallet() {
+		return nil, newError(ErrCodeWallet, "watch-only wallets cannot send transactions")
+	}
+	unlock := s.lockTxAuthor(p.WalletID)
+	defer unlock()
+	if err := prepareUnsignedTx(wallet, p.Account, p.Outputs); err != nil {
+		return nil, err
+	}
+	return wallet.Broadcast(p.Passphrase, p.Label)
+}
+
+func handleSignMessage(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p signMessageParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := wallet.SignMessage(p.Passphrase, p.Address, p.Message)
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func handleVerifyMessage(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p verifyMessageParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.VerifyMessage(p.Address, p.Message, p.Signature)
+}
+
+func handleTicketPrice(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.TicketPrice()
+}
+
+func handleStakingInfo(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	overview, err := wallet.StakingOverview()
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := wallet.TotalStakingRewards()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"overview":           overview,
+		"totalrewards":       rewards,
+		"ticketbuyeractive":  wallet.IsAutoTicketsPurchaseActive(),
+		"ticketbuyerconfig":  wallet.AutoTicketsBuyerConfig(),
+		"accountmixeractive": wallet.IsAccountMixerActive(),
+		"solostaking":        wallet.IsSoloStaking(),
+		"solovotingactive":   wallet.IsSoloVotingActive(),
+	}, nil
+}
+
+func handlePurchaseTickets(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p purchaseTicketsParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Count < 1 {
+		return nil, newError(ErrCodeInvalidParams, "ticket count must be at least 1")
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo tickets are bought without a VSP.
+	vsp := new(dcr.VSP)
+	if p.VSPHost != "" || !wallet.IsSoloStaking() {
+		if vsp, err = knownVSP(wallet, p.VSPHost); err != nil {
+			return nil, err
+		}
+	}
+
+	hashes, err := wallet.PurchaseTickets(p.Account, p.Count, vsp.Host, p.Passphrase, vsp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		result = append(result, hash.String())
+	}
+	return result, nil
+}
+
+// knownVSP returns the VSP matching host, saving it as a known VSP if it was
+// not already known by the wallet.
+func knownVSP(wallet *dcr.Asset, host string) (*dcr.VSP, error) {
+	if host == "" {
+		return nil, newError(ErrCodeInvalidParams, "vsphost is required")
+	}
+
+	findVSP := func() *dcr.VSP {
+		for _, vsp := range wallet.KnownVSPs() {
+			if vsp.Host == host {
+				return vsp
+			}
+		}
+		return nil
+	}
+
+	if vsp := findVSP(); vsp != nil {
+		return vsp, nil
+	}
+	if err := wallet.SaveVSP(host); err != nil {
+		return nil, err
+	}
+	if vsp := findVSP(); vsp != nil {
+		return vsp, nil
+	}
+	return nil, newError(ErrCodeWallet, "vsp %s is unavailable", host)
+}
+
+func handleStartTicketBuyer(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p passphraseParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, wallet.StartTicketBuyer(p.Passphrase)
+}
+
+func handleStopTicketBuyer(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, wallet.StopAutoTicketsPurchase()
+}
+
+func handleStartMixer(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p passphraseParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, wallet.StartAccountMixer(p.Passphrase)
+}
+
+func handleStopMixer(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, wallet.StopAccountMixer()
+}
+
+func handleSetSoloStaking(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p setSoloStakingParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Enabled && p.RPCHost == "" {
+		return nil, newError(ErrCodeInvalidParams, "rpchost is required to enable solo staking")
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Enabled {
+		wallet.StopSoloVoting()
+	}
+	wallet.SetSoloStaking(p.Enabled)
+	if p.RPCHost != "" {
+		wallet.SetSoloVotingConfig(&dcr.SoloVotingConfig{
+			RPCHost:     p.RPCHost,
+			RPCUser:     p.RPCUser,
+			RPCCertFile: p.RPCCertFile,
+			RPCNoTLS:    p.RPCNoTLS,
+		})
+	}
+	return nil, nil
+}
+
+func handleStartSoloVoting(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p startSoloVotingParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return nil, wallet.StartSoloVoting(p.Passphrase, p.RPCPass)
+}
+
+func handleStopSoloVoting(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.dcrWallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	wallet.StopSoloVoting()
+	return nil, nil
+}
+
+func handleGetFeeRates(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p walletParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	feeAsset, ok := wallet.(feeRateAsset)
+	if !ok {
+		return nil, newError(ErrCodeUnsupported, "wallet %d does not support custom fee rates", p.WalletID)
+	}
+
+	estimates, err := feeAsset.GetAPIFeeEstimateRate()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*feeRateResult, 0, len(estimates))
+	for _, estimate := range estimates {
+		result = append(result, &feeRateResult{
+			ConfirmedBlocks: estimate.ConfirmedBlocks,
+			FeeRate:         amountToInt(estimate.Feerate),
+		})
+	}
+	return map[string]interface{}{
+		"estimates": result,
+		"current":   amountToInt(feeAsset.GetUserFeeRate()),
+	}, nil
+}
+
+func handleSetFeeRate(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p setFeeRateParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	wallet, err := s.wallet(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	feeAsset, ok := wallet.(feeRateAsset)
+	if !ok {
+		return nil, newError(ErrCodeUnsupported, "wallet %d does not support custom fee rates", p.WalletID)
+	}
+	return nil, feeAsset.SetUserFeeRate(wallet.ToAmount(p.FeeRate))
+}
+
+func handleStartScheduler(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p startSchedulerParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	if s.mgr.IsOrderSchedulerRunning() {
+		return nil, newError(ErrCodeWallet, "scheduler already running")
+	}
+
+	var order instantswap.Order
+	if err := json.Unmarshal(p.Order, &order); err != nil {
+		return nil, newError(ErrCodeInvalidParams, "invalid order: %v", err)
+	}
+
+	schedulerParams := instantswap.SchedulerParams{
+		Order:              order,
+		Frequency:          time.Duration(p.FrequencyHours * float64(time.Hour)),
+		BalanceToMaintain:  p.BalanceToMaintain,
+		MaxDeviationRate:   p.MaxDeviationRate,
+		SpendingPassphrase: p.SpendingPassphrase,
+	}
+
+	// The scheduler runs until it is stopped or fails, tie it to the server's
+	// lifetime rather than the request's.
+	go func() {
+		if err := s.mgr.StartScheduler(s.ctx, schedulerParams); err != nil {
+			log.Errorf("Order scheduler stopped: %v", err)
+		}
+	}()
+	return nil, nil
+}
+
+func handleStopScheduler(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	s.mgr.StopScheduler()
+	return nil, nil
+}
+
+func handleSchedulerStatus(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	return map[string]interface{}{
+		"running": s.mgr.IsOrderSchedulerRunning(),
+		"runtime": s.mgr.GetShedulerRuntime(),
+	}, nil
+}
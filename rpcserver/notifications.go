@@ -0,0 +1,129 @@
+package rpcserver
+
+import (
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// listenerID identifies the notification listeners registered by the rpc
+// server on each wallet.
+const listenerID = "rpcserver"
+
+// Notification methods sent to websocket clients.
+const (
+	ntfnTransaction          = "transaction"
+	ntfnBlockConnected       = "blockconnected"
+	ntfnTransactionConfirmed = "transactionconfirmed"
+	ntfnSyncStarted          = "syncstarted"
+	ntfnSyncCompleted        = "synccompleted"
+	ntfnSyncCanceled         = "synccanceled"
+	ntfnSyncError            = "syncerror"
+	ntfnPeersChanged         = "peerschanged"
+	ntfnMixerStarted         = "mixerstarted"
+	ntfnMixerEnded           = "mixerended"
+)
+
+type transactionNtfn struct {
+	WalletID    int                  `json:"walletid"`
+	Transaction *sharedW.Transaction `json:"transaction"`
+}
+
+type blockNtfn struct {
+	WalletID int    `json:"walletid"`
+	Height   int32  `json:"height"`
+	TxHash   string `json:"txhash,omitempty"`
+}
+
+type walletNtfn struct {
+	WalletID int    `json:"walletid"`
+	Peers    int32  `json:"peers,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// listenForWalletNotifications registers the listeners that forward the
+// wallet's events to websocket clients. It is safe to call more than once for
+// the same wallet.
+func (s *Server) listenForWalletNotifications(wallet sharedW.Asset) {
+	walletID := wallet.GetWalletID()
+
+	s.listenersMtx.Lock()
+	defer s.listenersMtx.Unlock()
+	if _, ok := s.listeningWallets[walletID]; ok {
+		return
+	}
+
+	txListener := &sharedW.TxAndBlockNotificationListener{
+		OnTransaction: func(walletID int, tx *sharedW.Transaction) {
+			s.broadcast(ntfnTransaction, &transactionNtfn{WalletID: walletID, Transaction: tx})
+		},
+		OnBlockAttached: func(walletID int, height int32) {
+			s.broadcast(ntfnBlockConnected, &blockNtfn{WalletID: walletID, Height: height})
+		},
+		OnTransactionConfirmed: func(walletID int, hash string, height int32) {
+			s.broadcast(ntfnTransactionConfirmed, &blockNtfn{WalletID: walletID, Height: height, TxHash: hash})
+		},
+	}
+	if err := wallet.AddTxAndBlockNotificationListener(txListener, listenerID); err != nil {
+		log.Errorf("Unable to listen for tx notifications of wallet %d: %v", walletID, err)
+	}
+
+	syncListener := &sharedW.SyncProgressListener{
+		OnSyncStarted: func() {
+			s.broadcast(ntfnSyncStarted, &walletNtfn{WalletID: walletID})
+		},
+		OnPeerConnectedOrDisconnected: func(peers int32) {
+			s.broadcast(ntfnPeersChanged, &walletNtfn{WalletID: walletID, Peers: peers})
+		},
+		OnCFiltersFetchProgress:    func(*sharedW.CFiltersFetchProgressReport) {},
+		OnHeadersFetchProgress:     func(*sharedW.HeadersFetchProgressReport) {},
+		OnAddressDiscoveryProgress: func(*sharedW.AddressDiscoveryProgressReport) {},
+		OnHeadersRescanProgress:    func(*sharedW.HeadersRescanProgressReport) {},
+		OnSyncCompleted: func() {
+			s.broadcast(ntfnSyncCompleted, &walletNtfn{WalletID: walletID})
+		},
+		OnSyncCanceled: func(bool) {
+			s.broadcast(ntfnSyncCanceled, &walletNtfn{WalletID: walletID})
+		},
+		OnSyncEndedWithError: func(err error) {
+			s.broadcast(ntfnSyncError, &walletNtfn{WalletID: walletID, Error: err.Error()})
+		},
+	}
+	if err := wallet.AddSyncProgressListener(syncListener, listenerID); err != nil {
+		log.Errorf("Unable to listen for sync notifications of wallet %d: %v", walletID, err)
+	}
+
+	if dcrAsset, ok := wallet.(*dcr.Asset); ok {
+		mixerListener := &dcr.AccountMixerNotificationListener{
+			OnAccountMixerStarted: func(walletID int) {
+				s.broadcast(ntfnMixerStarted, &walletNtfn{WalletID: walletID})
+			},
+			OnAccountMixerEnded: func(walletID int) {
+				s.broadcast(ntfnMixerEnded, &walletNtfn{WalletID: walletID})
+			},
+		}
+		if err := dcrAsset.AddAccountMixerNotificationListener(mixerListener, listenerID); err != nil {
+			log.Errorf("Unable to listen for mixer notifications of wallet %d: %v", walletID, err)
+		}
+	}
+
+	s.listeningWallets[walletID] = struct{}{}
+}
+
+// stopListeningForNotifications removes all the listeners registered by
+// listenForWalletNotifications.
+func (s *Server) stopListeningForNotifications() {
+	s.listenersMtx.Lock()
+	defer s.listenersMtx.Unlock()
+
+	for walletID := range s.listeningWallets {
+		wallet := s.mgr.WalletWithID(walletID)
+		if wallet != nil {
+			wallet.RemoveTxAndBlockNotificationListener(listenerID)
+			wallet.RemoveSyncProgressListener(listenerID)
+			if dcrAsset, ok := wallet.(*dcr.Asset); ok {
+				dcrAsset.RemoveAccountMixerNotificationListener(listenerID)
+			}
+		}
+		delete(s.listeningWallets, walletID)
+	}
+}
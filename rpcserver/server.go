@@ -0,0 +1,268 @@
+// Package rpcserver implements the JSON-RPC API served when cryptopower runs
+// in daemon mode. Requests are accepted over HTTP POST and websocket
+// connections; websocket clients additionally receive wallet notifications.
+package rpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// maxRequestSize is the maximum size of a single JSON-RPC request body.
+	maxRequestSize = 1 << 20 // 1 MiB
+
+	// wsPath is the http path websocket clients connect to.
+	wsPath = "/ws"
+)
+
+// Config defines the options used to start the JSON-RPC server.
+type Config struct {
+	// Listen is the host:port the server listens on.
+	Listen string
+	// User and Pass are the credentials required from every client using
+	// HTTP basic authentication.
+	User string
+	Pass string
+	// CertFile and KeyFile enable TLS when both are set. Without TLS the
+	// server refuses to listen on anything but a loopback address.
+	CertFile string
+	KeyFile  string
+	// Version is the application version reported by the version method.
+	Version string
+}
+
+// Server serves the cryptopower JSON-RPC API on top of an AssetsManager.
+type Server struct {
+	cfg     *Config
+	mgr     *libwallet.AssetsManager
+	authSHA [sha256.Size]byte
+
+	httpServer *http.Server
+	upgrader   websocket.Upgrader
+
+	wsClientsMtx sync.Mutex
+	wsClients    map[*wsClient]struct{}
+
+	listenersMtx     sync.Mutex
+	listeningWallets map[int]struct{}
+
+	// txAuthorMtxs serialize the requests building a transaction on a
+	// wallet, they share the wallet's unsigned tx.
+	txAuthorMtxsMtx sync.Mutex
+	txAuthorMtxs    map[int]*sync.Mutex
+
+	// ctx is canceled when the server is stopped. It is used as the parent
+	// context of long running operations such as the order scheduler.
+	ctx context.Context
+}
+
+// New validates the provided config and returns a server that is ready to be
+// started with Run.
+func New(cfg *Config, mgr *libwallet.AssetsManager) (*Server, error) {
+	if cfg.User == "" || cfg.Pass == "" {
+		return nil, errors.New("rpc username and password must be set")
+	}
+
+	useTLS := cfg.CertFile != "" && cfg.KeyFile != ""
+	if !useTLS {
+		host, _, err := net.SplitHostPort(cfg.Listen)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rpc listen address %q: %w", cfg.Listen, err)
+		}
+		if !utils.IsLoopbackHost(host) {
+			return nil, fmt.Errorf("refusing to serve rpc without TLS on non-loopback address %s", cfg.Listen)
+		}
+	}
+
+	login := cfg.User + ":" + cfg.Pass
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+
+	return &Server{
+		cfg:              cfg,
+		mgr:              mgr,
+		authSHA:          sha256.Sum256([]byte(auth)),
+		wsClients:        make(map[*wsClient]struct{}),
+		listeningWallets: make(map[int]struct{}),
+		txAuthorMtxs:     make(map[int]*sync.Mutex),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+		},
+	}, nil
+}
+
+// Run starts the server and blocks until ctx is canceled or the listener
+// fails.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.ctx = ctx
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleHTTP)
+	mux.HandleFunc(wsPath, s.handleWebsocket)
+
+	s.httpServer = &http.Server{
+		Addr:              s.cfg.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	listener, err := net.Listen("tcp", s.cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("rpc listen error: %w", err)
+	}
+
+	if s.cfg.CertFile != "" && s.cfg.KeyFile != "" {
+		keyPair, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("unable to load rpc TLS key pair: %w", err)
+		}
+		listener = tls.NewListener(listener, &tls.Config{
+			Certificates: []tls.Certificate{keyPair},
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
+
+	for _, wallet := range s.mgr.AllWallets() {
+		s.listenForWalletNotifications(wallet)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Infof("RPC server listening on %s", listener.Addr())
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if shutdownErr := s.httpServer.Shutdown(shutdownCtx); shutdownErr != nil {
+		log.Errorf("RPC server shutdown error: %v", shutdownErr)
+	}
+
+	s.closeWebsocketClients()
+	s.stopListeningForNotifications()
+	log.Info("RPC server stopped")
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// checkAuth returns true if the request carries the configured credentials.
+// The comparison is done on hashes in constant time.
+func (s *Server) checkAuth(r *http.Request) bool {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return false
+	}
+	authSHA := sha256.Sum256([]byte(authHeader))
+	return subtle.ConstantTimeCompare(authSHA[:], s.authSHA[:]) == 1
+}
+
+func (s *Server) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAuth(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="cryptopower RPC"`)
+		http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "405 Method Not Allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil {
+		http.Error(w, "400 Bad Request.", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxRequestSize {
+		http.Error(w, "413 Request Entity Too Large.", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	resp := s.processRequest(r.Context(), body)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("Failed to write rpc response: %v", err)
+	}
+}
+
+// processRequest decodes and runs a single JSON-RPC request, returning the
+// response that should be sent back to the client.
+func (s *Server) processRequest(ctx context.Context, body []byte) *Response {
+	resp := &Response{JSONRPC: "2.0"}
+
+	var req Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		resp.Error = newError(ErrCodeParse, "failed to parse request: %v", err)
+		return resp
+	}
+	resp.ID = req.ID
+
+	if req.Method == "" {
+		resp.Error = newError(ErrCodeInvalidRequest, "missing method")
+		return resp
+	}
+
+	handler, ok := rpcHandlers[req.Method]
+	if !ok {
+		resp.Error = newError(ErrCodeMethodNotFound, "unknown method %q", req.Method)
+		return resp
+	}
+
+	log.Debugf("Handling rpc request %s", req.Method)
+	result, err := handler(ctx, s, req.Params)
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = newError(ErrCodeWallet, "%v", err)
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+
+	resp.Result = result
+	return resp
+}
+
+// lockTxAuthor locks the unsigned tx of the wallet with walletID until the
+// returned function is called. The unsigned tx is created by NewUnsignedTx
+// and shared by every caller, a request must hold the lock from creating it
+// to using it so concurrent requests don't mix their destinations.
+func (s *Server) lockTxAuthor(walletID int) func() {
+	s.txAuthorMtxsMtx.Lock()
+	mtx, ok := s.txAuthorMtxs[walletID]
+	if !ok {
+		mtx = new(sync.Mutex)
+		s.txAuthorMtxs[walletID] = mtx
+	}
+	s.txAuthorMtxsMtx.Unlock()
+
+	mtx.Lock()
+	return mtx.Unlock
+}
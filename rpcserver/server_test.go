@@ -0,0 +1,228 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	s, err := New(&Config{Listen: "127.0.0.1:0", User: "user", Pass: "pass"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func basicAuth(user, pass string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       *Config
+		expectErr bool
+	}{
+		{name: "loopback without tls", cfg: &Config{Listen: "127.0.0.1:9000", User: "u", Pass: "p"}},
+		{name: "localhost without tls", cfg: &Config{Listen: "localhost:9000", User: "u", Pass: "p"}},
+		{name: "ipv6 loopback without tls", cfg: &Config{Listen: "[::1]:9000", User: "u", Pass: "p"}},
+		{name: "public address without tls", cfg: &Config{Listen: "0.0.0.0:9000", User: "u", Pass: "p"}, expectErr: true},
+		{name: "lan address without tls", cfg: &Config{Listen: "192.168.1.2:9000", User: "u", Pass: "p"}, expectErr: true},
+		{
+			name: "public address with tls",
+			cfg:  &Config{Listen: "0.0.0.0:9000", User: "u", Pass: "p", CertFile: "rpc.cert", KeyFile: "rpc.key"},
+		},
+		{
+			name:      "public address with only a certificate",
+			cfg:       &Config{Listen: "0.0.0.0:9000", User: "u", Pass: "p", CertFile: "rpc.cert"},
+			expectErr: true,
+		},
+		{name: "invalid address", cfg: &Config{Listen: "127.0.0.1", User: "u", Pass: "p"}, expectErr: true},
+		{name: "missing password", cfg: &Config{Listen: "127.0.0.1:9000", User: "u"}, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.cfg, nil)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("(%v), expected error (%v), got (%v)", tc.name, tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckAuth(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{name: "missing header"},
+		{name: "wrong password", header: basicAuth("user", "wrong")},
+		{name: "wrong user", header: basicAuth("other", "pass")},
+		{name: "not basic auth", header: "Bearer " + base64.StdEncoding.EncodeToString([]byte("user:pass"))},
+		{name: "correct credentials", header: basicAuth("user", "pass"), expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tc.header != "" {
+				r.Header.Set("Authorization", tc.header)
+			}
+			if got := s.checkAuth(r); got != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestProcessRequest(t *testing.T) {
+	s := newTestServer(t)
+
+	rpcHandlers["testwalleterror"] = func(_ context.Context, _ *Server, _ json.RawMessage) (interface{}, error) {
+		return nil, errors.New("wallet is locked")
+	}
+	t.Cleanup(func() { delete(rpcHandlers, "testwalleterror") })
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedID      string
+		expectedErrCode int
+	}{
+		{name: "parse error", body: `{"method":`, expectedErrCode: ErrCodeParse},
+		{name: "missing method", body: `{"id":1}`, expectedID: "1", expectedErrCode: ErrCodeInvalidRequest},
+		{name: "unknown method", body: `{"id":2,"method":"nosuchmethod"}`, expectedID: "2", expectedErrCode: ErrCodeMethodNotFound},
+		{name: "missing params", body: `{"id":3,"method":"getbalance"}`, expectedID: "3", expectedErrCode: ErrCodeInvalidParams},
+		{
+			name:            "invalid params",
+			body:            `{"id":4,"method":"getbalance","params":{"walletid":"one"}}`,
+			expectedID:      "4",
+			expectedErrCode: ErrCodeInvalidParams,
+		},
+		{
+			name:            "wallet error",
+			body:            `{"id":"a","method":"testwalleterror"}`,
+			expectedID:      `"a"`,
+			expectedErrCode: ErrCodeWallet,
+		},
+		{name: "success", body: `{"id":5,"method":"help"}`, expectedID: "5"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := s.processRequest(context.Background(), []byte(tc.body))
+			if resp.JSONRPC != "2.0" {
+				t.Errorf("(%v), expected jsonrpc (2.0), got (%v)", tc.name, resp.JSONRPC)
+			}
+			if string(resp.ID) != tc.expectedID {
+				t.Errorf("(%v), expected id (%v), got (%v)", tc.name, tc.expectedID, string(resp.ID))
+			}
+			if tc.expectedErrCode != 0 {
+				if resp.Error == nil || resp.Error.Code != tc.expectedErrCode {
+					t.Errorf("(%v), expected error code (%v), got (%v)", tc.name, tc.expectedErrCode, resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("(%v), unexpected error: %v", tc.name, resp.Error)
+			}
+			methods, ok := resp.Result.([]string)
+			if !ok || len(methods) != len(rpcHandlers) {
+				t.Errorf("(%v), expected the (%v) methods, got (%v)", tc.name, len(rpcHandlers), resp.Result)
+			}
+		})
+	}
+}
+
+func TestHandleHTTP(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		method         string
+		auth           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "unauthorized", method: http.MethodPost, body: `{"method":"help"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "method not allowed", method: http.MethodGet, auth: basicAuth("user", "pass"), expectedStatus: http.StatusMethodNotAllowed},
+		{
+			name:           "request too large",
+			method:         http.MethodPost,
+			auth:           basicAuth("user", "pass"),
+			body:           strings.Repeat(" ", maxRequestSize+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "request at the size limit",
+			method:         http.MethodPost,
+			auth:           basicAuth("user", "pass"),
+			body:           `{"method":"help"}` + strings.Repeat(" ", maxRequestSize-len(`{"method":"help"}`)),
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, "/", strings.NewReader(tc.body))
+			if tc.auth != "" {
+				r.Header.Set("Authorization", tc.auth)
+			}
+			w := httptest.NewRecorder()
+			s.handleHTTP(w, r)
+			if w.Code != tc.expectedStatus {
+				t.Errorf("(%v), expected status (%v), got (%v)", tc.name, tc.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestLockTxAuthor(t *testing.T) {
+	s := newTestServer(t)
+
+	unlock := s.lockTxAuthor(1)
+
+	// Another wallet is not blocked.
+	otherLocked := make(chan struct{})
+	go func() {
+		s.lockTxAuthor(2)()
+		close(otherLocked)
+	}()
+	select {
+	case <-otherLocked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a request on another wallet was blocked")
+	}
+
+	// A second request on the same wallet waits for the first one.
+	locked := make(chan struct{})
+	go func() {
+		unlockSecond := s.lockTxAuthor(1)
+		close(locked)
+		unlockSecond()
+	}()
+	select {
+	case <-locked:
+		t.Fatal("two requests held the lock of the same wallet")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second request did not get the lock once released")
+	}
+}
@@ -0,0 +1,267 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// Standard JSON-RPC error codes plus the application specific codes returned
+// by the wallet handlers.
+const (
+	ErrCodeParse          = -32700
+	ErrCodeInvalidRequest = -32600
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeInternal       = -32603
+
+	// ErrCodeWallet is returned when the wallet backend rejects a request.
+	ErrCodeWallet = -4
+	// ErrCodeWalletNotFound is returned when no wallet matches the provided
+	// wallet id.
+	ErrCodeWalletNotFound = -5
+	// ErrCodeUnsupported is returned when a method is called on a wallet
+	// whose asset does not implement it.
+	ErrCodeUnsupported = -6
+)
+
+// Request is a JSON-RPC request. Params, when provided, must be a JSON object
+// whose fields match the parameters of the requested method.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is the reply sent for every Request.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+// Notification is pushed to websocket clients when a wallet event occurs.
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// Error is the error object returned in a failed Response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error satisfies the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func newError(code int, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+/** begin method parameter types */
+
+type walletParams struct {
+	WalletID int `json:"walletid"`
+}
+
+type accountParams struct {
+	WalletID int   `json:"walletid"`
+	Account  int32 `json:"account"`
+}
+
+type passphraseParams struct {
+	WalletID   int    `json:"walletid"`
+	Passphrase string `json:"passphrase"`
+}
+
+type openWalletsParams struct {
+	StartupPassphrase string `json:"startuppassphrase"`
+}
+
+type createWalletParams struct {
+	Asset      string `json:"asset"`
+	Name       string `json:"name"`
+	Passphrase string `json:"passphrase"`
+	SeedWords  int    `json:"seedwords"`
+	Seed       string `json:"seed,omitempty"`
+}
+
+type createWatchOnlyWalletParams struct {
+	Asset string `json:"asset"`
+	Name  string `json:"name"`
+	XPub  string `json:"xpub"`
+}
+
+type listTransactionsParams struct {
+	WalletID    int    `json:"walletid"`
+	Offset      int32  `json:"offset"`
+	Limit       int32  `json:"limit"`
+	Filter      int32  `json:"filter"`
+	NewestFirst bool   `json:"newestfirst"`
+	Search      string `json:"search,omitempty"`
+}
+
+type getTransactionParams struct {
+	WalletID int    `json:"walletid"`
+	Hash     string `json:"hash"`
+}
+
+type addressParams struct {
+	WalletID int    `json:"walletid"`
+	Address  string `json:"address"`
+}
+
+type signMessageParams struct {
+	WalletID   int    `json:"walletid"`
+	Address    string `json:"address"`
+	Message    string `json:"message"`
+	Passphrase string `json:"passphrase"`
+}
+
+type verifyMessageParams struct {
+	WalletID  int    `json:"walletid"`
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// sendOutput is a single destination of a send or fee estimate request.
+// Amount is in the smallest unit of the asset (atoms, satoshis or litoshis).
+type sendOutput struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+	SendMax bool   `json:"sendmax,omitempty"`
+}
+
+type sendParams struct {
+	WalletID   int          `json:"walletid"`
+	Account    int32        `json:"account"`
+	Outputs    []sendOutput `json:"outputs"`
+	Passphrase string       `json:"passphrase"`
+	Label      string       `json:"label,omitempty"`
+}
+
+type purchaseTicketsParams struct {
+	WalletID   int    `json:"walletid"`
+	Account    int32  `json:"account"`
+	Count      int32  `json:"count"`
+	VSPHost    string `json:"vsphost"`
+	Passphrase string `json:"passphrase"`
+}
+
+type setSoloStakingParams struct {
+	WalletID    int    `json:"walletid"`
+	Enabled     bool   `json:"enabled"`
+	RPCHost     string `json:"rpchost"`
+	RPCUser     string `json:"rpcuser"`
+	RPCCertFile string `json:"rpccertfile"`
+	RPCNoTLS    bool   `json:"rpcnotls"`
+}
+
+// startSoloVotingParams carries the dcrd RPC password, which is not saved by
+// setsolostaking.
+type startSoloVotingParams struct {
+	WalletID   int    `json:"walletid"`
+	Passphrase string `json:"passphrase"`
+	RPCPass    string `json:"rpcpass"`
+}
+
+type setFeeRateParams struct {
+	WalletID int   `json:"walletid"`
+	FeeRate  int64 `json:"feerate"` // per kvB in the asset's smallest unit.
+}
+
+type startSchedulerParams struct {
+	Order              json.RawMessage `json:"order"`
+	FrequencyHours     float64         `json:"frequencyhours"`
+	BalanceToMaintain  float64         `json:"balancetomaintain"`
+	MaxDeviationRate   float64         `json:"maxdeviationrate"`
+	SpendingPassphrase string          `json:"passphrase"`
+}
+
+/** end method parameter types */
+
+/** begin method result types */
+
+type walletInfo struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Asset          string `json:"asset"`
+	WatchingOnly   bool   `json:"watchingonly"`
+	Opened         bool   `json:"opened"`
+	Synced         bool   `json:"synced"`
+	Syncing        bool   `json:"syncing"`
+	ConnectedPeers int32  `json:"connectedpeers"`
+	BestBlock      int32  `json:"bestblock"`
+}
+
+type balanceResult struct {
+	Total                   int64 `json:"total"`
+	Spendable               int64 `json:"spendable"`
+	ImmatureReward          int64 `json:"immaturereward"`
+	Locked                  int64 `json:"locked"`
+	ImmatureStakeGeneration int64 `json:"immaturestakegeneration,omitempty"`
+	LockedByTickets         int64 `json:"lockedbytickets,omitempty"`
+	VotingAuthority         int64 `json:"votingauthority,omitempty"`
+	UnConfirmed             int64 `json:"unconfirmed,omitempty"`
+}
+
+type accountResult struct {
+	Number  int32          `json:"number"`
+	Name    string         `json:"name"`
+	Balance *balanceResult `json:"balance"`
+}
+
+type unspentResult struct {
+	TxID          string `json:"txid"`
+	Vout          uint32 `json:"vout"`
+	Address       string `json:"address"`
+	Amount        int64  `json:"amount"`
+	Confirmations int32  `json:"confirmations"`
+	Spendable     bool   `json:"spendable"`
+	Tree          int8   `json:"tree"`
+}
+
+type feeEstimateResult struct {
+	Fee                 int64 `json:"fee"`
+	Change              int64 `json:"change"`
+	FeeRate             int64 `json:"feerate"`
+	EstimatedSignedSize int   `json:"estimatedsignedsize"`
+}
+
+type feeRateResult struct {
+	ConfirmedBlocks int32 `json:"confirmedblocks"`
+	FeeRate         int64 `json:"feerate"`
+}
+
+/** end method result types */
+
+func amountToInt(amt sharedW.AssetAmount) int64 {
+	if amt == nil {
+		return 0
+	}
+	return amt.ToInt()
+}
+
+func toBalanceResult(bal *sharedW.Balance) *balanceResult {
+	if bal == nil {
+		return nil
+	}
+	return &balanceResult{
+		Total:                   amountToInt(bal.Total),
+		Spendable:               amountToInt(bal.Spendable),
+		ImmatureReward:          amountToInt(bal.ImmatureReward),
+		Locked:                  amountToInt(bal.Locked),
+		ImmatureStakeGeneration: amountToInt(bal.ImmatureStakeGeneration),
+		LockedByTickets:         amountToInt(bal.LockedByTickets),
+		VotingAuthority:         amountToInt(bal.VotingAuthority),
+		UnConfirmed:             amountToInt(bal.UnConfirmed),
+	}
+}
@@ -0,0 +1,171 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsSendQueueSize is the number of messages that can be queued for a
+	// websocket client before notifications start getting dropped.
+	wsSendQueueSize = 256
+
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+)
+
+// wsClient is a single authenticated websocket connection.
+type wsClient struct {
+	conn *websocket.Conn
+	send chan []byte
+
+	quitOnce sync.Once
+	quit     chan struct{}
+}
+
+func (s *Server) handleWebsocket(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAuth(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="cryptopower RPC"`)
+		http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("Websocket upgrade failed: %v", err)
+		return
+	}
+	conn.SetReadLimit(maxRequestSize)
+
+	client := &wsClient{
+		conn: conn,
+		send: make(chan []byte, wsSendQueueSize),
+		quit: make(chan struct{}),
+	}
+
+	s.wsClientsMtx.Lock()
+	s.wsClients[client] = struct{}{}
+	s.wsClientsMtx.Unlock()
+	log.Debugf("New websocket client %s", r.RemoteAddr)
+
+	go s.wsOutHandler(client)
+	s.wsInHandler(s.ctx, client)
+
+	s.wsClientsMtx.Lock()
+	delete(s.wsClients, client)
+	s.wsClientsMtx.Unlock()
+	client.disconnect()
+	log.Debugf("Websocket client %s disconnected", r.RemoteAddr)
+}
+
+// wsInHandler reads requests from the client until the connection is closed.
+// Requests are processed sequentially in the order they were received.
+func (s *Server) wsInHandler(ctx context.Context, client *wsClient) {
+	_ = client.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	client.conn.SetPongHandler(func(string) error {
+		return client.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, msg, err := client.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Errorf("Websocket read error: %v", err)
+			}
+			return
+		}
+
+		resp := s.processRequest(ctx, msg)
+		b, err := json.Marshal(resp)
+		if err != nil {
+			log.Errorf("Failed to marshal rpc response: %v", err)
+			continue
+		}
+		client.queue(b, true)
+	}
+}
+
+// wsOutHandler writes queued messages to the client and keeps the connection
+// alive with periodic pings.
+func (s *Server) wsOutHandler(client *wsClient) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-client.send:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := client.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				client.disconnect()
+				return
+			}
+		case <-ticker.C:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				client.disconnect()
+				return
+			}
+		case <-client.quit:
+			return
+		}
+	}
+}
+
+// queue adds msg to the client's send queue. Responses block until there is
+// room in the queue while notifications are dropped for slow clients.
+func (c *wsClient) queue(msg []byte, wait bool) {
+	if wait {
+		select {
+		case c.send <- msg:
+		case <-c.quit:
+		}
+		return
+	}
+
+	select {
+	case c.send <- msg:
+	default:
+		log.Warn("Dropping notification for slow websocket client")
+	}
+}
+
+func (c *wsClient) disconnect() {
+	c.quitOnce.Do(func() {
+		close(c.quit)
+		c.conn.Close()
+	})
+}
+
+// broadcast sends a notification to every connected websocket client.
+func (s *Server) broadcast(method string, params interface{}) {
+	b, err := json.Marshal(&Notification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		log.Errorf("Failed to marshal %s notification: %v", method, err)
+		return
+	}
+
+	s.wsClientsMtx.Lock()
+	defer s.wsClientsMtx.Unlock()
+	for client := range s.wsClients {
+		client.queue(b, false)
+	}
+}
+
+func (s *Server) closeWebsocketClients() {
+	s.wsClientsMtx.Lock()
+	defer s.wsClientsMtx.Unlock()
+	for client := range s.wsClients {
+		client.disconnect()
+		delete(s.wsClients, client)
+	}
+}
@@ -0,0 +1,116 @@
+package cryptomaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// BarChartItem is a single bar of a BarChart.
+type BarChartItem struct {
+	Label string
+	Value float64
+	// ValueText is drawn above the bar.
+	ValueText string
+}
+
+// BarChart draws a vertical bar per item from a zero baseline, negative
+// values are drawn below the baseline.
+type BarChart struct {
+	t *Theme
+
+	Items         []BarChartItem
+	Height        unit.Dp
+	TextSize      unit.Sp
+	Color         color.NRGBA
+	NegativeColor color.NRGBA
+}
+
+// BarChart returns a bar chart of items.
+func (t *Theme) BarChart(items []BarChartItem) *BarChart {
+	return &BarChart{
+		t:             t,
+		Items:         items,
+		Height:        values.MarginPadding120,
+		TextSize:      values.TextSize12,
+		Color:         t.Color.Primary,
+		NegativeColor: t.Color.Danger,
+	}
+}
+
+// Layout draws the value labels, the bars and the item labels.
+func (bc *BarChart) Layout(gtx C) D {
+	if len(bc.Items) == 0 {
+		return D{}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return bc.labelRow(gtx, func(item BarChartItem) string { return item.ValueText })
+		}),
+		layout.Rigid(bc.layoutBars),
+		layout.Rigid(func(gtx C) D {
+			return bc.labelRow(gtx, func(item BarChartItem) string { return item.Label })
+		}),
+	)
+}
+
+// labelRow lays out a label centered under each bar.
+func (bc *BarChart) labelRow(gtx C, labelText func(BarChartItem) string) D {
+	children := make([]layout.FlexChild, len(bc.Items))
+	for i, item := range bc.Items {
+		item := item
+		children[i] = layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			lbl := bc.t.Label(bc.TextSize, labelText(item))
+			lbl.Color = bc.t.Color.GrayText2
+			lbl.Alignment = text.Middle
+			lbl.MaxLines = 1
+			return lbl.Layout(gtx)
+		})
+	}
+	return layout.Flex{}.Layout(gtx, children...)
+}
+
+func (bc *BarChart) layoutBars(gtx C) D {
+	size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(bc.Height))
+
+	var maxValue, minValue float64
+	for _, item := range bc.Items {
+		maxValue = math.Max(maxValue, item.Value)
+		minValue = math.Min(minValue, item.Value)
+	}
+	span := maxValue - minValue
+	if span == 0 {
+		span = 1
+	}
+
+	// baseline is the y coordinate of the zero value.
+	baseline := int(float64(size.Y) * maxValue / span)
+	slot := size.X / len(bc.Items)
+	barWidth := slot * 3 / 5
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	for i, item := range bc.Items {
+		height := int(math.Abs(item.Value) / span * float64(size.Y))
+		x := i*slot + (slot-barWidth)/2
+		bar, col := image.Rect(x, baseline-height, x+barWidth, baseline), bc.Color
+		if item.Value < 0 {
+			bar, col = image.Rect(x, baseline, x+barWidth, baseline+height), bc.NegativeColor
+		}
+		paint.FillShape(gtx.Ops, col, clip.Rect(bar).Op())
+	}
+	paint.FillShape(gtx.Ops, bc.t.Color.Gray3, clip.Rect(image.Rect(0, baseline, size.X, baseline+1)).Op())
+
+	return D{Size: size}
+}
@@ -0,0 +1,122 @@
+package cryptomaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// LineChartPoint is a single point of a LineChart.
+type LineChartPoint struct {
+	Label string
+	Value float64
+}
+
+// LineChart draws a line through its points from left to right, scaled
+// between their lowest and highest values. The labels of the first, middle
+// and last points are drawn under the line.
+type LineChart struct {
+	t *Theme
+
+	Points      []LineChartPoint
+	Height      unit.Dp
+	TextSize    unit.Sp
+	StrokeWidth unit.Dp
+	Color       color.NRGBA
+}
+
+// LineChart returns a line chart of points.
+func (t *Theme) LineChart(points []LineChartPoint) *LineChart {
+	return &LineChart{
+		t:           t,
+		Points:      points,
+		Height:      values.MarginPadding120,
+		TextSize:    values.TextSize12,
+		StrokeWidth: values.MarginPadding2,
+		Color:       t.Color.Primary,
+	}
+}
+
+// Layout draws the line and the labels.
+func (lc *LineChart) Layout(gtx C) D {
+	if len(lc.Points) == 0 {
+		return D{}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(lc.layoutLine),
+		layout.Rigid(lc.layoutLabels),
+	)
+}
+
+func (lc *LineChart) layoutLine(gtx C) D {
+	size := image.Pt(gtx.Constraints.Max.X, gtx.Dp(lc.Height))
+
+	minValue, maxValue := math.Inf(1), math.Inf(-1)
+	for _, point := range lc.Points {
+		minValue = math.Min(minValue, point.Value)
+		maxValue = math.Max(maxValue, point.Value)
+	}
+	span := maxValue - minValue
+
+	// Keep the stroke inside the chart area.
+	stroke := float32(gtx.Dp(lc.StrokeWidth))
+	height := float32(size.Y) - stroke
+	pos := func(i int, value float64) f32.Point {
+		x := float32(size.X)
+		if len(lc.Points) > 1 {
+			x = float32(i) * float32(size.X) / float32(len(lc.Points)-1)
+		}
+		y := height / 2
+		if span > 0 {
+			y = height * float32((maxValue-value)/span)
+		}
+		return f32.Pt(x, y+stroke/2)
+	}
+
+	var path clip.Path
+	path.Begin(gtx.Ops)
+	start := pos(0, lc.Points[0].Value)
+	// A single point is drawn as a flat line across the chart.
+	path.MoveTo(f32.Pt(0, start.Y))
+	for i, point := range lc.Points {
+		path.LineTo(pos(i, point.Value))
+	}
+	paint.FillShape(gtx.Ops, lc.Color, clip.Stroke{Path: path.End(), Width: stroke}.Op())
+	paint.FillShape(gtx.Ops, lc.t.Color.Gray3, clip.Rect(image.Rect(0, size.Y-1, size.X, size.Y)).Op())
+
+	return D{Size: size}
+}
+
+// layoutLabels lays out the labels of the first, middle and last points.
+func (lc *LineChart) layoutLabels(gtx C) D {
+	label := func(index int, alignment text.Alignment) layout.FlexChild {
+		return layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			lbl := lc.t.Label(lc.TextSize, lc.Points[index].Label)
+			lbl.Color = lc.t.Color.GrayText2
+			lbl.Alignment = alignment
+			lbl.MaxLines = 1
+			return lbl.Layout(gtx)
+		})
+	}
+
+	last := len(lc.Points) - 1
+	if last == 0 {
+		return layout.Flex{}.Layout(gtx, label(0, text.End))
+	}
+	return layout.Flex{}.Layout(gtx,
+		label(0, text.Start),
+		label(last/2, text.Middle),
+		label(last, text.End),
+	)
+}
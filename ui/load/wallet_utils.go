@@ -55,3 +55,199 @@ func GetAPIFeeRate(w sharedW.Asset) ([]sharedW.FeeEstimate, error) {
 		return nil, fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
 	}
 }
+
+// CanBumpFee returns true if the transaction can be replaced by one paying a
+// higher fee.
+func CanBumpFee(w sharedW.Asset, txHash string) bool {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.CanBumpFee(txHash)
+	case *ltc.Asset:
+		return asset.CanBumpFee(txHash)
+	default:
+		return false
+	}
+}
+
+// BumpFee replaces the transaction with one paying feeRatePerkvB and returns
+// the hash of the replacement.
+func BumpFee(w sharedW.Asset, txHash string, feeRatePerkvB int64, passphrase string) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.BumpFee(txHash, feeRatePerkvB, passphrase)
+	case *ltc.Asset:
+		return asset.BumpFee(txHash, feeRatePerkvB, passphrase)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// CanCPFP returns true if the transaction has unconfirmed wallet outputs that
+// a child transaction can spend to accelerate it.
+func CanCPFP(w sharedW.Asset, txHash string) bool {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.CanCPFP(txHash)
+	case *ltc.Asset:
+		return asset.CanCPFP(txHash)
+	default:
+		return false
+	}
+}
+
+// CPFP accelerates the transaction with a child transaction paying
+// feeRatePerkvB for the package and returns the hash of the child.
+func CPFP(w sharedW.Asset, txHash string, feeRatePerkvB int64, passphrase string) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.CPFP(txHash, feeRatePerkvB, passphrase)
+	case *ltc.Asset:
+		return asset.CPFP(txHash, feeRatePerkvB, passphrase)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// SupportsPSBT returns true if the wallet can create and publish partially
+// signed transactions.
+func SupportsPSBT(w sharedW.Asset) bool {
+	switch w.(type) {
+	case *btc.Asset, *ltc.Asset:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateUnsignedPSBT returns the wallet's current unsigned transaction as a
+// base64 encoded PSBT.
+func CreateUnsignedPSBT(w sharedW.Asset) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.CreateUnsignedPSBT()
+	case *ltc.Asset:
+		return asset.CreateUnsignedPSBT()
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// SignPSBT signs the inputs of the PSBT that belong to the wallet if its
+// transaction is the one reviewed with reviewedTxHash.
+func SignPSBT(w sharedW.Asset, psbt, reviewedTxHash, passphrase string) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.SignPSBT(psbt, reviewedTxHash, passphrase)
+	case *ltc.Asset:
+		return asset.SignPSBT(psbt, reviewedTxHash, passphrase)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// PublishPSBT finalizes the fully signed PSBT and broadcasts its transaction.
+func PublishPSBT(w sharedW.Asset, psbt, label string) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.PublishPSBT(psbt, label)
+	case *ltc.Asset:
+		return asset.PublishPSBT(psbt, label)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// ExportPSBTFile saves the PSBT to the wallet's data directory and returns
+// the file path.
+func ExportPSBTFile(w sharedW.Asset, psbt string) (string, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.ExportPSBTFile(psbt)
+	case *ltc.Asset:
+		return asset.ExportPSBTFile(psbt)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// SupportsOfflineSigning returns true if a watch-only wallet of this asset
+// can export unsigned transactions for signing by an offline wallet.
+func SupportsOfflineSigning(w sharedW.Asset) bool {
+	switch w.(type) {
+	case *btc.Asset, *ltc.Asset, *dcr.Asset:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExportUnsignedTx saves the wallet's current unsigned transaction to a file,
+// a PSBT for BTC and LTC or a transaction file for DCR. It returns the file
+// path and, for PSBTs, the base64 encoded packet.
+func ExportUnsignedTx(w sharedW.Asset) (string, string, error) {
+	if asset, ok := w.(*dcr.Asset); ok {
+		path, err := asset.ExportUnsignedTx()
+		return "", path, err
+	}
+
+	psbt, err := CreateUnsignedPSBT(w)
+	if err != nil {
+		return "", "", err
+	}
+	path, err := ExportPSBTFile(w, psbt)
+	return psbt, path, err
+}
+
+// ReviewOfflineTx decodes the outputs and fee of an exported transaction for
+// review before it is signed with SignOfflineTx.
+func ReviewOfflineTx(w sharedW.Asset, tx string) (*sharedW.UnsignedTxSummary, error) {
+	switch asset := w.(type) {
+	case *btc.Asset:
+		return asset.ReviewPSBT(tx)
+	case *ltc.Asset:
+		return asset.ReviewPSBT(tx)
+	case *dcr.Asset:
+		return asset.ReviewOfflineTx(tx)
+	default:
+		return nil, fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// SignOfflineTx signs an exported transaction and saves the result to a file.
+// It returns the file path and, for PSBTs, the signed base64 encoded packet.
+func SignOfflineTx(w sharedW.Asset, tx, reviewedTxHash, passphrase string) (string, string, error) {
+	if asset, ok := w.(*dcr.Asset); ok {
+		path, err := asset.SignOfflineTx(tx, reviewedTxHash, passphrase)
+		return "", path, err
+	}
+
+	psbt, err := SignPSBT(w, tx, reviewedTxHash, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+	path, err := ExportPSBTFile(w, psbt)
+	return psbt, path, err
+}
+
+// ReadOfflineTxFile reads a transaction file exchanged with an offline wallet
+// and returns its contents in the form the offline signing functions accept.
+func ReadOfflineTxFile(w sharedW.Asset, path string) (string, error) {
+	switch w.(type) {
+	case *btc.Asset:
+		return btc.ReadPSBTFile(path)
+	case *ltc.Asset:
+		return ltc.ReadPSBTFile(path)
+	case *dcr.Asset:
+		return dcr.ReadOfflineTxFile(path)
+	default:
+		return "", fmt.Errorf("(%v) wallet not supported", w.GetAssetType())
+	}
+}
+
+// PublishOfflineTx broadcasts a transaction signed by an offline wallet.
+func PublishOfflineTx(w sharedW.Asset, tx, label string) (string, error) {
+	if asset, ok := w.(*dcr.Asset); ok {
+		return asset.PublishOfflineTx(tx, label)
+	}
+	return PublishPSBT(w, tx, label)
+}
@@ -103,7 +103,7 @@ func (pg *Page) OnNavigatedTo() {
 func (pg *Page) OnNavigatedFrom() {}
 
 func (pg *Page) fetchExchangeRate() {
-	market, err := utils.USDMarketFromAsset(pg.wallet.GetAssetType())
+	market, err := pg.AssetsManager.FiatMarket(pg.wallet.GetAssetType())
 	if err != nil {
 		log.Errorf("Unsupported asset type: %s", pg.wallet.GetAssetType())
 		return
@@ -242,10 +242,10 @@ func (pg *Page) accountBalanceLayout(title string, bal sharedW.AssetAmount, balA
 								return D{}
 							}
 
-							balanceUSD := fmt.Sprintf(" (%v)", utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, bal.ToCoin())))
-							usdAmtLabel := pg.Theme.Label(pg.ConvertTextSize(values.TextSize16), balanceUSD)
-							usdAmtLabel.Font.Weight = font.SemiBold
-							return usdAmtLabel.Layout(gtx)
+							balanceFiat := fmt.Sprintf(" (%v)", utils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), utils.CryptoToFiat(pg.exchangeRate, bal.ToCoin())))
+							fiatAmtLabel := pg.Theme.Label(pg.ConvertTextSize(values.TextSize16), balanceFiat)
+							fiatAmtLabel.Font.Weight = font.SemiBold
+							return fiatAmtLabel.Layout(gtx)
 						}),
 					)
 				})
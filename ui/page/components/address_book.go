@@ -0,0 +1,178 @@
+package components
+
+import (
+	"errors"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/libwallet/addressbook"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// contactAddress is a single selectable address of an address book contact.
+type contactAddress struct {
+	contact *addressbook.Contact
+	address string
+}
+
+// NewContactPickerModal returns a modal listing the addresses of the address
+// book contacts of assetType. Picking one calls onSelect and closes the modal.
+func NewContactPickerModal(l *load.Load, assetType libutils.AssetType, onSelect func(contact *addressbook.Contact, address string)) *modal.InfoModal {
+	var items []contactAddress
+	contacts, err := l.AssetsManager.AddressBook.Contacts(assetType)
+	if err != nil {
+		log.Errorf("Error loading contacts: %v", err)
+	}
+	for _, contact := range contacts {
+		for _, address := range contact.Addresses {
+			items = append(items, contactAddress{contact: contact, address: address})
+		}
+	}
+
+	list := l.Theme.NewClickableList(layout.Vertical)
+	list.DividerHeight = values.MarginPadding1
+
+	picker := modal.NewCustomModal(l).
+		Title(values.String(values.StrSelectContact)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	picker.UseCustomWidget(func(gtx C) D {
+		if clicked, index := list.ItemClicked(); clicked {
+			picker.Dismiss()
+			onSelect(items[index].contact, items[index].address)
+		}
+
+		if len(items) == 0 {
+			lbl := l.Theme.Body1(values.String(values.StrNoContacts))
+			lbl.Color = l.Theme.Color.GrayText3
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+		}
+
+		return list.Layout(gtx, len(items), func(gtx C, index int) D {
+			return layout.UniformInset(values.MarginPadding8).Layout(gtx, func(gtx C) D {
+				return contactRowLayout(gtx, l, items[index].contact.Name, items[index].address)
+			})
+		})
+	})
+	return picker
+}
+
+// NewContactEditorModal returns a modal to add a contact, or edit it if
+// contact is not nil. onSaved is called after the contact has been stored.
+func NewContactEditorModal(l *load.Load, contact *addressbook.Contact, onSaved func()) *modal.InfoModal {
+	isNew := contact == nil
+	if isNew {
+		contact = &addressbook.Contact{}
+	}
+
+	assetTypes := l.AssetsManager.AllAssetTypes()
+	assetGroup := new(widget.Enum)
+	if isNew && len(assetTypes) > 0 {
+		assetGroup.Value = assetTypes[0].String()
+	}
+
+	nameEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	nameEditor.Editor.SetText(contact.Name)
+
+	addressEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrContactAddresses))
+	addressEditor.Editor.SingleLine = false
+	addressEditor.Editor.SetText(strings.Join(contact.Addresses, "\n"))
+
+	notesEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrNote))
+	notesEditor.Editor.SingleLine = false
+	notesEditor.Editor.SetText(contact.Notes)
+
+	title := values.String(values.StrEditContact)
+	if isNew {
+		title = values.String(values.StrAddContact)
+	}
+
+	return modal.NewCustomModal(l).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if !isNew {
+						return D{}
+					}
+					options := make([]layout.FlexChild, 0, len(assetTypes))
+					for _, assetType := range assetTypes {
+						radio := l.Theme.RadioButton(assetGroup, assetType.String(), assetType.String(), l.Theme.Color.DeepBlue, l.Theme.Color.Primary)
+						options = append(options, layout.Rigid(radio.Layout))
+					}
+					return layout.Flex{}.Layout(gtx, options...)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, nameEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, addressEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, notesEditor.Layout)
+				}),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			nameEditor.SetError("")
+			addressEditor.SetError("")
+
+			updated := *contact
+			if isNew {
+				updated.AssetType = libutils.AssetType(assetGroup.Value)
+			}
+			updated.Name = nameEditor.Editor.Text()
+			updated.Addresses = strings.FieldsFunc(addressEditor.Editor.Text(), func(r rune) bool {
+				return r == '\n' || r == ',' || r == ' '
+			})
+			updated.Notes = notesEditor.Editor.Text()
+
+			err := l.AssetsManager.AddressBook.SaveContact(&updated)
+			switch {
+			case errors.Is(err, addressbook.ErrEmptyName), errors.Is(err, addressbook.ErrDuplicateName):
+				nameEditor.SetError(err.Error())
+				return false
+			case err != nil:
+				addressEditor.SetError(err.Error())
+				return false
+			}
+
+			*contact = updated
+			if onSaved != nil {
+				onSaved()
+			}
+			return true
+		})
+}
+
+// ContactLabel returns the name of the address book contact that owns
+// address, or an empty string if the address is not in the address book.
+func ContactLabel(l *load.Load, assetType libutils.AssetType, address string) string {
+	if contact := l.AssetsManager.AddressBook.ContactForAddress(assetType, address); contact != nil {
+		return contact.Name
+	}
+	return ""
+}
+
+func contactRowLayout(gtx C, l *load.Load, name, address string) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Body1(name)
+			lbl.Font.Weight = font.SemiBold
+			return lbl.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Body2(address)
+			lbl.Color = l.Theme.Color.GrayText2
+			return lbl.Layout(gtx)
+		}),
+	)
+}
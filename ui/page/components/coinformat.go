@@ -10,6 +10,7 @@ import (
 	"gioui.org/unit"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/load"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -83,7 +84,7 @@ func formatBalanceWithHidden(gtx C, l *load.Load, amount string, mainTextSize un
 	txt := l.Theme.Label(mainTextSize, amount)
 	if isUSD {
 		if !l.AssetsManager.ExchangeRateFetchingEnabled() {
-			txt.Text = "$ --"
+			txt.Text = pageutils.FiatPlaceholder(l.AssetsManager.FiatCurrency())
 		}
 	}
 	if isBalanceHidden {
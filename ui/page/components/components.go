@@ -364,6 +364,15 @@ func LayoutTransactionRow(gtx C, l *load.Load, wal sharedW.Asset, tx *sharedW.Tr
 						}),
 					)
 				}),
+				layout.Rigid(func(gtx C) D {
+					if tx.Label == "" {
+						return D{}
+					}
+					lbl := l.Theme.Label(values.TextSize12, tx.Label)
+					lbl.Color = grayText
+					lbl.MaxLines = 1
+					return lbl.Layout(gtx)
+				}),
 			)
 		}),
 		layout.Flexed(1, func(gtx C) D {
@@ -1,7 +1,9 @@
 package components
 
 import (
+	"fmt"
 	"image/color"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -12,9 +14,16 @@ import (
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
+// maxTallyBars is the number of most recent tallies drawn in the approval
+// chart of an agenda.
+const maxTallyBars = 12
+
 type ConsensusItem struct {
 	Agenda     *dcr.Agenda
 	VoteButton cryptomaterial.Button
+	// Tallies is the recorded vote history of an agenda in progress.
+	Tallies    []dcr.AgendaTally
+	Projection *dcr.AgendaProjection
 }
 
 func AgendaItemWidget(gtx C, l *load.Load, consensusItem *ConsensusItem, hasVotingWallet bool) D {
@@ -30,12 +39,80 @@ func AgendaItemWidget(gtx C, l *load.Load, consensusItem *ConsensusItem, hasVoti
 				layout.Rigid(layoutAgendaDetails(l, " "+consensusItem.Agenda.VotingPreference)),
 			)
 		}),
+		layout.Rigid(func(gtx C) D {
+			return layoutAgendaTally(gtx, l, consensusItem)
+		}),
 		layout.Rigid(func(gtx C) D {
 			return layoutAgendaVoteAction(gtx, l, consensusItem, hasVotingWallet)
 		}),
 	)
 }
 
+// layoutAgendaTally draws the latest vote tally of an agenda in progress, its
+// approval over time and its projected outcome.
+func layoutAgendaTally(gtx C, l *load.Load, item *ConsensusItem) D {
+	if len(item.Tallies) == 0 {
+		return D{}
+	}
+
+	latest := item.Tallies[len(item.Tallies)-1]
+	yes, no, abstain := latest.Percentages()
+
+	tallies := item.Tallies
+	if len(tallies) > maxTallyBars {
+		tallies = tallies[len(tallies)-maxTallyBars:]
+	}
+	bars := make([]cryptomaterial.BarChartItem, 0, len(tallies))
+	for _, tally := range tallies {
+		var approval float64
+		if votes := tally.Yes + tally.No; votes > 0 {
+			approval = float64(tally.Yes) / float64(votes) * 100
+		}
+		bars = append(bars, cryptomaterial.BarChartItem{
+			Label:     time.Unix(tally.Timestamp, 0).Format("Jan 2"),
+			Value:     approval,
+			ValueText: fmt.Sprintf("%.0f%%", approval),
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(layoutAgendaDetails(l, values.String(values.StrVoteTally), font.SemiBold)),
+				layout.Rigid(layoutAgendaDetails(l, " "+values.StringF(values.StrVoteTallyPercentages, yes, no, abstain))),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if item.Projection == nil {
+				return D{}
+			}
+			date := time.Unix(item.Projection.Time, 0).Format("Jan 2, 2006")
+			text := values.StringF(values.StrProjectedFail, date)
+			if item.Projection.LockIn {
+				text = values.StringF(values.StrProjectedLockIn, date)
+			}
+			lbl := l.Theme.Label(l.ConvertTextSize(values.TextSize14), text)
+			lbl.Color = l.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if len(bars) < 2 {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := l.Theme.Label(l.ConvertTextSize(values.TextSize14), values.String(values.StrApprovalHistory))
+						lbl.Font.Weight = font.SemiBold
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(l.Theme.BarChart(bars).Layout),
+				)
+			})
+		}),
+	)
+}
+
 func layoutAgendaStatus(gtx C, l *load.Load, agenda *dcr.Agenda) D {
 	var statusLabel cryptomaterial.Label = l.Theme.Label(l.ConvertTextSize(values.TextSize14), agenda.Status)
 	var statusIcon *cryptomaterial.Icon
@@ -170,6 +247,15 @@ func LoadAgendas(l *load.Load, dcrWallet *dcr.Asset, newestFirst bool) []*Consen
 			Agenda:     agenda,
 			VoteButton: button,
 		}
+
+		if agenda.Status == dcr.AgendaStatusInProgress.String() {
+			consensusItems[i].Tallies, err = l.AssetsManager.ConsensusAgenda.AgendaTallyHistory(agenda.AgendaID)
+			if err != nil {
+				log.Errorf("Error reading %s tallies: %v", agenda.AgendaID, err)
+				continue
+			}
+			consensusItems[i].Projection, _ = l.AssetsManager.ConsensusAgenda.ProjectAgendaOutcome(agenda.AgendaID)
+		}
 	}
 
 	return consensusItems
@@ -16,6 +16,7 @@ import (
 
 type ProposalItem struct {
 	Proposal     libwallet.Proposal
+	Watched      bool
 	tooltip      *cryptomaterial.Tooltip
 	tooltipLabel cryptomaterial.Label
 	voteBar      *VoteBar
@@ -123,6 +124,12 @@ func layoutTitleAndDate(gtx C, l *load.Load, item *ProposalItem) D {
 						)
 					})
 				}),
+				layout.Rigid(func(gtx C) D {
+					if !item.Watched {
+						return D{}
+					}
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, l.Theme.Icons.Notification.Layout16dp)
+				}),
 				layout.Rigid(func(gtx C) D {
 					lbl := l.Theme.H6(proposal.Name)
 					lbl.TextSize = l.ConvertTextSize(values.TextSize20)
@@ -310,6 +317,7 @@ func LoadProposals(l *load.Load, category, offset, pageSize int32, newestFirst b
 			proposal := proposals[i]
 			item := &ProposalItem{
 				Proposal: libwallet.Proposal{Proposal: proposal},
+				Watched:  l.AssetsManager.Politeia.IsProposalWatched(proposal.Token),
 				voteBar:  NewVoteBar(l),
 			}
 
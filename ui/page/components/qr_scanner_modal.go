@@ -0,0 +1,197 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"image"
+	"sync"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/device"
+	"github.com/crypto-power/cryptopower/device/qrscan"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	// qrScanInterval is how often a camera frame is read and scanned.
+	qrScanInterval = 100 * time.Millisecond
+	// cameraStartInterval is how often starting the camera is retried
+	// while the user is asked for the camera permission.
+	cameraStartInterval = time.Second
+)
+
+// QRScannerModal previews the frames of the camera and calls onScanned with
+// the text of the first QR code read from them.
+type QRScannerModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	onScanned func(text string)
+	cancelBtn cryptomaterial.Button
+
+	scanCancel context.CancelFunc
+
+	mu      sync.Mutex
+	frameOp *paint.ImageOp
+	status  string
+	scanned *string
+}
+
+// NewQRScannerModal returns a modal scanning QR codes with the camera. It
+// should only be shown if l.Device.HasCamera().
+func NewQRScannerModal(l *load.Load, onScanned func(text string)) *QRScannerModal {
+	return &QRScannerModal{
+		Load:      l,
+		Modal:     l.Theme.ModalFloatTitle("qr_scanner_modal", l.IsMobileView(), nil),
+		onScanned: onScanned,
+		cancelBtn: l.Theme.OutlineButton(values.String(values.StrCancel)),
+		status:    values.String(values.StrScanQRCodeHint),
+	}
+}
+
+func (sm *QRScannerModal) OnResume() {
+	if sm.scanCancel != nil {
+		// Already scanning.
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	sm.scanCancel = cancel
+	go sm.scan(ctx)
+}
+
+func (sm *QRScannerModal) OnDismiss() {
+	if sm.scanCancel != nil {
+		sm.scanCancel()
+		sm.scanCancel = nil
+	}
+}
+
+// scan starts the camera, then reads its frames until one holds a QR code or
+// the modal is dismissed.
+func (sm *QRScannerModal) scan(ctx context.Context) {
+	// Stop the camera even if it never started, so that the permission is
+	// asked for again the next time.
+	defer sm.Device.StopCamera()
+
+	ticker := time.NewTicker(qrScanInterval)
+	defer ticker.Stop()
+
+	var started bool
+	var lastStart time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !started {
+			if time.Since(lastStart) < cameraStartInterval {
+				continue
+			}
+			lastStart = time.Now()
+
+			err := sm.Device.StartCamera()
+			switch {
+			case err == nil:
+				started = true
+				sm.setStatus(values.String(values.StrScanQRCodeHint))
+			case errors.Is(err, device.ErrCameraPermission):
+				sm.setStatus(values.String(values.StrAllowCameraAccess))
+			default:
+				log.Errorf("Error starting the camera: %v", err)
+				sm.setStatus(values.String(values.StrCameraUnavailable))
+				return
+			}
+			continue
+		}
+
+		frame := sm.Device.CameraFrame()
+		if frame == nil {
+			continue
+		}
+		text, err := qrscan.Decode(frame)
+		sm.setFrame(frame, text, err == nil)
+		if err == nil {
+			return
+		}
+	}
+}
+
+func (sm *QRScannerModal) setStatus(status string) {
+	sm.mu.Lock()
+	sm.status = status
+	sm.mu.Unlock()
+	sm.ParentWindow().Reload()
+}
+
+func (sm *QRScannerModal) setFrame(frame *image.Gray, text string, scanned bool) {
+	frameOp := paint.NewImageOp(frame)
+	sm.mu.Lock()
+	sm.frameOp = &frameOp
+	if scanned {
+		sm.scanned = &text
+	}
+	sm.mu.Unlock()
+	sm.ParentWindow().Reload()
+}
+
+func (sm *QRScannerModal) Handle(gtx C) {
+	sm.mu.Lock()
+	scanned := sm.scanned
+	sm.mu.Unlock()
+	if scanned != nil {
+		sm.Dismiss()
+		sm.onScanned(*scanned)
+		return
+	}
+
+	if sm.cancelBtn.Clicked(gtx) || sm.Modal.BackdropClicked(gtx, true) {
+		sm.Dismiss()
+	}
+}
+
+func (sm *QRScannerModal) Layout(gtx C) D {
+	sm.mu.Lock()
+	frameOp, status := sm.frameOp, sm.status
+	sm.mu.Unlock()
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := sm.Theme.H6(values.String(values.StrScanQRCode))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Center.Layout(gtx, func(gtx C) D {
+				size := gtx.Dp(values.MarginPadding280)
+				gtx.Constraints.Min = image.Pt(size, size)
+				gtx.Constraints.Max = gtx.Constraints.Min
+				if frameOp == nil {
+					card := sm.Theme.Card()
+					card.Color = sm.Theme.Color.Gray2
+					return card.Layout(gtx, func(gtx C) D {
+						return D{Size: gtx.Constraints.Min}
+					})
+				}
+				return widget.Image{Src: *frameOp, Fit: widget.Cover, Position: layout.Center}.Layout(gtx)
+			})
+		},
+		func(gtx C) D {
+			lbl := sm.Theme.Body2(status)
+			lbl.Color = sm.Theme.Color.GrayText2
+			return lbl.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, sm.cancelBtn.Layout)
+		},
+	}
+	return sm.Modal.Layout(gtx, w)
+}
@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
 	"gioui.org/font"
@@ -8,9 +9,11 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/decred/dcrd/dcrutil/v4"
 )
 
 type TreasuryItem struct {
@@ -117,3 +120,114 @@ func LoadPolicies(l *load.Load, selectedDCRWallet *dcr.Asset, pikey string) []*T
 	}
 	return treasuryItems
 }
+
+// TSpendItem is a treasury spend with the widgets to vote on it.
+type TSpendItem struct {
+	*TreasuryItem
+	TSpend *dcr.TSpend
+	// Proposals are the names of the proposals that mention the tspend.
+	Proposals []string
+}
+
+func LoadTSpends(l *load.Load, selectedDCRWallet *dcr.Asset) []*TSpendItem {
+	if l.AssetsManager.IsHTTPAPIPrivacyModeOff(libutils.GovernanceHTTPAPI) {
+		if err := selectedDCRWallet.SyncMinedTSpends(l.AssetsManager.ExternalService); err != nil {
+			log.Errorf("Error looking up mined treasury spends: %v", err)
+		}
+	}
+
+	tspends, err := selectedDCRWallet.TSpends()
+	if err != nil {
+		return nil
+	}
+
+	tspendItems := make([]*TSpendItem, len(tspends))
+	for i, tspend := range tspends {
+		button := l.Theme.Button(values.String(values.StrSetChoice))
+		button.TextSize = l.ConvertTextSize(values.TextSize16)
+		item := &TSpendItem{
+			TreasuryItem: &TreasuryItem{
+				Policy:            dcr.TreasuryKeyPolicy{PiKey: tspend.PiKey, Policy: tspend.Policy},
+				OptionsRadioGroup: new(widget.Enum),
+				SetChoiceButton:   button,
+			},
+			TSpend: tspend,
+		}
+		item.OptionsRadioGroup.Value = tspend.Policy
+
+		terms := []string{tspend.Hash}
+		for _, payout := range tspend.Payouts {
+			terms = append(terms, payout.Address)
+		}
+		proposals, err := l.AssetsManager.Politeia.ProposalsMentioningRaw(terms...)
+		if err == nil {
+			for _, proposal := range proposals {
+				item.Proposals = append(item.Proposals, proposal.Name)
+			}
+		}
+
+		tspendItems[i] = item
+	}
+	return tspendItems
+}
+
+func TSpendItemWidget(gtx C, l *load.Load, item *TSpendItem) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	tspend := item.TSpend
+
+	row := func(title, value string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := l.Theme.Label(l.ConvertTextSize(values.TextSize14), title)
+						lbl.Color = l.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(l.Theme.Label(l.ConvertTextSize(values.TextSize14), value).Layout),
+				)
+			})
+		})
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Label(l.ConvertTextSize(values.TextSize16), tspend.Hash)
+			lbl.Font.Weight = font.SemiBold
+			lbl.MaxLines = 1
+			return lbl.Layout(gtx)
+		}),
+		row(values.String(values.StrAmount), dcrutil.Amount(tspend.Amount).String()),
+	}
+	if tspend.BlockHeight > 0 {
+		children = append(children, row(values.String(values.StrTSpendMinedIn), fmt.Sprintf("%d", tspend.BlockHeight)))
+	} else {
+		children = append(children, row(values.String(values.StrTSpendExpiry), fmt.Sprintf("%d", tspend.Expiry)))
+	}
+	children = append(children, row(values.String(values.StrPayouts), ""))
+	for _, payout := range tspend.Payouts {
+		children = append(children, row(TruncateString(payout.Address, 24), dcrutil.Amount(payout.Amount).String()))
+	}
+	if len(item.Proposals) > 0 {
+		children = append(children, row(values.String(values.StrLinkedProposals), strings.Join(item.Proposals, ", ")))
+	}
+	if tspend.BlockHeight > 0 {
+		// A mined tspend is no longer voted on.
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+	children = append(children, layout.Rigid(func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.Flex{Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, layoutItems(l, item.TreasuryItem)...)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layoutPolicyVoteAction(gtx, l, item.TreasuryItem)
+			}),
+		)
+	}))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
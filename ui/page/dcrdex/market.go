@@ -603,13 +603,13 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 	var change24, priceChange float64
 	marketRate, low24, high24, baseVol24, quoteVol24 := "------", "------", "------", "------", "------"
-	mkt, ticker := pg.selectedMarketInfo(), pg.selectedMarketUSDRateTicker()
+	mkt, ticker := pg.selectedMarketInfo(), pg.selectedMarketFiatRateTicker()
 	if mkt != nil && mkt.SpotPrice != nil {
 		rate := mkt.MsgRateToConventional(mkt.SpotPrice.Rate)
 		if ticker == nil {
 			marketRate = pg.Printer.Sprintf("%f", rate)
 		} else {
-			marketRate = pg.Printer.Sprintf("%f (~ %s)", rate, pageutils.FormatAsUSDString(pg.Printer, rate*ticker.LastTradePrice))
+			marketRate = pg.Printer.Sprintf("%f (~ %s)", rate, pageutils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), rate*ticker.LastTradePrice))
 		}
 
 		change24 = mkt.SpotPrice.Change24
@@ -662,8 +662,14 @@ func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 	)
 }
 
-func (pg *DEXMarketPage) selectedMarketUSDRateTicker() *ext.Ticker {
-	return pg.AssetsManager.RateSource.GetTicker(rateSourceMarketName(pg.marketSelector.Selected()), true)
+// selectedMarketFiatRateTicker returns the fiat rate of the quote asset of the
+// selected market. Rates too few sources agree on are not used.
+func (pg *DEXMarketPage) selectedMarketFiatRateTicker() *ext.Ticker {
+	ticker := pg.AssetsManager.RateSource.GetTicker(rateSourceMarketName(pg.marketSelector.Selected(), pg.AssetsManager.FiatCurrency()), true)
+	if ticker == nil || ticker.LowConfidence() {
+		return nil
+	}
+	return ticker
 }
 
 func (pg *DEXMarketPage) selectedMarketInfo() (mkt *core.Market) {
@@ -1181,8 +1187,8 @@ func (pg *DEXMarketPage) orderbook(gtx C) D {
 							if mkt != nil && mkt.SpotPrice != nil {
 								marketRate := mkt.MsgRateToConventional(mkt.SpotPrice.Rate)
 								marketRateStr = fmt.Sprintf("%f %s", marketRate, quoteAsset)
-								if ticker := pg.selectedMarketUSDRateTicker(); ticker != nil {
-									marketRateStr = fmt.Sprintf("%f %s (~ %s)", marketRate, quoteAsset, pageutils.FormatAsUSDString(pg.Printer, marketRate*ticker.LastTradePrice))
+								if ticker := pg.selectedMarketFiatRateTicker(); ticker != nil {
+									marketRateStr = fmt.Sprintf("%f %s (~ %s)", marketRate, quoteAsset, pageutils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), marketRate*ticker.LastTradePrice))
 								}
 							}
 							lb := pg.Theme.Label(values.TextSize16, marketRateStr)
@@ -1968,19 +1974,15 @@ func trimmedConventionalAmtString(r float64) string {
 }
 
 // rateSourceMarketName converts the provided marketPair to the expected market
-// name for fiat rate fetching.
-func rateSourceMarketName(marketPair string) values.Market {
+// name for fetching the rate of its quote asset in the fiat currency.
+func rateSourceMarketName(marketPair, currency string) values.Market {
 	base, quote, _ := strings.Cut(marketPair, "/")
 	_, baseSymOk := dex.BipSymbolID(strings.ToLower(base))
 	_, quoteSymOk := dex.BipSymbolID(strings.ToLower(quote))
 	if baseSymOk && quoteSymOk {
 		switch quote {
-		case libutils.DCRWalletAsset.String():
-			return values.DCRUSDTMarket
-		case libutils.BTCWalletAsset.String():
-			return values.BTCUSDTMarket
-		case libutils.LTCWalletAsset.String():
-			return values.LTCUSDTMarket
+		case libutils.DCRWalletAsset.String(), libutils.BTCWalletAsset.String(), libutils.LTCWalletAsset.String():
+			return values.NewMarket(quote, currency)
 		}
 	}
 	return ""
@@ -960,7 +960,7 @@ func (pg *CreateOrderPage) layoutDesktop(gtx C) D {
 								layout.Rigid(func(gtx C) D {
 									market := values.NewMarket(fromCur, toCur)
 									ticker := pg.AssetsManager.RateSource.GetTicker(market, true)
-									if ticker == nil || ticker.LastTradePrice <= 0 {
+									if ticker == nil || ticker.LastTradePrice <= 0 || ticker.LowConfidence() {
 										return D{}
 									}
 
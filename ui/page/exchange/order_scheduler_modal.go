@@ -298,7 +298,7 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																						layout.Rigid(func(gtx C) D {
 																							market := values.NewMarket(fromCur, toCur)
 																							ticker := osm.AssetsManager.RateSource.GetTicker(market, true)
-																							if ticker == nil || ticker.LastTradePrice <= 0 {
+																							if ticker == nil || ticker.LastTradePrice <= 0 || ticker.LowConfidence() {
 																								return D{}
 																							}
 
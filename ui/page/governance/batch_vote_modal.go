@@ -0,0 +1,267 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// batchVoteModal votes on a proposal with the eligible tickets of all the
+// DCR wallets at once.
+type batchVoteModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	mu          sync.Mutex
+	walletVotes []*libwallet.WalletProposalVotes
+	results     []*libwallet.WalletVoteResult
+	loading     bool
+	isVoting    bool
+
+	proposal *libwallet.Proposal
+
+	yesPercentEditor cryptomaterial.Editor
+	passEditors      map[int]*cryptomaterial.Editor
+	materialLoader   material.LoaderStyle
+	voteBtn          cryptomaterial.Button
+	cancelBtn        cryptomaterial.Button
+}
+
+func newBatchVoteModal(l *load.Load, proposal *libwallet.Proposal) *batchVoteModal {
+	bm := &batchVoteModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle("batch_vote_modal", l.IsMobileView(), nil),
+		proposal:       proposal,
+		passEditors:    make(map[int]*cryptomaterial.Editor),
+		materialLoader: material.Loader(l.Theme.Base),
+		voteBtn:        l.Theme.Button(values.String(values.StrVote)),
+		cancelBtn:      l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	bm.yesPercentEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrYesVotesPercent))
+	bm.yesPercentEditor.Editor.SingleLine = true
+	bm.yesPercentEditor.Editor.SetText("100")
+
+	return bm
+}
+
+func (bm *batchVoteModal) OnResume() {
+	bm.loading = true
+	go func() {
+		walletVotes := bm.AssetsManager.ProposalVotesForAllWallets(context.Background(), bm.proposal.Token)
+
+		bm.mu.Lock()
+		bm.walletVotes = walletVotes
+		for _, wv := range walletVotes {
+			if wv.Err != nil {
+				continue
+			}
+			editor := bm.Theme.EditorPassword(new(widget.Editor), values.StringF(values.StrWalletTickets, wv.Wallet.GetWalletName(), len(wv.EligibleTickets)))
+			editor.Editor.SingleLine = true
+			bm.passEditors[wv.Wallet.GetWalletID()] = &editor
+		}
+		bm.loading = false
+		bm.mu.Unlock()
+		bm.ParentWindow().Reload()
+	}()
+}
+
+func (bm *batchVoteModal) OnDismiss() {}
+
+// yesPercent returns the entered percentage of yes votes.
+func (bm *batchVoteModal) yesPercent() (int, bool) {
+	percent, err := strconv.Atoi(strings.TrimSpace(bm.yesPercentEditor.Editor.Text()))
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, false
+	}
+	return percent, true
+}
+
+func (bm *batchVoteModal) eligibleTickets() int {
+	var total int
+	for _, wv := range bm.walletVotes {
+		if wv.Err == nil {
+			total += len(wv.EligibleTickets)
+		}
+	}
+	return total
+}
+
+func (bm *batchVoteModal) Handle(gtx C) {
+	if bm.cancelBtn.Clicked(gtx) && !bm.isVoting {
+		bm.Dismiss()
+	}
+
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if bm.results != nil {
+		bm.voteBtn.Text = values.String(values.StrOk)
+		bm.voteBtn.SetEnabled(true)
+		if bm.voteBtn.Clicked(gtx) {
+			bm.Dismiss()
+		}
+		return
+	}
+
+	_, validPercent := bm.yesPercent()
+	canVote := validPercent && bm.eligibleTickets() > 0
+	for _, editor := range bm.passEditors {
+		canVote = canVote && editor.Editor.Text() != ""
+	}
+	bm.voteBtn.SetEnabled(canVote && !bm.isVoting)
+
+	if bm.voteBtn.Clicked(gtx) && canVote && !bm.isVoting {
+		bm.isVoting = true
+		bm.castVotes()
+	}
+}
+
+// castVotes casts the votes of all the wallets, it must be called with bm.mu
+// held.
+func (bm *batchVoteModal) castVotes() {
+	yesPercent, _ := bm.yesPercent()
+	passphrases := make(map[int]string, len(bm.passEditors))
+	for walletID, editor := range bm.passEditors {
+		passphrases[walletID] = editor.Editor.Text()
+	}
+	walletVotes := bm.walletVotes
+
+	go func() {
+		ctx := context.Background()
+		results := bm.AssetsManager.CastVotesForAllWallets(ctx, bm.proposal.Token, walletVotes, yesPercent, passphrases)
+
+		bm.mu.Lock()
+		bm.results = results
+		bm.isVoting = false
+		bm.mu.Unlock()
+
+		go func() { _ = bm.AssetsManager.Politeia.Sync(ctx) }()
+		bm.ParentWindow().Reload()
+	}()
+}
+
+func (bm *batchVoteModal) Layout(gtx C) D {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := bm.Theme.H6(values.String(values.StrVoteWithAllWallets))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+	}
+
+	switch {
+	case bm.loading:
+		w = append(w, func(gtx C) D {
+			return layout.Center.Layout(gtx, bm.materialLoader.Layout)
+		})
+	case bm.results != nil:
+		w = append(w, bm.resultsLayout)
+	case len(bm.walletVotes) == 0:
+		w = append(w, bm.Theme.Body1(values.String(values.StrNoEligibleTickets)).Layout)
+	default:
+		w = append(w, bm.votesLayout)
+	}
+
+	w = append(w, func(gtx C) D {
+		return layout.E.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if bm.results != nil {
+						return D{}
+					}
+					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, bm.cancelBtn.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if bm.isVoting {
+						return bm.materialLoader.Layout(gtx)
+					}
+					return bm.voteBtn.Layout(gtx)
+				}),
+			)
+		})
+	})
+
+	return bm.Modal.Layout(gtx, w)
+}
+
+func (bm *batchVoteModal) votesLayout(gtx C) D {
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, bm.yesPercentEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lbl := bm.Theme.Body2(values.String(values.StrInvalidPercentage))
+			lbl.Color = bm.Theme.Color.Danger
+			yesPercent, ok := bm.yesPercent()
+			if ok {
+				total := bm.eligibleTickets()
+				yes := libwallet.YesVotesCount(total, yesPercent)
+				lbl = bm.Theme.Body2(values.StringF(values.StrVoteSplit, yes, total-yes))
+				lbl.Color = bm.Theme.Color.GrayText2
+			}
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+		}),
+	}
+
+	for _, wv := range bm.walletVotes {
+		wv := wv
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				if wv.Err != nil {
+					lbl := bm.Theme.Body2(fmt.Sprintf("%s: %v", wv.Wallet.GetWalletName(), wv.Err))
+					lbl.Color = bm.Theme.Color.Danger
+					return lbl.Layout(gtx)
+				}
+				return bm.passEditors[wv.Wallet.GetWalletID()].Layout(gtx)
+			})
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+// resultsLayout shows the votes sent or the error of every wallet.
+func (bm *batchVoteModal) resultsLayout(gtx C) D {
+	rows := make([]layout.FlexChild, 0, len(bm.results))
+	for _, result := range bm.results {
+		result := result
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			text := values.StringF(values.StrVotesSent, result.YesVotes, result.NoVotes)
+			col := bm.Theme.Color.Success
+			if result.Err != nil {
+				text, col = result.Err.Error(), bm.Theme.Color.Danger
+			}
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := bm.Theme.Body1(result.Wallet.GetWalletName())
+						lbl.Font.Weight = font.SemiBold
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						lbl := bm.Theme.Body2(text)
+						lbl.Color = col
+						return lbl.Layout(gtx)
+					}),
+				)
+			})
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
@@ -345,7 +345,12 @@ func (pg *ConsensusPage) HandleUserInteractions(gtx C) {
 func (pg *ConsensusPage) SyncAgenda() {
 	pg.isSyncing = true
 	pg.syncCompleted = false
-	go func() { _ = pg.AssetsManager.ConsensusAgenda.Sync(context.Background()) }()
+	go func() {
+		if err := pg.AssetsManager.SyncAgendaTallies(); err != nil {
+			log.Errorf("Error recording agenda tallies: %v", err)
+		}
+		_ = pg.AssetsManager.ConsensusAgenda.Sync(context.Background())
+	}()
 	pg.ParentWindow().Reload()
 }
 
@@ -0,0 +1,184 @@
+package governance
+
+import (
+	"fmt"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/renderers"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// maxCommentDepth is the deepest reply level that is indented further, deeper
+// replies are drawn at this level to keep them readable on small screens.
+const maxCommentDepth = 4
+
+// proposalCommentItem is a comment of a thread flattened for drawing, its
+// markdown is rendered once when the comments are loaded.
+type proposalCommentItem struct {
+	*libwallet.ProposalComment
+	depth   int
+	widgets []layout.Widget
+}
+
+func (pg *ProposalDetails) loadProposalComments() {
+	if pg.loadingComments {
+		return
+	}
+
+	pg.loadingComments = true
+	go func() {
+		defer func() {
+			pg.loadingComments = false
+			pg.ParentWindow().Reload()
+		}()
+
+		threads, err := pg.AssetsManager.Politeia.SyncProposalComments(pg.proposal.Token)
+		if err != nil {
+			log.Errorf("Error loading proposal comments: %v", err)
+			pg.commentsErr = true
+			if threads == nil {
+				return
+			}
+		} else {
+			pg.commentsErr = false
+		}
+
+		var items []*proposalCommentItem
+		var flatten func(threads []*libwallet.CommentThread, depth int)
+		flatten = func(threads []*libwallet.CommentThread, depth int) {
+			for _, thread := range threads {
+				item := &proposalCommentItem{
+					ProposalComment: thread.ProposalComment,
+					depth:           depth,
+				}
+				if !thread.Deleted {
+					item.widgets, _ = renderers.RenderMarkdown(pg.Load, pg.Theme, thread.Comment).Layout()
+				}
+				items = append(items, item)
+				flatten(thread.Replies, depth+1)
+			}
+		}
+		flatten(threads, 0)
+		pg.comments = items
+	}()
+}
+
+// commentWidgets returns the comments section of the proposal description.
+func (pg *ProposalDetails) commentWidgets() []layout.Widget {
+	w := []layout.Widget{
+		pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}),
+		func(gtx C) D {
+			lbl := pg.Theme.H6(values.StringF(values.StrComments, len(pg.comments)))
+			lbl.TextSize = pg.ConvertTextSize(values.TextSize18)
+			lbl.Font.Weight = font.SemiBold
+			return lbl.Layout(gtx)
+		},
+	}
+
+	if pg.commentsErr {
+		w = append(w, func(gtx C) D {
+			lbl := pg.Theme.Body2(values.String(values.StrCommentsLoadFailed))
+			lbl.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		})
+	}
+
+	switch {
+	case pg.comments == nil && pg.loadingComments:
+		w = append(w, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return components.VerticalInset(values.MarginPadding8).Layout(gtx, func(gtx C) D {
+				return layout.Center.Layout(gtx, material.Loader(pg.Theme.Base).Layout)
+			})
+		})
+	case len(pg.comments) == 0:
+		w = append(w, func(gtx C) D {
+			lbl := pg.Theme.Body2(values.String(values.StrNoComments))
+			lbl.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		})
+	}
+
+	for _, item := range pg.comments {
+		w = append(w, pg.layoutComment(item))
+	}
+	return w
+}
+
+func (pg *ProposalDetails) layoutComment(item *proposalCommentItem) layout.Widget {
+	return func(gtx C) D {
+		depth := item.depth
+		if depth > maxCommentDepth {
+			depth = maxCommentDepth
+		}
+		inset := layout.Inset{
+			Top:  values.MarginPadding16,
+			Left: values.MarginPadding16 * unit.Dp(depth),
+		}
+		return inset.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return pg.commentHeader(gtx, item)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if item.Deleted {
+						lbl := pg.Theme.Body2(values.StringF(values.StrCommentDeleted, item.Reason))
+						lbl.Color = pg.Theme.Color.GrayText3
+						return lbl.Layout(gtx)
+					}
+					children := make([]layout.FlexChild, len(item.widgets))
+					for i, widget := range item.widgets {
+						children[i] = layout.Rigid(widget)
+					}
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+				}),
+			)
+		})
+	}
+}
+
+func (pg *ProposalDetails) commentHeader(gtx C, item *proposalCommentItem) D {
+	grayCol := pg.Theme.Color.GrayText2
+
+	userLabel := pg.Theme.Body2(item.Username)
+	userLabel.Font.Weight = font.SemiBold
+	userLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+
+	score := item.Score()
+	scoreLabel := pg.Theme.Body2(fmt.Sprintf("%+d", score))
+	scoreLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+	switch {
+	case score > 0:
+		scoreLabel.Color = pg.Theme.Color.Success
+	case score < 0:
+		scoreLabel.Color = pg.Theme.Color.Danger
+	default:
+		scoreLabel.Color = grayCol
+	}
+
+	timeLabel := pg.Theme.Body2(pageutils.TimeAgo(item.CreatedAt))
+	timeLabel.Color = grayCol
+	timeLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+
+	dotLabel := pg.Theme.H4(" . ")
+	dotLabel.Color = grayCol
+
+	return layout.Flex{}.Layout(gtx,
+		layout.Rigid(userLabel.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
+		}),
+		layout.Rigid(scoreLabel.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
+		}),
+		layout.Rigid(timeLabel.Layout),
+	)
+}
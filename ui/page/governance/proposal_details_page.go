@@ -59,9 +59,12 @@ type ProposalDetails struct {
 
 	viewInPoliteiaBtn *cryptomaterial.Clickable
 	copyRedirectURL   *cryptomaterial.Clickable
+	watchBtn          *cryptomaterial.Clickable
+	isWatched         bool
 
 	descriptionCard cryptomaterial.Card
 	vote            cryptomaterial.Button
+	voteAllWallets  cryptomaterial.Button
 	backButton      cryptomaterial.IconButton
 
 	assetWallets []sharedW.Asset
@@ -71,6 +74,10 @@ type ProposalDetails struct {
 
 	voteBar            *components.VoteBar
 	loadingDescription bool
+
+	comments        []*proposalCommentItem
+	loadingComments bool
+	commentsErr     bool
 }
 
 func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *ProposalDetails {
@@ -90,6 +97,7 @@ func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *Proposa
 		successIcon:       l.Theme.Icons.ActionCheckCircle,
 		viewInPoliteiaBtn: l.Theme.NewClickable(true),
 		copyRedirectURL:   l.Theme.NewClickable(false),
+		watchBtn:          l.Theme.NewClickable(true),
 		voteBar:           components.NewVoteBar(l),
 	}
 
@@ -106,6 +114,10 @@ func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *Proposa
 		Right:  values.MarginPadding12,
 	}
 
+	pg.voteAllWallets = l.Theme.OutlineButton(values.String(values.StrVoteWithAllWallets))
+	pg.voteAllWallets.TextSize = pg.vote.TextSize
+	pg.voteAllWallets.Inset = pg.vote.Inset
+
 	return pg
 }
 
@@ -115,7 +127,9 @@ func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *Proposa
 // Part of the load.Page interface.
 func (pg *ProposalDetails) OnNavigatedTo() {
 	pg.initWalletSelector()
+	pg.isWatched = pg.AssetsManager.Politeia.IsProposalWatched(pg.proposal.Token)
 	pg.loadProposalDescription()
+	pg.loadProposalComments()
 	pg.listenForSyncNotifications() // listener is stopped in OnNavigatedFrom()
 }
 
@@ -226,6 +240,25 @@ func (pg *ProposalDetails) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(newVoteModal(pg.Load, pg.proposal))
 	}
 
+	if pg.voteAllWallets.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newBatchVoteModal(pg.Load, pg.proposal))
+	}
+
+	if pg.watchBtn.Clicked(gtx) {
+		var err error
+		if pg.isWatched {
+			err = pg.AssetsManager.Politeia.UnwatchProposal(pg.proposal.Token)
+		} else {
+			err = pg.AssetsManager.Politeia.WatchProposal(pg.proposal.Token)
+		}
+		if err != nil {
+			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(errModal)
+		} else {
+			pg.isWatched = !pg.isWatched
+		}
+	}
+
 	if pg.viewInPoliteiaBtn.Clicked(gtx) {
 		host := mainnetBaseHost + pg.proposal.Token
 		if pg.AssetsManager.NetType() == libwallet.Testnet {
@@ -426,7 +459,16 @@ func (pg *ProposalDetails) summaryRow(title, content string, gtx C) D {
 
 func (pg *ProposalDetails) layoutProposalVoteAction(gtx C) D {
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
-	return pg.vote.Layout(gtx)
+	if len(pg.assetWallets) < 2 {
+		return pg.vote.Layout(gtx)
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.vote.Layout),
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.voteAllWallets.Layout)
+		}),
+	)
 }
 
 func (pg *ProposalDetails) layoutInDiscussionState(gtx C) D {
@@ -608,6 +650,11 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 	publishedLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
 	updatedLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
 
+	watchText := values.String(values.StrWatchProposal)
+	if pg.isWatched {
+		watchText = values.String(values.StrUnwatchProposal)
+	}
+
 	w := []layout.Widget{
 		func(gtx C) D {
 			lbl := pg.Theme.H5(proposal.Name)
@@ -648,6 +695,7 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 			)
 		},
 		pg.layoutRedirect(values.String(values.StrViewOnPoliteia), pg.redirectIcon, pg.viewInPoliteiaBtn),
+		pg.layoutRedirect(watchText, pg.Theme.Icons.Notification, pg.watchBtn),
 		pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}),
 	}
 
@@ -665,6 +713,7 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 
 		w = append(w, loading)
 	}
+	w = append(w, pg.commentWidgets()...)
 
 	return pg.descriptionCard.Layout(gtx, func(gtx C) D {
 		return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, _ int) D {
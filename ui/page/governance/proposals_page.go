@@ -106,6 +106,7 @@ func NewProposalsPage(l *load.Load, detailData interface{}) *ProposalsPage {
 		{Text: values.String(values.StrApproved)},
 		{Text: values.String(values.StrRejected)},
 		{Text: values.String(values.StrAbandoned)},
+		{Text: values.String(values.StrWatchlist)},
 	}, values.ProposalDropdownGroup, 1, 0, false)
 
 	pg.orderDropDown = l.Theme.DropdownWithCustomPos([]cryptomaterial.DropDownItem{
@@ -202,7 +203,8 @@ func (pg *ProposalsPage) fetchProposals(offset, pageSize int32) ([]*components.P
 	proposalItems := components.LoadProposals(pg.Load, proposalFilter, offset, pageSize, orderNewest, strings.TrimSpace(searchKey))
 	listItems := make([]*components.ProposalItem, 0)
 
-	if selectedType == values.String(values.StrUnderReview) {
+	switch selectedType {
+	case values.String(values.StrUnderReview):
 		// group 'In discussion' and 'Active' proposals into under review
 		for _, item := range proposalItems {
 			if item.Proposal.Category == libwallet.ProposalCategoryPre ||
@@ -210,7 +212,13 @@ func (pg *ProposalsPage) fetchProposals(offset, pageSize int32) ([]*components.P
 				listItems = append(listItems, item)
 			}
 		}
-	} else {
+	case values.String(values.StrWatchlist):
+		for _, item := range proposalItems {
+			if item.Watched {
+				listItems = append(listItems, item)
+			}
+		}
+	default:
 		listItems = proposalItems
 	}
 
@@ -46,6 +46,7 @@ type TreasuryPage struct {
 	selectedDCRWallet *dcr.Asset
 
 	treasuryItems []*components.TreasuryItem
+	tspendItems   []*components.TSpendItem
 
 	listContainer      *widget.List
 	viewGovernanceKeys *cryptomaterial.Clickable
@@ -140,6 +141,12 @@ func (pg *TreasuryPage) HandleUserInteractions(gtx C) {
 		}
 	}
 
+	for i := range pg.tspendItems {
+		if pg.tspendItems[i].SetChoiceButton.Clicked(gtx) {
+			pg.updateTSpendPolicy(pg.tspendItems[i])
+		}
+	}
+
 	if pg.walletDropDown != nil && pg.walletDropDown.Changed(gtx) {
 		pg.selectedDCRWallet = pg.assetWallets[pg.walletDropDown.SelectedIndex()].(*dcr.Asset)
 		pg.FetchPolicies()
@@ -197,6 +204,7 @@ func (pg *TreasuryPage) FetchPolicies() {
 
 	go func() {
 		pg.treasuryItems = components.LoadPolicies(pg.Load, pg.selectedDCRWallet, pg.PiKey)
+		pg.tspendItems = components.LoadTSpends(pg.Load, pg.selectedDCRWallet)
 		pg.isPolicyFetchInProgress = true
 		pg.ParentWindow().Reload()
 	}()
@@ -307,7 +315,10 @@ func (pg *TreasuryPage) layoutContent(gtx C) D {
 	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
 		list := layout.List{Axis: layout.Vertical}
 		return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, _ int) D {
-			return list.Layout(gtx, len(pg.treasuryItems), func(gtx C, i int) D {
+			return list.Layout(gtx, len(pg.treasuryItems)+1, func(gtx C, i int) D {
+				if i == len(pg.treasuryItems) {
+					return pg.layoutTSpends(gtx)
+				}
 				return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
 					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 						layout.Rigid(pg.layoutPiKey),
@@ -378,6 +389,60 @@ func (pg *TreasuryPage) updatePolicyPreference(treasuryItem *components.Treasury
 	pg.ParentWindow().ShowModal(passwordModal)
 }
 
+// layoutTSpends lists the pending treasury spends to vote on individually.
+func (pg *TreasuryPage) layoutTSpends(gtx C) D {
+	children := []layout.FlexChild{
+		layout.Rigid(pg.Theme.Separator().Layout),
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Label(pg.ConvertTextSize(values.TextSize18), values.String(values.StrTreasurySpends))
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+		}),
+	}
+	if len(pg.tspendItems) == 0 {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrNoTSpends))
+			lbl.Color = pg.Theme.Color.GrayText3
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, lbl.Layout)
+		}))
+	}
+	for _, item := range pg.tspendItems {
+		item := item
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return components.TSpendItemWidget(gtx, pg.Load, item)
+			})
+		}))
+	}
+
+	return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+func (pg *TreasuryPage) updateTSpendPolicy(tspendItem *components.TSpendItem) {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrSetTSpendPolicy)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			votingPreference := tspendItem.OptionsRadioGroup.Value
+			err := pg.selectedDCRWallet.SetTSpendPolicy(tspendItem.TSpend.Hash, votingPreference, "", password)
+			if err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pg.FetchPolicies() // re-fetch policies when voting is done.
+			infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrPolicySetSuccessful), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(infoModal)
+
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
 // TODO: Temporary UI. Pending when new designs will be ready for this feature
 func (pg *TreasuryPage) decredWalletRequired(gtx C) D {
 	return cryptomaterial.LinearLayout{
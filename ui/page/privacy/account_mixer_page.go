@@ -1,6 +1,9 @@
 package privacy
 
 import (
+	"strconv"
+
+	"gioui.org/font"
 	"gioui.org/layout"
 	"github.com/decred/dcrd/dcrutil/v4"
 
@@ -32,12 +35,14 @@ type AccountMixerPage struct {
 	settingsCollapsible *cryptomaterial.Collapsible
 	unmixedAccount      *cryptomaterial.Clickable
 	mixedAccount        *cryptomaterial.Clickable
+	mixerSchedule       *cryptomaterial.Clickable
 	toggleMixer         *cryptomaterial.Switch
 	mixerProgress       cryptomaterial.ProgressBarStyle
 
 	mixedBalance       sharedW.AssetAmount
 	unmixedBalance     sharedW.AssetAmount
 	totalWalletBalance sharedW.AssetAmount
+	mixerStats         *dcr.MixerStats
 
 	allAccount []preference.ItemPreference
 
@@ -54,6 +59,7 @@ func NewAccountMixerPage(l *load.Load, wallet *dcr.Asset) *AccountMixerPage {
 		settingsCollapsible: l.Theme.Collapsible(),
 		unmixedAccount:      l.Theme.NewClickable(false),
 		mixedAccount:        l.Theme.NewClickable(false),
+		mixerSchedule:       l.Theme.NewClickable(false),
 		pageContainer:       layout.List{Axis: layout.Vertical},
 	}
 }
@@ -68,7 +74,7 @@ func (pg *AccountMixerPage) OnNavigatedTo() {
 		pg.listenForMixerNotifications() // listener is stopped in OnNavigatedFrom().
 	}
 
-	pg.toggleMixer.SetChecked(pg.dcrWallet.IsAccountMixerActive())
+	pg.toggleMixer.SetChecked(pg.isMixing())
 	pg.mixerProgress.Height = values.MarginPadding18
 	pg.mixerProgress.Radius = cryptomaterial.Radius(2)
 	totalBalance, _ := components.CalculateTotalWalletsBalance(pg.dcrWallet) // TODO - handle error
@@ -100,6 +106,16 @@ func (pg *AccountMixerPage) getMixerBalance() {
 	pg.unmixedBalance = getSafeAmount(pg.unmixedBalance)
 
 	pg.allAccount = vm
+
+	pg.mixerStats, err = pg.dcrWallet.MixerStats()
+	if err != nil {
+		log.Errorf("could not load mixer stats: %v", err)
+	}
+}
+
+// isMixing returns true if the mixer is running or scheduled to run.
+func (pg *AccountMixerPage) isMixing() bool {
+	return pg.dcrWallet.IsAccountMixerActive() || pg.dcrWallet.IsMixerSchedulerActive()
 }
 
 // This function return dcr amount default is 0 if amount passed is nil
@@ -267,7 +283,7 @@ func (pg *AccountMixerPage) mixerSettings(l *load.Load) layout.FlexChild {
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.dcrWallet.IsAccountMixerActive() {
+				if pg.isMixing() {
 					return D{}
 				}
 				return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
@@ -282,6 +298,7 @@ func (pg *AccountMixerPage) mixerSettings(l *load.Load) layout.FlexChild {
 								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 									layout.Rigid(pg.bottomSectionLabel(pg.mixedAccount, values.String(values.StrMixedAccount))),
 									layout.Rigid(pg.bottomSectionLabel(pg.unmixedAccount, values.String(values.StrUnmixedAccount))),
+									layout.Rigid(pg.bottomSectionLabel(pg.mixerSchedule, pg.mixerScheduleTitle())),
 								)
 							})
 						},
@@ -292,6 +309,67 @@ func (pg *AccountMixerPage) mixerSettings(l *load.Load) layout.FlexChild {
 	})
 }
 
+func (pg *AccountMixerPage) mixerScheduleTitle() string {
+	schedule := pg.dcrWallet.MixerSchedule()
+	if schedule == nil || schedule.StartHour == schedule.EndHour {
+		return values.String(values.StrMixerSchedule)
+	}
+	return values.String(values.StrMixerSchedule) + " - " + values.StringF(values.StrMixerScheduleSummary, schedule.StartHour, schedule.EndHour)
+}
+
+// mixerStatsLayout shows the rounds completed, the amount mixed per
+// denomination and the unmixed balance left to mix.
+func (pg *AccountMixerPage) mixerStatsLayout() layout.FlexChild {
+	return layout.Rigid(func(gtx C) D {
+		if pg.mixerStats == nil {
+			return D{}
+		}
+
+		row := func(label, value string) layout.FlexChild {
+			return layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return components.EndToEndRow(gtx, pg.Theme.Body1(label).Layout, pg.Theme.Body1(value).Layout)
+				})
+			})
+		}
+
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding15}.Layout(gtx, pg.Theme.Separator().Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				txt := pg.Theme.Label(values.TextSize16, values.String(values.StrMixerStats))
+				txt.Color = pg.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}),
+			row(values.String(values.StrRoundsCompleted), strconv.Itoa(pg.mixerStats.Rounds)),
+			row(values.String(values.StrAmountMixed), pg.dcrWallet.ToAmount(pg.mixerStats.MixedAmount).String()),
+		}
+		for _, denom := range pg.mixerStats.Denominations {
+			denom := denom
+			label := values.StringF(values.StrMixDenominationOutputs, pg.dcrWallet.ToAmount(denom.Denomination).String(), denom.Outputs)
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4, Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					lbl := pg.Theme.Body2(label)
+					lbl.Color = pg.Theme.Color.GrayText2
+					amount := pg.Theme.Body2(pg.dcrWallet.ToAmount(denom.Amount).String())
+					amount.Color = pg.Theme.Color.GrayText2
+					return components.EndToEndRow(gtx, lbl.Layout, amount.Layout)
+				})
+			}))
+		}
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				lbl := pg.Theme.Body1(values.String(values.StrRemainingUnmixed))
+				lbl.Font.Weight = font.SemiBold
+				return components.EndToEndRow(gtx, lbl.Layout, pg.Theme.Body1(pg.dcrWallet.ToAmount(pg.mixerStats.UnmixedBalance).String()).Layout)
+			})
+		}))
+
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
+
 // Layout draws the page UI components into the provided layout context
 // to be eventually drawn on screen.
 // Layout is part of the load.Page interface.
@@ -305,6 +383,7 @@ func (pg *AccountMixerPage) Layout(gtx layout.Context) layout.Dimensions {
 					pg.mixerImage(),
 					pg.balanceInfo(values.String(values.StrUnmixed), pg.unmixedBalance.String(), pg.Theme.Icons.UnmixedTxIcon),
 					pg.mixerSettings(pg.Load),
+					pg.mixerStatsLayout(),
 				)
 			})
 		}
@@ -339,7 +418,12 @@ func (pg *AccountMixerPage) HandleUserInteractions(gtx C) {
 				SetPositiveButtonText(values.String(values.StrYes)).
 				SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
 					pg.toggleMixer.SetChecked(false)
-					go func() { _ = pg.dcrWallet.StopAccountMixer() }()
+					go func() {
+						pg.dcrWallet.StopMixerScheduler()
+						if pg.dcrWallet.IsAccountMixerActive() {
+							_ = pg.dcrWallet.StopAccountMixer()
+						}
+					}()
 					return true
 				})
 			pg.ParentWindow().ShowModal(info)
@@ -347,7 +431,8 @@ func (pg *AccountMixerPage) HandleUserInteractions(gtx C) {
 	}
 
 	if pg.mixerCompleted {
-		pg.toggleMixer.SetChecked(false)
+		// A scheduled mixer is only paused until it may run again.
+		pg.toggleMixer.SetChecked(pg.dcrWallet.IsMixerSchedulerActive())
 		pg.mixerCompleted = false
 		pg.ParentWindow().Reload()
 	}
@@ -362,6 +447,10 @@ func (pg *AccountMixerPage) HandleUserInteractions(gtx C) {
 		return num
 	}
 
+	if pg.mixerSchedule.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newMixerScheduleModal(pg.Load, pg.dcrWallet, pg.ParentWindow().Reload))
+	}
+
 	if pg.mixedAccount.Clicked(gtx) {
 		name, err := pg.dcrWallet.AccountName(pg.dcrWallet.MixedAccountNumber())
 		if err != nil {
@@ -446,7 +535,12 @@ func (pg *AccountMixerPage) showModalPasswordStartAccountMixer() {
 		}).
 		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
 			go func() {
-				err := pg.dcrWallet.StartAccountMixer(password)
+				var err error
+				if pg.dcrWallet.MixerSchedule() != nil {
+					err = pg.dcrWallet.StartMixerScheduler(password)
+				} else {
+					err = pg.dcrWallet.StartAccountMixer(password)
+				}
 				if err != nil {
 					pg.Toast.NotifyError(err.Error())
 					pg.toggleMixer.SetChecked(false)
@@ -0,0 +1,180 @@
+package privacy
+
+import (
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// mixerScheduleModal edits the hours and the conditions the account mixer
+// runs under when it is started with a schedule.
+type mixerScheduleModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	dcrWallet   *dcr.Asset
+	onUpdated   func()
+	hasSchedule bool
+
+	startHourEditor    cryptomaterial.Editor
+	endHourEditor      cryptomaterial.Editor
+	maxRoundsEditor    cryptomaterial.Editor
+	acPowerCheckBox    cryptomaterial.CheckBoxStyle
+	canDetectACPower   bool
+	stopWhenEmptyCheck cryptomaterial.CheckBoxStyle
+	saveBtn            cryptomaterial.Button
+	clearBtn           cryptomaterial.Button
+	cancelBtn          cryptomaterial.Button
+	invalidSchedule    bool
+}
+
+func newMixerScheduleModal(l *load.Load, wallet *dcr.Asset, onUpdated func()) *mixerScheduleModal {
+	sm := &mixerScheduleModal{
+		Load:               l,
+		Modal:              l.Theme.ModalFloatTitle("mixer_schedule_modal", l.IsMobileView(), nil),
+		dcrWallet:          wallet,
+		onUpdated:          onUpdated,
+		canDetectACPower:   utils.CanDetectPowerSource(),
+		startHourEditor:    l.Theme.Editor(new(widget.Editor), values.String(values.StrMixStartHour)),
+		endHourEditor:      l.Theme.Editor(new(widget.Editor), values.String(values.StrMixEndHour)),
+		maxRoundsEditor:    l.Theme.Editor(new(widget.Editor), values.String(values.StrMixMaxRounds)),
+		acPowerCheckBox:    l.Theme.CheckBox(new(widget.Bool), values.String(values.StrMixOnlyOnACPower)),
+		stopWhenEmptyCheck: l.Theme.CheckBox(new(widget.Bool), values.String(values.StrMixStopWhenEmpty)),
+		saveBtn:            l.Theme.Button(values.String(values.StrSave)),
+		clearBtn:           l.Theme.OutlineButton(values.String(values.StrClear)),
+		cancelBtn:          l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	for _, editor := range []*cryptomaterial.Editor{&sm.startHourEditor, &sm.endHourEditor, &sm.maxRoundsEditor} {
+		editor.Editor.SingleLine = true
+		editor.Editor.Filter = "0123456789"
+	}
+
+	schedule := wallet.MixerSchedule()
+	sm.hasSchedule = schedule != nil
+	if schedule == nil {
+		schedule = &dcr.MixerSchedule{StopWhenUnmixedEmpty: true}
+	}
+	sm.startHourEditor.Editor.SetText(strconv.Itoa(schedule.StartHour))
+	sm.endHourEditor.Editor.SetText(strconv.Itoa(schedule.EndHour))
+	sm.maxRoundsEditor.Editor.SetText(strconv.Itoa(schedule.MaxRounds))
+	// The power source cannot be detected on every device, the setting
+	// would not be honored there.
+	sm.acPowerCheckBox.CheckBox.Value = schedule.OnlyOnACPower && sm.canDetectACPower
+	sm.stopWhenEmptyCheck.CheckBox.Value = schedule.StopWhenUnmixedEmpty
+
+	return sm
+}
+
+func (sm *mixerScheduleModal) OnResume() {}
+
+func (sm *mixerScheduleModal) OnDismiss() {}
+
+// schedule returns the schedule entered or false if it is not valid.
+func (sm *mixerScheduleModal) schedule() (*dcr.MixerSchedule, bool) {
+	number := func(editor cryptomaterial.Editor) (int, bool) {
+		n, err := strconv.Atoi(strings.TrimSpace(editor.Editor.Text()))
+		return n, err == nil && n >= 0
+	}
+
+	startHour, validStart := number(sm.startHourEditor)
+	endHour, validEnd := number(sm.endHourEditor)
+	maxRounds, validRounds := number(sm.maxRoundsEditor)
+	if !validStart || !validEnd || !validRounds || startHour > 23 || endHour > 23 {
+		return nil, false
+	}
+
+	return &dcr.MixerSchedule{
+		StartHour:            startHour,
+		EndHour:              endHour,
+		OnlyOnACPower:        sm.acPowerCheckBox.CheckBox.Value,
+		MaxRounds:            maxRounds,
+		StopWhenUnmixedEmpty: sm.stopWhenEmptyCheck.CheckBox.Value,
+	}, true
+}
+
+func (sm *mixerScheduleModal) Handle(gtx C) {
+	if sm.cancelBtn.Clicked(gtx) {
+		sm.Dismiss()
+	}
+
+	if sm.clearBtn.Clicked(gtx) {
+		sm.dcrWallet.SetMixerSchedule(nil)
+		sm.onUpdated()
+		sm.Dismiss()
+	}
+
+	schedule, valid := sm.schedule()
+	sm.invalidSchedule = !valid
+	sm.saveBtn.SetEnabled(valid)
+	if sm.saveBtn.Clicked(gtx) && valid {
+		sm.dcrWallet.SetMixerSchedule(schedule)
+		sm.onUpdated()
+		sm.Dismiss()
+	}
+}
+
+func (sm *mixerScheduleModal) Layout(gtx C) D {
+	editor := func(e cryptomaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, e.Layout)
+		}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := sm.Theme.H6(values.String(values.StrMixerSchedule))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(editor(sm.startHourEditor)),
+				layout.Rigid(editor(sm.endHourEditor)),
+				layout.Rigid(editor(sm.maxRoundsEditor)),
+				layout.Rigid(func(gtx C) D {
+					if !sm.canDetectACPower {
+						return D{}
+					}
+					return sm.acPowerCheckBox.Layout(gtx)
+				}),
+				layout.Rigid(sm.stopWhenEmptyCheck.Layout),
+				layout.Rigid(func(gtx C) D {
+					if !sm.invalidSchedule {
+						return D{}
+					}
+					lbl := sm.Theme.Body2(values.String(values.StrInvalidMixerSchedule))
+					lbl.Color = sm.Theme.Color.Danger
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if !sm.hasSchedule {
+							return D{}
+						}
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, sm.clearBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, sm.cancelBtn.Layout)
+					}),
+					layout.Rigid(sm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return sm.Modal.Layout(gtx, w)
+}
@@ -16,6 +16,8 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/paymenturi"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -45,10 +47,13 @@ type Page struct {
 	scrollContainer *widget.List
 	isNewAddr       bool
 	currentAddress  string
+	addressLabel    string
+	editLabel       *cryptomaterial.Clickable
 	qrImage         *image.Image
 	newAddr, copy   *cryptomaterial.Clickable
 	info            cryptomaterial.IconButton
 	card            cryptomaterial.Card
+	amountEditor    cryptomaterial.Editor
 
 	walletDropdown     *components.WalletDropdown
 	accountDropdown    *components.AccountDropdown
@@ -78,6 +83,7 @@ func NewReceivePage(l *load.Load, wallet sharedW.Asset) *Page {
 		info:              l.Theme.IconButton(cryptomaterial.MustIcon(widget.NewIcon(icons.ActionInfo))),
 		copy:              l.Theme.NewClickable(false),
 		newAddr:           l.Theme.NewClickable(false),
+		editLabel:         l.Theme.NewClickable(false),
 		card:              l.Theme.Card(),
 		backdrop:          new(widget.Clickable),
 		qrCopyButton:      new(widget.Clickable),
@@ -86,6 +92,11 @@ func NewReceivePage(l *load.Load, wallet sharedW.Asset) *Page {
 		selectedWallet:    wallet,
 	}
 
+	pg.amountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrRequestAmount))
+	pg.amountEditor.Editor.SingleLine = true
+	pg.amountEditor.IsTitleLabel = false
+	pg.amountEditor.TextSize = values.TextSizeTransform(l.IsMobileView(), values.TextSize16)
+
 	pg.info.Inset, pg.info.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
 
 	_, pg.infoButton = components.SubpageHeaderButtons(l)
@@ -125,6 +136,7 @@ func (pg *Page) initWalletSelectors(wallet sharedW.Asset) {
 				log.Errorf("Error getting current address: %v", err)
 			} else {
 				pg.currentAddress = currentAddress
+				pg.loadAddressLabel()
 			}
 
 			pg.generateQRForAddress()
@@ -177,12 +189,56 @@ func (pg *Page) OnNavigatedTo() {
 		pg.ParentWindow().ShowModal(errModal)
 	} else {
 		pg.currentAddress = currentAddress
+		pg.loadAddressLabel()
 		pg.generateQRForAddress()
 	}
 }
 
+// loadAddressLabel reads the label of the current address.
+func (pg *Page) loadAddressLabel() {
+	pg.addressLabel = pg.selectedWallet.Label(walletdata.LabelTypeAddress, pg.currentAddress)
+}
+
+// requestedAmount returns the amount entered in the request amount editor in
+// the smallest unit of the selected wallet's asset. Zero is returned if no
+// valid amount was entered.
+func (pg *Page) requestedAmount() int64 {
+	pg.amountEditor.SetError("")
+	text := strings.TrimSpace(pg.amountEditor.Editor.Text())
+	if text == "" {
+		return 0
+	}
+
+	// DCR, BTC and LTC all have 8 decimal places. The payment URI parser only
+	// accepts plain decimal numbers, unlike strconv.ParseFloat which also
+	// accepts NaN, Inf and exponents.
+	amount, err := paymenturi.ParseAmount(text)
+	if err != nil || amount <= 0 {
+		pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+		return 0
+	}
+	return amount
+}
+
+// paymentRequest returns the text encoded in the QR code and copied to the
+// clipboard. It is the bare address unless an amount is requested, in which
+// case a payment URI carrying the address and amount is returned.
+func (pg *Page) paymentRequest() string {
+	amount := pg.requestedAmount()
+	if amount == 0 || pg.currentAddress == "" {
+		return pg.currentAddress
+	}
+
+	uri := &paymenturi.PaymentURI{
+		AssetType: pg.selectedWallet.GetAssetType(),
+		Address:   pg.currentAddress,
+		Amount:    amount,
+	}
+	return uri.String()
+}
+
 func (pg *Page) generateQRForAddress() {
-	qrCode, err := qrcode.New(pg.currentAddress, qrcode.WithLogoImage(pg.getSelectedWalletLogo()))
+	qrCode, err := qrcode.New(pg.paymentRequest(), qrcode.WithLogoImage(pg.getSelectedWalletLogo()))
 	if err != nil {
 		log.Error("Error generating address qrCode: " + err.Error())
 		return
@@ -323,6 +379,8 @@ func (pg *Page) contentLayout(gtx C) D {
 								layout.Rigid(layout.Spacer{Height: values.MarginPadding24}.Layout),
 								layout.Rigid(pg.addressLayout),
 								layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
+								layout.Rigid(pg.amountEditor.Layout),
+								layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
 								layout.Rigid(pg.copyAndNewAddressLayout),
 							)
 						}),
@@ -428,17 +486,61 @@ func (pg *Page) addressLayout(gtx C) D {
 		Width:        values.MarginPadding2,
 	}
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
-	return border.Layout(gtx, func(gtx C) D {
-		return pg.addressCopyButton.Layout(gtx, func(gtx C) D {
-			return components.VerticalInset(values.MarginPadding12).Layout(gtx, func(gtx C) D {
-				lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize16), "")
-				if pg.currentAddress != "" && pg.selectedWallet.IsSynced() {
-					lbl.Text = pg.currentAddress
-				}
-				return layout.Center.Layout(gtx, lbl.Layout)
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return border.Layout(gtx, func(gtx C) D {
+				return pg.addressCopyButton.Layout(gtx, func(gtx C) D {
+					return components.VerticalInset(values.MarginPadding12).Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize16), "")
+						if pg.currentAddress != "" && pg.selectedWallet.IsSynced() {
+							lbl.Text = pg.currentAddress
+						}
+						return layout.Center.Layout(gtx, lbl.Layout)
+					})
+				})
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.currentAddress == "" || !pg.selectedWallet.IsSynced() {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Center.Layout(gtx, func(gtx C) D {
+					return pg.editLabel.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize14), pg.addressLabel)
+						lbl.Color = pg.Theme.Color.GrayText2
+						if pg.addressLabel == "" {
+							lbl.Text = values.String(values.StrAddLabel)
+							lbl.Color = pg.Theme.Color.Primary
+						}
+						return lbl.Layout(gtx)
+					})
+				})
 			})
+		}),
+	)
+}
+
+// showLabelModal lets the user label the current address.
+func (pg *Page) showLabelModal() {
+	address := pg.currentAddress
+	labelModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrLabel)).
+		SetText(pg.addressLabel).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(label string, tim *modal.TextInputModal) bool {
+			if err := pg.selectedWallet.SetLabel(walletdata.LabelTypeAddress, address, label); err != nil {
+				tim.SetError(err.Error())
+				return false
+			}
+			if address == pg.currentAddress {
+				pg.addressLabel = strings.TrimSpace(label)
+			}
+			return true
 		})
-	})
+	labelModal.Title(values.String(values.StrEditLabel)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(labelModal)
 }
 
 // HandleUserInteractions is called just before Layout() to determine
@@ -453,6 +555,10 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		pg.isNewAddr = false
 	}
 
+	if pg.amountEditor.Changed() {
+		pg.generateQRForAddress()
+	}
+
 	if pg.newAddr.Clicked(gtx) {
 		newAddr, err := pg.generateNewAddress()
 		if err != nil {
@@ -461,10 +567,15 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		}
 
 		pg.currentAddress = newAddr
+		pg.loadAddressLabel()
 		pg.generateQRForAddress()
 		pg.isNewAddr = false
 	}
 
+	if pg.editLabel.Clicked(gtx) {
+		pg.showLabelModal()
+	}
+
 	if pg.infoButton.Button.Clicked(gtx) {
 		textWithUnit := values.String(values.StrReceive) + " " + string(pg.selectedWallet.GetAssetType())
 		info := modal.NewCustomModal(pg.Load).
@@ -501,7 +612,7 @@ generateAddress:
 func (pg *Page) handleCopyEvent(gtx C) {
 	// Prevent copying again if the timer hasn't expired
 	if (pg.copy.Clicked(gtx) || pg.qrCopyButton.Clicked(gtx) || pg.addressCopyButton.Clicked(gtx)) && !pg.isCopying {
-		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(pg.currentAddress))})
+		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(pg.paymentRequest()))})
 		pg.Toast.Notify(values.String(values.StrCopied))
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/page/exchange"
 	"github.com/crypto-power/cryptopower/ui/page/governance"
@@ -39,7 +40,7 @@ const (
 	HomePageID = "Home"
 )
 
-var totalBalanceUSD string
+var totalBalanceFiat string
 
 type HomePage struct {
 	*app.MasterPage
@@ -74,6 +75,8 @@ type HomePage struct {
 	updateAvailableBtn *cryptomaterial.Clickable
 	copyRedirectURL    *cryptomaterial.Clickable
 	releaseResponse    *components.ReleaseResponse
+
+	systemNotification *notification.SystemNotification
 }
 
 func NewHomePage(dexCtx context.Context, l *load.Load) *HomePage {
@@ -92,6 +95,12 @@ func NewHomePage(dexCtx context.Context, l *load.Load) *HomePage {
 	hp.infoButton.Size = values.MarginPadding15
 	hp.updateAvailableBtn = l.Theme.NewClickable(false)
 
+	systemNotification, err := notification.NewSystemNotification()
+	if err != nil {
+		log.Errorf("Error initializing system notifications: %v", err)
+	}
+	hp.systemNotification = systemNotification
+
 	go func() {
 		hp.isConnected.Store(libutils.IsOnline())
 	}()
@@ -197,7 +206,7 @@ func (hp *HomePage) OnNavigatedTo() {
 	}
 
 	if hp.AssetsManager.ExchangeRateFetchingEnabled() {
-		go hp.CalculateAssetsUSDBalance()
+		go hp.CalculateAssetsFiatBalance()
 	}
 	hp.isBalanceHidden = hp.AssetsManager.IsTotalBalanceVisible()
 
@@ -206,8 +215,10 @@ func (hp *HomePage) OnNavigatedTo() {
 	}
 
 	hp.AssetsManager.WatchBalanceChange(func() {
-		go hp.CalculateAssetsUSDBalance()
+		go hp.CalculateAssetsFiatBalance()
 	})
+
+	hp.listenForWatchedProposals()
 }
 
 // initDEX initializes a new dex client if dex is not ready.
@@ -562,7 +573,7 @@ func (hp *HomePage) HandleKeyPress(gtx C, evt *key.Event) {
 }
 
 func (hp *HomePage) OnCurrencyChanged() {
-	go hp.CalculateAssetsUSDBalance()
+	go hp.CalculateAssetsFiatBalance()
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
@@ -579,6 +590,7 @@ func (hp *HomePage) OnNavigatedFrom() {
 	}
 
 	hp.AssetsManager.RemoveAssetChange()
+	hp.AssetsManager.Politeia.RemoveWatchlistCallback(HomePageID)
 	hp.ctxCancel()
 }
 
@@ -779,7 +791,7 @@ func (hp *HomePage) totalBalanceLayout(gtx C) D {
 		}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				// Check if exchange rate fetching is enabled and total balance is available
-				if hp.AssetsManager.ExchangeRateFetchingEnabled() && totalBalanceUSD != "" {
+				if hp.AssetsManager.ExchangeRateFetchingEnabled() && totalBalanceFiat != "" {
 					// Render total balance text and icon button
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Rigid(hp.totalBalanceTextAndIconButtonLayout),
@@ -890,9 +902,9 @@ func (hp *HomePage) totalBalanceLayout(gtx C) D {
 }
 
 func (hp *HomePage) balanceLayout(gtx C) D {
-	if hp.AssetsManager.ExchangeRateFetchingEnabled() && totalBalanceUSD != "" {
+	if hp.AssetsManager.ExchangeRateFetchingEnabled() && totalBalanceFiat != "" {
 		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
-			layout.Rigid(hp.LayoutUSDBalance),
+			layout.Rigid(hp.LayoutFiatBalance),
 			layout.Rigid(func(gtx C) D {
 				icon := hp.Theme.Icons.VisibilityOffIcon
 				if hp.isBalanceHidden {
@@ -909,8 +921,8 @@ func (hp *HomePage) balanceLayout(gtx C) D {
 }
 
 // TODO: use real values
-func (hp *HomePage) LayoutUSDBalance(gtx C) D {
-	lblText := hp.Theme.Label(values.TextSize30, totalBalanceUSD)
+func (hp *HomePage) LayoutFiatBalance(gtx C) D {
+	lblText := hp.Theme.Label(values.TextSize30, totalBalanceFiat)
 
 	if hp.isBalanceHidden {
 		lblText = hp.Theme.Label(values.TextSize24, "******")
@@ -1061,7 +1073,7 @@ func (hp *HomePage) unlockWalletForSyncing(wal sharedW.Asset, unlock load.NeedUn
 	hp.ParentWindow().ShowModal(spendingPasswordModal)
 }
 
-func (hp *HomePage) CalculateAssetsUSDBalance() {
+func (hp *HomePage) CalculateAssetsFiatBalance() {
 	if hp.AssetsManager.ExchangeRateFetchingEnabled() {
 		assetsBalance, err := hp.AssetsManager.CalculateTotalAssetsBalance(true)
 		if err != nil {
@@ -1069,18 +1081,18 @@ func (hp *HomePage) CalculateAssetsUSDBalance() {
 			return
 		}
 
-		assetsTotalUSDBalance, err := hp.AssetsManager.CalculateAssetsUSDBalance(assetsBalance)
+		assetsTotalFiatBalance, err := hp.AssetsManager.CalculateAssetsFiatBalance(assetsBalance)
 		if err != nil {
 			log.Error(err)
 			return
 		}
 
 		var totalBalance float64
-		for _, balance := range assetsTotalUSDBalance {
+		for _, balance := range assetsTotalFiatBalance {
 			totalBalance += balance
 		}
 
-		totalBalanceUSD = utils.FormatAsUSDString(hp.Printer, totalBalance)
+		totalBalanceFiat = utils.FormatAsFiatString(hp.Printer, hp.AssetsManager.FiatCurrency(), totalBalance)
 		hp.ParentWindow().Reload()
 	}
 }
@@ -1125,3 +1137,37 @@ func (hp *HomePage) checkForUpdates() {
 func (hp *HomePage) isUpdateAPIAllowed() bool {
 	return hp.AssetsManager.IsHTTPAPIPrivacyModeOff(libutils.UpdateAPI)
 }
+
+// listenForWatchedProposals posts a system notification for the vote updates
+// of the proposals on the watchlist.
+func (hp *HomePage) listenForWatchedProposals() {
+	if hp.systemNotification == nil {
+		return
+	}
+
+	watchlistCallback := func(token, propName string, status libutils.ProposalStatus, unvotedTickets int) {
+		var message string
+		switch status {
+		case libutils.ProposalStatusVoteStarted:
+			message = values.StringF(values.StrWatchedVoteStartedNotif, propName)
+		case libutils.ProposalStatusVoteEnding:
+			message = values.StringF(values.StrWatchedVoteEndingNotif, propName, unvotedTickets)
+		case libutils.ProposalStatusVoteFinished:
+			result := values.String(values.StrRejected)
+			if proposal, err := hp.AssetsManager.Politeia.GetProposalRaw(token); err == nil && proposal.VoteApproved {
+				result = values.String(values.StrApproved)
+			}
+			message = values.StringF(values.StrWatchedVoteFinishedNotif, propName, result)
+		default:
+			return
+		}
+
+		if err := hp.systemNotification.Notify(message); err != nil {
+			log.Infof("could not post watched proposal notification: %v", err)
+		}
+	}
+	err := hp.AssetsManager.Politeia.AddWatchlistCallback(watchlistCallback, HomePageID)
+	if err != nil {
+		log.Errorf("Error adding watchlist notification listener: %v", err)
+	}
+}
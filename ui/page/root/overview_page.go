@@ -5,6 +5,8 @@ import (
 	"image/color"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -37,8 +39,16 @@ import (
 
 const (
 	OverviewPageID = "Overview"
+
+	// maxPortfolioChartPoints is the number of points the portfolio value
+	// chart is reduced to.
+	maxPortfolioChartPoints = 60
 )
 
+// portfolioRanges are the number of days shown by the portfolio value chart,
+// in the order of the range selector. Zero shows the whole history.
+var portfolioRanges = []int{7, 30, 365, 0}
+
 type multiWalletTx struct {
 	*sharedW.Transaction
 	walletID int
@@ -86,12 +96,18 @@ type OverviewPage struct {
 	showNavigationFunc showNavigationFunc
 
 	listInfoWallets []*components.WalletSyncInfo
+
+	portfolioRange   *cryptomaterial.SegmentedControl
+	portfolioMtx     sync.Mutex
+	portfolioChart   *cryptomaterial.LineChart
+	portfolioValue   string
+	loadingPortfolio bool
 }
 
 type assetBalanceSliderItem struct {
-	assetType       string
-	totalBalance    sharedW.AssetAmount
-	totalBalanceUSD string
+	assetType        string
+	totalBalance     sharedW.AssetAmount
+	totalBalanceFiat string
 
 	image           *cryptomaterial.Image
 	backgroundImage *cryptomaterial.Image
@@ -99,7 +115,6 @@ type assetBalanceSliderItem struct {
 
 type assetMarketData struct {
 	assetType libutils.AssetType
-	market    values.Market
 	image     *cryptomaterial.Image
 }
 
@@ -139,17 +154,14 @@ func NewOverviewPage(l *load.Load, showNavigationFunc showNavigationFunc) *Overv
 		mktValues: []assetMarketData{
 			{
 				assetType: libutils.DCRWalletAsset,
-				market:    values.DCRUSDTMarket,
 				image:     l.Theme.Icons.DCR,
 			},
 			{
 				assetType: libutils.BTCWalletAsset,
-				market:    values.BTCUSDTMarket,
 				image:     l.Theme.Icons.BTC,
 			},
 			{
 				assetType: libutils.LTCWalletAsset,
-				market:    values.LTCUSDTMarket,
 				image:     l.Theme.Icons.LTC,
 			},
 		},
@@ -168,6 +180,15 @@ func NewOverviewPage(l *load.Load, showNavigationFunc showNavigationFunc) *Overv
 	}
 
 	pg.materialLoader = material.Loader(l.Theme.Base)
+
+	pg.portfolioRange = l.Theme.SegmentedControl([]string{
+		values.String(values.StrRange7D),
+		values.String(values.StrRange30D),
+		values.String(values.StrRange1Y),
+		values.String(values.StrAll),
+	}, cryptomaterial.SegmentTypeDynamicSplit)
+	pg.portfolioRange.SetEnableSwipe(false)
+	pg.portfolioRange.DisableUniform(true)
 	pg.mixerSlider.IndicatorBackgroundColor = values.TransparentColor(values.TransparentDeepBlue, 0.02)
 	pg.mixerSlider.SelectedIndicatorColor = pg.Theme.Color.DeepBlue
 
@@ -202,7 +223,8 @@ func (pg *OverviewPage) OnNavigatedTo() {
 	pg.updateAssetsSliders()
 	if pg.AssetsManager.ExchangeRateFetchingEnabled() {
 		go pg.AssetsManager.RateSource.Refresh(false)
-		go pg.updateAssetsUSDBalance()
+		go pg.updateAssetsFiatBalance()
+		go pg.loadPortfolioHistory()
 	}
 	go pg.loadTransactions()
 
@@ -233,6 +255,10 @@ func (pg *OverviewPage) HandleUserInteractions(gtx C) {
 		go pg.AssetsManager.RateSource.Refresh(true)
 	}
 
+	if pg.portfolioRange.Changed() {
+		go pg.loadPortfolioHistory()
+	}
+
 	if clicked, selectedTxIndex := pg.recentTransactions.ItemClicked(); clicked {
 		tx, wal := pg.txAndWallet(pg.transactions[selectedTxIndex])
 		pg.ParentNavigator().Display(transaction.NewTransactionDetailsPage(pg.Load, wal, tx))
@@ -299,7 +325,8 @@ func (pg *OverviewPage) OnNavigatedFrom() {
 }
 
 func (pg *OverviewPage) OnCurrencyChanged() {
-	go pg.updateAssetsUSDBalance()
+	go pg.updateAssetsFiatBalance()
+	go pg.loadPortfolioHistory()
 }
 
 func (pg *OverviewPage) reload() {
@@ -328,6 +355,7 @@ func (pg *OverviewPage) layoutDesktop(gtx C) D {
 		pg.sliderLayout,
 		pg.infoWalletLayout,
 		pg.marketOverview,
+		pg.portfolioOverview,
 		pg.txStakingSection,
 		pg.recentTrades,
 		pg.recentProposal,
@@ -351,6 +379,7 @@ func (pg *OverviewPage) layoutMobile(gtx C) D {
 		pg.sliderLayout,
 		pg.infoWalletLayout,
 		pg.mobileMarketOverview,
+		pg.portfolioOverview,
 		pg.txStakingSection,
 		pg.recentProposal,
 	}
@@ -529,7 +558,7 @@ func (pg *OverviewPage) contentSliderLayout(item *assetBalanceSliderItem) layout
 								Right:  values.MarginPadding8,
 								Left:   values.MarginPadding8,
 							}.Layout(gtx, func(gtx C) D {
-								return components.LayoutBalanceColorWithStateUSD(gtx, pg.Load, item.totalBalanceUSD, col)
+								return components.LayoutBalanceColorWithStateUSD(gtx, pg.Load, item.totalBalanceFiat, col)
 							})
 						})
 					})
@@ -603,7 +632,7 @@ func (pg *OverviewPage) marketOverview(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
 					return pg.marketOverviewList.Layout(gtx, len(pg.mktValues), func(gtx C, i int) D {
 						asset := pg.mktValues[i]
-						rate, ok := rates[asset.market.String()]
+						rate, ok := rates[asset.assetType]
 						if !ok {
 							return D{}
 						}
@@ -637,6 +666,102 @@ func (pg *OverviewPage) marketOverview(gtx C) D {
 	})
 }
 
+// portfolioOverview shows the value of all the wallets over the selected range
+// of days.
+func (pg *OverviewPage) portfolioOverview(gtx C) D {
+	if !pg.AssetsManager.ExchangeRateFetchingEnabled() {
+		return D{}
+	}
+
+	pg.portfolioMtx.Lock()
+	chart, value, loading := pg.portfolioChart, pg.portfolioValue, pg.loadingPortfolio
+	pg.portfolioMtx.Unlock()
+
+	return pg.pageContentWrapper(gtx, values.String(values.StrPortfolioValue), nil, func(gtx C) D {
+		return pg.portfolioRange.Layout(gtx, func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				if chart == nil {
+					if !loading {
+						return D{}
+					}
+					gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding20)
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return pg.materialLoader.Layout(gtx)
+				}
+
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.H6(value).Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, chart.Layout)
+					}),
+				)
+			})
+		}, pg.IsMobileView())
+	})
+}
+
+// loadPortfolioHistory loads the value of the wallets over the selected range
+// of days for the portfolio value chart.
+func (pg *OverviewPage) loadPortfolioHistory() {
+	if !pg.AssetsManager.ExchangeRateFetchingEnabled() {
+		return
+	}
+
+	pg.portfolioMtx.Lock()
+	if pg.loadingPortfolio {
+		pg.portfolioMtx.Unlock()
+		return
+	}
+	pg.loadingPortfolio = true
+	pg.portfolioMtx.Unlock()
+
+	rangeIndex := pg.portfolioRange.SelectedIndex()
+	days := portfolioRanges[rangeIndex]
+	var from time.Time
+	if days > 0 {
+		from = time.Now().AddDate(0, 0, -days)
+	}
+
+	history, err := pg.AssetsManager.PortfolioHistory(from)
+	if err != nil {
+		log.Errorf("error loading portfolio history: %v", err)
+	}
+
+	var chart *cryptomaterial.LineChart
+	var value string
+	if len(history) > 0 {
+		dateFormat := "Jan 02"
+		if days == 0 || days > 30 {
+			dateFormat = "Jan 02, 2006"
+		}
+
+		// Keep the last point, it is the current value.
+		step := (len(history) + maxPortfolioChartPoints - 1) / maxPortfolioChartPoints
+		points := make([]cryptomaterial.LineChartPoint, 0, maxPortfolioChartPoints+1)
+		for i := (len(history) - 1) % step; i < len(history); i += step {
+			points = append(points, cryptomaterial.LineChartPoint{
+				Label: time.Unix(history[i].Time, 0).UTC().Format(dateFormat),
+				Value: history[i].Value,
+			})
+		}
+
+		chart = pg.Theme.LineChart(points)
+		currency := pg.AssetsManager.FiatCurrency()
+		value = pageutils.FormatAsFiatString(pg.Printer, currency, history[len(history)-1].Value)
+	}
+
+	pg.portfolioMtx.Lock()
+	pg.portfolioChart, pg.portfolioValue = chart, value
+	pg.loadingPortfolio = false
+	pg.portfolioMtx.Unlock()
+	pg.ParentWindow().Reload()
+
+	// The range may have changed while loading.
+	if rangeIndex != pg.portfolioRange.SelectedIndex() {
+		pg.loadPortfolioHistory()
+	}
+}
+
 func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 	rates := pg.marketRates()
 	if len(rates) == 0 {
@@ -675,7 +800,7 @@ func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 				layout.Rigid(func(gtx C) D {
 					return pg.mobileMarketOverviewList.Layout(gtx, len(pg.mktValues), func(gtx C, i int) D {
 						asset := pg.mktValues[i]
-						rate, ok := rates[asset.market.String()]
+						rate, ok := rates[asset.assetType]
 						if !ok {
 							return D{}
 						}
@@ -752,7 +877,7 @@ func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 									}),
 									layout.Rigid(func(gtx C) D {
 										return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
-											txt := pg.Theme.Label(values.TextSize16, pageutils.FormatAsUSDString(pg.Printer, rate.LastTradePrice))
+											txt := pg.Theme.Label(values.TextSize16, pageutils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), rate.LastTradePrice))
 											txt.Color = pg.Theme.Color.Text
 											return txt.Layout(gtx)
 										})
@@ -802,8 +927,8 @@ func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 	)
 }
 
-func (pg *OverviewPage) marketRates() map[string]*ext.Ticker {
-	marketRates := make(map[string]*ext.Ticker)
+func (pg *OverviewPage) marketRates() map[libutils.AssetType]*ext.Ticker {
+	marketRates := make(map[libutils.AssetType]*ext.Ticker)
 
 	if !pg.AssetsManager.ExchangeRateFetchingEnabled() {
 		return marketRates
@@ -811,11 +936,15 @@ func (pg *OverviewPage) marketRates() map[string]*ext.Ticker {
 
 	for i := range pg.mktValues {
 		asset := pg.mktValues[i]
-		rate := pg.AssetsManager.RateSource.GetTicker(asset.market, true)
+		market, err := pg.AssetsManager.FiatMarket(asset.assetType)
+		if err != nil {
+			continue
+		}
+		rate := pg.AssetsManager.RateSource.GetTicker(market, true)
 		if rate == nil || rate.LastTradePrice <= 0 {
 			continue
 		}
-		marketRates[asset.market.String()] = rate
+		marketRates[asset.assetType] = rate
 	}
 
 	return marketRates
@@ -869,7 +998,7 @@ func (pg *OverviewPage) marketTableRows(gtx C, asset assetMarketData, rate *ext.
 			Alignment: layout.Middle,
 		}.Layout(gtx,
 			layout.Flexed(.785, func(gtx C) D {
-				return layout.E.Layout(gtx, pg.assetTableLabel(pageutils.FormatAsUSDString(pg.Printer, rate.LastTradePrice), pg.Theme.Color.Text))
+				return layout.E.Layout(gtx, pg.assetTableLabel(pageutils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), rate.LastTradePrice), pg.Theme.Color.Text))
 			}),
 			layout.Flexed(.215, func(gtx C) D {
 				hasRateChange := rate.PriceChangePercent != nil
@@ -1062,26 +1191,27 @@ func (pg *OverviewPage) txAndWallet(mtx *multiWalletTx) (*sharedW.Transaction, s
 	return mtx.Transaction, pg.AssetsManager.WalletWithID(mtx.walletID)
 }
 
-func (pg *OverviewPage) updateAssetsUSDBalance() {
+func (pg *OverviewPage) updateAssetsFiatBalance() {
 	if pg.AssetsManager.ExchangeRateFetchingEnabled() {
-		assetsTotalUSDBalance, err := pg.AssetsManager.CalculateAssetsUSDBalance(pg.assetsTotalBalance)
+		assetsTotalFiatBalance, err := pg.AssetsManager.CalculateAssetsFiatBalance(pg.assetsTotalBalance)
 		if err != nil {
 			log.Error(err)
 			return
 		}
 
-		toUSDString := func(balance float64) string {
-			return pageutils.FormatAsUSDString(pg.Printer, balance)
+		currency := pg.AssetsManager.FiatCurrency()
+		toFiatString := func(balance float64) string {
+			return pageutils.FormatAsFiatString(pg.Printer, currency, balance)
 		}
 
-		for assetType, balance := range assetsTotalUSDBalance {
+		for assetType, balance := range assetsTotalFiatBalance {
 			switch assetType {
 			case libutils.DCRWalletAsset:
-				pg.dcr.totalBalanceUSD = toUSDString(balance)
+				pg.dcr.totalBalanceFiat = toFiatString(balance)
 			case libutils.BTCWalletAsset:
-				pg.btc.totalBalanceUSD = toUSDString(balance)
+				pg.btc.totalBalanceFiat = toFiatString(balance)
 			case libutils.LTCWalletAsset:
-				pg.ltc.totalBalanceUSD = toUSDString(balance)
+				pg.ltc.totalBalanceFiat = toFiatString(balance)
 			default:
 				log.Errorf("Unsupported asset type: %s", assetType)
 				return
@@ -1103,11 +1233,11 @@ func (pg *OverviewPage) updateAssetsSliders() {
 
 	sliderItem := func(totalBalance sharedW.AssetAmount, assetFullName string, icon, bkgImage *cryptomaterial.Image) *assetBalanceSliderItem {
 		return &assetBalanceSliderItem{
-			assetType:       assetFullName,
-			totalBalance:    totalBalance,
-			totalBalanceUSD: "$--",
-			image:           icon,
-			backgroundImage: bkgImage,
+			assetType:        assetFullName,
+			totalBalance:     totalBalance,
+			totalBalanceFiat: pageutils.FiatPlaceholder(pg.AssetsManager.FiatCurrency()),
+			image:            icon,
+			backgroundImage:  bkgImage,
 		}
 	}
 
@@ -1212,7 +1342,7 @@ func (pg *OverviewPage) listenForMixerNotifications() {
 	// add rate listener
 	rateListener := &ext.RateListener{
 		OnRateUpdated: func() {
-			pg.updateAssetsUSDBalance()
+			pg.updateAssetsFiatBalance()
 		},
 	}
 	if !pg.AssetsManager.RateSource.IsRateListenerExist(OverviewPageID) {
@@ -305,8 +305,8 @@ func (pg *WalletSelectorPage) layoutUSDBalance(gtx C, item *walletWithBalance) D
 
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X // full-width, so we can align the usd balance text to the right
 	return layout.E.Layout(gtx, func(gtx C) D {
-		usdBalance := utils.FormatAsUSDString(pg.Printer, item.totalBalance.MulF64(pg.assetRate[item.wallet.GetAssetType()]).ToCoin())
-		return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
+		fiatBalance := utils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), item.totalBalance.MulF64(pg.assetRate[item.wallet.GetAssetType()]).ToCoin())
+		return components.LayoutBalanceWithStateUSD(gtx, pg.Load, fiatBalance)
 	})
 }
 
@@ -63,11 +63,11 @@ type WalletSelectorPage struct {
 	indexMapping   map[int]walletIndexTuple
 	badWalletsList map[libutils.AssetType][]*badWalletListItem
 
-	walletComponents      *cryptomaterial.ClickableList
-	assetCollapsibles     map[libutils.AssetType]*cryptomaterial.Collapsible
-	assetsBalance         map[libutils.AssetType]sharedW.AssetAmount
-	assetsTotalUSDBalance map[libutils.AssetType]float64
-	assetRate             map[libutils.AssetType]float64
+	walletComponents       *cryptomaterial.ClickableList
+	assetCollapsibles      map[libutils.AssetType]*cryptomaterial.Collapsible
+	assetsBalance          map[libutils.AssetType]sharedW.AssetAmount
+	assetsTotalFiatBalance map[libutils.AssetType]float64
+	assetRate              map[libutils.AssetType]float64
 
 	showNavigationFunc showNavigationFunc
 }
@@ -93,7 +93,7 @@ func NewWalletSelectorPage(l *load.Load) *WalletSelectorPage {
 
 	pg.assetCollapsibles = make(map[libutils.AssetType]*cryptomaterial.Collapsible)
 	pg.assetsBalance = make(map[libutils.AssetType]sharedW.AssetAmount)
-	pg.assetsTotalUSDBalance = make(map[libutils.AssetType]float64)
+	pg.assetsTotalFiatBalance = make(map[libutils.AssetType]float64)
 	pg.assetRate = make(map[libutils.AssetType]float64)
 	pg.walletsList = make(map[libutils.AssetType][]*walletWithBalance)
 	pg.indexMapping = make(map[int]walletIndexTuple)
@@ -127,24 +127,23 @@ func (pg *WalletSelectorPage) OnNavigatedTo() {
 		}
 		pg.assetsBalance = assetsBalance
 
-		// calculate total assets balance in USD
-		assetsTotalUSDBalance, err := pg.AssetsManager.CalculateAssetsUSDBalance(assetsBalance)
+		// calculate total assets balance in the fiat currency
+		assetsTotalFiatBalance, err := pg.AssetsManager.CalculateAssetsFiatBalance(assetsBalance)
 		if err != nil {
 			log.Error(err)
 		}
-		pg.assetsTotalUSDBalance = assetsTotalUSDBalance
+		pg.assetsTotalFiatBalance = assetsTotalFiatBalance
 
-		// calculate assets USD rate
+		// calculate assets fiat rate
 		for assetType := range assetsBalance {
-			marketValue, exist := values.AssetExchangeMarketValue[assetType]
-			if !exist {
-				log.Errorf("Unsupported asset type: %s", assetType)
+			market, err := pg.AssetsManager.FiatMarket(assetType)
+			if err != nil {
+				log.Error(err)
 				break
 			}
 
-			rate := pg.AssetsManager.RateSource.GetTicker(marketValue, true)
-			if err != nil {
-				log.Error(err)
+			rate := pg.AssetsManager.RateSource.GetTicker(market, true)
+			if rate == nil {
 				break
 			}
 			pg.assetRate[assetType] = rate.LastTradePrice
@@ -370,11 +369,11 @@ func (pg *WalletSelectorPage) dropdownTitleLayout(gtx C, asset libutils.AssetTyp
 								return components.LayoutBalanceWithStateSemiBold(gtx, pg.Load, pg.assetsBalance[asset].String())
 							}),
 							layout.Rigid(func(gtx C) D {
-								usdBalance := ""
+								fiatBalance := ""
 								if pg.AssetsManager.ExchangeRateFetchingEnabled() {
-									usdBalance = utils.FormatAsUSDString(pg.Printer, pg.assetsTotalUSDBalance[asset])
+									fiatBalance = utils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), pg.assetsTotalFiatBalance[asset])
 								}
-								return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
+								return components.LayoutBalanceWithStateUSD(gtx, pg.Load, fiatBalance)
 							}),
 						)
 					}),
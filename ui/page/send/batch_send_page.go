@@ -0,0 +1,351 @@
+package send
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/batchpay"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	txpage "github.com/crypto-power/cryptopower/ui/page/transaction"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const BatchSendPageID = "BatchSend"
+
+// BatchSendPage pays every recipient of a CSV file in a single transaction.
+type BatchSendPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet        sharedW.Asset
+	sourceAccount *sharedW.Account
+
+	backButton  cryptomaterial.IconButton
+	fileEditor  cryptomaterial.Editor
+	labelEditor cryptomaterial.Editor
+	loadButton  cryptomaterial.Button
+	sendButton  cryptomaterial.Button
+
+	paymentList *widget.List
+	payments    []*batchpay.Payment
+
+	// loadErr is set if the file could not be read or the transaction could
+	// not be created.
+	loadErr  string
+	txFee    string
+	totalPay string
+	total    string
+}
+
+func NewBatchSendPage(l *load.Load, wallet sharedW.Asset, sourceAccount *sharedW.Account) *BatchSendPage {
+	pg := &BatchSendPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(BatchSendPageID),
+		wallet:           wallet,
+		sourceAccount:    sourceAccount,
+		loadButton:       l.Theme.OutlineButton(values.String(values.StrImport)),
+		sendButton:       l.Theme.Button(values.String(values.StrSend)),
+		paymentList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	pg.fileEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrBatchFileHint))
+	pg.fileEditor.Editor.SingleLine = true
+
+	pg.labelEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrDescriptionNote))
+	pg.labelEditor.Editor.SingleLine = true
+	pg.labelEditor.Editor.MaxLen = MaxTxLabelSize
+
+	pg.sendButton.SetEnabled(false)
+	pg.backButton = components.GetBackButton(l)
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) OnNavigatedTo() {}
+
+// loadPayments reads the payments of the selected file and creates the batch
+// transaction if every payment is valid.
+func (pg *BatchSendPage) loadPayments() {
+	pg.payments = nil
+	pg.loadErr, pg.txFee, pg.totalPay, pg.total = "", "", "", ""
+	pg.sendButton.SetEnabled(false)
+
+	f, err := os.Open(strings.TrimSpace(pg.fileEditor.Editor.Text()))
+	if err != nil {
+		pg.loadErr = err.Error()
+		return
+	}
+	defer f.Close()
+
+	payments, err := batchpay.Read(f, batchpay.Options{
+		IsAddressValid: pg.wallet.IsAddressValid,
+		MaxAmount:      pg.sourceAccount.Balance.Spendable.ToInt(),
+	})
+	if err != nil {
+		pg.loadErr = err.Error()
+		return
+	}
+
+	pg.payments = payments
+	pg.totalPay = pg.wallet.ToAmount(batchpay.Total(payments)).String()
+	if !batchpay.Valid(payments) {
+		pg.loadErr = values.String(values.StrBatchHasErrors)
+		return
+	}
+
+	pg.constructTx()
+}
+
+func (pg *BatchSendPage) constructTx() {
+	if err := pg.wallet.NewUnsignedTx(pg.sourceAccount.Number, nil); err != nil {
+		pg.loadErr = err.Error()
+		return
+	}
+
+	for i, payment := range pg.payments {
+		if err := pg.wallet.AddSendDestination(i, payment.Address, payment.Amount, false); err != nil {
+			payment.Err = err
+		}
+	}
+	if !batchpay.Valid(pg.payments) {
+		pg.loadErr = values.String(values.StrBatchHasErrors)
+		return
+	}
+
+	feeAndSize, err := pg.wallet.EstimateFeeAndSize()
+	if err != nil {
+		pg.loadErr = err.Error()
+		return
+	}
+
+	total := batchpay.Total(pg.payments) + feeAndSize.Fee.UnitValue
+	if total > pg.sourceAccount.Balance.Spendable.ToInt() {
+		pg.loadErr = values.String(values.StrInsufficientFundsInAccount)
+		return
+	}
+
+	pg.txFee = pg.wallet.ToAmount(feeAndSize.Fee.UnitValue).String()
+	pg.total = pg.wallet.ToAmount(total).String()
+	pg.sendButton.SetEnabled(true)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrBatchPayment),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, false, container)
+	}
+	return cryptomaterial.UniformPadding(gtx, container)
+}
+
+func (pg *BatchSendPage) layoutContent(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body2(values.StringF(values.StrBatchPaymentInfo, pg.sourceAccount.Name))
+			lbl.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.fileEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.loadButton.Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.loadErr == "" {
+				return D{}
+			}
+			lbl := pg.Theme.Body2(pg.loadErr)
+			lbl.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return pg.Theme.List(pg.paymentList).Layout(gtx, len(pg.payments), func(gtx C, index int) D {
+					return pg.paymentLayout(gtx, pg.payments[index])
+				})
+			})
+		}),
+		layout.Rigid(pg.summaryLayout),
+	)
+}
+
+func (pg *BatchSendPage) paymentLayout(gtx C, payment *batchpay.Payment) D {
+	return layout.Inset{Bottom: values.MarginPadding8, Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding12).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Flex{}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								lbl := pg.Theme.Body2(fmt.Sprintf("%d.", payment.Line))
+								lbl.Color = pg.Theme.Color.GrayText3
+								return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+							}),
+							layout.Flexed(1, pg.Theme.Body2(payment.Address).Layout),
+							layout.Rigid(func(gtx C) D {
+								lbl := pg.Theme.Body1(pg.wallet.ToAmount(payment.Amount).String())
+								lbl.Font.Weight = font.SemiBold
+								return lbl.Layout(gtx)
+							}),
+						)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if payment.Label == "" {
+							return D{}
+						}
+						lbl := pg.Theme.Caption(payment.Label)
+						lbl.Color = pg.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if payment.Err == nil {
+							return D{}
+						}
+						lbl := pg.Theme.Caption(payment.Err.Error())
+						lbl.Color = pg.Theme.Color.Danger
+						return lbl.Layout(gtx)
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (pg *BatchSendPage) summaryLayout(gtx C) D {
+	if len(pg.payments) == 0 {
+		return D{}
+	}
+
+	row := func(key, value string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(1, pg.Theme.Body2(key).Layout),
+					layout.Rigid(pg.Theme.Body1(value).Layout),
+				)
+			})
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		row(values.String(values.StrBatchRecipients), fmt.Sprintf("%d", len(pg.payments))),
+		row(values.String(values.StrAmount), pg.totalPay),
+		row(values.String(values.StrTxFee), pg.txFee),
+		row(values.String(values.StrTotalCost), pg.total),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.labelEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return pg.sendButton.Layout(gtx)
+		}),
+	)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) HandleUserInteractions(gtx C) {
+	if pg.loadButton.Clicked(gtx) {
+		pg.loadPayments()
+	}
+
+	if pg.sendButton.Clicked(gtx) && batchpay.Valid(pg.payments) {
+		pg.showPasswordModal()
+	}
+}
+
+func (pg *BatchSendPage) showPasswordModal() {
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrConfirmSend)).
+		Description(values.StringF(values.StrBatchSendConfirm, len(pg.payments), pg.total)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrSend), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				txHash, err := pg.wallet.Broadcast(password, strings.TrimSpace(pg.labelEditor.Editor.Text()))
+				if err != nil {
+					pm.SetError(values.TranslateErr(err.Error()))
+					pm.SetLoading(false)
+					return
+				}
+				pm.Dismiss()
+				pg.saveRecipientLabels()
+
+				successModal := modal.NewSuccessModal(pg.Load, values.String(values.StrTxSent), func(_ bool, _ *modal.InfoModal) bool {
+					transaction, err := pg.wallet.GetTransactionRaw(txHash)
+					if err != nil {
+						log.Error("get transaction error: ", err)
+						pg.ParentNavigator().CloseCurrentPage()
+						return true
+					}
+					pg.ParentNavigator().Display(txpage.NewTransactionDetailsPage(pg.Load, pg.wallet, transaction))
+					return true
+				})
+				pg.ParentWindow().ShowModal(successModal)
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// saveRecipientLabels keeps the labels of the file as address labels so the
+// payments can be recognised in the transaction details.
+func (pg *BatchSendPage) saveRecipientLabels() {
+	for _, payment := range pg.payments {
+		if payment.Label == "" {
+			continue
+		}
+		if err := pg.wallet.SetLabel(walletdata.LabelTypeAddress, payment.Address, payment.Label); err != nil {
+			log.Errorf("saving the label of %s failed: %v", payment.Address, err)
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) OnNavigatedFrom() {}
@@ -170,10 +170,27 @@ func (pg *Page) recipientsLayout(gtx C) D {
 				return re.recipientLayout(j+1, len(pg.recipients) > 1)(gtx)
 			}))
 		}
-		if pg.modalLayout == nil && len(pg.recipients) < 3 {
+		if pg.modalLayout == nil {
 			flexChilds = append(flexChilds, layout.Rigid(func(gtx C) D {
 				gtx.Constraints.Min.X = gtx.Constraints.Max.X
-				return layout.E.Layout(gtx, pg.addRecipentBtnLayout)
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if pg.selectedWallet.IsWatchingOnlyWallet() {
+							return D{}
+						}
+						return pg.batchPayment.Layout(gtx, func(gtx C) D {
+							txt := pg.Theme.Label(values.TextSize16, values.String(values.StrBatchPayment))
+							txt.Color = pg.Theme.Color.Primary
+							return txt.Layout(gtx)
+						})
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.recipients) >= 3 {
+							return D{}
+						}
+						return layout.E.Layout(gtx, pg.addRecipentBtnLayout)
+					}),
+				)
 			}))
 		}
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, flexChilds...)
@@ -237,9 +237,18 @@ func (pg *ManualCoinSelectionPage) fetchAccountsInfo() error {
 		pg.selectedUTXOrows = pg.sendPage.selectedUTXOs.selectedUTXOs
 	}
 
-	rowInfo := make([]*UTXOInfo, len(info))
+	// Frozen outputs can only be spent after they are unfrozen on the UTXO
+	// management page.
+	unfrozen := make([]*sharedW.UnspentOutput, 0, len(info))
+	for _, row := range info {
+		if !pg.sendPage.selectedWallet.IsOutputFrozen(row.TxID, row.Vout) {
+			unfrozen = append(unfrozen, row)
+		}
+	}
+
+	rowInfo := make([]*UTXOInfo, len(unfrozen))
 	// create checkboxes and address copy components for all the utxos available.
-	for i, row := range info {
+	for i, row := range unfrozen {
 		info := &UTXOInfo{
 			UnspentOutput: row,
 			checkbox:      pg.Theme.CheckBox(new(widget.Bool), ""),
@@ -59,6 +59,7 @@ type Page struct {
 	nextButton     cryptomaterial.Button
 	closeButton    cryptomaterial.Button
 	addRecipentBtn *cryptomaterial.Clickable
+	batchPayment   *cryptomaterial.Clickable
 
 	isFetchingExchangeRate bool
 
@@ -113,6 +114,7 @@ func NewSendPage(l *load.Load, wallet sharedW.Asset) *Page {
 		exchangeRate:      -1,
 		navigateToSyncBtn: l.Theme.Button(values.String(values.StrStartSync)),
 		addRecipentBtn:    l.Theme.NewClickable(false),
+		batchPayment:      l.Theme.NewClickable(false),
 		recipients:        make([]*recipient, 0),
 	}
 
@@ -164,7 +166,7 @@ func (pg *Page) addRecipient() {
 	if pg.accountDropdown != nil && pg.accountDropdown.SelectedAccount() != nil {
 		rc.initializeAccountSelectors(pg.accountDropdown.SelectedAccount())
 	}
-	rc.amount.setExchangeRate(pg.exchangeRate)
+	rc.amount.setExchangeRate(pg.exchangeRate, pg.AssetsManager.FiatCurrency())
 	pg.recipients = append(pg.recipients, rc)
 	pg.currentIDRecipient++
 }
@@ -215,7 +217,9 @@ func (pg *Page) initModalWalletSelector(wallet sharedW.Asset) {
 			if pg.selectedWallet == nil {
 				return false
 			}
-			accountIsValid := account.Number != load.MaxInt32 && !pg.selectedWallet.IsWatchingOnlyWallet()
+			// Watch-only wallets can only export unsigned transactions.
+			canSpend := !pg.selectedWallet.IsWatchingOnlyWallet() || load.SupportsOfflineSigning(pg.selectedWallet)
+			accountIsValid := account.Number != load.MaxInt32 && canSpend
 
 			if pg.selectedWallet.ReadBoolConfigValueForKey(sharedW.AccountMixerConfigSet, false) &&
 				!pg.selectedWallet.ReadBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, false) {
@@ -312,7 +316,7 @@ func (pg *Page) fetchExchangeRate() {
 		return
 	}
 	pg.isFetchingExchangeRate = true
-	market, err := utils.USDMarketFromAsset(pg.selectedWallet.GetAssetType())
+	market, err := pg.AssetsManager.FiatMarket(pg.selectedWallet.GetAssetType())
 	if err != nil {
 		log.Errorf("Unsupported asset type: %s", pg.selectedWallet.GetAssetType())
 		pg.isFetchingExchangeRate = false
@@ -395,13 +399,14 @@ func (pg *Page) constructTx() {
 
 	if pg.exchangeRate != -1 && pg.usdExchangeSet {
 		pg.feeRateSelector.USDExchangeSet = true
-		pg.txFeeUSD = fmt.Sprintf("$%.4f", utils.CryptoToUSD(pg.exchangeRate, feeAndSize.Fee.CoinValue))
+		currency := pg.AssetsManager.FiatCurrency()
+		pg.txFeeUSD = utils.FormatAsFiatStringWithPrecision(pg.Printer, currency, utils.CryptoToFiat(pg.exchangeRate, feeAndSize.Fee.CoinValue), 4)
 		pg.feeRateSelector.TxFeeUSD = pg.txFeeUSD
-		pg.totalCostUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, totalCost.ToCoin()))
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.totalCostUSD = utils.FormatAsFiatString(pg.Printer, currency, utils.CryptoToFiat(pg.exchangeRate, totalCost.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, currency, utils.CryptoToFiat(pg.exchangeRate, balanceAfterSend.ToCoin()))
 
-		usdAmount := utils.CryptoToUSD(pg.exchangeRate, wal.ToAmount(totalAmount).ToCoin())
-		pg.sendAmountUSD = utils.FormatAsUSDString(pg.Printer, usdAmount)
+		fiatAmount := utils.CryptoToFiat(pg.exchangeRate, wal.ToAmount(totalAmount).ToCoin())
+		pg.sendAmountUSD = utils.FormatAsFiatString(pg.Printer, currency, fiatAmount)
 	}
 }
 
@@ -473,7 +478,7 @@ func (pg *Page) cleanAllRecipientErrors() {
 func (pg *Page) updateRecipientExchangeRate() {
 	for i := range pg.recipients {
 		recipient := pg.recipients[i]
-		recipient.amount.setExchangeRate(pg.exchangeRate)
+		recipient.amount.setExchangeRate(pg.exchangeRate, pg.AssetsManager.FiatCurrency())
 	}
 }
 
@@ -556,7 +561,7 @@ func (pg *Page) showBalanceAfterSend() {
 		}
 		balanceAfterSend := sourceAccount.Balance.Spendable
 		pg.balanceAfterSend = balanceAfterSend.String()
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, pg.AssetsManager.FiatCurrency(), utils.CryptoToFiat(pg.exchangeRate, balanceAfterSend.ToCoin()))
 	}
 }
 
@@ -649,6 +654,12 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		pg.addRecipient()
 	}
 
+	if pg.batchPayment.Clicked(gtx) {
+		if sourceAccount := pg.accountDropdown.SelectedAccount(); sourceAccount != nil {
+			pg.ParentNavigator().Display(NewBatchSendPage(pg.Load, pg.selectedWallet, sourceAccount))
+		}
+	}
+
 	// handle recipient user interactions
 	for _, re := range pg.recipients {
 		re.HandleUserInteractions(gtx)
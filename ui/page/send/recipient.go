@@ -8,11 +8,14 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/addressbook"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/paymenturi"
 	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -48,6 +51,7 @@ func newRecipient(l *load.Load, selectedWallet sharedW.Asset, pageParam getPageF
 	rp.amount = newSendAmount(l.Theme, assetType)
 	rp.amount.amountEditor.TextSize = values.TextSizeTransform(l.IsMobileView(), values.TextSize16)
 	rp.sendDestination = newSendDestination(l, assetType)
+	rp.sendDestination.paymentURIEntered = rp.fillFromPaymentURI
 
 	rp.description = rp.Theme.Editor(new(widget.Editor), values.String(values.StrNote))
 	rp.description.Editor.SingleLine = false
@@ -65,6 +69,31 @@ func (rp *recipient) onAddressChanged(addressChanged func()) {
 	rp.sendDestination.addressChanged = addressChanged
 }
 
+// fillFromPaymentURI pre-fills the amount and note fields with the values
+// requested in a payment URI entered as the destination address.
+func (rp *recipient) fillFromPaymentURI(uri *paymenturi.PaymentURI) {
+	if uri.AssetType != rp.amount.assetType {
+		errMsg := values.StringF(values.StrPaymentURIWrongAsset, uri.AssetType.ToFull(), rp.amount.assetType.ToFull())
+		rp.sendDestination.setError(errMsg)
+		return
+	}
+
+	if uri.Amount > 0 {
+		rp.amount.SendMax = false
+		rp.setAmount(uri.Amount)
+		rp.amount.validateAmount()
+		rp.amount.amountChanged()
+	}
+
+	if rp.description.Editor.Text() == "" {
+		note := uri.Message
+		if note == "" {
+			note = uri.Label
+		}
+		rp.description.Editor.SetText(note)
+	}
+}
+
 func (rp *recipient) onAmountChanged(amountChanged func()) {
 	rp.amount.amountChanged = amountChanged
 }
@@ -227,7 +256,7 @@ func (rp *recipient) recipientLayout(index int, showIcon bool) layout.Widget {
 			layout.Rigid(func(gtx C) D {
 				layoutBody := func(gtx C) D {
 					txt := fmt.Sprintf("%s %s", values.String(values.StrDestination), values.String(values.StrAddress))
-					return rp.contentWrapper(gtx, txt, rp.sendDestination.destinationAddressEditor.Layout)
+					return rp.contentWrapper(gtx, txt, rp.sendDestination.addressLayout)
 				}
 
 				if !rp.isShowSendToWallet() {
@@ -383,6 +412,17 @@ func (rp *recipient) handle(gtx C) {
 		rp.amount.amountChanged()
 	}
 
+	if rp.sendDestination.addressBookBtn.Clicked(gtx) {
+		picker := components.NewContactPickerModal(rp.Load, rp.sendDestination.assetType, func(_ *addressbook.Contact, address string) {
+			rp.sendDestination.setAddress(address)
+		})
+		rp.navigator.ShowModal(picker)
+	}
+
+	if rp.sendDestination.scanBtn.Clicked(gtx) {
+		rp.navigator.ShowModal(components.NewQRScannerModal(rp.Load, rp.sendDestination.setScannedText))
+	}
+
 	if rp.deleteBtn.Clicked(gtx) {
 		title := values.String(values.StrRemoveRecipient)
 		msg := values.String(values.StrRemoveRecipientWarning)
@@ -82,8 +82,11 @@ func (sa *sendAmount) styleWidgets() {
 	sa.usdAmountEditor.EditorStyle.Color = sa.theme.Color.Text
 }
 
-func (sa *sendAmount) setExchangeRate(exchangeRate float64) {
+// setExchangeRate sets the rate of the asset in the fiat currency the amount
+// is also entered in.
+func (sa *sendAmount) setExchangeRate(exchangeRate float64, currency string) {
 	sa.exchangeRate = exchangeRate
+	sa.usdAmountEditor.Hint = fmt.Sprintf("%s (%s)", values.String(values.StrAmount), currency)
 	sa.validateAmount() // convert dcr input to usd
 }
 
@@ -98,7 +101,7 @@ func (sa *sendAmount) setAmount(amount int64) {
 	sa.amountEditor.Editor.SetText(fmt.Sprintf("%.8f", amountSet))
 
 	if sa.exchangeRate != -1 {
-		usdAmount := utils.CryptoToUSD(sa.exchangeRate, amountSet)
+		usdAmount := utils.CryptoToFiat(sa.exchangeRate, amountSet)
 		sa.usdSendMaxChangeEvent = true
 		sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount))
 	}
@@ -142,7 +145,7 @@ func (sa *sendAmount) validateAmount() {
 			return
 		}
 		if sa.exchangeRate != -1 {
-			usdAmount := utils.CryptoToUSD(sa.exchangeRate, amount)
+			usdAmount := utils.CryptoToFiat(sa.exchangeRate, amount)
 			sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount)) // 2 decimal places
 		}
 
@@ -166,7 +169,7 @@ func (sa *sendAmount) validateUSDAmount() bool {
 		}
 
 		if sa.exchangeRate != -1 {
-			dcrAmount := utils.USDToDCR(sa.exchangeRate, usdAmount)
+			dcrAmount := utils.FiatToCrypto(sa.exchangeRate, usdAmount)
 			sa.amountEditor.Editor.SetText(fmt.Sprintf("%.8f", dcrAmount)) // 8 decimal places
 		}
 
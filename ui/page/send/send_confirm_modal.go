@@ -3,8 +3,11 @@ package send
 import (
 	"fmt"
 	"image"
+	"io"
+	"strings"
 
 	"gioui.org/font"
+	"gioui.org/io/clipboard"
 	"gioui.org/io/key"
 	"gioui.org/layout"
 	"gioui.org/op/clip"
@@ -32,6 +35,9 @@ type sendConfirmModal struct {
 
 	txSent    func()
 	isSending bool
+	// exportTx is set for watch-only wallets, which cannot sign and hand
+	// the transaction over to an offline signer instead.
+	exportTx bool
 
 	*authoredTxData
 	asset           sharedW.Asset
@@ -46,6 +52,7 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData, asset sharedW.Asset
 		authoredTxData: data,
 		asset:          asset,
 		sentHandle:     sentHandle,
+		exportTx:       asset.IsWatchingOnlyWallet(),
 	}
 	scm.Modal = l.Theme.ModalFloatTitle("send_confirm_modal", l.IsMobileView(), scm.firstLoad)
 
@@ -54,7 +61,7 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData, asset sharedW.Asset
 
 	scm.confirmButton = l.Theme.Button("")
 	scm.confirmButton.Font.Weight = font.Medium
-	scm.confirmButton.SetEnabled(false)
+	scm.confirmButton.SetEnabled(scm.exportTx)
 
 	scm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
 	scm.passwordEditor.Editor.SetText("")
@@ -67,6 +74,9 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData, asset sharedW.Asset
 func (scm *sendConfirmModal) OnResume() {}
 
 func (scm *sendConfirmModal) firstLoad(gtx C) {
+	if scm.exportTx {
+		return
+	}
 	gtx.Execute(key.FocusCmd{Tag: scm.passwordEditor.Editor})
 }
 
@@ -107,7 +117,40 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 	}()
 }
 
+// exportTransaction saves the unsigned transaction to a file for signing on
+// another device. PSBTs are also copied to the clipboard.
+func (scm *sendConfirmModal) exportTransaction(gtx C) {
+	psbt, path, err := load.ExportUnsignedTx(scm.asset)
+	if err != nil && psbt == "" {
+		errModal := modal.NewErrorModal(scm.Load, err.Error(), modal.DefaultClickFunc())
+		scm.ParentWindow().ShowModal(errModal)
+		return
+	}
+	if err != nil {
+		log.Errorf("saving psbt file failed: %v", err)
+	}
+
+	body := values.StringF(values.StrUnsignedTxSaved, path)
+	if psbt != "" {
+		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(psbt))})
+		body = values.String(values.StrPSBTCopied)
+		if path != "" {
+			body = values.StringF(values.StrPSBTSaved, path)
+		}
+	}
+	successModal := modal.NewSuccessModal(scm.Load, values.String(values.StrUnsignedTxExported), modal.DefaultClickFunc()).
+		Body(body)
+	scm.ParentWindow().ShowModal(successModal)
+
+	scm.txSent()
+	scm.Dismiss()
+}
+
 func (scm *sendConfirmModal) Handle(gtx C) {
+	if scm.exportTx && scm.confirmButton.Clicked(gtx) {
+		scm.exportTransaction(gtx)
+	}
+
 	if scm.passwordEditor.Changed() {
 		scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "")
 		scm.passwordEditor.SetError("")
@@ -251,6 +294,9 @@ func (scm *sendConfirmModal) Layout(gtx C) D {
 			})
 		},
 		func(gtx C) D {
+			if scm.exportTx {
+				return D{}
+			}
 			return layout.Inset{Left: dp16, Right: dp16}.Layout(gtx, scm.passwordEditor.Layout)
 		},
 		func(gtx C) D {
@@ -274,6 +320,9 @@ func (scm *sendConfirmModal) Layout(gtx C) D {
 								})
 							}
 							scm.confirmButton.Text = values.StrSend
+							if scm.exportTx {
+								scm.confirmButton.Text = values.String(values.StrExportUnsignedTx)
+							}
 							return scm.confirmButton.Layout(gtx)
 						}),
 					)
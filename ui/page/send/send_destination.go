@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"gioui.org/layout"
 	"gioui.org/widget"
 
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/paymenturi"
 	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -23,9 +25,18 @@ type destination struct {
 	*load.Load
 
 	addressChanged           func()
+	paymentURIEntered        func(*paymenturi.PaymentURI)
 	destinationAddressEditor cryptomaterial.Editor
 	sourceAccount            *sharedW.Account
 
+	assetType      libUtil.AssetType
+	addressBookBtn *cryptomaterial.Clickable
+	// scanBtn opens the QR code scanner, on devices with a camera only.
+	scanBtn   *cryptomaterial.Clickable
+	hasCamera bool
+	// contactName is the address book name of the entered address.
+	contactName string
+
 	walletDropdown  *components.WalletDropdown
 	accountDropdown *components.AccountDropdown
 
@@ -34,14 +45,17 @@ type destination struct {
 
 func newSendDestination(l *load.Load, assetType libUtil.AssetType) *destination {
 	dst := &destination{
-		Load:          l,
-		accountSwitch: l.Theme.SegmentedControl(tabOptions, cryptomaterial.SegmentTypeGroupMax),
+		Load:           l,
+		accountSwitch:  l.Theme.SegmentedControl(tabOptions, cryptomaterial.SegmentTypeGroupMax),
+		addressBookBtn: l.Theme.NewClickable(false),
+		scanBtn:        l.Theme.NewClickable(false),
+		hasCamera:      l.Device.HasCamera(),
 	}
 
 	dst.accountSwitch.SetEnableSwipe(false)
 	dst.accountSwitch.DisableUniform(true)
 
-	dst.destinationAddressEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrDestAddr))
+	dst.destinationAddressEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrDestAddrOrPaymentURI))
 	dst.destinationAddressEditor.TextSize = values.TextSizeTransform(l.IsMobileView(), values.TextSize16)
 	dst.destinationAddressEditor.Editor.SingleLine = true
 	dst.destinationAddressEditor.Editor.SetText("")
@@ -52,6 +66,7 @@ func newSendDestination(l *load.Load, assetType libUtil.AssetType) *destination
 }
 
 func (dst *destination) initDestinationWalletSelector(assetType libUtil.AssetType) {
+	dst.assetType = assetType
 	dst.walletDropdown = components.NewWalletDropdown(dst.Load, assetType).
 		SetChangedCallback(func(wallet sharedW.Asset) {
 			if dst.accountDropdown != nil {
@@ -162,6 +177,23 @@ func (dst *destination) setError(errMsg string) {
 func (dst *destination) clearAddressInput() {
 	dst.destinationAddressEditor.SetError("")
 	dst.destinationAddressEditor.Editor.SetText("")
+	dst.contactName = ""
+}
+
+// setAddress replaces the entered address, e.g. with one picked from the
+// address book.
+func (dst *destination) setAddress(address string) {
+	dst.destinationAddressEditor.SetError("")
+	dst.destinationAddressEditor.Editor.SetText(address)
+	dst.destinationAddressEditor.Editor.SetCaret(len(address), len(address))
+	dst.updateContactName()
+	dst.addressChanged()
+}
+
+// updateContactName looks up the entered address in the address book.
+func (dst *destination) updateContactName() {
+	address := strings.TrimSpace(dst.destinationAddressEditor.Editor.Text())
+	dst.contactName = components.ContactLabel(dst.Load, dst.assetType, address)
 }
 
 // isSendToAddress returns the current tab selection status without depending
@@ -189,12 +221,102 @@ func (dst *destination) handle(gtx C) {
 		if gtx.Source.Focused(dst.destinationAddressEditor.Editor) {
 			switch event.(type) {
 			case widget.ChangeEvent:
+				if dst.handlePaymentURI() {
+					continue
+				}
+				dst.updateContactName()
 				dst.addressChanged()
 			}
 		}
 	}
 }
 
+// setScannedText fills the destination with the text of a scanned QR code,
+// either a payment URI or a bare address.
+func (dst *destination) setScannedText(text string) {
+	text = strings.TrimSpace(text)
+	if !paymenturi.IsPaymentURI(text) {
+		dst.setAddress(text)
+		return
+	}
+
+	dst.destinationAddressEditor.SetError("")
+	dst.destinationAddressEditor.Editor.SetText(text)
+	dst.handlePaymentURI()
+}
+
+// handlePaymentURI replaces a payment URI pasted into the address editor with
+// the address it contains and passes the decoded request on so the other
+// fields can be filled. It returns true if the editor held a payment URI.
+func (dst *destination) handlePaymentURI() bool {
+	text := dst.destinationAddressEditor.Editor.Text()
+	if !paymenturi.IsPaymentURI(text) {
+		return false
+	}
+
+	uri, err := paymenturi.Parse(text)
+	if err != nil {
+		dst.destinationAddressEditor.SetError(values.StringF(values.StrInvalidPaymentURI, err))
+		return true
+	}
+
+	dst.destinationAddressEditor.Editor.SetText(uri.Address)
+	dst.destinationAddressEditor.Editor.SetCaret(len(uri.Address), len(uri.Address))
+	dst.updateContactName()
+	if dst.paymentURIEntered != nil {
+		dst.paymentURIEntered(uri)
+	}
+	dst.addressChanged()
+	return true
+}
+
+// addressLayout draws the address editor followed by the QR code scanner and
+// address book shortcuts and the contact name of the entered address.
+func (dst *destination) addressLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(dst.destinationAddressEditor.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						if dst.contactName == "" {
+							hint := values.String(values.StrPastePaymentURI)
+							if dst.hasCamera {
+								hint = values.String(values.StrPasteOrScanPaymentURI)
+							}
+							lbl := dst.Theme.Caption(hint)
+							lbl.Color = dst.Theme.Color.GrayText3
+							return lbl.Layout(gtx)
+						}
+						lbl := dst.Theme.Body2(dst.contactName)
+						lbl.Color = dst.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if !dst.hasCamera {
+							return D{}
+						}
+						return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return dst.scanBtn.Layout(gtx, func(gtx C) D {
+								lbl := dst.Theme.Body2(values.String(values.StrScanQRCode))
+								lbl.Color = dst.Theme.Color.Primary
+								return lbl.Layout(gtx)
+							})
+						})
+					}),
+					layout.Rigid(func(gtx C) D {
+						return dst.addressBookBtn.Layout(gtx, func(gtx C) D {
+							lbl := dst.Theme.Body2(values.String(values.StrAddressBook))
+							lbl.Color = dst.Theme.Color.Primary
+							return lbl.Layout(gtx)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
 // styleWidgets sets the appropriate colors for the destination widgets.
 func (dst *destination) styleWidgets() {
 	// dst.accountSwitch.Active, dst.accountSwitch.Inactive = dst.Theme.Color.Surface, color.NRGBA{}
@@ -0,0 +1,262 @@
+package settings
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/addressbook"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const AddressBookPageID = "AddressBook"
+
+// contactItem holds the widgets of a single address book entry.
+type contactItem struct {
+	*addressbook.Contact
+	editBtn   *cryptomaterial.Clickable
+	deleteBtn *cryptomaterial.Clickable
+}
+
+type AddressBookPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	backButton cryptomaterial.IconButton
+	addContact *cryptomaterial.Clickable
+	importBtn  cryptomaterial.Button
+	exportBtn  cryptomaterial.Button
+
+	contactList *widget.List
+	contacts    []*contactItem
+}
+
+func NewAddressBookPage(l *load.Load) *AddressBookPage {
+	pg := &AddressBookPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AddressBookPageID),
+		addContact:       l.Theme.NewClickable(true),
+		importBtn:        l.Theme.OutlineButton(values.String(values.StrImport)),
+		exportBtn:        l.Theme.OutlineButton(values.String(values.StrExport)),
+		contactList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	pg.backButton = components.GetBackButton(l)
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) OnNavigatedTo() {
+	pg.loadContacts()
+}
+
+func (pg *AddressBookPage) loadContacts() {
+	contacts, err := pg.AssetsManager.AddressBook.Contacts("")
+	if err != nil {
+		log.Errorf("Error loading contacts: %v", err)
+		return
+	}
+
+	items := make([]*contactItem, 0, len(contacts))
+	for _, contact := range contacts {
+		items = append(items, &contactItem{
+			Contact:   contact,
+			editBtn:   pg.Theme.NewClickable(false),
+			deleteBtn: pg.Theme.NewClickable(false),
+		})
+	}
+	pg.contacts = items
+	pg.ParentWindow().Reload()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrAddressBook),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			ExtraItem: pg.addContact,
+			Extra:     pg.Theme.IconButton(pg.Theme.Icons.ContentAdd).Layout,
+			HandleExtra: func() {
+				pg.ParentWindow().ShowModal(components.NewContactEditorModal(pg.Load, nil, pg.loadContacts))
+			},
+			Body: pg.layoutContent,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, false, container)
+	}
+	return cryptomaterial.UniformPadding(gtx, container)
+}
+
+func (pg *AddressBookPage) layoutContent(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.importBtn.Layout)
+					}),
+					layout.Rigid(pg.exportBtn.Layout),
+				)
+			})
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			if len(pg.contacts) == 0 {
+				lbl := pg.Theme.Body1(values.String(values.StrNoContacts))
+				lbl.Color = pg.Theme.Color.GrayText3
+				return lbl.Layout(gtx)
+			}
+
+			return pg.Theme.List(pg.contactList).Layout(gtx, len(pg.contacts), func(gtx C, index int) D {
+				return layout.Inset{Bottom: values.MarginPadding8, Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+							return pg.contactLayout(gtx, pg.contacts[index])
+						})
+					})
+				})
+			})
+		}),
+	)
+}
+
+func (pg *AddressBookPage) contactLayout(gtx C, item *contactItem) D {
+	return layout.Flex{Alignment: layout.Start}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Body1(item.Name + " (" + item.AssetType.String() + ")")
+					lbl.Font.Weight = font.SemiBold
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Body2(strings.Join(item.Addresses, "\n"))
+					lbl.Color = pg.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if item.Notes == "" {
+						return D{}
+					}
+					lbl := pg.Theme.Caption(item.Notes)
+					lbl.Color = pg.Theme.Color.GrayText3
+					return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return item.editBtn.Layout(gtx, pg.Theme.Icons.EditIcon.Layout16dp)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return item.deleteBtn.Layout(gtx, pg.Theme.NewIcon(pg.Theme.Icons.DeleteIcon).Layout20dp)
+		}),
+	)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) HandleUserInteractions(gtx C) {
+	for _, item := range pg.contacts {
+		if item.editBtn.Clicked(gtx) {
+			pg.ParentWindow().ShowModal(components.NewContactEditorModal(pg.Load, item.Contact, pg.loadContacts))
+		}
+
+		if item.deleteBtn.Clicked(gtx) {
+			pg.deleteContactModal(item.Contact)
+		}
+	}
+
+	if pg.importBtn.Clicked(gtx) {
+		pg.importModal()
+	}
+
+	if pg.exportBtn.Clicked(gtx) {
+		go func() {
+			fileName, err := pg.AssetsManager.ExportAddressBook()
+			if err != nil {
+				pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+				return
+			}
+			info := modal.NewSuccessModal(pg.Load, values.StringF(values.StrAddressBookExported, fileName), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(info)
+		}()
+	}
+}
+
+func (pg *AddressBookPage) deleteContactModal(contact *addressbook.Contact) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrDelete)).
+		Body(values.StringF(values.StrDeleteContactConfirm, contact.Name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrDelete)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.AddressBook.DeleteContact(contact.ID); err != nil {
+				log.Errorf("Error deleting contact: %v", err)
+				return false
+			}
+			pg.loadContacts()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+func (pg *AddressBookPage) importModal() {
+	importModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrAddressBookFileHint)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(fileName string, tim *modal.TextInputModal) bool {
+			count, err := pg.AssetsManager.ImportAddressBook(strings.TrimSpace(fileName))
+			if err != nil {
+				tim.SetError(err.Error())
+				return false
+			}
+
+			pg.loadContacts()
+			pg.Toast.Notify(values.StringF(values.StrAddressBookImported, count))
+			return true
+		})
+	importModal.Title(values.String(values.StrAddressBook)).
+		SetPositiveButtonText(values.String(values.StrImport))
+	pg.ParentWindow().ShowModal(importModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) OnNavigatedFrom() {}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/logger"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -53,7 +54,11 @@ type AppSettingsPage struct {
 	changeStartupPass       *cryptomaterial.Clickable
 	network                 *cryptomaterial.Clickable
 	language                *cryptomaterial.Clickable
+	addressBook             *cryptomaterial.Clickable
+	proxy                   *cryptomaterial.Clickable
+	blockExplorers          *cryptomaterial.Clickable
 	currency                *cryptomaterial.Clickable
+	fiatCurrency            *cryptomaterial.Clickable
 	help                    *cryptomaterial.Clickable
 	about                   *cryptomaterial.Clickable
 	appearanceMode          *cryptomaterial.Clickable
@@ -100,7 +105,11 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		changeStartupPass: l.Theme.NewClickable(false),
 		network:           l.Theme.NewClickable(false),
 		language:          l.Theme.NewClickable(false),
+		addressBook:       l.Theme.NewClickable(false),
+		proxy:             l.Theme.NewClickable(false),
+		blockExplorers:    l.Theme.NewClickable(false),
 		currency:          l.Theme.NewClickable(false),
+		fiatCurrency:      l.Theme.NewClickable(false),
 		help:              l.Theme.NewClickable(false),
 		about:             l.Theme.NewClickable(false),
 		appearanceMode:    l.Theme.NewClickable(false),
@@ -304,6 +313,38 @@ func (pg *AppSettingsPage) general() layout.Widget {
 					}
 					return pg.clickableRow(gtx, languageRow)
 				}),
+				layout.Rigid(func(gtx C) D {
+					addressBookRow := row{
+						title:     values.String(values.StrAddressBook),
+						clickable: pg.addressBook,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, addressBookRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					proxyHost := values.String(values.StrProxyOff)
+					if cfg := libutils.GetProxyConfig(); cfg != nil {
+						proxyHost = cfg.Host
+					}
+					proxyRow := row{
+						title:     values.String(values.StrProxy),
+						clickable: pg.proxy,
+						label:     pg.Theme.Body2(proxyHost),
+					}
+					return pg.clickableRow(gtx, proxyRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					explorers := values.String(values.StrPublicExplorers)
+					if !libutils.GetBackendConfig().IsEmpty() {
+						explorers = values.String(values.StrSelfHostedExplorers)
+					}
+					explorersRow := row{
+						title:     values.String(values.StrBlockExplorers),
+						clickable: pg.blockExplorers,
+						label:     pg.Theme.Body2(explorers),
+					}
+					return pg.clickableRow(gtx, explorersRow)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrTxNotification), pg.transactionNotification)
 				}),
@@ -329,6 +370,14 @@ func (pg *AppSettingsPage) networkSettings() layout.Widget {
 					}
 					return pg.clickableRow(gtx, exchangeRate)
 				}),
+				layout.Rigid(func(gtx C) D {
+					fiatCurrency := row{
+						title:     values.String(values.StrFiatCurrency),
+						clickable: pg.fiatCurrency,
+						label:     pg.Theme.Body2(pg.AssetsManager.FiatCurrency()),
+					}
+					return pg.clickableRow(gtx, fiatCurrency)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrGovernanceAPI), pg.governanceAPI)
 				}),
@@ -515,6 +564,18 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(langSelectorModal)
 	}
 
+	if pg.proxy.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newProxyModal(pg.Load, func() {
+			pg.ParentWindow().Reload()
+		}))
+	}
+
+	if pg.blockExplorers.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newBackendsModal(pg.Load, func() {
+			pg.ParentWindow().Reload()
+		}))
+	}
+
 	if pg.backButton.Button.Clicked(gtx) {
 		pg.ParentNavigator().CloseCurrentPage()
 	}
@@ -528,6 +589,15 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(currencySelectorModal)
 	}
 
+	if pg.fiatCurrency.Clicked(gtx) {
+		fiatCurrencyModal := preference.NewListPreference(pg.Load,
+			sharedW.FiatCurrencyConfigKey, ext.USDCurrency,
+			preference.FiatCurrencyOptions).
+			Title(values.StrFiatCurrency).
+			UpdateValues(func(_ string) {})
+		pg.ParentWindow().ShowModal(fiatCurrencyModal)
+	}
+
 	if pg.appearanceMode.Clicked(gtx) {
 		pg.isDarkModeOn = !pg.isDarkModeOn
 		pg.AssetsManager.SetDarkMode(pg.isDarkModeOn)
@@ -575,6 +645,10 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(info)
 	}
 
+	if pg.addressBook.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewAddressBookPage(pg.Load))
+	}
+
 	if pg.help.Clicked(gtx) {
 		pg.ParentNavigator().Display(NewHelpPage(pg.Load))
 	}
@@ -0,0 +1,152 @@
+package settings
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// backendsModal edits the self-hosted block explorers used instead of the
+// public ones.
+type backendsModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	onUpdated func()
+
+	dcrdataEditor    cryptomaterial.Editor
+	blockBookEditor  cryptomaterial.Editor
+	btcEsploraEditor cryptomaterial.Editor
+	ltcEsploraEditor cryptomaterial.Editor
+	saveBtn          cryptomaterial.Button
+	cancelBtn        cryptomaterial.Button
+
+	checking bool
+	errMsg   string
+}
+
+func newBackendsModal(l *load.Load, onUpdated func()) *backendsModal {
+	bm := &backendsModal{
+		Load:             l,
+		Modal:            l.Theme.ModalFloatTitle("backends_modal", l.IsMobileView(), nil),
+		onUpdated:        onUpdated,
+		dcrdataEditor:    l.Theme.Editor(new(widget.Editor), values.String(values.StrDcrdataURL)),
+		blockBookEditor:  l.Theme.Editor(new(widget.Editor), values.String(values.StrBlockBookURL)),
+		btcEsploraEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrBTCEsploraURL)),
+		ltcEsploraEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrLTCEsploraURL)),
+		saveBtn:          l.Theme.Button(values.String(values.StrSave)),
+		cancelBtn:        l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	bm.dcrdataEditor.Editor.SingleLine = true
+	bm.blockBookEditor.Editor.SingleLine = true
+	bm.btcEsploraEditor.Editor.SingleLine = true
+	bm.ltcEsploraEditor.Editor.SingleLine = true
+
+	cfg := l.AssetsManager.BackendConfig()
+	bm.dcrdataEditor.Editor.SetText(cfg.DCRData)
+	bm.blockBookEditor.Editor.SetText(cfg.DCRBlockBook)
+	bm.btcEsploraEditor.Editor.SetText(cfg.BTCEsplora)
+	bm.ltcEsploraEditor.Editor.SetText(cfg.LTCEsplora)
+
+	return bm
+}
+
+func (bm *backendsModal) OnResume() {}
+
+func (bm *backendsModal) OnDismiss() {}
+
+func (bm *backendsModal) backendConfig() libutils.BackendConfig {
+	return libutils.BackendConfig{
+		DCRData:      strings.TrimSpace(bm.dcrdataEditor.Editor.Text()),
+		DCRBlockBook: strings.TrimSpace(bm.blockBookEditor.Editor.Text()),
+		BTCEsplora:   strings.TrimSpace(bm.btcEsploraEditor.Editor.Text()),
+		LTCEsplora:   strings.TrimSpace(bm.ltcEsploraEditor.Editor.Text()),
+	}
+}
+
+func (bm *backendsModal) Handle(gtx C) {
+	if bm.cancelBtn.Clicked(gtx) && !bm.checking {
+		bm.Dismiss()
+	}
+
+	bm.saveBtn.SetEnabled(!bm.checking)
+	if bm.saveBtn.Clicked(gtx) && !bm.checking {
+		bm.checking = true
+		bm.errMsg = ""
+		cfg := bm.backendConfig()
+		// The explorers are checked over the network.
+		go func() {
+			err := bm.AssetsManager.SetBackendConfig(cfg)
+			bm.checking = false
+			if err != nil {
+				bm.errMsg = err.Error()
+				bm.ParentWindow().Reload()
+				return
+			}
+			bm.onUpdated()
+			bm.Dismiss()
+		}()
+	}
+}
+
+func (bm *backendsModal) Layout(gtx C) D {
+	editor := func(e cryptomaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, e.Layout)
+		}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := bm.Theme.H6(values.String(values.StrBlockExplorers))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(editor(bm.dcrdataEditor)),
+				layout.Rigid(editor(bm.blockBookEditor)),
+				layout.Rigid(editor(bm.btcEsploraEditor)),
+				layout.Rigid(editor(bm.ltcEsploraEditor)),
+				layout.Rigid(func(gtx C) D {
+					lbl := bm.Theme.Body2(values.String(values.StrBlockExplorersNote))
+					lbl.Color = bm.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					switch {
+					case bm.checking:
+						lbl := bm.Theme.Body2(values.String(values.StrCheckingExplorers))
+						lbl.Color = bm.Theme.Color.GrayText2
+						return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					case bm.errMsg != "":
+						lbl := bm.Theme.Body2(bm.errMsg)
+						lbl.Color = bm.Theme.Color.Danger
+						return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}
+					return D{}
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, bm.cancelBtn.Layout)
+					}),
+					layout.Rigid(bm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return bm.Modal.Layout(gtx, w)
+}
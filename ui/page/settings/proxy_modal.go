@@ -0,0 +1,170 @@
+package settings
+
+import (
+	"net"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// proxyModal edits the SOCKS5 proxy the network traffic is sent through.
+type proxyModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	onUpdated func()
+	hasProxy  bool
+
+	hostEditor     cryptomaterial.Editor
+	usernameEditor cryptomaterial.Editor
+	passwordEditor cryptomaterial.Editor
+	torModeCheck   cryptomaterial.CheckBoxStyle
+	proxyOnlyCheck cryptomaterial.CheckBoxStyle
+	saveBtn        cryptomaterial.Button
+	clearBtn       cryptomaterial.Button
+	cancelBtn      cryptomaterial.Button
+	invalidHost    bool
+}
+
+func newProxyModal(l *load.Load, onUpdated func()) *proxyModal {
+	pm := &proxyModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle("proxy_modal", l.IsMobileView(), nil),
+		onUpdated:      onUpdated,
+		hostEditor:     l.Theme.Editor(new(widget.Editor), values.String(values.StrProxyHost)),
+		usernameEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrProxyUsername)),
+		passwordEditor: l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrProxyPassword)),
+		torModeCheck:   l.Theme.CheckBox(new(widget.Bool), values.String(values.StrProxyTorMode)),
+		proxyOnlyCheck: l.Theme.CheckBox(new(widget.Bool), values.String(values.StrProxyOnly)),
+		saveBtn:        l.Theme.Button(values.String(values.StrSave)),
+		clearBtn:       l.Theme.OutlineButton(values.String(values.StrClear)),
+		cancelBtn:      l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	pm.hostEditor.Editor.SingleLine = true
+	pm.usernameEditor.Editor.SingleLine = true
+	pm.passwordEditor.Editor.SingleLine = true
+
+	if cfg := l.AssetsManager.ProxyConfig(); cfg != nil {
+		pm.hasProxy = true
+		pm.hostEditor.Editor.SetText(cfg.Host)
+		pm.usernameEditor.Editor.SetText(cfg.Username)
+		pm.passwordEditor.Editor.SetText(cfg.Password)
+		pm.torModeCheck.CheckBox.Value = cfg.TorMode
+		pm.proxyOnlyCheck.CheckBox.Value = cfg.ProxyOnly
+	}
+
+	return pm
+}
+
+func (pm *proxyModal) OnResume() {}
+
+func (pm *proxyModal) OnDismiss() {}
+
+// proxyConfig returns the proxy entered or false if its host is not a valid
+// host:port.
+func (pm *proxyModal) proxyConfig() (*libutils.ProxyConfig, bool) {
+	host := strings.TrimSpace(pm.hostEditor.Editor.Text())
+	if _, port, err := net.SplitHostPort(host); err != nil || port == "" {
+		return nil, false
+	}
+
+	return &libutils.ProxyConfig{
+		Host:      host,
+		Username:  strings.TrimSpace(pm.usernameEditor.Editor.Text()),
+		Password:  pm.passwordEditor.Editor.Text(),
+		TorMode:   pm.torModeCheck.CheckBox.Value,
+		ProxyOnly: pm.proxyOnlyCheck.CheckBox.Value,
+	}, true
+}
+
+func (pm *proxyModal) Handle(gtx C) {
+	if pm.cancelBtn.Clicked(gtx) {
+		pm.Dismiss()
+	}
+
+	if pm.clearBtn.Clicked(gtx) {
+		pm.AssetsManager.SetProxyConfig(nil)
+		pm.onUpdated()
+		pm.Dismiss()
+	}
+
+	cfg, valid := pm.proxyConfig()
+	pm.invalidHost = !valid && pm.hostEditor.Editor.Len() > 0
+	pm.saveBtn.SetEnabled(valid)
+	if pm.saveBtn.Clicked(gtx) && valid {
+		pm.AssetsManager.SetProxyConfig(cfg)
+		pm.onUpdated()
+		pm.Dismiss()
+	}
+}
+
+func (pm *proxyModal) Layout(gtx C) D {
+	editor := func(e cryptomaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, e.Layout)
+		}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := pm.Theme.H6(values.String(values.StrProxy))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(editor(pm.hostEditor)),
+				layout.Rigid(func(gtx C) D {
+					if pm.torModeCheck.CheckBox.Value {
+						return D{}
+					}
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(editor(pm.usernameEditor)),
+						layout.Rigid(editor(pm.passwordEditor)),
+					)
+				}),
+				layout.Rigid(pm.torModeCheck.Layout),
+				layout.Rigid(pm.proxyOnlyCheck.Layout),
+				layout.Rigid(func(gtx C) D {
+					if !pm.invalidHost {
+						return D{}
+					}
+					lbl := pm.Theme.Body2(values.String(values.StrInvalidProxyHost))
+					lbl.Color = pm.Theme.Color.Danger
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					lbl := pm.Theme.Body2(values.String(values.StrProxyRestartNote))
+					lbl.Color = pm.Theme.Color.GrayText2
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if !pm.hasProxy {
+							return D{}
+						}
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pm.clearBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pm.cancelBtn.Layout)
+					}),
+					layout.Rigid(pm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return pm.Modal.Layout(gtx, w)
+}
@@ -0,0 +1,134 @@
+package staking
+
+import (
+	"fmt"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet/stakeanalytics"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// maxAnalyticsMonths is the number of months shown in the rewards chart.
+const maxAnalyticsMonths = 12
+
+func (pg *Page) loadStakingAnalytics() {
+	tickets, err := pg.dcrWallet.StakingAnalytics()
+	if err != nil {
+		log.Errorf("Error loading staking analytics: %v", err)
+		return
+	}
+	pg.stakingReport = stakeanalytics.Analyze(tickets)
+}
+
+func (pg *Page) handleStakingAnalytics(gtx C) {
+	if !pg.exportAnalyticsBtn.Clicked(gtx) {
+		return
+	}
+
+	go func() {
+		fileName, err := pg.AssetsManager.ExportStakingAnalytics(pg.dcrWallet)
+		if err != nil {
+			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(errModal)
+			return
+		}
+
+		infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrExportStakingSuccess, fileName), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(infoModal)
+	}()
+}
+
+// stakingAnalyticsSection shows the costs and returns of the wallet's tickets
+// with the rewards earned per month and the ROI of each VSP.
+func (pg *Page) stakingAnalyticsSection(gtx C) D {
+	report := pg.stakingReport
+	if report == nil || report.Total.Tickets == 0 {
+		return D{}
+	}
+
+	isMobile := pg.IsMobileView()
+	total := report.Total
+	figures := [][2]string{
+		{values.String(values.StrTicketsCost), dcrutil.Amount(total.Cost).String()},
+		{values.String(values.StrTxFee), dcrutil.Amount(total.TxFees).String()},
+		{values.String(values.StrVspFee), dcrutil.Amount(total.VSPFees).String()},
+		{values.String(values.StrTotalReward), dcrutil.Amount(total.Rewards).String()},
+		{values.String(values.StrNetProfit), dcrutil.Amount(total.Profit).String()},
+		{values.String(values.StrROI), fmt.Sprintf("%.2f%%", total.ROI)},
+		{values.String(values.StrAnnualizedROI), fmt.Sprintf("%.2f%%", total.AnnualizedROI)},
+		{values.String(values.StrAvgDaysToVote), fmt.Sprintf("%.1f", total.AvgDaysToVote)},
+	}
+
+	months := report.ByMonth
+	if len(months) > maxAnalyticsMonths {
+		months = months[len(months)-maxAnalyticsMonths:]
+	}
+	monthBars := make([]cryptomaterial.BarChartItem, 0, len(months))
+	for _, month := range months {
+		reward := dcrutil.Amount(month.Rewards).ToCoin()
+		monthBars = append(monthBars, cryptomaterial.BarChartItem{
+			Label:     month.Key,
+			Value:     reward,
+			ValueText: fmt.Sprintf("%.2f", reward),
+		})
+	}
+	vspBars := make([]cryptomaterial.BarChartItem, 0, len(report.ByVSP))
+	for _, vsp := range report.ByVSP {
+		vspBars = append(vspBars, cryptomaterial.BarChartItem{
+			Label:     components.TruncateString(vsp.Key, 16),
+			Value:     vsp.ROI,
+			ValueText: fmt.Sprintf("%.2f", vsp.ROI),
+		})
+	}
+
+	chart := func(title string, bars []cryptomaterial.BarChartItem) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			if len(bars) == 0 {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding24}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize16), title)
+						lbl.Font.Weight = font.SemiBold
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(pg.Theme.BarChart(bars).Layout),
+				)
+			})
+		})
+	}
+
+	return pg.pageSections(gtx, func(gtx C) D {
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						txt := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize20), values.String(values.StrStakingAnalytics))
+						txt.Font.Weight = font.SemiBold
+						return txt.Layout(gtx)
+					}),
+					layout.Rigid(pg.exportAnalyticsBtn.Layout),
+				)
+			}),
+			layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
+		}
+		for _, figure := range figures {
+			figure := figure
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return pg.dataRows(gtx, figure[0], figure[1], layout.Horizontal, layout.Middle)
+			}))
+		}
+		rows = append(rows,
+			chart(values.String(values.StrRewardsByMonth), monthBars),
+			chart(values.String(values.StrROIByVSP), vspBars),
+		)
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
@@ -0,0 +1,54 @@
+package staking
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// maxTicketBuyerLogRows is the number of ticket buyer decisions shown on the
+// page.
+const maxTicketBuyerLogRows = 10
+
+// ticketBuyerLogSection lists the latest decisions of the automatic ticket
+// buyer since the app started, e.g. why no ticket was bought in the current
+// interval. The decisions are not persisted.
+func (pg *Page) ticketBuyerLogSection(gtx C) D {
+	decisions := pg.dcrWallet.TicketBuyerLog()
+	if len(decisions) == 0 {
+		return D{}
+	}
+	if len(decisions) > maxTicketBuyerLogRows {
+		decisions = decisions[:maxTicketBuyerLogRows]
+	}
+
+	isMobile := pg.IsMobileView()
+	textSize14 := values.TextSizeTransform(isMobile, values.TextSize14)
+	return pg.pageSections(gtx, func(gtx C) D {
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				txt := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize20), values.String(values.StrTicketBuyerLog))
+				txt.Font.Weight = font.SemiBold
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+			}),
+		}
+		for _, decision := range decisions {
+			decision := decision
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, pg.Theme.Label(textSize14, decision.Message).Layout),
+						layout.Rigid(func(gtx C) D {
+							lbl := pg.Theme.Label(textSize14, pageutils.TimeAgo(decision.Timestamp))
+							lbl.Color = pg.Theme.Color.GrayText2
+							return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+						}),
+					)
+				})
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
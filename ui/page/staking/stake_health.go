@@ -0,0 +1,212 @@
+package staking
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// ticketHealthRow is a ticket that may miss its vote along with the button
+// that re-registers it with another VSP.
+type ticketHealthRow struct {
+	*dcr.TicketHealth
+	reregister cryptomaterial.Button
+}
+
+func (pg *Page) listenForTicketHealthIssues() {
+	listener := &dcr.TicketHealthListener{
+		OnTicketHealthIssues: func(_ int, _ []*dcr.TicketHealth) {
+			pg.loadTicketHealth()
+			pg.ParentWindow().Reload()
+		},
+		OnTicketHealthResolved: func(_ int, _ []string) {
+			pg.loadTicketHealth()
+			pg.ParentWindow().Reload()
+		},
+	}
+	if err := pg.dcrWallet.AddTicketHealthListener(listener, OverviewPageID); err != nil {
+		log.Errorf("Error adding ticket health listener: %v", err)
+	}
+}
+
+func (pg *Page) stopTicketHealthListener() {
+	pg.dcrWallet.RemoveTicketHealthListener(OverviewPageID)
+}
+
+func (pg *Page) loadTicketHealth() {
+	issues := pg.dcrWallet.TicketHealthIssues()
+	rows := make([]*ticketHealthRow, 0, len(issues))
+	for _, issue := range issues {
+		btn := pg.Theme.OutlineButton(values.String(values.StrReregister))
+		btn.TextSize = values.TextSize14
+		rows = append(rows, &ticketHealthRow{TicketHealth: issue, reregister: btn})
+	}
+	pg.ticketHealth = rows
+}
+
+func (pg *Page) handleTicketHealth(gtx C) {
+	for _, row := range pg.ticketHealth {
+		if row.reregister.Clicked(gtx) {
+			pg.showReregisterModal(row.TicketHash)
+		}
+	}
+
+	if pg.retryTicketFeesBtn.Clicked(gtx) {
+		pg.retryTicketFees()
+	}
+}
+
+// feesNeedUnlock returns true if VSP fee payments were not retried because
+// the wallet was locked.
+func (pg *Page) feesNeedUnlock() bool {
+	for _, row := range pg.ticketHealth {
+		if row.NeedsUnlock {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTicketFees asks for the wallet passphrase and retries the VSP fee
+// payments that were left for when the wallet is unlocked.
+func (pg *Page) retryTicketFees() {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrRetryTicketFees)).
+		SetPositiveButtonCallback(func(_, password string, _ *modal.CreatePasswordModal) bool {
+			go func() {
+				if _, err := pg.dcrWallet.RetryTicketFees(password); err != nil {
+					errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+				}
+			}()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// showReregisterModal asks for a new VSP and the wallet passphrase, then
+// registers the ticket with that VSP.
+func (pg *Page) showReregisterModal(ticketHash string) {
+	vspSelector := components.NewVSPSelector(pg.Load, pg.dcrWallet).Title(values.String(values.StrSelectVSP))
+	vspModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrReregisterTicket)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Body2(values.StringF(values.StrReregisterTicketInfo, components.TruncateString(ticketHash, 24)))
+					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return vspSelector.Layout(pg.ParentWindow(), gtx)
+				}),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrReregister)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			vsp := vspSelector.SelectedVSP()
+			if vsp == nil {
+				return false
+			}
+			pg.reregisterTicket(ticketHash, vsp.Host)
+			return true
+		})
+	pg.ParentWindow().ShowModal(vspModal)
+}
+
+// reregisterTicket asks for the wallet passphrase and re-registers the ticket
+// in the background, the result is reported with a toast or an error modal.
+// The ticket health section is refreshed by the ticket health listener.
+func (pg *Page) reregisterTicket(ticketHash, vspHost string) {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrReregisterTicket)).
+		SetPositiveButtonCallback(func(_, password string, _ *modal.CreatePasswordModal) bool {
+			go func() {
+				if err := pg.dcrWallet.ReregisterTicket(ticketHash, vspHost, password); err != nil {
+					errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+					return
+				}
+				pg.Toast.Notify(values.String(values.StrTicketReregistered))
+			}()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// ticketHealthSection lists the tickets that may miss their vote, e.g.
+// because their VSP went offline or the VSP fee could not be paid.
+func (pg *Page) ticketHealthSection(gtx C) D {
+	if len(pg.ticketHealth) == 0 {
+		return D{}
+	}
+
+	isMobile := pg.IsMobileView()
+	textSize14 := values.TextSizeTransform(isMobile, values.TextSize14)
+	return pg.pageSections(gtx, func(gtx C) D {
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				txt := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize20), values.String(values.StrTicketHealth))
+				txt.Font.Weight = font.SemiBold
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+			}),
+		}
+		if pg.feesNeedUnlock() {
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							lbl := pg.Theme.Label(textSize14, values.String(values.StrTicketFeesNeedUnlock))
+							lbl.Color = pg.Theme.Color.GrayText2
+							return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+						}),
+						layout.Rigid(pg.retryTicketFeesBtn.Layout),
+					)
+				})
+			}))
+		}
+		for _, row := range pg.ticketHealth {
+			row := row
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(pg.Theme.Label(textSize14, components.TruncateString(row.TicketHash, 24)).Layout),
+								layout.Rigid(func(gtx C) D {
+									lbl := pg.Theme.Label(textSize14, ticketHealthIssueText(row.TicketHealth))
+									lbl.Color = pg.Theme.Color.Danger
+									return lbl.Layout(gtx)
+								}),
+							)
+						}),
+						layout.Rigid(row.reregister.Layout),
+					)
+				})
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
+
+func ticketHealthIssueText(issue *dcr.TicketHealth) string {
+	switch issue.Issue {
+	case dcr.TicketFeeErrored:
+		return values.StringF(values.StrTicketFeeErrored, issue.VSP)
+	case dcr.TicketVSPUnreachable:
+		return values.StringF(values.StrTicketVSPUnreachable, issue.VSP)
+	case dcr.TicketVoteChoicesMismatch:
+		return values.StringF(values.StrTicketVoteChoicesMismatch, issue.VSP)
+	default:
+		return issue.Issue.String()
+	}
+}
@@ -17,6 +17,7 @@ func (pg *Page) initStakePriceWidget() *Page {
 	_, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
 
 	pg.stake = pg.Theme.Switch()
+	pg.soloVoting = pg.Theme.Switch()
 	return pg
 }
 
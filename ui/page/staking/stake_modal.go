@@ -3,6 +3,7 @@ package staking
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -14,6 +15,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -30,6 +32,19 @@ type ticketBuyerModal struct {
 	balToMaintainEditor cryptomaterial.Editor
 	accountDropdown     *components.AccountDropdown
 
+	maxPerIntervalEditor cryptomaterial.Editor
+	maxPerDayEditor      cryptomaterial.Editor
+	maxPriceEditor       cryptomaterial.Editor
+	maxVSPFeeEditor      cryptomaterial.Editor
+	minVSPUptimeEditor   cryptomaterial.Editor
+	fallbackVSPsEditor   cryptomaterial.Editor
+
+	soloStaking       *cryptomaterial.Switch
+	dcrdRPCHostEditor cryptomaterial.Editor
+	dcrdRPCUserEditor cryptomaterial.Editor
+	dcrdRPCCertEditor cryptomaterial.Editor
+	dcrdRPCNoTLS      *cryptomaterial.Switch
+
 	vspSelector *components.VSPSelector
 
 	dcrImpl *dcr.Asset
@@ -49,11 +64,40 @@ func newTicketBuyerModal(l *load.Load, wallet *dcr.Asset) *ticketBuyerModal {
 	tb.balToMaintainEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrBalToMaintain))
 	tb.balToMaintainEditor.Editor.SingleLine = true
 
+	tb.maxPerIntervalEditor = tb.policyEditor(values.String(values.StrMaxTicketsPerInterval))
+	tb.maxPerDayEditor = tb.policyEditor(values.String(values.StrMaxTicketsPerDay))
+	tb.maxPriceEditor = tb.policyEditor(values.String(values.StrMaxTicketPrice))
+	tb.maxVSPFeeEditor = tb.policyEditor(values.String(values.StrMaxVSPFee))
+	tb.minVSPUptimeEditor = tb.policyEditor(values.String(values.StrMinVSPUptime))
+	tb.fallbackVSPsEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrFallbackVSPs))
+
+	tb.soloStaking = l.Theme.Switch()
+	tb.dcrdRPCHostEditor = tb.rpcEditor(values.String(values.StrDcrdRPCHost))
+	tb.dcrdRPCUserEditor = tb.rpcEditor(values.String(values.StrDcrdRPCUser))
+	tb.dcrdRPCCertEditor = tb.rpcEditor(values.String(values.StrDcrdRPCCert))
+	tb.dcrdRPCNoTLS = l.Theme.Switch()
+
 	tb.saveSettingsBtn.SetEnabled(false)
 
 	return tb
 }
 
+// policyEditor returns an editor for an optional ticket buyer limit.
+func (tb *ticketBuyerModal) policyEditor(hint string) cryptomaterial.Editor {
+	editor := tb.Theme.Editor(new(widget.Editor), hint)
+	editor.Editor.SingleLine = true
+	editor.Editor.Filter = "0123456789."
+	return editor
+}
+
+// rpcEditor returns an editor for a setting of the dcrd connection used to
+// vote solo.
+func (tb *ticketBuyerModal) rpcEditor(hint string) cryptomaterial.Editor {
+	editor := tb.Theme.Editor(new(widget.Editor), hint)
+	editor.Editor.SingleLine = true
+	return editor
+}
+
 func (tb *ticketBuyerModal) OnSettingsSaved(settingsSaved func()) *ticketBuyerModal {
 	tb.settingsSaved = settingsSaved
 	return tb
@@ -99,6 +143,23 @@ func (tb *ticketBuyerModal) OnResume() {
 		tb.vspSelector.SelectVSP(tbConfig.VspHost)
 		w := tb.dcrImpl
 		tb.balToMaintainEditor.Editor.SetText(strconv.FormatFloat(w.ToAmount(tbConfig.BalanceToMaintain).ToCoin(), 'f', 0, 64))
+
+		setPolicyText(&tb.maxPerIntervalEditor, float64(tbConfig.MaxTicketsPerInterval))
+		setPolicyText(&tb.maxPerDayEditor, float64(tbConfig.MaxTicketsPerDay))
+		setPolicyText(&tb.maxPriceEditor, w.ToAmount(tbConfig.MaxTicketPrice).ToCoin())
+		setPolicyText(&tb.maxVSPFeeEditor, tbConfig.MaxVSPFeePercent)
+		setPolicyText(&tb.minVSPUptimeEditor, tbConfig.MinVSPUptime)
+		if len(tbConfig.VspHosts) > 1 {
+			tb.fallbackVSPsEditor.Editor.SetText(strings.Join(tbConfig.VspHosts[1:], "\n"))
+		}
+	}
+
+	tb.soloStaking.SetChecked(tb.dcrImpl.IsSoloStaking())
+	if cfg := tb.dcrImpl.SoloVotingConfig(); cfg != nil {
+		tb.dcrdRPCHostEditor.Editor.SetText(cfg.RPCHost)
+		tb.dcrdRPCUserEditor.Editor.SetText(cfg.RPCUser)
+		tb.dcrdRPCCertEditor.Editor.SetText(cfg.RPCCertFile)
+		tb.dcrdRPCNoTLS.SetChecked(cfg.RPCNoTLS)
 	}
 
 	if tb.accountDropdown.SelectedAccount() == nil {
@@ -128,13 +189,25 @@ func (tb *ticketBuyerModal) Layout(gtx C) D {
 					tb.balToMaintainEditor.TextSize = values.TextSizeTransform(tb.IsMobileView(), values.TextSize14)
 					return tb.balToMaintainEditor.Layout(gtx)
 				}),
+				layout.Rigid(tb.soloStakingLayout),
 				layout.Rigid(func(gtx C) D {
+					if tb.soloStaking.IsChecked() {
+						return D{}
+					}
 					return components.VerticalInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
 						return tb.vspSelector.Layout(tb.ParentWindow(), gtx)
 					})
 				}),
+				layout.Rigid(func(gtx C) D {
+					if tb.soloStaking.IsChecked() {
+						return D{}
+					}
+					tb.fallbackVSPsEditor.TextSize = values.TextSizeTransform(tb.IsMobileView(), values.TextSize14)
+					return tb.fallbackVSPsEditor.Layout(gtx)
+				}),
 			)
 		},
+		tb.policyLayout,
 		func(gtx C) D {
 			return layout.E.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
@@ -154,8 +227,167 @@ func (tb *ticketBuyerModal) Layout(gtx C) D {
 	return tb.Modal.Layout(gtx, l)
 }
 
+// soloStakingLayout lays out the solo staking toggle and, when it is on, the
+// dcrd connection used to vote in place of a VSP.
+func (tb *ticketBuyerModal) soloStakingLayout(gtx C) D {
+	textSize14 := values.TextSizeTransform(tb.IsMobileView(), values.TextSize14)
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, tb.Theme.Label(values.TextSizeTransform(tb.IsMobileView(), values.TextSize16), values.String(values.StrSoloStaking)).Layout),
+				layout.Rigid(tb.soloStaking.Layout),
+			)
+		}),
+	}
+	if tb.soloStaking.IsChecked() {
+		children = append(children,
+			layout.Rigid(func(gtx C) D {
+				lbl := tb.Theme.Label(textSize14, values.String(values.StrSoloStakingInfo))
+				lbl.Color = tb.Theme.Color.GrayText2
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				lbl := tb.Theme.Label(textSize14, values.String(values.StrSoloVotingWarning))
+				lbl.Color = tb.Theme.Color.Danger
+				return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+			}),
+		)
+		for _, editor := range []*cryptomaterial.Editor{&tb.dcrdRPCHostEditor, &tb.dcrdRPCUserEditor, &tb.dcrdRPCCertEditor} {
+			editor := editor
+			children = append(children, layout.Rigid(func(gtx C) D {
+				editor.TextSize = textSize14
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, editor.Layout)
+			}))
+		}
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, tb.Theme.Label(textSize14, values.String(values.StrDcrdRPCNoTLS)).Layout),
+					layout.Rigid(tb.dcrdRPCNoTLS.Layout),
+				)
+			})
+		}))
+	}
+
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
+// policyLayout lays out the optional purchase limits of the ticket buyer.
+func (tb *ticketBuyerModal) policyLayout(gtx C) D {
+	row := func(left, right *cryptomaterial.Editor) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				children := []layout.FlexChild{layout.Flexed(1, left.Layout)}
+				if right != nil {
+					children = append(children,
+						layout.Rigid(layout.Spacer{Width: values.MarginPadding12}.Layout),
+						layout.Flexed(1, right.Layout))
+				}
+				return layout.Flex{}.Layout(gtx, children...)
+			})
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lbl := tb.Theme.Label(values.TextSizeTransform(tb.IsMobileView(), values.TextSize16), values.String(values.StrPurchaseLimits))
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+		}),
+		row(&tb.maxPerIntervalEditor, &tb.maxPerDayEditor),
+		row(&tb.maxPriceEditor, nil),
+		layout.Rigid(func(gtx C) D {
+			if tb.soloStaking.IsChecked() {
+				return D{}
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, row(&tb.maxVSPFeeEditor, &tb.minVSPUptimeEditor))
+		}),
+	)
+}
+
+func setPolicyText(editor *cryptomaterial.Editor, value float64) {
+	if value > 0 {
+		editor.Editor.SetText(strconv.FormatFloat(value, 'f', -1, 64))
+	}
+}
+
+// parsePolicyValue parses the value of an optional limit, an empty editor
+// disables the limit.
+func parsePolicyValue(editor *cryptomaterial.Editor) (float64, bool) {
+	editor.ClearError()
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0, true
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+	return value, true
+}
+
+// parsePolicyCount parses the value of an optional ticket count limit, an
+// empty editor disables the limit.
+func parsePolicyCount(editor *cryptomaterial.Editor) (int, bool) {
+	editor.ClearError()
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0, true
+	}
+	value, err := strconv.ParseUint(text, 10, 31)
+	if err != nil {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+	return int(value), true
+}
+
+// policy reads the purchase limits and the fallback VSPs from the editors.
+func (tb *ticketBuyerModal) policy() (*dcr.TicketBuyerPolicy, []string, bool) {
+	maxPerInterval, ok1 := parsePolicyCount(&tb.maxPerIntervalEditor)
+	maxPerDay, ok2 := parsePolicyCount(&tb.maxPerDayEditor)
+	maxPrice, ok3 := parsePolicyValue(&tb.maxPriceEditor)
+	maxVSPFee, ok4 := parsePolicyValue(&tb.maxVSPFeeEditor)
+	minVSPUptime, ok5 := parsePolicyValue(&tb.minVSPUptimeEditor)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return nil, nil, false
+	}
+	if minVSPUptime > 100 {
+		tb.minVSPUptimeEditor.SetError(values.String(values.StrInvalidAmount))
+		return nil, nil, false
+	}
+
+	tb.fallbackVSPsEditor.ClearError()
+	var fallbackVSPs []string
+	for _, host := range strings.Fields(strings.ReplaceAll(tb.fallbackVSPsEditor.Editor.Text(), ",", " ")) {
+		if !pageutils.ValidateHost(host) {
+			tb.fallbackVSPsEditor.SetError(values.StringF(values.StrInvalidVSPHost, host))
+			return nil, nil, false
+		}
+		fallbackVSPs = append(fallbackVSPs, strings.TrimSuffix(host, "/"))
+	}
+
+	return &dcr.TicketBuyerPolicy{
+		MaxTicketsPerInterval: maxPerInterval,
+		MaxTicketsPerDay:      maxPerDay,
+		MaxTicketPrice:        dcr.AmountAtom(maxPrice),
+		MaxVSPFeePercent:      maxVSPFee,
+		MinVSPUptime:          minVSPUptime,
+	}, fallbackVSPs, true
+}
+
 func (tb *ticketBuyerModal) canSave() bool {
-	if tb.vspSelector.SelectedVSP() == nil {
+	if tb.soloStaking.IsChecked() {
+		if strings.TrimSpace(tb.dcrdRPCHostEditor.Editor.Text()) == "" {
+			return false
+		}
+		if strings.TrimSpace(tb.dcrdRPCCertEditor.Editor.Text()) == "" && !tb.dcrdRPCNoTLS.IsChecked() {
+			return false
+		}
+	} else if tb.vspSelector.SelectedVSP() == nil {
 		return false
 	}
 
@@ -198,17 +430,38 @@ func (tb *ticketBuyerModal) Handle(gtx C) {
 	}
 
 	if tb.saveSettingsBtn.Clicked(gtx) {
-		vspHost := tb.vspSelector.SelectedVSP().Host
+		solo := tb.soloStaking.IsChecked()
+		var vspHost string
+		if !solo {
+			vspHost = tb.vspSelector.SelectedVSP().Host
+		}
 		amount, err := strconv.ParseFloat(tb.balToMaintainEditor.Editor.Text(), 64)
 		if err != nil {
 			tb.SetError(err.Error())
 			return
 		}
 
+		policy, fallbackVSPs, ok := tb.policy()
+		if !ok {
+			return
+		}
+
 		balToMaintain := dcr.AmountAtom(amount)
 		account := tb.accountDropdown.SelectedAccount()
 
 		tb.dcrImpl.SetAutoTicketsBuyerConfig(vspHost, account.Number, balToMaintain)
+		tb.dcrImpl.SetAutoTicketsBuyerPolicy(policy, fallbackVSPs)
+		tb.dcrImpl.SetSoloStaking(solo)
+		if solo {
+			tb.dcrImpl.SetSoloVotingConfig(&dcr.SoloVotingConfig{
+				RPCHost:     strings.TrimSpace(tb.dcrdRPCHostEditor.Editor.Text()),
+				RPCUser:     strings.TrimSpace(tb.dcrdRPCUserEditor.Editor.Text()),
+				RPCCertFile: strings.TrimSpace(tb.dcrdRPCCertEditor.Editor.Text()),
+				RPCNoTLS:    tb.dcrdRPCNoTLS.IsChecked(),
+			})
+		} else {
+			tb.dcrImpl.StopSoloVoting()
+		}
 		tb.settingsSaved()
 		tb.Dismiss()
 	}
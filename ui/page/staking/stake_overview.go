@@ -13,6 +13,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/stakeanalytics"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -55,6 +56,7 @@ type Page struct {
 	ticketsList    *cryptomaterial.ClickableList
 	stakeSettings  *cryptomaterial.Clickable
 	stake          *cryptomaterial.Switch
+	soloVoting     *cryptomaterial.Switch
 	infoButton     cryptomaterial.IconButton
 	materialLoader material.LoaderStyle
 
@@ -67,6 +69,12 @@ type Page struct {
 
 	dcrWallet *dcr.Asset
 
+	ticketHealth       []*ticketHealthRow
+	retryTicketFeesBtn cryptomaterial.Button
+
+	stakingReport      *stakeanalytics.Report
+	exportAnalyticsBtn cryptomaterial.Button
+
 	// ticketContext is a managed context instance that is shut once a shutdown
 	// request is made. It helps avoid the use of context.TODO() that isn't
 	// responsive to the shutdown request.
@@ -96,6 +104,10 @@ func NewStakingPage(l *load.Load, dcrWallet *dcr.Asset) *Page {
 	pg.initTicketList()
 
 	pg.navToSettingsBtn = l.Theme.Button(values.StringF(values.StrEnableAPI, values.String(values.StrVsp)))
+	pg.exportAnalyticsBtn = l.Theme.OutlineButton(values.String(values.StrExport))
+	pg.retryTicketFeesBtn = l.Theme.OutlineButton(values.String(values.StrRetryTicketFees))
+	pg.retryTicketFeesBtn.TextSize = values.TextSize14
+	pg.exportAnalyticsBtn.TextSize = values.TextSize14
 
 	return pg
 }
@@ -115,10 +127,13 @@ func (pg *Page) OnNavigatedTo() {
 		pg.loadPageData() // starts go routines to refresh the display which is just about to be displayed, ok?
 
 		pg.stake.SetChecked(pg.dcrWallet.IsAutoTicketsPurchaseActive())
+		pg.soloVoting.SetChecked(pg.dcrWallet.IsSoloVotingActive())
 
 		pg.setStakingButtonsState()
 
 		pg.listenForTxNotifications() // tx ntfn listener is stopped in OnNavigatedFrom().
+		pg.listenForTicketHealthIssues()
+		pg.loadTicketHealth()
 
 		go func() {
 			pg.showMaterialLoader = true
@@ -169,6 +184,8 @@ func (pg *Page) loadPageData() {
 			pg.ticketOverview = overview
 		}
 
+		pg.loadStakingAnalytics()
+
 		pg.ParentWindow().Reload()
 	}()
 }
@@ -199,7 +216,11 @@ func (pg *Page) Layout(gtx C) D {
 		return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 				layout.Rigid(pg.stakePriceSection),
+				layout.Rigid(pg.soloVotingSection),
+				layout.Rigid(pg.ticketHealthSection),
+				layout.Rigid(pg.ticketBuyerLogSection),
 				layout.Rigid(pg.stakeStatisticsSection),
+				layout.Rigid(pg.stakingAnalyticsSection),
 				layout.Rigid(pg.ticketListLayout),
 			)
 		})
@@ -226,6 +247,9 @@ func (pg *Page) pageSections(gtx C, body layout.Widget) D {
 // Part of the load.Page interface.
 func (pg *Page) HandleUserInteractions(gtx C) {
 	pg.setStakingButtonsState()
+	pg.handleTicketHealth(gtx)
+	pg.handleSoloVoting(gtx)
+	pg.handleStakingAnalytics(gtx)
 
 	if pg.navToSettingsBtn.Clicked(gtx) {
 		pg.ParentWindow().Display(settings.NewAppSettingsPage(pg.Load))
@@ -368,7 +392,11 @@ func (pg *Page) startTicketBuyerPasswordModal() {
 				layout.Rigid(pg.Theme.Label(values.TextSize14, values.StringF(values.StrWalletToPurchaseFrom, pg.dcrWallet.GetWalletName())).Layout),
 				layout.Rigid(pg.Theme.Label(values.TextSize14, values.StringF(values.StrSelectedAccount, name)).Layout),
 				layout.Rigid(pg.Theme.Label(values.TextSize14, values.StringF(values.StrBalToMaintainValue, balToMaintain)).Layout), layout.Rigid(func(gtx C) D {
-					label := pg.Theme.Label(values.TextSize14, fmt.Sprintf("VSP: %s", tbConfig.VspHost))
+					vspHost := tbConfig.VspHost
+					if pg.dcrWallet.IsSoloStaking() {
+						vspHost = values.String(values.StrSoloStaking)
+					}
+					label := pg.Theme.Label(values.TextSize14, fmt.Sprintf("VSP: %s", vspHost))
 					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, label.Layout)
 				}),
 				layout.Rigid(func(gtx C) D {
@@ -435,4 +463,5 @@ func (pg *Page) startTicketBuyerPasswordModal() {
 // Part of the load.Page interface.
 func (pg *Page) OnNavigatedFrom() {
 	pg.stopTxNotificationsListener()
+	pg.stopTicketHealthListener()
 }
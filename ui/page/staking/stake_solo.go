@@ -0,0 +1,83 @@
+package staking
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+func (pg *Page) handleSoloVoting(gtx C) {
+	if !pg.soloVoting.Changed(gtx) {
+		return
+	}
+
+	if !pg.soloVoting.IsChecked() {
+		pg.dcrWallet.StopSoloVoting()
+		return
+	}
+
+	pg.soloVoting.SetChecked(false)
+	// The dcrd RPC password is not saved with the rest of the connection,
+	// it is asked for along with the wallet passphrase.
+	rpcPassEditor := pg.Theme.EditorPassword(new(widget.Editor), values.String(values.StrDcrdRPCPass))
+	rpcPassEditor.Editor.SingleLine = true
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrStartSoloVoting)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrSoloVotingWarning))
+					lbl.Color = pg.Theme.Color.Danger
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, rpcPassEditor.Layout)
+				}),
+			)
+		}).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := pg.dcrWallet.StartSoloVoting(password, rpcPassEditor.Editor.Text()); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pg.soloVoting.SetChecked(true)
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// soloVotingSection warns that solo tickets miss their votes while the app is
+// offline and lets the user keep the wallet unlocked to vote.
+func (pg *Page) soloVotingSection(gtx C) D {
+	if !pg.dcrWallet.IsSoloStaking() || pg.dcrWallet.IsWatchingOnlyWallet() {
+		return D{}
+	}
+
+	isMobile := pg.IsMobileView()
+	return pg.pageSections(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, func(gtx C) D {
+						txt := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize20), values.String(values.StrSoloVoting))
+						txt.Font.Weight = font.SemiBold
+						return txt.Layout(gtx)
+					}),
+					layout.Rigid(pg.soloVoting.Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				lbl := pg.Theme.Label(values.TextSizeTransform(isMobile, values.TextSize14), values.String(values.StrSoloVotingWarning))
+				lbl.Color = pg.Theme.Color.Danger
+				return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+			}),
+		)
+	})
+}
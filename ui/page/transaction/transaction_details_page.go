@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
 	"github.com/crypto-power/cryptopower/libwallet/txhelper"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -36,8 +38,9 @@ type transactionWdg struct {
 	confirmationIcons    *cryptomaterial.Image
 	time, status, wallet cryptomaterial.Label
 
-	copyTextButtons []*cryptomaterial.Clickable
-	txStatus        *components.TxStatus
+	copyTextButtons  []*cryptomaterial.Clickable
+	editLabelButtons []*cryptomaterial.Clickable
+	txStatus         *components.TxStatus
 }
 
 type moreItem struct {
@@ -62,6 +65,7 @@ type TxDetailsPage struct {
 	associatedTicketClickable *cryptomaterial.Clickable
 	hashClickable             *cryptomaterial.Clickable
 	rebroadcastClickable      *cryptomaterial.Clickable
+	speedUpClickable          *cryptomaterial.Clickable
 	moreOption                *cryptomaterial.Clickable
 	outputsCollapsible        *cryptomaterial.Collapsible
 	inputsCollapsible         *cryptomaterial.Collapsible
@@ -72,8 +76,10 @@ type TxDetailsPage struct {
 
 	backButton  cryptomaterial.IconButton
 	rebroadcast cryptomaterial.Label
+	speedUp     cryptomaterial.Label
 
-	copyURLBtn *cryptomaterial.Clickable
+	copyURLBtn  *cryptomaterial.Clickable
+	editTxLabel *cryptomaterial.Clickable
 
 	transaction   *sharedW.Transaction
 	ticketSpender *sharedW.Transaction // vote or revoke ticket
@@ -86,17 +92,27 @@ type TxDetailsPage struct {
 
 	txSourceAccount, txDestinationAccount string
 	txDestinationAddresses                []string
-	title                                 string
-	vspHost                               string
-	vspHostFees                           string
+	// contactNames maps the external output addresses found in the address
+	// book to the contact names.
+	contactNames map[string]string
+	// ioLabels holds the labels of the inputs followed by the labels of the
+	// outputs of the transaction.
+	ioLabels    []string
+	title       string
+	vspHost     string
+	vspHostFees string
 
 	moreOptionIsOpen bool
+	canBumpFee       bool
+	canCPFP          bool
 }
 
 func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *sharedW.Transaction) *TxDetailsPage {
 	rebroadcast := l.Theme.Label(values.TextSize14, values.String(values.StrRebroadcast))
 	rebroadcast.TextSize = values.TextSize14
 	rebroadcast.Color = l.Theme.Color.Text
+	speedUp := l.Theme.Label(values.TextSize14, values.String(values.StrSpeedUp))
+	speedUp.Color = l.Theme.Color.Text
 	pg := &TxDetailsPage{
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(TransactionDetailsPageID),
@@ -114,7 +130,8 @@ func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *
 		inputsCollapsible:  l.Theme.Collapsible(),
 		txLabelCollapsible: l.Theme.Collapsible(),
 
-		copyURLBtn: l.Theme.NewClickable(false),
+		copyURLBtn:  l.Theme.NewClickable(false),
+		editTxLabel: l.Theme.NewClickable(false),
 
 		associatedTicketClickable: l.Theme.NewClickable(true),
 		hashClickable:             l.Theme.NewClickable(true),
@@ -127,6 +144,8 @@ func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *
 		rebroadcast:            rebroadcast,
 		rebroadcastClickable:   l.Theme.NewClickable(true),
 		rebroadcastIcon:        l.Theme.Icons.Rebroadcast,
+		speedUp:                speedUp,
+		speedUpClickable:       l.Theme.NewClickable(true),
 		txDestinationAddresses: make([]string, 0),
 	}
 
@@ -140,6 +159,75 @@ func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *
 	return pg
 }
 
+// loadContactNames looks up the external outputs of the transaction in the
+// address book.
+func (pg *TxDetailsPage) loadContactNames() {
+	pg.contactNames = make(map[string]string)
+	for _, output := range pg.transaction.Outputs {
+		if output.AccountNumber != -1 {
+			continue
+		}
+		if name := components.ContactLabel(pg.Load, pg.wallet.GetAssetType(), output.Address); name != "" {
+			pg.contactNames[output.Address] = name
+		}
+	}
+}
+
+// loadLabels reads the labels of the inputs and outputs of the transaction.
+// An output without a label of its own shows the label of its address.
+func (pg *TxDetailsPage) loadLabels() {
+	tx := pg.transaction
+	pg.ioLabels = make([]string, 0, len(tx.Inputs)+len(tx.Outputs))
+	for _, input := range tx.Inputs {
+		label := pg.wallet.Label(walletdata.LabelTypeInput, input.PreviousOutpoint)
+		if label == "" {
+			label = pg.wallet.Label(walletdata.LabelTypeOutput, input.PreviousOutpoint)
+		}
+		pg.ioLabels = append(pg.ioLabels, label)
+	}
+
+	for _, output := range tx.Outputs {
+		label := pg.wallet.Label(walletdata.LabelTypeOutput, sharedW.OutputLabelRef(tx.Hash, uint32(output.Index)))
+		if label == "" {
+			label = pg.wallet.Label(walletdata.LabelTypeAddress, output.Address)
+		}
+		pg.ioLabels = append(pg.ioLabels, label)
+	}
+}
+
+// ioLabelRef returns the label type and reference of the input or output
+// displayed at index i.
+func (pg *TxDetailsPage) ioLabelRef(i int) (walletdata.LabelType, string) {
+	tx := pg.transaction
+	if i < len(tx.Inputs) {
+		return walletdata.LabelTypeInput, tx.Inputs[i].PreviousOutpoint
+	}
+	output := tx.Outputs[i-len(tx.Inputs)]
+	return walletdata.LabelTypeOutput, sharedW.OutputLabelRef(tx.Hash, uint32(output.Index))
+}
+
+// showLabelModal lets the user change a label. onSaved is called with the
+// new label once it has been stored.
+func (pg *TxDetailsPage) showLabelModal(labelType walletdata.LabelType, ref, current string, onSaved func(string)) {
+	labelModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrLabel)).
+		SetText(current).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(label string, tim *modal.TextInputModal) bool {
+			label = strings.TrimSpace(label)
+			if err := pg.wallet.SetLabel(labelType, ref, label); err != nil {
+				tim.SetError(err.Error())
+				return false
+			}
+			onSaved(label)
+			pg.ParentWindow().Reload()
+			return true
+		})
+	labelModal.Title(values.String(values.StrEditLabel)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(labelModal)
+}
+
 func (pg *TxDetailsPage) getTXSourceAccountAndDirection() {
 	// find source account
 	for _, input := range pg.transaction.Inputs {
@@ -222,6 +310,9 @@ destinationAddrLoop:
 // the page is displayed.
 // Part of the load.Page interface.
 func (pg *TxDetailsPage) OnNavigatedTo() {
+	pg.loadContactNames()
+	pg.loadLabels()
+
 	if dcrImp, ok := pg.wallet.(*dcr.Asset); ok {
 		// this tx is a vote transaction
 		if pg.transaction.TicketSpentHash != "" {
@@ -273,6 +364,18 @@ func (pg *TxDetailsPage) OnNavigatedTo() {
 
 	pg.getTXSourceAccountAndDirection()
 	pg.txnWidgets = pg.initTxnWidgets()
+	pg.checkSpeedUp()
+}
+
+// checkSpeedUp determines whether the fee of an unconfirmed transaction can
+// be bumped with a replacement or a child transaction.
+func (pg *TxDetailsPage) checkSpeedUp() {
+	pg.canBumpFee, pg.canCPFP = false, false
+	if pg.transaction.BlockHeight != -1 || pg.wallet.IsWatchingOnlyWallet() {
+		return
+	}
+	pg.canBumpFee = load.CanBumpFee(pg.wallet, pg.transaction.Hash)
+	pg.canCPFP = load.CanCPFP(pg.wallet, pg.transaction.Hash)
 }
 
 func (pg *TxDetailsPage) getMoreItem() []moreItem {
@@ -316,6 +419,7 @@ func (pg *TxDetailsPage) Layout(gtx C) D {
 				pg.transaction = pg.txBackStack
 				pg.getTXSourceAccountAndDirection()
 				pg.txnWidgets = pg.initTxnWidgets()
+				pg.loadLabels()
 				pg.txBackStack = nil
 				pg.ParentWindow().Reload()
 			},
@@ -504,25 +608,7 @@ func (pg *TxDetailsPage) txDetailsHeader(gtx C) D {
 								if !pg.rebroadcastClickable.Enabled() {
 									gtx = pg.rebroadcastClickable.SetEnabled(false, &gtx)
 								}
-								return cryptomaterial.LinearLayout{
-									Width:     cryptomaterial.WrapContent,
-									Height:    cryptomaterial.WrapContent,
-									Clickable: pg.rebroadcastClickable,
-									Direction: layout.Center,
-									Alignment: layout.Middle,
-									Border: cryptomaterial.Border{
-										Color:  pg.Theme.Color.Gray2,
-										Width:  values.MarginPadding1,
-										Radius: cryptomaterial.Radius(10),
-									},
-									Padding: layout.Inset{
-										Top:    values.MarginPadding3,
-										Bottom: values.MarginPadding3,
-										Left:   values.MarginPadding8,
-										Right:  values.MarginPadding8,
-									},
-									Margin: layout.Inset{Left: values.MarginPadding10},
-								}.Layout(gtx,
+								return pg.txActionButton(gtx, pg.rebroadcastClickable,
 									layout.Rigid(func(gtx C) D {
 										return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, pg.rebroadcastIcon.Layout16dp)
 									}),
@@ -531,6 +617,15 @@ func (pg *TxDetailsPage) txDetailsHeader(gtx C) D {
 							}
 							return D{}
 						}),
+						layout.Rigid(func(gtx C) D {
+							if pg.canBumpFee || pg.canCPFP {
+								if !pg.speedUpClickable.Enabled() {
+									gtx = pg.speedUpClickable.SetEnabled(false, &gtx)
+								}
+								return pg.txActionButton(gtx, pg.speedUpClickable, layout.Rigid(pg.speedUp.Layout))
+							}
+							return D{}
+						}),
 					)
 				}),
 			)
@@ -538,6 +633,30 @@ func (pg *TxDetailsPage) txDetailsHeader(gtx C) D {
 	)
 }
 
+// txActionButton lays out a bordered pill button shown beside the status of
+// an unconfirmed transaction.
+func (pg *TxDetailsPage) txActionButton(gtx C, clickable *cryptomaterial.Clickable, children ...layout.FlexChild) D {
+	return cryptomaterial.LinearLayout{
+		Width:     cryptomaterial.WrapContent,
+		Height:    cryptomaterial.WrapContent,
+		Clickable: clickable,
+		Direction: layout.Center,
+		Alignment: layout.Middle,
+		Border: cryptomaterial.Border{
+			Color:  pg.Theme.Color.Gray2,
+			Width:  values.MarginPadding1,
+			Radius: cryptomaterial.Radius(10),
+		},
+		Padding: layout.Inset{
+			Top:    values.MarginPadding3,
+			Bottom: values.MarginPadding3,
+			Left:   values.MarginPadding8,
+			Right:  values.MarginPadding8,
+		},
+		Margin: layout.Inset{Left: values.MarginPadding10},
+	}.Layout(gtx, children...)
+}
+
 func (pg *TxDetailsPage) getTimeToMatureOrExpire() int {
 	var progress float32
 	if dcrImpl, ok := pg.wallet.(*dcr.Asset); ok {
@@ -623,7 +742,11 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx C) D {
 								gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(address))})
 								pg.Toast.Notify(values.String(values.StrTxHashCopied))
 							}
-							lbl := pg.Theme.Label(values.TextSize14, pageutils.SplitSingleString(address, 0))
+							text := pageutils.SplitSingleString(address, 0)
+							if name, ok := pg.contactNames[address]; ok {
+								text = fmt.Sprintf("%s (%s)", name, text)
+							}
+							lbl := pg.Theme.Label(values.TextSize14, text)
 							lbl.Color = pg.Theme.Color.Primary
 							return clickable.Layout(gtx, lbl.Layout)
 						}))
@@ -791,11 +914,16 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx C) D {
 			return pg.keyValue(gtx, values.String(values.StrTransactionID), dim)
 		}),
 		layout.Rigid(func(gtx C) D {
-			if len(pg.transaction.Label) != 0 {
-				txlabel := pg.Theme.Label(values.TextSize14, pg.transaction.Label)
-				return pg.keyValue(gtx, values.String(values.StrDescriptionNote), txlabel.Layout)
-			}
-			return D{}
+			return pg.keyValue(gtx, values.String(values.StrDescriptionNote), func(gtx C) D {
+				return pg.editTxLabel.Layout(gtx, func(gtx C) D {
+					if len(pg.transaction.Label) != 0 {
+						return pg.Theme.Label(values.TextSize14, pg.transaction.Label).Layout(gtx)
+					}
+					lbl := pg.Theme.Label(values.TextSize14, values.String(values.StrAddLabel))
+					lbl.Color = pg.Theme.Color.Primary
+					return lbl.Layout(gtx)
+				})
+			})
 		}),
 	)
 }
@@ -844,7 +972,9 @@ func (pg *TxDetailsPage) txnOutputs(gtx C) D {
 
 func (pg *TxDetailsPage) txnIORow(gtx C, amount int64, acctNum int32, address string, i int) D {
 	accountName := values.String(values.StrExternal)
-	if acctNum != -1 {
+	if name, ok := pg.contactNames[address]; ok {
+		accountName = name
+	} else if acctNum != -1 {
 		name, err := pg.wallet.AccountName(acctNum)
 		if err == nil {
 			accountName = name
@@ -853,6 +983,10 @@ func (pg *TxDetailsPage) txnIORow(gtx C, amount int64, acctNum int32, address st
 
 	accountName = fmt.Sprintf("(%s)", accountName)
 	amt := pg.wallet.ToAmount(amount).String()
+	var ioLabel string
+	if i < len(pg.ioLabels) {
+		ioLabel = pg.ioLabels[i]
+	}
 
 	return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
 		card := pg.Theme.Card()
@@ -871,8 +1005,21 @@ func (pg *TxDetailsPage) txnIORow(gtx C, amount int64, acctNum int32, address st
 									Right: m,
 								}.Layout(gtx, pg.Theme.Label(values.TextSize14, accountName).Layout)
 							}),
+							layout.Flexed(1, func(gtx C) D {
+								return layout.E.Layout(gtx, func(gtx C) D {
+									return pg.txnWidgets.editLabelButtons[i].Layout(gtx, pg.Theme.Icons.EditIcon.Layout16dp)
+								})
+							}),
 						)
 					}),
+					layout.Rigid(func(gtx C) D {
+						if ioLabel == "" {
+							return D{}
+						}
+						lbl := pg.Theme.Label(values.TextSize12, ioLabel)
+						lbl.Color = pg.Theme.Color.GrayText2
+						return lbl.Layout(gtx)
+					}),
 					layout.Rigid(func(gtx C) D {
 						// copy address
 						if pg.txnWidgets.copyTextButtons[i].Clicked(gtx) {
@@ -966,16 +1113,38 @@ func (pg *TxDetailsPage) HandleUserInteractions(gtx C) {
 		pg.moreOptionIsOpen = !pg.moreOptionIsOpen
 	}
 
+	if pg.editTxLabel.Clicked(gtx) {
+		tx := pg.transaction
+		pg.showLabelModal(walletdata.LabelTypeTx, tx.Hash, tx.Label, func(label string) {
+			tx.Label = label
+		})
+	}
+
+	for i, btn := range pg.txnWidgets.editLabelButtons {
+		if !btn.Clicked(gtx) {
+			continue
+		}
+		labelType, ref := pg.ioLabelRef(i)
+		pg.showLabelModal(labelType, ref, pg.wallet.Label(labelType, ref), func(_ string) {
+			pg.loadLabels()
+		})
+	}
+
 	if pg.associatedTicketClickable.Clicked(gtx) {
 		if pg.ticketSpent != nil {
 			pg.txBackStack = pg.transaction
 			pg.transaction = pg.ticketSpent
 			pg.getTXSourceAccountAndDirection()
 			pg.txnWidgets = pg.initTxnWidgets()
+			pg.loadLabels()
 			pg.ParentWindow().Reload()
 		}
 	}
 
+	if pg.speedUpClickable.Clicked(gtx) {
+		pg.showSpeedUpModal()
+	}
+
 	if pg.rebroadcastClickable.Clicked(gtx) {
 		go func() {
 			pg.rebroadcastClickable.SetEnabled(false, nil)
@@ -1006,6 +1175,63 @@ func (pg *TxDetailsPage) HandleUserInteractions(gtx C) {
 	}
 }
 
+// showSpeedUpModal asks for the new fee rate and the spending password, then
+// replaces the transaction with one paying the higher fee. If the transaction
+// cannot be replaced, a child transaction spending its outputs is created
+// instead.
+func (pg *TxDetailsPage) showSpeedUpModal() {
+	feeUnit := "Sat/kvB"
+	if pg.wallet.GetAssetType() == libutils.LTCWalletAsset {
+		feeUnit = "Lit/kvB"
+	}
+
+	feeRateModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.StringF(values.StrNewFeeRate, feeUnit)).
+		SetText(fmt.Sprintf("%d", pg.transaction.FeeRate*2)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(rate string, tim *modal.TextInputModal) bool {
+			feeRate, err := strconv.ParseInt(strings.TrimSpace(rate), 10, 64)
+			if err != nil || feeRate <= pg.transaction.FeeRate {
+				tim.SetError(values.StringF(values.StrInvalidFeeRate, pg.transaction.FeeRate, feeUnit))
+				return false
+			}
+			pg.confirmSpeedUp(feeRate)
+			return true
+		})
+	feeRateModal.Title(values.String(values.StrSpeedUpTransaction)).
+		SetPositiveButtonText(values.String(values.StrNext))
+	pg.ParentWindow().ShowModal(feeRateModal)
+}
+
+func (pg *TxDetailsPage) confirmSpeedUp(feeRate int64) {
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrSpeedUpTransaction)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				var err error
+				if pg.canBumpFee {
+					_, err = load.BumpFee(pg.wallet, pg.transaction.Hash, feeRate, password)
+				} else {
+					_, err = load.CPFP(pg.wallet, pg.transaction.Hash, feeRate, password)
+				}
+				if err != nil {
+					pm.SetError(err.Error())
+					pm.SetLoading(false)
+					return
+				}
+				pm.Dismiss()
+
+				pg.checkSpeedUp()
+				infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrTxSpedUp), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(infoModal)
+				pg.ParentWindow().Reload()
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
 func (pg *TxDetailsPage) initTxnWidgets() transactionWdg {
 	var txn transactionWdg
 
@@ -1028,8 +1254,10 @@ func (pg *TxDetailsPage) initTxnWidgets() transactionWdg {
 
 	x := len(pg.transaction.Inputs) + len(pg.transaction.Outputs)
 	txn.copyTextButtons = make([]*cryptomaterial.Clickable, x)
+	txn.editLabelButtons = make([]*cryptomaterial.Clickable, x)
 	for i := 0; i < x; i++ {
 		txn.copyTextButtons[i] = pg.Theme.NewClickable(false)
+		txn.editLabelButtons[i] = pg.Theme.NewClickable(false)
 	}
 
 	return txn
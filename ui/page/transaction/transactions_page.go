@@ -1,15 +1,9 @@
 package transaction
 
 import (
-	"encoding/csv"
 	"fmt"
-	"math"
-	"os"
-	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
-	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -19,7 +13,7 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
-	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/txexport"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -615,31 +609,7 @@ func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
 	}
 
 	if pg.exportBtn.Clicked(gtx) {
-		exportModal := modal.NewCustomModal(pg.Load).
-			Title(values.String(values.StrExportTransaction)).
-			Body(values.String(values.StrExportTransactionsMsg)).
-			SetNegativeButtonText(values.String(values.StrCancel)).
-			SetPositiveButtonText(values.String(values.StrExport)).
-			SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
-				assets := []sharedW.Asset{pg.selectedWallet}
-				if pg.selectedWallet == nil {
-					assets = pg.assetWallets
-				}
-				go func() {
-					fileName := filepath.Join(pg.AssetsManager.RootDir(), "exports", fmt.Sprintf("transaction_export_%d.csv", time.Now().Unix()))
-					err := exportTxs(assets, fileName)
-					if err != nil {
-						errModal := modal.NewErrorModal(pg.Load, fmt.Errorf("error exporting your wallet(s) transactions: %v", err).Error(), modal.DefaultClickFunc())
-						pg.ParentWindow().ShowModal(errModal)
-						return
-					}
-
-					infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrExportTransactionSuccessMsg, fileName), modal.DefaultClickFunc())
-					pg.ParentWindow().ShowModal(infoModal)
-				}()
-				return true
-			})
-		pg.ParentWindow().ShowModal(exportModal)
+		pg.showExportModal()
 	}
 
 	if pg.orderDropDown.Changed(gtx) {
@@ -661,62 +631,50 @@ func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
 	}
 }
 
-func exportTxs(assets []sharedW.Asset, fileName string) error {
-	if err := os.MkdirAll(filepath.Dir(fileName), utils.UserFilePerm); err != nil {
-		return fmt.Errorf("os.MkdirAll error: %w", err)
-	}
-
-	var success bool
-	defer func() {
-		if !success {
-			os.Remove(fileName)
-		}
-	}()
-
-	f, err := os.Create(fileName)
-	if err != nil {
-		return fmt.Errorf("os.Create error: %w", err)
-	}
-	defer f.Close()
-
-	headers := []string{values.String(values.StrTime), values.String(values.StrHash), values.String(values.StrType), values.String(values.StrDirection), values.String(values.StrFee), values.String(values.StrAmount)}
-
-	writer := csv.NewWriter(f)
-	writer.UseCRLF = runtime.GOOS == "windows"
-	err = writer.Write(headers)
-	if err != nil {
-		return fmt.Errorf("csv.Writer.Write error: %w", err)
-	}
-
-	for _, a := range assets {
-		txs, err := a.GetTransactionsRaw(0, math.MaxInt32, utils.TxFilterAll, true, "")
-		if err != nil {
-			return fmt.Errorf("wallet.GetTransactionsRaw error: %w", err)
-		}
-
-		// Write txs to file.
-		for _, tx := range txs {
-			err := writer.Write([]string{
-				time.Unix(tx.Timestamp, 0).String(),
-				tx.Hash,
-				tx.Type,
-				txhelper.TxDirectionString(tx.Direction),
-				a.ToAmount(tx.Fee).String(),
-				a.ToAmount(tx.Amount).String(),
-			})
-			if err != nil {
-				return fmt.Errorf("csv.Writer.Write error: %v", err)
+// showExportModal lets the user pick the export format and whether to add
+// fiat values, then exports the transactions of the selected wallet(s) that
+// match the current filter.
+func (pg *TransactionsPage) showExportModal() {
+	formatGroup := &widget.Enum{Value: string(txexport.FormatCSV)}
+	includeFiat := new(widget.Bool)
+	canIncludeFiat := pg.AssetsManager.ExchangeRateFetchingEnabled()
+
+	exportModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrExportTransaction)).
+		Body(values.String(values.StrExportTransactionsMsg)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(pg.Theme.RadioButton(formatGroup, string(txexport.FormatCSV), values.String(values.StrCSV), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary).Layout),
+				layout.Rigid(pg.Theme.RadioButton(formatGroup, string(txexport.FormatJSON), values.String(values.StrJSON), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary).Layout),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrExport)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			assets := []sharedW.Asset{pg.selectedWallet}
+			if pg.selectedWallet == nil {
+				assets = pg.assetWallets
 			}
+			format := txexport.Format(formatGroup.Value)
+			withFiat := canIncludeFiat && includeFiat.Value
+			txFilter := pg.txFilter
+			go func() {
+				fileName, err := pg.AssetsManager.ExportTransactions(assets, txFilter, format, withFiat)
+				if err != nil {
+					errModal := modal.NewErrorModal(pg.Load, fmt.Errorf("error exporting your wallet(s) transactions: %v", err).Error(), modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+					return
+				}
 
-			writer.Flush()
-			if err = writer.Error(); err != nil {
-				return fmt.Errorf("csv.Writer error: %w", err)
-			}
-		}
+				infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrExportTransactionSuccessMsg, fileName), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(infoModal)
+			}()
+			return true
+		})
+	if canIncludeFiat {
+		exportModal.CheckBox(pg.Theme.CheckBox(includeFiat, values.String(values.StrIncludeFiatValue)), false)
 	}
-
-	success = true
-	return nil
+	pg.ParentWindow().ShowModal(exportModal)
 }
 
 func (pg *TransactionsPage) listenForTxNotifications() {
@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	offlineTxFromFile = iota
+	offlineTxPasted
+)
+
+// offlineTxModal asks for a transaction exchanged with an offline wallet. The
+// user picks whether a file path or the transaction itself is entered, the
+// input is never guessed to be one or the other.
+type offlineTxModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	wallet   sharedW.Asset
+	title    string
+	textHint string
+	// callback receives the transaction read from the file or pasted. The
+	// modal is dismissed if it returns true. It is called from a goroutine.
+	callback func(tx string, m *offlineTxModal) bool
+
+	sourceSwitch *cryptomaterial.SegmentedControl
+	txEditor     cryptomaterial.Editor
+	submitBtn    cryptomaterial.Button
+	cancelBtn    cryptomaterial.Button
+
+	isLoading bool
+}
+
+func newOfflineTxModal(l *load.Load, wallet sharedW.Asset, title, textHint, submitText string, callback func(string, *offlineTxModal) bool) *offlineTxModal {
+	m := &offlineTxModal{
+		Load:      l,
+		Modal:     l.Theme.ModalFloatTitle("offline_tx_modal", l.IsMobileView(), nil),
+		wallet:    wallet,
+		title:     title,
+		textHint:  textHint,
+		callback:  callback,
+		txEditor:  l.Theme.Editor(new(widget.Editor), values.String(values.StrOfflineTxPathHint)),
+		submitBtn: l.Theme.Button(submitText),
+		cancelBtn: l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	m.sourceSwitch = l.Theme.SegmentedControl([]string{
+		values.String(values.StrOfflineTxFromFile),
+		values.String(values.StrOfflineTxPaste),
+	}, cryptomaterial.SegmentTypeDynamicSplit)
+	m.sourceSwitch.SetEnableSwipe(false)
+	m.sourceSwitch.DisableUniform(true)
+	m.txEditor.Editor.SingleLine = true
+
+	return m
+}
+
+func (m *offlineTxModal) OnResume() {}
+
+func (m *offlineTxModal) OnDismiss() {}
+
+// SetError shows err under the transaction editor.
+func (m *offlineTxModal) SetError(err string) {
+	m.txEditor.SetError(err)
+}
+
+func (m *offlineTxModal) setLoading(loading bool) {
+	m.isLoading = loading
+	m.Modal.SetDisabled(loading)
+}
+
+func (m *offlineTxModal) Handle(gtx C) {
+	if m.sourceSwitch.Changed() {
+		m.txEditor.SetError("")
+		m.txEditor.Editor.SetText("")
+		if m.sourceSwitch.SelectedIndex() == offlineTxPasted {
+			m.txEditor.Hint = m.textHint
+			m.txEditor.Editor.SingleLine = false
+		} else {
+			m.txEditor.Hint = values.String(values.StrOfflineTxPathHint)
+			m.txEditor.Editor.SingleLine = true
+		}
+	}
+
+	input := strings.TrimSpace(m.txEditor.Editor.Text())
+	m.submitBtn.SetEnabled(input != "" && !m.isLoading)
+	if m.submitBtn.Clicked(gtx) && input != "" && !m.isLoading {
+		m.setLoading(true)
+		m.txEditor.SetError("")
+		fromFile := m.sourceSwitch.SelectedIndex() == offlineTxFromFile
+		go func() {
+			defer m.setLoading(false)
+
+			tx := input
+			if fromFile {
+				var err error
+				if tx, err = load.ReadOfflineTxFile(m.wallet, input); err != nil {
+					m.txEditor.SetError(err.Error())
+					return
+				}
+			}
+
+			if m.callback(tx, m) {
+				m.Dismiss()
+			}
+		}()
+	}
+
+	if m.cancelBtn.Clicked(gtx) && !m.isLoading {
+		m.Dismiss()
+	}
+}
+
+func (m *offlineTxModal) Layout(gtx C) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := m.Theme.H6(m.title)
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return m.sourceSwitch.Layout(gtx, func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, m.txEditor.Layout)
+			}, m.IsMobileView())
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, m.cancelBtn.Layout)
+					}),
+					layout.Rigid(m.submitBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return m.Modal.Layout(gtx, w)
+}
@@ -0,0 +1,126 @@
+package wallet
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// offlineTxReviewModal shows the destinations and fee of a transaction about
+// to be signed by an offline wallet. The user must confirm them before the
+// transaction can be signed.
+type offlineTxReviewModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	wallet    sharedW.Asset
+	summary   *sharedW.UnsignedTxSummary
+	onConfirm func()
+
+	confirmCheck cryptomaterial.CheckBoxStyle
+	nextBtn      cryptomaterial.Button
+	cancelBtn    cryptomaterial.Button
+}
+
+func newOfflineTxReviewModal(l *load.Load, wallet sharedW.Asset, summary *sharedW.UnsignedTxSummary, onConfirm func()) *offlineTxReviewModal {
+	return &offlineTxReviewModal{
+		Load:         l,
+		Modal:        l.Theme.ModalFloatTitle("offline_tx_review_modal", l.IsMobileView(), nil),
+		wallet:       wallet,
+		summary:      summary,
+		onConfirm:    onConfirm,
+		confirmCheck: l.Theme.CheckBox(new(widget.Bool), values.String(values.StrConfirmOfflineTx)),
+		nextBtn:      l.Theme.Button(values.String(values.StrNext)),
+		cancelBtn:    l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+}
+
+func (m *offlineTxReviewModal) OnResume() {}
+
+func (m *offlineTxReviewModal) OnDismiss() {}
+
+func (m *offlineTxReviewModal) Handle(gtx C) {
+	confirmed := m.confirmCheck.CheckBox.Value
+	m.nextBtn.SetEnabled(confirmed)
+	if m.nextBtn.Clicked(gtx) && confirmed {
+		m.Dismiss()
+		m.onConfirm()
+	}
+
+	if m.cancelBtn.Clicked(gtx) {
+		m.Dismiss()
+	}
+}
+
+func (m *offlineTxReviewModal) outputLayout(gtx C, output *sharedW.UnsignedTxOutput) D {
+	address := output.Address
+	if address == "" {
+		address = values.String(values.StrUnknown)
+	}
+
+	return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(m.Theme.Body2(address).Layout),
+			layout.Rigid(func(gtx C) D {
+				amount := m.wallet.ToAmount(output.Amount).String()
+				if output.Internal {
+					amount += " (" + values.String(values.StrOwnAddress) + ")"
+				}
+				lbl := m.Theme.Body2(amount)
+				lbl.Color = m.Theme.Color.GrayText2
+				return lbl.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func (m *offlineTxReviewModal) Layout(gtx C) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := m.Theme.H6(values.String(values.StrReviewOfflineTx))
+			t.Font.Weight = font.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			lbl := m.Theme.Label(values.TextSize16, values.String(values.StrDestination))
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		},
+	}
+
+	for _, output := range m.summary.Outputs {
+		output := output
+		w = append(w, func(gtx C) D {
+			return m.outputLayout(gtx, output)
+		})
+	}
+
+	w = append(w,
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Flexed(1, m.Theme.Body1(values.String(values.StrTxFee)).Layout),
+				layout.Rigid(m.Theme.Body1(m.wallet.ToAmount(m.summary.Fee).String()).Layout),
+			)
+		},
+		func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, m.confirmCheck.Layout)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, m.cancelBtn.Layout)
+					}),
+					layout.Rigid(m.nextBtn.Layout),
+				)
+			})
+		},
+	)
+
+	return m.Modal.Layout(gtx, w)
+}
@@ -77,12 +77,12 @@ type SingleWalletMasterPage struct {
 	walletDropdown         *cryptomaterial.DropDown
 	allWallets             []sharedW.Asset
 
-	usdExchangeRate        float64
-	usdExchangeSet         bool
+	fiatExchangeRate       float64
+	fiatExchangeSet        bool
 	isFetchingExchangeRate bool
 	isBalanceHidden        bool
 
-	totalBalanceUSD string
+	totalBalanceFiat string
 
 	activeTab         map[string]string
 	PageNavigationMap map[string]string
@@ -211,7 +211,9 @@ func (swmp *SingleWalletMasterPage) initTabOptions() {
 		values.String(values.StrSettings),
 	}
 
-	if !swmp.selectedWallet.IsWatchingOnlyWallet() {
+	// Watch-only wallets can build unsigned transactions for offline signing.
+	canSend := !swmp.selectedWallet.IsWatchingOnlyWallet() || load.SupportsOfflineSigning(swmp.selectedWallet)
+	if canSend {
 		// Add 'Send' to the tabs for non-watching-only wallets.
 		sendTab := []string{values.String(values.StrSend)}
 		// Insert 'Send' after 'StrInfo'.
@@ -235,7 +237,7 @@ func (swmp *SingleWalletMasterPage) initTabOptions() {
 		insertIndex := 3 // Default position before 'StrAccounts' in the commonTabs.
 
 		// If 'Send' has been added, adjust the insertIndex accordingly.
-		if !swmp.selectedWallet.IsWatchingOnlyWallet() {
+		if canSend {
 			insertIndex++
 		}
 
@@ -258,7 +260,7 @@ func (swmp *SingleWalletMasterPage) isGovernanceAPIAllowed() bool {
 }
 
 func (swmp *SingleWalletMasterPage) updateExchangeSetting() {
-	swmp.usdExchangeSet = false
+	swmp.fiatExchangeSet = false
 	if swmp.AssetsManager.ExchangeRateFetchingEnabled() {
 		go swmp.fetchExchangeRate()
 	}
@@ -270,7 +272,7 @@ func (swmp *SingleWalletMasterPage) fetchExchangeRate() {
 	}
 
 	swmp.isFetchingExchangeRate = true
-	market, err := utils.USDMarketFromAsset(swmp.selectedWallet.GetAssetType())
+	market, err := swmp.AssetsManager.FiatMarket(swmp.selectedWallet.GetAssetType())
 	if err != nil {
 		log.Errorf("Asset type %q is not supported for exchange rate fetching", swmp.selectedWallet.GetAssetType())
 		swmp.isFetchingExchangeRate = false
@@ -283,9 +285,9 @@ func (swmp *SingleWalletMasterPage) fetchExchangeRate() {
 		return
 	}
 
-	swmp.usdExchangeRate = rate.LastTradePrice
+	swmp.fiatExchangeRate = rate.LastTradePrice
 	swmp.updateBalance()
-	swmp.usdExchangeSet = true
+	swmp.fiatExchangeSet = true
 	swmp.ParentWindow().Reload()
 	swmp.isFetchingExchangeRate = false
 }
@@ -297,8 +299,8 @@ func (swmp *SingleWalletMasterPage) updateBalance() {
 		return
 	}
 	swmp.walletBalance = totalBalance.Total
-	balanceInUSD := totalBalance.Total.MulF64(swmp.usdExchangeRate).ToCoin()
-	swmp.totalBalanceUSD = utils.FormatAsUSDString(swmp.Printer, balanceInUSD)
+	balanceInFiat := totalBalance.Total.MulF64(swmp.fiatExchangeRate).ToCoin()
+	swmp.totalBalanceFiat = utils.FormatAsFiatString(swmp.Printer, swmp.AssetsManager.FiatCurrency(), balanceInFiat)
 }
 
 // OnDarkModeChanged is triggered whenever the dark mode setting is changed
@@ -599,7 +601,7 @@ func (swmp *SingleWalletMasterPage) LayoutTopBar(gtx C) D {
 												layout.Rigid(swmp.totalAssetBalance),
 												layout.Rigid(func(gtx C) D {
 													if !swmp.isBalanceHidden {
-														return swmp.LayoutUSDBalance(gtx)
+														return swmp.LayoutFiatBalance(gtx)
 													}
 													return D{}
 												}),
@@ -645,12 +647,12 @@ func (swmp *SingleWalletMasterPage) walletDropdownLayout(gtx C) D {
 	)
 }
 
-func (swmp *SingleWalletMasterPage) LayoutUSDBalance(gtx C) D {
-	if !swmp.usdExchangeSet {
+func (swmp *SingleWalletMasterPage) LayoutFiatBalance(gtx C) D {
+	if !swmp.fiatExchangeSet {
 		return D{}
 	}
 	switch {
-	case swmp.isFetchingExchangeRate && swmp.usdExchangeRate == 0:
+	case swmp.isFetchingExchangeRate && swmp.fiatExchangeRate == 0:
 		gtx.Constraints.Max.Y = gtx.Dp(values.MarginPadding18)
 		gtx.Constraints.Max.X = gtx.Constraints.Max.Y
 		return layout.Inset{
@@ -660,22 +662,22 @@ func (swmp *SingleWalletMasterPage) LayoutUSDBalance(gtx C) D {
 			loader := material.Loader(swmp.Theme.Base)
 			return loader.Layout(gtx)
 		})
-	case !swmp.isFetchingExchangeRate && swmp.usdExchangeRate == 0:
+	case !swmp.isFetchingExchangeRate && swmp.fiatExchangeRate == 0:
 		return layout.Inset{
 			Top:  values.MarginPadding7,
 			Left: values.MarginPadding5,
 		}.Layout(gtx, func(gtx C) D {
 			return swmp.refreshExchangeRateBtn.Layout(gtx, swmp.Theme.NewIcon(swmp.Theme.Icons.NavigationRefresh).Layout16dp)
 		})
-	case len(swmp.totalBalanceUSD) > 0:
+	case len(swmp.totalBalanceFiat) > 0:
 		textSize := values.TextSize20
 		if swmp.Load.IsMobileView() {
 			textSize = values.TextSize16
 		}
-		lbl := swmp.Theme.Label(textSize, fmt.Sprintf("/ %s", swmp.totalBalanceUSD))
+		lbl := swmp.Theme.Label(textSize, fmt.Sprintf("/ %s", swmp.totalBalanceFiat))
 		marginLeft := values.MarginPadding8
 		if swmp.IsMobileView() {
-			lbl = swmp.Theme.Label(textSize, swmp.totalBalanceUSD)
+			lbl = swmp.Theme.Label(textSize, swmp.totalBalanceFiat)
 			marginLeft = 0
 		}
 		lbl.Color = swmp.Theme.Color.PageNavText
@@ -825,6 +827,23 @@ func (swmp *SingleWalletMasterPage) listenForNotifications() {
 		}
 	}
 
+	if dcrW, ok := swmp.selectedWallet.(*dcr.Asset); ok {
+		ticketHealthListener := &dcr.TicketHealthListener{
+			OnTicketHealthIssues: func(_ int, issues []*dcr.TicketHealth) {
+				notification := values.StringF(values.StrTicketsNeedAttention, len(issues))
+				if swmp.AssetsManager.OpenedWalletsCount() > 1 {
+					notification = fmt.Sprintf("[%s] %s", dcrW.GetWalletName(), notification)
+				}
+				initializeBeepNotification(notification)
+			},
+		}
+		err = dcrW.AddTicketHealthListener(ticketHealthListener, MainPageID)
+		if err != nil {
+			log.Errorf("Error adding ticket health listener: %v", err)
+			return
+		}
+	}
+
 	// TODO: Register trade order ntfn listener and post desktop ntfns for all
 	// events except the synced event.
 }
@@ -833,6 +852,9 @@ func (swmp *SingleWalletMasterPage) stopNtfnListeners() {
 	swmp.selectedWallet.RemoveSyncProgressListener(MainPageID)
 	swmp.selectedWallet.RemoveTxAndBlockNotificationListener(MainPageID)
 	swmp.AssetsManager.Politeia.RemoveSyncCallback(MainPageID)
+	if dcrW, ok := swmp.selectedWallet.(*dcr.Asset); ok {
+		dcrW.RemoveTicketHealthListener(MainPageID)
+	}
 }
 
 func (swmp *SingleWalletMasterPage) showBackupInfo() {
@@ -0,0 +1,301 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const UTXOManagementPageID = "UTXOManagement"
+
+// utxoItem holds the widgets of a single unspent output row.
+type utxoItem struct {
+	*sharedW.UnspentOutput
+	accountName string
+	frozen      *sharedW.FrozenOutput
+
+	freezeBtn cryptomaterial.Button
+	editBtn   *cryptomaterial.Clickable
+}
+
+// UTXOManagementPage lists the unspent outputs of a wallet and lets the user
+// freeze, unfreeze and annotate them. Frozen outputs are never selected for
+// automatic sends, ticket purchases or DEX orders.
+type UTXOManagementPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet     sharedW.Asset
+	backButton cryptomaterial.IconButton
+
+	utxoList *widget.List
+	utxos    []*utxoItem
+}
+
+func NewUTXOManagementPage(l *load.Load, wallet sharedW.Asset) *UTXOManagementPage {
+	pg := &UTXOManagementPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(UTXOManagementPageID),
+		wallet:           wallet,
+		utxoList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	pg.backButton = components.GetBackButton(l)
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *UTXOManagementPage) OnNavigatedTo() {
+	go pg.loadUTXOs()
+}
+
+func (pg *UTXOManagementPage) loadUTXOs() {
+	accounts, err := pg.wallet.GetAccountsRaw()
+	if err != nil {
+		log.Errorf("error retrieving wallet accounts: %v", err)
+		return
+	}
+
+	items := make([]*utxoItem, 0)
+	for _, acct := range accounts.Accounts {
+		if utils.IsImportedAccount(pg.wallet.GetAssetType(), acct) {
+			continue
+		}
+
+		unspents, err := pg.wallet.UnspentOutputs(acct.Number)
+		if err != nil {
+			log.Errorf("error listing unspent outputs of account %s: %v", acct.Name, err)
+			continue
+		}
+
+		for _, utxo := range unspents {
+			items = append(items, &utxoItem{
+				UnspentOutput: utxo,
+				accountName:   acct.Name,
+				frozen:        pg.wallet.FrozenOutput(utxo.TxID, utxo.Vout),
+				editBtn:       pg.Theme.NewClickable(false),
+			})
+		}
+	}
+
+	// Show the most recent outputs first.
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ReceiveTime.After(items[j].ReceiveTime)
+	})
+
+	for _, item := range items {
+		pg.setFreezeButton(item)
+	}
+
+	pg.utxos = items
+	pg.ParentWindow().Reload()
+}
+
+func (pg *UTXOManagementPage) setFreezeButton(item *utxoItem) {
+	if item.frozen != nil {
+		item.freezeBtn = pg.Theme.OutlineButton(values.String(values.StrUnfreeze))
+	} else {
+		item.freezeBtn = pg.Theme.OutlineButton(values.String(values.StrFreeze))
+	}
+	item.freezeBtn.Inset = layout.UniformInset(values.MarginPadding6)
+	item.freezeBtn.TextSize = values.TextSizeTransform(pg.IsMobileView(), values.TextSize14)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *UTXOManagementPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrManageUTXOs),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, false, container)
+	}
+	return cryptomaterial.UniformPadding(gtx, container)
+}
+
+func (pg *UTXOManagementPage) layoutContent(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	if len(pg.utxos) == 0 {
+		lbl := pg.Theme.Body1(values.String(values.StrNoUTXOs))
+		lbl.Color = pg.Theme.Color.GrayText3
+		return lbl.Layout(gtx)
+	}
+
+	return pg.Theme.List(pg.utxoList).Layout(gtx, len(pg.utxos), func(gtx C, index int) D {
+		return layout.Inset{Bottom: values.MarginPadding8, Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+					return pg.utxoLayout(gtx, pg.utxos[index])
+				})
+			})
+		})
+	})
+}
+
+func (pg *UTXOManagementPage) utxoLayout(gtx C, item *utxoItem) D {
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Body1(item.Amount.String())
+					lbl.Font.Weight = font.SemiBold
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					outpoint := fmt.Sprintf("%s:%d", item.TxID, item.Vout)
+					lbl := pg.Theme.Body2(outpoint)
+					lbl.Color = pg.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					details := fmt.Sprintf("%s · %s · %d %s", item.accountName, item.Address,
+						item.Confirmations, values.String(values.StrConfirmations))
+					lbl := pg.Theme.Caption(details)
+					lbl.Color = pg.Theme.Color.GrayText3
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if item.frozen == nil {
+						return D{}
+					}
+					text := values.String(values.StrFrozen)
+					if item.frozen.Label != "" {
+						text += ": " + item.frozen.Label
+					}
+					if item.frozen.Reason != "" {
+						text += " (" + item.frozen.Reason + ")"
+					}
+					lbl := pg.Theme.Caption(text)
+					lbl.Color = pg.Theme.Color.Danger
+					return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if item.frozen == nil {
+				return D{}
+			}
+			return layout.Inset{Right: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return item.editBtn.Layout(gtx, pg.Theme.Icons.EditIcon.Layout16dp)
+			})
+		}),
+		layout.Rigid(item.freezeBtn.Layout),
+	)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *UTXOManagementPage) HandleUserInteractions(gtx C) {
+	for _, item := range pg.utxos {
+		if item.freezeBtn.Clicked(gtx) {
+			if item.frozen != nil {
+				pg.unfreezeOutput(item)
+			} else {
+				pg.showFreezeModal(item)
+			}
+		}
+
+		if item.frozen != nil && item.editBtn.Clicked(gtx) {
+			pg.showFreezeModal(item)
+		}
+	}
+}
+
+func (pg *UTXOManagementPage) unfreezeOutput(item *utxoItem) {
+	if err := pg.wallet.UnfreezeOutput(item.TxID, item.Vout); err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+
+	item.frozen = nil
+	pg.setFreezeButton(item)
+}
+
+// showFreezeModal asks for the label and the reason of an output before
+// freezing it. It edits both if the output is already frozen.
+func (pg *UTXOManagementPage) showFreezeModal(item *utxoItem) {
+	labelEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrLabel))
+	labelEditor.Editor.SingleLine = true
+
+	reasonEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrReason))
+	reasonEditor.Editor.SingleLine = false
+
+	title := values.String(values.StrFreezeOutput)
+	if item.frozen != nil {
+		title = values.String(values.StrEdit)
+		labelEditor.Editor.SetText(item.frozen.Label)
+		reasonEditor.Editor.SetText(item.frozen.Reason)
+	}
+
+	freezeModal := modal.NewCustomModal(pg.Load).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, labelEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}.Layout(gtx, reasonEditor.Layout)
+				}),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			err := pg.wallet.FreezeOutput(item.TxID, item.Vout, labelEditor.Editor.Text(), reasonEditor.Editor.Text())
+			if err != nil {
+				labelEditor.SetError(err.Error())
+				return false
+			}
+
+			item.frozen = pg.wallet.FrozenOutput(item.TxID, item.Vout)
+			pg.setFreezeButton(item)
+			pg.ParentWindow().Reload()
+			return true
+		})
+	pg.ParentWindow().ShowModal(freezeModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *UTXOManagementPage) OnNavigatedFrom() {}
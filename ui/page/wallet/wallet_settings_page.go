@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 
 	"decred.org/dcrdex/dex"
 	"gioui.org/font"
+	"gioui.org/io/clipboard"
 	"gioui.org/layout"
 	"gioui.org/widget"
 
@@ -59,18 +61,24 @@ type SettingsPage struct {
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	signOfflineTx, broadcastOfflineTx          *cryptomaterial.Clickable
+	manageUTXOs, exportLabels, importLabels    *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
 
 	spendUnconfirmed  *cryptomaterial.Switch
 	spendUnmixedFunds *cryptomaterial.Switch
+	rbfSignaling      *cryptomaterial.Switch
 	connectToPeer     *cryptomaterial.Switch
 
 	walletCallbackFunc func()
 	changeTab          func(string)
 
 	peerAddr string
+	// psbtToCopy holds a signed PSBT waiting to be written to the clipboard
+	// on the next frame.
+	psbtToCopy string
 }
 
 func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func(), changeTab func(string)) *SettingsPage {
@@ -92,9 +100,15 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		validateAddr:        l.Theme.NewClickable(false),
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
+		signOfflineTx:       l.Theme.NewClickable(false),
+		broadcastOfflineTx:  l.Theme.NewClickable(false),
+		manageUTXOs:         l.Theme.NewClickable(false),
+		exportLabels:        l.Theme.NewClickable(false),
+		importLabels:        l.Theme.NewClickable(false),
 
 		spendUnconfirmed:  l.Theme.Switch(),
 		spendUnmixedFunds: l.Theme.Switch(),
+		rbfSignaling:      l.Theme.Switch(),
 		connectToPeer:     l.Theme.Switch(),
 
 		pageContainer: &widget.List{
@@ -117,6 +131,7 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 func (pg *SettingsPage) OnNavigatedTo() {
 	pg.spendUnconfirmed.SetChecked(pg.readBool(sharedW.SpendUnconfirmedConfigKey))
 	pg.spendUnmixedFunds.SetChecked(pg.readBool(sharedW.SpendUnmixedFundsKey))
+	pg.rbfSignaling.SetChecked(pg.readBool(sharedW.RBFSignalingConfigKey))
 
 	pg.loadPeerAddress()
 
@@ -190,6 +205,9 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 				}
 				return layout.Inset{}.Layout(gtx, pg.sectionContent(pg.viewSeed, values.String(values.StrExportWalletSeed)))
 			}),
+			layout.Rigid(pg.sectionContent(pg.manageUTXOs, values.String(values.StrManageUTXOs))),
+			layout.Rigid(pg.sectionContent(pg.exportLabels, values.String(values.StrExportLabels))),
+			layout.Rigid(pg.sectionContent(pg.importLabels, values.String(values.StrImportLabels))),
 			layout.Rigid(func(gtx C) D {
 				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
 					return pg.subSection(gtx, values.String(values.StrUnconfirmedFunds), pg.spendUnconfirmed.Layout)
@@ -202,6 +220,12 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 				}
 				return D{}
 			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset || pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.subSection(gtx, values.String(values.StrRBFSignaling), pg.rbfSignaling.Layout)
+			}),
 			layout.Rigid(func(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(pg.subSectionSwitch(values.String(values.StrConnectToSpecificPeer), pg.connectToPeer)),
@@ -252,6 +276,20 @@ func (pg *SettingsPage) securityTools() layout.Widget {
 			layout.Rigid(pg.sectionContent(pg.verifyMessage, values.String(values.StrVerifyMessage))),
 			layout.Rigid(pg.sectionContent(pg.validateAddr, values.String(values.StrValidateMsg))),
 			layout.Rigid(pg.sectionContent(pg.signMessage, values.String(values.StrSignMessage))),
+			layout.Rigid(func(gtx C) D {
+				if !load.SupportsOfflineSigning(pg.wallet) || pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				title, _ := pg.offlineTxStrings(true)
+				return pg.sectionDimension(gtx, pg.signOfflineTx, title)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !load.SupportsOfflineSigning(pg.wallet) {
+					return D{}
+				}
+				title, _ := pg.offlineTxStrings(false)
+				return pg.sectionDimension(gtx, pg.broadcastOfflineTx, title)
+			}),
 		)
 	}
 	return func(gtx C) D {
@@ -371,6 +409,25 @@ func (pg *SettingsPage) subSectionSwitch(title string, option *cryptomaterial.Sw
 	}
 }
 
+func (pg *SettingsPage) importLabelsModal() {
+	importModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrLabelsFileHint)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(fileName string, tim *modal.TextInputModal) bool {
+			count, err := pg.AssetsManager.ImportLabels(pg.wallet, strings.TrimSpace(fileName))
+			if err != nil {
+				tim.SetError(err.Error())
+				return false
+			}
+
+			pg.Toast.Notify(values.StringF(values.StrLabelsImported, count))
+			return true
+		})
+	importModal.Title(values.String(values.StrImportLabels)).
+		SetPositiveButtonText(values.String(values.StrImport))
+	pg.ParentWindow().ShowModal(importModal)
+}
+
 func (pg *SettingsPage) changeSpendingPasswordModal() {
 	var currentPassword, dexPass string
 	// New wallet password modal.
@@ -717,6 +774,10 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.wallet.SaveUserConfigValue(sharedW.SpendUnconfirmedConfigKey, pg.spendUnconfirmed.IsChecked())
 	}
 
+	if pg.rbfSignaling.Changed(gtx) {
+		pg.wallet.SaveUserConfigValue(sharedW.RBFSignalingConfigKey, pg.rbfSignaling.IsChecked())
+	}
+
 	if pg.spendUnmixedFunds.Changed(gtx) {
 		if pg.spendUnmixedFunds.IsChecked() {
 			textModal := modal.NewTextInputModal(pg.Load).
@@ -779,10 +840,43 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(security.NewSignMessagePage(pg.Load, pg.wallet))
 	}
 
+	if pg.signOfflineTx.Clicked(gtx) {
+		pg.signOfflineTxModal()
+	}
+
+	if pg.broadcastOfflineTx.Clicked(gtx) {
+		pg.broadcastOfflineTxModal()
+	}
+
+	if pg.psbtToCopy != "" {
+		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(pg.psbtToCopy))})
+		pg.psbtToCopy = ""
+	}
+
 	if pg.checklog.Clicked(gtx) {
 		pg.ParentNavigator().Display(s.NewLogPage(pg.Load, pg.wallet.LogFile(), values.String(values.StrWalletLog)))
 	}
 
+	if pg.manageUTXOs.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewUTXOManagementPage(pg.Load, pg.wallet))
+	}
+
+	if pg.exportLabels.Clicked(gtx) {
+		go func() {
+			fileName, err := pg.AssetsManager.ExportLabels(pg.wallet)
+			if err != nil {
+				pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+				return
+			}
+			info := modal.NewSuccessModal(pg.Load, values.StringF(values.StrLabelsExported, fileName), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(info)
+		}()
+	}
+
+	if pg.importLabels.Clicked(gtx) {
+		pg.importLabelsModal()
+	}
+
 	if pg.checkStats.Clicked(gtx) {
 		pg.ParentNavigator().Display(s.NewStatPage(pg.Load, pg.wallet))
 	}
@@ -813,6 +907,103 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 	}
 }
 
+// offlineTxStrings returns the title and input hint of the offline signing
+// tools. BTC and LTC exchange PSBTs, DCR uses its own transaction file.
+func (pg *SettingsPage) offlineTxStrings(sign bool) (string, string) {
+	if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+		if sign {
+			return values.String(values.StrSignTxFile), values.String(values.StrTxFileHint)
+		}
+		return values.String(values.StrBroadcastTxFile), values.String(values.StrTxFileHint)
+	}
+	if sign {
+		return values.String(values.StrSignPSBT), values.String(values.StrPSBTHint)
+	}
+	return values.String(values.StrBroadcastPSBT), values.String(values.StrPSBTHint)
+}
+
+// signOfflineTxModal asks for an exported transaction, shows its destinations
+// and fee for the user to confirm, then asks for the spending password, signs
+// the inputs owned by this wallet and saves the result for the online wallet
+// to broadcast.
+func (pg *SettingsPage) signOfflineTxModal() {
+	title, hint := pg.offlineTxStrings(true)
+	txModal := newOfflineTxModal(pg.Load, pg.wallet, title, hint, values.String(values.StrNext), func(tx string, m *offlineTxModal) bool {
+		summary, err := load.ReviewOfflineTx(pg.wallet, tx)
+		if err != nil {
+			m.SetError(err.Error())
+			return false
+		}
+
+		reviewModal := newOfflineTxReviewModal(pg.Load, pg.wallet, summary, func() {
+			pg.signReviewedOfflineTx(title, tx, summary.TxHash)
+		})
+		pg.ParentWindow().ShowModal(reviewModal)
+		return true
+	})
+	pg.ParentWindow().ShowModal(txModal)
+}
+
+// signReviewedOfflineTx asks for the spending password and signs the
+// transaction if it is still the one reviewed with txHash.
+func (pg *SettingsPage) signReviewedOfflineTx(title, tx, txHash string) {
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(title).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				psbt, path, err := load.SignOfflineTx(pg.wallet, tx, txHash, password)
+				if err != nil && psbt == "" {
+					pm.SetError(err.Error())
+					pm.SetLoading(false)
+					return
+				}
+				pm.Dismiss()
+
+				body := values.StringF(values.StrSignedTxSaved, path)
+				if psbt != "" {
+					if err != nil {
+						log.Errorf("saving psbt file failed: %v", err)
+						body = values.String(values.StrPSBTCopied)
+					} else {
+						body = values.StringF(values.StrPSBTSaved, path)
+					}
+					pg.psbtToCopy = psbt
+				}
+
+				info := modal.NewSuccessModal(pg.Load, values.String(values.StrTxSigned), modal.DefaultClickFunc()).
+					Body(body)
+				pg.ParentWindow().ShowModal(info)
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// broadcastOfflineTxModal asks for a transaction signed by an offline wallet,
+// verifies it and broadcasts it.
+func (pg *SettingsPage) broadcastOfflineTxModal() {
+	title, hint := pg.offlineTxStrings(false)
+	txModal := newOfflineTxModal(pg.Load, pg.wallet, title, hint, values.String(values.StrSend), func(tx string, m *offlineTxModal) bool {
+		if !pg.wallet.IsConnectedToNetwork() {
+			m.SetError(values.String(values.StrNotConnected))
+			return false
+		}
+
+		txHash, err := load.PublishOfflineTx(pg.wallet, tx, "")
+		if err != nil {
+			m.SetError(err.Error())
+			return false
+		}
+
+		info := modal.NewSuccessModal(pg.Load, values.String(values.StrTxSent), modal.DefaultClickFunc()).
+			Body(txHash)
+		pg.ParentWindow().ShowModal(info)
+		return true
+	})
+	pg.ParentWindow().ShowModal(txModal)
+}
+
 func (pg *SettingsPage) gapLimitModal() {
 	walGapLim := pg.wallet.ReadStringConfigValueForKey(load.GapLimitConfigKey, "20")
 	textModal := modal.NewTextInputModal(pg.Load).
@@ -10,6 +10,7 @@ import (
 	"gioui.org/widget"
 
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -42,6 +43,7 @@ var (
 		{Key: values.Coinpaprika, Value: values.StrUsdCoinpaprika},
 		{Key: values.Messari, Value: values.StrUsdMessari},
 		{Key: values.KucoinExchange, Value: values.StrUsdKucoin, Warning: values.String(values.StrRateKucoinWarning), WarningLink: kucoinProhibitedCountries},
+		{Key: values.AggregateExchange, Value: values.StrUsdAggregate},
 		{Key: values.DefaultExchangeValue, Value: values.StrNone},
 	}
 
@@ -52,6 +54,10 @@ var (
 		{Key: localizable.SPANISH, Value: values.StrSpanish},
 	}
 
+	// FiatCurrencyOptions are the fiat currencies balances and rates can be
+	// shown in.
+	FiatCurrencyOptions = fiatCurrencyOptions()
+
 	// LogOptions are the selectable debug levels.
 	LogOptions = []ItemPreference{
 		{Key: libutils.LogLevelTrace, Value: values.StrLogLevelTrace},
@@ -63,6 +69,14 @@ var (
 	}
 )
 
+func fiatCurrencyOptions() []ItemPreference {
+	options := make([]ItemPreference, 0, len(ext.SupportedFiatCurrencies))
+	for _, currency := range ext.SupportedFiatCurrencies {
+		options = append(options, ItemPreference{Key: currency, Value: currency})
+	}
+	return options
+}
+
 type ListPreferenceModal struct {
 	*load.Load
 	*cryptomaterial.Modal
@@ -127,6 +141,8 @@ func (lp *ListPreferenceModal) ReadPreferenceKeyedValue() string {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		return lp.AssetsManager.GetCurrencyConversionExchange()
+	case sharedW.FiatCurrencyConfigKey:
+		return lp.AssetsManager.FiatCurrency()
 	case sharedW.LanguagePreferenceKey:
 		return lp.AssetsManager.GetLanguagePreference()
 	case sharedW.LogLevelConfigKey:
@@ -141,6 +157,8 @@ func (lp *ListPreferenceModal) SavePreferenceKeyedValue() {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		lp.AssetsManager.SetCurrencyConversionExchange(val)
+	case sharedW.FiatCurrencyConfigKey:
+		lp.AssetsManager.SetFiatCurrency(val)
 	case sharedW.LanguagePreferenceKey:
 		// TODO: We should be able to update dex core's language when the user
 		// changes language.
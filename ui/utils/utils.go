@@ -18,7 +18,6 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
-	"github.com/crypto-power/cryptopower/ui/values"
 
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -81,16 +80,45 @@ func BreakBalance(p *message.Printer, balance string) (b1, b2 string) {
 	return
 }
 
-func FormatAsUSDString(p *message.Printer, usdAmt float64) string {
-	return p.Sprintf("$%.2f", usdAmt)
+// fiatSymbols are the symbols of the fiat currencies that are shown in place
+// of the currency code.
+var fiatSymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"BRL": "R$",
 }
 
-func CryptoToUSD(exchangeRate, coin float64) float64 {
+// FormatAsFiatString formats the amount in the fiat currency, e.g. $1.00 or
+// NGN 1.00.
+func FormatAsFiatString(p *message.Printer, currency string, fiatAmt float64) string {
+	return FormatAsFiatStringWithPrecision(p, currency, fiatAmt, 2)
+}
+
+// FormatAsFiatStringWithPrecision formats the amount in the fiat currency with
+// precision decimal places.
+func FormatAsFiatStringWithPrecision(p *message.Printer, currency string, fiatAmt float64, precision int) string {
+	if symbol, ok := fiatSymbols[currency]; ok {
+		return p.Sprintf("%s%.*f", symbol, precision, fiatAmt)
+	}
+	return p.Sprintf("%s %.*f", currency, precision, fiatAmt)
+}
+
+// FiatPlaceholder is shown in place of a fiat amount that is not available.
+func FiatPlaceholder(currency string) string {
+	if symbol, ok := fiatSymbols[currency]; ok {
+		return symbol + "--"
+	}
+	return currency + " --"
+}
+
+func CryptoToFiat(exchangeRate, coin float64) float64 {
 	return coin * exchangeRate
 }
 
-func USDToDCR(exchangeRate, usd float64) float64 {
-	return usd / exchangeRate
+func FiatToCrypto(exchangeRate, fiat float64) float64 {
+	return fiat / exchangeRate
 }
 
 func ComputePasswordStrength(pb *cryptomaterial.ProgressBarStyle, th *cryptomaterial.Theme, editors ...*widget.Editor) {
@@ -156,19 +184,6 @@ func RadiusLayout(gtx layout.Context, radius int, w layout.Widget) layout.Dimens
 	return dims
 }
 
-func USDMarketFromAsset(asset utils.AssetType) (values.Market, error) {
-	switch asset {
-	case utils.DCRWalletAsset:
-		return values.DCRUSDTMarket, nil
-	case utils.BTCWalletAsset:
-		return values.BTCUSDTMarket, nil
-	case utils.LTCWalletAsset:
-		return values.LTCUSDTMarket, nil
-	default:
-		return values.UnknownMarket, fmt.Errorf("unsupported asset type: %s", asset)
-	}
-}
-
 func IsImportedAccount(assetType libutils.AssetType, acc *sharedW.Account) bool {
 	switch assetType {
 	case libutils.BTCWalletAsset:
@@ -10,6 +10,7 @@ const (
 	Coinpaprika          = "coinpaprika"
 	Messari              = "messari"
 	KucoinExchange       = "kucoin"
+	AggregateExchange    = "aggregate"
 )
 
 // initialize an asset market value map
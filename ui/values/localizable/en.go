@@ -956,4 +956,177 @@ const EN = `
 "privacy" = "Privacy"
 "removeRecipient" = "Remove recipient"
 "removeRecipientWarning" = "Are you sure you want to proceed with removing the recipient?"
+"paymentURIWrongAsset" = "This payment request is for %s, not %s"
+"invalidPaymentURI" = "Invalid payment request: %v"
+"destAddrOrPaymentURI" = "Destination address or payment URI"
+"pastePaymentURI" = "Paste a payment URI to fill in the amount"
+"pasteOrScanPaymentURI" = "Paste or scan a payment URI to fill in the amount"
+"scanQRCode" = "Scan QR code"
+"scanQRCodeHint" = "Point the camera at a payment request QR code"
+"allowCameraAccess" = "Allow camera access to scan QR codes"
+"cameraUnavailable" = "The camera could not be started"
+"requestAmount" = "Request amount (optional)"
+"rbfSignaling" = "Signal replace-by-fee"
+"speedUp" = "Speed up"
+"speedUpTransaction" = "Speed up transaction"
+"newFeeRate" = "New fee rate (%s)"
+"invalidFeeRate" = "Fee rate must be a whole number above %d %s"
+"txSpedUp" = "Transaction fee bumped"
+"exportUnsignedTx" = "Export unsigned"
+"unsignedTxExported" = "Unsigned transaction exported"
+"psbtCopied" = "The PSBT has been copied to the clipboard."
+"psbtSaved" = "The PSBT has been copied to the clipboard and saved to %s"
+"signPSBT" = "Sign PSBT"
+"broadcastPSBT" = "Broadcast PSBT"
+"psbtHint" = "Base64 PSBT"
+"txSigned" = "Transaction signed"
+"unsignedTxSaved" = "Sign the transaction file saved to %s with an offline wallet."
+"signedTxSaved" = "The signed transaction has been saved to %s"
+"signTxFile" = "Sign transaction file"
+"broadcastTxFile" = "Broadcast signed transaction"
+"txFileHint" = "Transaction file contents"
+"csv" = "CSV"
+"json" = "JSON"
+"includeFiatValue" = "Include the fiat value at the time of each transaction"
+"addressBook" = "Address book"
+"addContact" = "Add contact"
+"editContact" = "Edit contact"
+"selectContact" = "Select contact"
+"noContacts" = "No contacts in your address book yet"
+"contactAddresses" = "Addresses (one per line)"
+"deleteContactConfirm" = "Remove %s from your address book?"
+"addressBookExported" = "Your address book has been saved to %s"
+"addressBookImported" = "%d contact(s) imported"
+"addressBookFileHint" = "Address book file path"
+"manageUTXOs" = "Manage unspent outputs"
+"freeze" = "Freeze"
+"unfreeze" = "Unfreeze"
+"frozen" = "Frozen"
+"freezeOutput" = "Freeze output"
+"label" = "Label"
+"reason" = "Reason"
+"addLabel" = "Add label"
+"editLabel" = "Edit label"
+"exportLabels" = "Export labels (BIP329)"
+"importLabels" = "Import labels (BIP329)"
+"labelsExported" = "The wallet labels have been saved to %s"
+"labelsImported" = "%d label(s) imported"
+"labelsFileHint" = "BIP329 labels file path"
+"batchPayment" = "Batch payment"
+"batchPaymentInfo" = "Pay every recipient of a CSV file from the %s account in a single transaction. Each row holds an address, an amount and an optional label."
+"batchFileHint" = "CSV file path"
+"batchHasErrors" = "Fix the rows below and import the file again"
+"batchRecipients" = "Recipients"
+"batchSendConfirm" = "Send %d payments for a total of %s?"
+"ticketBuyerLog" = "Ticket buyer activity since the app started"
+"purchaseLimits" = "Purchase limits (optional)"
+"maxTicketsPerInterval" = "Max tickets per interval"
+"maxTicketsPerDay" = "Max tickets per day"
+"maxTicketPrice" = "Max ticket price (DCR)"
+"maxVSPFee" = "Max VSP fee (%)"
+"minVSPUptime" = "Min VSP uptime (%)"
+"fallbackVSPs" = "Fallback VSPs, one per line (optional)"
+"invalidVSPHost" = "Invalid VSP host: %s"
+"ticketHealth" = "Tickets needing attention"
+"reregister" = "Re-register"
+"reregisterTicket" = "Re-register ticket"
+"reregisterTicketInfo" = "Select another VSP for ticket %s. A new VSP fee will be paid."
+"ticketReregistered" = "Ticket re-registered"
+"ticketFeeErrored" = "VSP fee payment to %s failed"
+"ticketVSPUnreachable" = "VSP %s is unreachable"
+"ticketVoteChoicesMismatch" = "VSP %s does not have your vote choices"
+"ticketsNeedAttention" = "%d ticket(s) may miss their vote, check the staking page"
+"ticketFeesNeedUnlock" = "Failed VSP fee payments are only retried while the wallet is unlocked, e.g. while the ticket buyer is running."
+"retryTicketFees" = "Retry fee payments"
+"soloStaking" = "Solo staking"
+"soloStakingInfo" = "Tickets keep their voting rights in this wallet and no VSP fee is paid. Winning tickets are learned from your own dcrd node."
+"dcrdRPCHost" = "dcrd RPC host (host:port)"
+"dcrdRPCUser" = "dcrd RPC user"
+"dcrdRPCPass" = "dcrd RPC password"
+"dcrdRPCCert" = "dcrd RPC certificate file"
+"dcrdRPCNoTLS" = "Connect without TLS (dcrd on this machine only)"
+"soloVoting" = "Solo voting"
+"soloVotingWarning" = "Solo tickets only vote while this app is running, unlocked and connected to dcrd. Votes are missed whenever the app is offline."
+"startSoloVoting" = "Start solo voting"
+"stakingAnalytics" = "Staking analytics"
+"rewardsByMonth" = "Rewards by month"
+"roiByVSP" = "ROI by VSP (%)"
+"ticketsCost" = "Tickets cost"
+"netProfit" = "Net profit"
+"roi" = "ROI"
+"annualizedROI" = "Annualized ROI"
+"avgDaysToVote" = "Average days to vote"
+"exportStakingSuccess" = "Staking analytics exported to %s"
+"comments" = "Comments (%d)"
+"noComments" = "No comments yet"
+"commentDeleted" = "Comment deleted: %s"
+"commentsLoadFailed" = "Unable to load the latest comments"
+"watchlist" = "Watchlist"
+"watchProposal" = "Add to watchlist"
+"unwatchProposal" = "Remove from watchlist"
+"watchedVoteStartedNotif" = "Voting has started on watched proposal: %s"
+"watchedVoteEndingNotif" = "Voting on %s ends soon and %d of your eligible tickets have not voted"
+"watchedVoteFinishedNotif" = "Voting has ended on watched proposal %s: %s"
+"voteWithAllWallets" = "Vote with all wallets"
+"yesVotesPercent" = "Yes votes (%)"
+"voteSplit" = "%d yes / %d no"
+"walletTickets" = "%s: %d eligible tickets"
+"noEligibleTickets" = "None of your wallets has tickets eligible to vote on this proposal"
+"votesSent" = "%d yes / %d no votes sent"
+"invalidPercentage" = "Enter a percentage from 0 to 100"
+"treasurySpends" = "Treasury spends"
+"noTSpends" = "There are no treasury spends to show"
+"tspendExpiry" = "Expires at block"
+"tspendMinedIn" = "Mined in block"
+"payouts" = "Payouts"
+"linkedProposals" = "Linked proposals"
+"setTSpendPolicy" = "Set tspend vote"
+"voteTally" = "Vote tally"
+"voteTallyPercentages" = "Yes %.1f%% · No %.1f%% · Abstain %.1f%%"
+"approvalHistory" = "Approval over time"
+"projectedLockIn" = "Projected to lock in on %s"
+"projectedFail" = "Projected to fail on %s"
+"mixerSchedule" = "Mixing schedule"
+"mixStartHour" = "Start hour (0-23)"
+"mixEndHour" = "End hour (0-23)"
+"mixOnlyOnACPower" = "Only mix while on AC power"
+"mixMaxRounds" = "Stop after rounds (0 for no limit)"
+"mixStopWhenEmpty" = "Stop when the unmixed account is empty"
+"invalidMixerSchedule" = "Hours must be from 0 to 23 and rounds cannot be negative"
+"mixerStats" = "Mixing statistics"
+"roundsCompleted" = "Rounds completed"
+"amountMixed" = "Amount mixed"
+"remainingUnmixed" = "Remaining unmixed"
+"mixDenominationOutputs" = "%s outputs (x%d)"
+"mixerScheduleSummary" = "Mixes from %02d:00 to %02d:00"
+"proxy" = "Proxy"
+"proxyHost" = "Proxy address (host:port)"
+"proxyUsername" = "Username (optional)"
+"proxyPassword" = "Password (optional)"
+"proxyTorMode" = "Tor mode (isolate wallets and resolve names through Tor)"
+"proxyOnly" = "Refuse connections that cannot use the proxy"
+"invalidProxyHost" = "Enter the proxy address as host:port"
+"proxyRestartNote" = "Applies to new connections. Restart the app for the DEX to use it."
+"proxyOff" = "Off"
+"fiatCurrency" = "Fiat currency"
+"portfolioValue" = "Portfolio value"
+"range7D" = "7D"
+"range30D" = "30D"
+"range1Y" = "1Y"
+"usdAggregate" = "USD (Multiple sources)"
+"blockExplorers" = "Block explorers"
+"publicExplorers" = "Public"
+"selfHostedExplorers" = "Self-hosted"
+"dcrdataURL" = "dcrdata URL"
+"blockBookURL" = "Decred BlockBook URL"
+"btcEsploraURL" = "Bitcoin Esplora URL"
+"ltcEsploraURL" = "Litecoin Esplora URL"
+"blockExplorersNote" = "These explorers are used instead of the public ones, leave a URL empty to use the public explorer. dcrdata serves Decred network data and transaction links. While dcrdata is set, Decred address and xpub lookups only use BlockBook and fail without it. Bitcoin and Litecoin wallets sync over SPV, so Esplora or mempool.space instances only serve fee rates and transaction links."
+"checkingExplorers" = "Checking the explorers..."
+"offlineTxFromFile" = "From file"
+"offlineTxPaste" = "Paste"
+"offlineTxPathHint" = "File path"
+"reviewOfflineTx" = "Review transaction"
+"ownAddress" = "This wallet"
+"confirmOfflineTx" = "I have checked the destinations, amounts and fee"
 `
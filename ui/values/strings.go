@@ -1065,4 +1065,177 @@ const (
 	StrPrivacy                               = "privacy"
 	StrRemoveRecipient                       = "removeRecipient"
 	StrRemoveRecipientWarning                = "removeRecipientWarning"
+	StrPaymentURIWrongAsset                  = "paymentURIWrongAsset"
+	StrInvalidPaymentURI                     = "invalidPaymentURI"
+	StrDestAddrOrPaymentURI                  = "destAddrOrPaymentURI"
+	StrPastePaymentURI                       = "pastePaymentURI"
+	StrPasteOrScanPaymentURI                 = "pasteOrScanPaymentURI"
+	StrScanQRCode                            = "scanQRCode"
+	StrScanQRCodeHint                        = "scanQRCodeHint"
+	StrAllowCameraAccess                     = "allowCameraAccess"
+	StrCameraUnavailable                     = "cameraUnavailable"
+	StrRequestAmount                         = "requestAmount"
+	StrRBFSignaling                          = "rbfSignaling"
+	StrSpeedUp                               = "speedUp"
+	StrSpeedUpTransaction                    = "speedUpTransaction"
+	StrNewFeeRate                            = "newFeeRate"
+	StrInvalidFeeRate                        = "invalidFeeRate"
+	StrTxSpedUp                              = "txSpedUp"
+	StrExportUnsignedTx                      = "exportUnsignedTx"
+	StrUnsignedTxExported                    = "unsignedTxExported"
+	StrPSBTCopied                            = "psbtCopied"
+	StrPSBTSaved                             = "psbtSaved"
+	StrSignPSBT                              = "signPSBT"
+	StrBroadcastPSBT                         = "broadcastPSBT"
+	StrPSBTHint                              = "psbtHint"
+	StrTxSigned                              = "txSigned"
+	StrUnsignedTxSaved                       = "unsignedTxSaved"
+	StrSignedTxSaved                         = "signedTxSaved"
+	StrSignTxFile                            = "signTxFile"
+	StrBroadcastTxFile                       = "broadcastTxFile"
+	StrTxFileHint                            = "txFileHint"
+	StrCSV                                   = "csv"
+	StrJSON                                  = "json"
+	StrIncludeFiatValue                      = "includeFiatValue"
+	StrAddressBook                           = "addressBook"
+	StrAddContact                            = "addContact"
+	StrEditContact                           = "editContact"
+	StrSelectContact                         = "selectContact"
+	StrNoContacts                            = "noContacts"
+	StrContactAddresses                      = "contactAddresses"
+	StrDeleteContactConfirm                  = "deleteContactConfirm"
+	StrAddressBookExported                   = "addressBookExported"
+	StrAddressBookImported                   = "addressBookImported"
+	StrAddressBookFileHint                   = "addressBookFileHint"
+	StrManageUTXOs                           = "manageUTXOs"
+	StrFreeze                                = "freeze"
+	StrUnfreeze                              = "unfreeze"
+	StrFrozen                                = "frozen"
+	StrFreezeOutput                          = "freezeOutput"
+	StrLabel                                 = "label"
+	StrReason                                = "reason"
+	StrAddLabel                              = "addLabel"
+	StrEditLabel                             = "editLabel"
+	StrExportLabels                          = "exportLabels"
+	StrImportLabels                          = "importLabels"
+	StrLabelsExported                        = "labelsExported"
+	StrLabelsImported                        = "labelsImported"
+	StrLabelsFileHint                        = "labelsFileHint"
+	StrBatchPayment                          = "batchPayment"
+	StrBatchPaymentInfo                      = "batchPaymentInfo"
+	StrBatchFileHint                         = "batchFileHint"
+	StrBatchHasErrors                        = "batchHasErrors"
+	StrBatchRecipients                       = "batchRecipients"
+	StrBatchSendConfirm                      = "batchSendConfirm"
+	StrTicketBuyerLog                        = "ticketBuyerLog"
+	StrPurchaseLimits                        = "purchaseLimits"
+	StrMaxTicketsPerInterval                 = "maxTicketsPerInterval"
+	StrMaxTicketsPerDay                      = "maxTicketsPerDay"
+	StrMaxTicketPrice                        = "maxTicketPrice"
+	StrMaxVSPFee                             = "maxVSPFee"
+	StrMinVSPUptime                          = "minVSPUptime"
+	StrFallbackVSPs                          = "fallbackVSPs"
+	StrInvalidVSPHost                        = "invalidVSPHost"
+	StrTicketHealth                          = "ticketHealth"
+	StrReregister                            = "reregister"
+	StrReregisterTicket                      = "reregisterTicket"
+	StrReregisterTicketInfo                  = "reregisterTicketInfo"
+	StrTicketReregistered                    = "ticketReregistered"
+	StrTicketFeeErrored                      = "ticketFeeErrored"
+	StrTicketVSPUnreachable                  = "ticketVSPUnreachable"
+	StrTicketVoteChoicesMismatch             = "ticketVoteChoicesMismatch"
+	StrTicketsNeedAttention                  = "ticketsNeedAttention"
+	StrTicketFeesNeedUnlock                  = "ticketFeesNeedUnlock"
+	StrRetryTicketFees                       = "retryTicketFees"
+	StrSoloStaking                           = "soloStaking"
+	StrSoloStakingInfo                       = "soloStakingInfo"
+	StrDcrdRPCHost                           = "dcrdRPCHost"
+	StrDcrdRPCUser                           = "dcrdRPCUser"
+	StrDcrdRPCPass                           = "dcrdRPCPass"
+	StrDcrdRPCCert                           = "dcrdRPCCert"
+	StrDcrdRPCNoTLS                          = "dcrdRPCNoTLS"
+	StrSoloVoting                            = "soloVoting"
+	StrSoloVotingWarning                     = "soloVotingWarning"
+	StrStartSoloVoting                       = "startSoloVoting"
+	StrStakingAnalytics                      = "stakingAnalytics"
+	StrRewardsByMonth                        = "rewardsByMonth"
+	StrROIByVSP                              = "roiByVSP"
+	StrTicketsCost                           = "ticketsCost"
+	StrNetProfit                             = "netProfit"
+	StrROI                                   = "roi"
+	StrAnnualizedROI                         = "annualizedROI"
+	StrAvgDaysToVote                         = "avgDaysToVote"
+	StrExportStakingSuccess                  = "exportStakingSuccess"
+	StrComments                              = "comments"
+	StrNoComments                            = "noComments"
+	StrCommentDeleted                        = "commentDeleted"
+	StrCommentsLoadFailed                    = "commentsLoadFailed"
+	StrWatchlist                             = "watchlist"
+	StrWatchProposal                         = "watchProposal"
+	StrUnwatchProposal                       = "unwatchProposal"
+	StrWatchedVoteStartedNotif               = "watchedVoteStartedNotif"
+	StrWatchedVoteEndingNotif                = "watchedVoteEndingNotif"
+	StrWatchedVoteFinishedNotif              = "watchedVoteFinishedNotif"
+	StrVoteWithAllWallets                    = "voteWithAllWallets"
+	StrYesVotesPercent                       = "yesVotesPercent"
+	StrVoteSplit                             = "voteSplit"
+	StrWalletTickets                         = "walletTickets"
+	StrNoEligibleTickets                     = "noEligibleTickets"
+	StrVotesSent                             = "votesSent"
+	StrInvalidPercentage                     = "invalidPercentage"
+	StrTreasurySpends                        = "treasurySpends"
+	StrNoTSpends                             = "noTSpends"
+	StrTSpendExpiry                          = "tspendExpiry"
+	StrTSpendMinedIn                         = "tspendMinedIn"
+	StrPayouts                               = "payouts"
+	StrLinkedProposals                       = "linkedProposals"
+	StrSetTSpendPolicy                       = "setTSpendPolicy"
+	StrVoteTally                             = "voteTally"
+	StrVoteTallyPercentages                  = "voteTallyPercentages"
+	StrApprovalHistory                       = "approvalHistory"
+	StrProjectedLockIn                       = "projectedLockIn"
+	StrProjectedFail                         = "projectedFail"
+	StrMixerSchedule                         = "mixerSchedule"
+	StrMixStartHour                          = "mixStartHour"
+	StrMixEndHour                            = "mixEndHour"
+	StrMixOnlyOnACPower                      = "mixOnlyOnACPower"
+	StrMixMaxRounds                          = "mixMaxRounds"
+	StrMixStopWhenEmpty                      = "mixStopWhenEmpty"
+	StrInvalidMixerSchedule                  = "invalidMixerSchedule"
+	StrMixerStats                            = "mixerStats"
+	StrRoundsCompleted                       = "roundsCompleted"
+	StrAmountMixed                           = "amountMixed"
+	StrRemainingUnmixed                      = "remainingUnmixed"
+	StrMixDenominationOutputs                = "mixDenominationOutputs"
+	StrMixerScheduleSummary                  = "mixerScheduleSummary"
+	StrProxy                                 = "proxy"
+	StrProxyHost                             = "proxyHost"
+	StrProxyUsername                         = "proxyUsername"
+	StrProxyPassword                         = "proxyPassword"
+	StrProxyTorMode                          = "proxyTorMode"
+	StrProxyOnly                             = "proxyOnly"
+	StrInvalidProxyHost                      = "invalidProxyHost"
+	StrProxyRestartNote                      = "proxyRestartNote"
+	StrProxyOff                              = "proxyOff"
+	StrFiatCurrency                          = "fiatCurrency"
+	StrPortfolioValue                        = "portfolioValue"
+	StrRange7D                               = "range7D"
+	StrRange30D                              = "range30D"
+	StrRange1Y                               = "range1Y"
+	StrUsdAggregate                          = "usdAggregate"
+	StrBlockExplorers                        = "blockExplorers"
+	StrPublicExplorers                       = "publicExplorers"
+	StrSelfHostedExplorers                   = "selfHostedExplorers"
+	StrDcrdataURL                            = "dcrdataURL"
+	StrBlockBookURL                          = "blockBookURL"
+	StrBTCEsploraURL                         = "btcEsploraURL"
+	StrLTCEsploraURL                         = "ltcEsploraURL"
+	StrBlockExplorersNote                    = "blockExplorersNote"
+	StrCheckingExplorers                     = "checkingExplorers"
+	StrOfflineTxFromFile                     = "offlineTxFromFile"
+	StrOfflineTxPaste                        = "offlineTxPaste"
+	StrOfflineTxPathHint                     = "offlineTxPathHint"
+	StrReviewOfflineTx                       = "reviewOfflineTx"
+	StrOwnAddress                            = "ownAddress"
+	StrConfirmOfflineTx                      = "confirmOfflineTx"
 )